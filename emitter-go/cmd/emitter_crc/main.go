@@ -2,73 +2,86 @@ package main
 
 import (
 	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"strings"
 
-	// "github.com/gerco/r-lab2/pkg/frame"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
 )
 
-func main() {
-	var bits string
-	flag.StringVar(&bits, "bits", "", "Cadena binaria (ej: '110101')")
-	flag.Parse()
+// errUso indica que falta --bits; main muestra entonces el uso
+var errUso = errors.New("falta --bits")
 
-	if bits == "" {
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if errors.Is(err, errUso) {
 		fmt.Fprintf(os.Stderr, "Uso: %s --bits <cadena_binaria>\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Ejemplo: %s --bits 110101\n", os.Args[0])
 		os.Exit(1)
 	}
-
-	// Validar que solo contiene 0s y 1s
-	for i, r := range bits {
-		if r != '0' && r != '1' {
-			fmt.Fprintf(os.Stderr, "Error: carácter inválido '%c' en posición %d\n", r, i)
-			os.Exit(1)
-		}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+}
 
-	// Convertir string de bits a []byte de bits
-	bitSlice := make([]byte, len(bits))
-	for i, r := range bits {
-		bit, _ := strconv.Atoi(string(r))
-		bitSlice[i] = byte(bit)
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("emitter_crc", flag.ContinueOnError)
+	bits := fs.String("bits", "", "Cadena binaria (ej: '110101')")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *bits == "" {
+		return errUso
 	}
 
-	// Convertir bits a bytes
-	payload := frame.BitsToBytes(bitSlice)
-
-	// Construir frame con CRC
-	frameBytes, err := frame.BuildFrame(payload)
+	bitSlice, err := parsearBits(*bits)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error construyendo frame: %v\n", err)
-		os.Exit(1)
+		return err
+	}
+	frameBytes, err := construirFrame(bitSlice)
+	if errors.Is(err, frame.ErrPayloadTooLarge) {
+		return fmt.Errorf("%d bits no entran en una trama (máximo %d)", len(bitSlice), 0xFFFF*8)
+	}
+	if err != nil {
+		return fmt.Errorf("construyendo frame: %w", err)
 	}
 
 	// Mostrar resultado en hexadecimal
-	fmt.Printf("Bits de entrada: %s\n", bits)
-	fmt.Printf("Payload (hex): %s\n", hex.EncodeToString(payload))
-	fmt.Printf("Frame completo (hex): %s\n", hex.EncodeToString(frameBytes))
+	fmt.Fprintf(out, "Bits de entrada: %s\n", *bits)
+	fmt.Fprintf(out, "Payload (hex): %s\n", hex.EncodeToString(frame.BitsToBytes(bitSlice)))
+	fmt.Fprintf(out, "Frame completo (hex): %s\n", hex.EncodeToString(frameBytes))
 
 	// También mostrar en bits para verificación manual
-	frameBits := frame.BytesToBits(frameBytes)
-	frameBitsStr := ""
-	for _, bit := range frameBits {
-		frameBitsStr += fmt.Sprintf("%d", bit)
+	var frameBits strings.Builder
+	for _, bit := range frame.BytesToBits(frameBytes) {
+		frameBits.WriteByte('0' + bit)
 	}
-	fmt.Printf("Frame completo (bits): %s\n", frameBitsStr)
+	fmt.Fprintf(out, "Frame completo (bits): %s\n", frameBits.String())
 
 	// Desglosar componentes del frame
-	if len(frameBytes) >= 7 {
-		header := frameBytes[:3]
-		payload = frameBytes[3 : len(frameBytes)-4]
-		crc := frameBytes[len(frameBytes)-4:]
+	fmt.Fprintf(out, "\nDesglose del frame:\n")
+	return frame.Dump(out, frameBytes)
+}
 
-		fmt.Printf("\nDesglose del frame:\n")
-		fmt.Printf("  Header (hex): %s\n", hex.EncodeToString(header))
-		fmt.Printf("  Payload (hex): %s\n", hex.EncodeToString(payload))
-		fmt.Printf("  CRC-32 (hex): %s\n", hex.EncodeToString(crc))
+// parsearBits convierte la cadena de --bits en un bit por byte
+func parsearBits(s string) ([]byte, error) {
+	bitSlice := make([]byte, len(s))
+	for i, r := range s {
+		if r != '0' && r != '1' {
+			return nil, fmt.Errorf("carácter inválido '%c' en posición %d", r, i)
+		}
+		bitSlice[i] = byte(r - '0')
 	}
-}
\ No newline at end of file
+	return bitSlice, nil
+}
+
+// construirFrame arma la trama con BuildFrameFromBits y CRC-32: si los bits
+// completan bytes es la del algoritmo crc del layered emitter; si no, una
+// trama MsgTypeBits que registra el relleno del último byte
+func construirFrame(bitSlice []byte) ([]byte, error) {
+	return frame.BuildFrameFromBits(bitSlice)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+var update = flag.Bool("update", false, "regenerar los archivos golden")
+
+func TestRun_Golden(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"--bits", "01001000011010010"}, &out); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "bits17.golden")
+	if *update {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("no se pudo leer %s: %v", golden, err)
+	}
+	if out.String() != string(want) {
+		t.Errorf("la salida difiere del golden:\n--- obtenido ---\n%s\n--- esperado ---\n%s", out.String(), want)
+	}
+}
+
+// Con bytes completos la trama tiene que ser la del algoritmo crc del layered
+// emitter, que arma BuildFrameWithOptions con los bits agrupados por
+// AppendBytes; con bits sueltos, ParseFrame tiene que devolverlos exactos
+func TestConstruirFrame_IgualQueElLayeredEmitter(t *testing.T) {
+	for n := 0; n <= 24; n++ {
+		bitSlice := make([]byte, n)
+		for i := range bitSlice {
+			bitSlice[i] = byte(i*5/3) & 1
+		}
+		got, err := construirFrame(bitSlice)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := frame.ParseFrame(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bits, err := f.Bits(); err != nil || !bytes.Equal(bits, bitSlice) {
+			t.Errorf("%d bits: Bits = %v, %v", n, bits, err)
+		}
+		if n%8 != 0 {
+			if f.MsgType != frame.MsgTypeBits {
+				t.Errorf("%d bits: tipo %#02x, se esperaba %#02x", n, f.MsgType, frame.MsgTypeBits)
+			}
+			continue
+		}
+		want, err := frame.BuildFrameWithOptions(frame.AppendBytes(nil, bitSlice), frame.FrameOptions{MsgType: frame.MsgTypeData})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%d bits: % x, se esperaba % x", n, got, want)
+		}
+		if want, _ := frame.BuildFrame(frame.BitsToBytes(bitSlice)); !bytes.Equal(got, want) {
+			t.Errorf("%d bits: la trama difiere de BuildFrame", n)
+		}
+	}
+}
+
+func TestRun_Errores(t *testing.T) {
+	if err := run(nil, &bytes.Buffer{}); !errors.Is(err, errUso) {
+		t.Errorf("sin --bits: %v, se esperaba %v", err, errUso)
+	}
+	if err := run([]string{"--bits", "0102"}, &bytes.Buffer{}); err == nil {
+		t.Error("se esperaba error con un carácter inválido")
+	}
+}
@@ -1,82 +1,115 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"strings"
 
-	// "github.com/gerco/r-lab2/pkg/frame"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
-
 )
 
-func main() {
-	var bits string
-	flag.StringVar(&bits, "bits", "", "Cadena binaria (ej: '110101')")
-	flag.Parse()
+// errUso indica que falta --bits; main muestra entonces el uso
+var errUso = errors.New("falta --bits")
 
-	if bits == "" {
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if errors.Is(err, errUso) {
 		fmt.Fprintf(os.Stderr, "Uso: %s --bits <cadena_binaria>\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Ejemplo: %s --bits 110101\n", os.Args[0])
 		os.Exit(1)
 	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-	// Validar que solo contiene 0s y 1s
-	for i, r := range bits {
-		if r != '0' && r != '1' {
-			fmt.Fprintf(os.Stderr, "Error: carácter inválido '%c' en posición %d\n", r, i)
-			os.Exit(1)
-		}
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("emitter_hamming", flag.ContinueOnError)
+	bits := fs.String("bits", "", "Cadena binaria (ej: '110101')")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *bits == "" {
+		return errUso
 	}
 
-	// Convertir string de bits a []byte de bits
-	bitSlice := make([]byte, len(bits))
-	for i, r := range bits {
-		bit, _ := strconv.Atoi(string(r))
-		bitSlice[i] = byte(bit)
+	bitSlice, err := parsearBits(*bits)
+	if err != nil {
+		return err
 	}
 
 	// Aplicar codificación Hamming (7,4)
 	encodedBits, err := frame.Hamming74Encode(bitSlice)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error en codificación Hamming: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Convertir resultado a string para mostrar
-	encodedBitsStr := ""
-	for _, bit := range encodedBits {
-		encodedBitsStr += fmt.Sprintf("%d", bit)
+		return fmt.Errorf("en codificación Hamming: %w", err)
 	}
 
-	fmt.Printf("Bits de entrada: %s (longitud: %d)\n", bits, len(bits))
-	fmt.Printf("Bits codificados: %s (longitud: %d)\n", encodedBitsStr, len(encodedBits))
+	fmt.Fprintf(out, "Bits de entrada: %s (longitud: %d)\n", *bits, len(bitSlice))
+	fmt.Fprintf(out, "Bits codificados: %s (longitud: %d)\n", cadenaBits(encodedBits), len(encodedBits))
 
 	// Mostrar desglose por bloques
-	fmt.Printf("\nDesglose por bloques de 7 bits:\n")
+	fmt.Fprintf(out, "\nDesglose por bloques de 7 bits:\n")
 	numBlocks := len(encodedBits) / 7
 	for i := 0; i < numBlocks; i++ {
-		start := i * 7
-		block := encodedBits[start : start+7]
-		blockStr := ""
-		for _, bit := range block {
-			blockStr += fmt.Sprintf("%d", bit)
-		}
+		block := encodedBits[i*7 : i*7+7]
 
 		// Mostrar estructura del bloque: [p2, p1, d3, p0, d2, d1, d0]
-		fmt.Printf("  Bloque %d: %s [p2=%d, p1=%d, d3=%d, p0=%d, d2=%d, d1=%d, d0=%d]\n",
-			i+1, blockStr, block[0], block[1], block[2], block[3], block[4], block[5], block[6])
-		
+		fmt.Fprintf(out, "  Bloque %d: %s [p2=%d, p1=%d, d3=%d, p0=%d, d2=%d, d1=%d, d0=%d]\n",
+			i+1, cadenaBits(block), block[0], block[1], block[2], block[3], block[4], block[5], block[6])
+
 		// Mostrar datos originales del bloque
-		originalData := fmt.Sprintf("%d%d%d%d", block[2], block[4], block[5], block[6])
-		fmt.Printf("    Datos orig.: %s\n", originalData)
+		fmt.Fprintf(out, "    Datos orig.: %d%d%d%d\n", block[2], block[4], block[5], block[6])
 	}
 
 	// Mostrar información de padding si aplica
-	originalPadded := (len(bits) + 3) / 4 * 4  // redondear hacia arriba a múltiplo de 4
-	if originalPadded > len(bits) {
-		paddingBits := originalPadded - len(bits)
-		fmt.Printf("\nPadding aplicado: %d bits (de %d a %d bits)\n", paddingBits, len(bits), originalPadded)
+	originalPadded := numBlocks * 4
+	if originalPadded > len(bitSlice) {
+		paddingBits := originalPadded - len(bitSlice)
+		fmt.Fprintf(out, "\nPadding aplicado: %d bits (de %d a %d bits)\n", paddingBits, len(bitSlice), originalPadded)
 	}
-}
\ No newline at end of file
+
+	// Mostrar la trama Hamming completa que se enviaría
+	frameBytes, err := construirFrame(bitSlice)
+	if err != nil {
+		return fmt.Errorf("construyendo frame: %w", err)
+	}
+	// El subheader cuenta además el bloque de ceros que puede dejar el
+	// agrupado en bytes, que el receptor también decodifica y descarta
+	relleno := int(frameBytes[frame.HeaderSize] &^ frame.HammingFlagInterleave)
+	if relleno != originalPadded-len(bitSlice) {
+		fmt.Fprintf(out, "Relleno en el subheader: %d bits (el agrupado en bytes deja un bloque de ceros)\n", relleno)
+	}
+	fmt.Fprintf(out, "\nDesglose del frame:\n")
+	return frame.Dump(out, frameBytes)
+}
+
+// parsearBits convierte la cadena de --bits en un bit por byte
+func parsearBits(s string) ([]byte, error) {
+	bitSlice := make([]byte, len(s))
+	for i, r := range s {
+		if r != '0' && r != '1' {
+			return nil, fmt.Errorf("carácter inválido '%c' en posición %d", r, i)
+		}
+		bitSlice[i] = byte(r - '0')
+	}
+	return bitSlice, nil
+}
+
+func cadenaBits(bits []byte) string {
+	var b strings.Builder
+	for _, bit := range bits {
+		b.WriteByte('0' + bit)
+	}
+	return b.String()
+}
+
+// construirFrame arma la trama como el algoritmo hamming del layered emitter
+// sin --interleave y con el --checksum por defecto: Hamming(7,4) con el
+// subheader de relleno y CRC-32
+func construirFrame(bitSlice []byte) ([]byte, error) {
+	return frame.BuildFrameWithHammingBits(bitSlice, frame.HammingSEC)
+}
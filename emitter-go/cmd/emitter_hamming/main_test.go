@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+var update = flag.Bool("update", false, "regenerar los archivos golden")
+
+func TestRun_Golden(t *testing.T) {
+	casos := []struct {
+		nombre string
+		bits   string
+	}{
+		{"bits6", "110101"},                        // padding del encoder
+		{"bits28", "0100100001101001010000100100"}, // el agrupado en bytes deja un bloque de ceros
+		{"bits8", "01001000"},                      // sin relleno en el subheader
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run([]string{"--bits", c.bits}, &out); err != nil {
+				t.Fatalf("run falló: %v", err)
+			}
+			golden := filepath.Join("testdata", c.nombre+".golden")
+			if *update {
+				if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("no se pudo leer %s: %v", golden, err)
+			}
+			if out.String() != string(want) {
+				t.Errorf("la salida difiere del golden:\n--- obtenido ---\n%s\n--- esperado ---\n%s", out.String(), want)
+			}
+		})
+	}
+}
+
+// La trama tiene que ser la del algoritmo hamming del layered emitter, que
+// arma EncodeHammingPayload con Hamming74Encode, y los bloques del desglose
+// los mismos que lleva su payload
+func TestConstruirFrame_IgualQueElLayeredEmitter(t *testing.T) {
+	for n := 0; n <= 24; n++ {
+		bitSlice := make([]byte, n)
+		for i := range bitSlice {
+			bitSlice[i] = byte(i*5/3) & 1
+		}
+		got, err := construirFrame(bitSlice)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload, err := frame.EncodeHammingPayload(bitSlice, 7, 4, frame.Hamming74Encode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := frame.BuildFrameWithOptions(payload, frame.FrameOptions{MsgType: frame.MsgTypeHamming})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%d bits: % x, se esperaba % x", n, got, want)
+		}
+
+		encoded, _ := frame.Hamming74Encode(bitSlice)
+		bloques := frame.BytesToBits(got[frame.HeaderSize+frame.HammingPadSize : len(got)-frame.CRCSize])
+		if !bytes.Equal(bloques[:len(encoded)], encoded) {
+			t.Errorf("%d bits: el payload no lleva los bloques del desglose", n)
+		}
+	}
+}
+
+func TestRun_Errores(t *testing.T) {
+	if err := run(nil, &bytes.Buffer{}); !errors.Is(err, errUso) {
+		t.Errorf("sin --bits: %v, se esperaba %v", err, errUso)
+	}
+	if err := run([]string{"--bits", "1x"}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "posición 1") {
+		t.Errorf("error %v, se esperaba el carácter inválido en posición 1", err)
+	}
+}
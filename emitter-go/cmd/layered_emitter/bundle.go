@@ -0,0 +1,337 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+// VersionBundle es la versión del esquema de index.json; se incrementa si
+// cambian los campos del índice o la disposición de los archivos del zip
+const VersionBundle = 1
+
+// EntradaBundle describe un archivo del bundle
+type EntradaBundle struct {
+	Ruta   string `json:"path"`
+	Tipo   string `json:"type"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// IndiceBundle es el index.json en la raíz del bundle. No se lista a sí mismo.
+type IndiceBundle struct {
+	Version  int             `json:"schema_version"`
+	Generado time.Time       `json:"generated"`
+	RunID    string          `json:"run_id"`
+	Parcial  bool            `json:"partial"` // La corrida se interrumpió antes de terminar
+	Archivos []EntradaBundle `json:"files"`
+}
+
+// configuracionBundle es la configuración resuelta de la corrida: el mensaje
+// pedido y el valor final de cada flag, incluidos los que quedaron por defecto
+type configuracionBundle struct {
+	Subcomando  string            `json:"subcommand,omitempty"`
+	Flags       map[string]string `json:"flags"`
+	Texto       string            `json:"text"`
+	Algoritmo   string            `json:"algorithm"`
+	BER         float64           `json:"ber"`
+	Modo        string            `json:"mode"`
+	Iteraciones int               `json:"count"`
+	Duracion    string            `json:"duration,omitempty"`
+}
+
+// metadatosBundle describe el experimento: el registro del historial más lo
+// necesario para reproducirlo o saber que quedó incompleto
+type metadatosBundle struct {
+	Registro  RegistroHistorial `json:"run"`
+	Inicio    time.Time         `json:"started"`
+	Fin       time.Time         `json:"ended"`
+	Parcial   bool              `json:"partial"`
+	GoVersion string            `json:"go_version"`
+}
+
+// tramaFallida es una línea de failures.ndjson. La trama con ruido puede
+// reenviarse tal cual con --frame-hex.
+type tramaFallida struct {
+	Iteracion  int     `json:"iteration"`
+	Secuencia  *uint16 `json:"seq,omitempty"`
+	Error      string  `json:"error"`
+	Trama      string  `json:"frame_hex"`
+	TramaRuido string  `json:"noisy_frame_hex,omitempty"`
+	Posiciones []int   `json:"error_positions,omitempty"`
+}
+
+// contenidoBundle reúne lo que se empaqueta al terminar un benchmark
+type contenidoBundle struct {
+	config    configuracionBundle
+	benchmark *BenchmarkResult
+	registro  RegistroHistorial
+	exports   *export.Conjunto
+	parcial   bool
+}
+
+// valoresFlags devuelve el valor resuelto de todos los flags de fs
+func valoresFlags(fs *flag.FlagSet) map[string]string {
+	valores := make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) { valores[f.Name] = f.Value.String() })
+	return valores
+}
+
+// nuevoContenidoBundle prepara el bundle del benchmark con las opciones o
+func nuevoContenidoBundle(o *opciones, b *BenchmarkResult, reg RegistroHistorial, exports *export.Conjunto, parcial bool) *contenidoBundle {
+	c := b.Config
+	config := configuracionBundle{
+		Subcomando:  o.subcomando,
+		Flags:       o.flags,
+		Texto:       c.Text,
+		Algoritmo:   c.Algorithm,
+		BER:         c.BER,
+		Modo:        c.Mode,
+		Iteraciones: c.Count,
+	}
+	if c.Duration > 0 {
+		config.Duracion = c.Duration.String()
+	}
+	return &contenidoBundle{config: config, benchmark: b, registro: reg, exports: exports, parcial: parcial}
+}
+
+// escritorBundle agrega entradas a un zip calculando tamaño y hash mientras
+// se escriben, así que ningún archivo se carga entero en memoria
+type escritorBundle struct {
+	zw     *zip.Writer
+	indice IndiceBundle
+	rutas  map[string]bool
+}
+
+// agregar crea la entrada ruta y la llena con escribir
+func (e *escritorBundle) agregar(ruta, tipo string, escribir func(io.Writer) error) error {
+	w, err := e.zw.CreateHeader(&zip.FileHeader{Name: ruta, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	var n contadorBytes
+	if err := escribir(io.MultiWriter(w, h, &n)); err != nil {
+		return fmt.Errorf("%s: %v", ruta, err)
+	}
+	e.rutas[ruta] = true
+	e.indice.Archivos = append(e.indice.Archivos, EntradaBundle{
+		Ruta: ruta, Tipo: tipo, Bytes: int64(n), SHA256: hex.EncodeToString(h.Sum(nil)),
+	})
+	return nil
+}
+
+// agregarJSON agrega v como JSON indentado
+func (e *escritorBundle) agregarJSON(ruta, tipo string, v interface{}) error {
+	return e.agregar(ruta, tipo, func(w io.Writer) error {
+		datos, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(datos, '\n'))
+		return err
+	})
+}
+
+// agregarArchivo copia al bundle el archivo en disco origen
+func (e *escritorBundle) agregarArchivo(ruta, tipo, origen string) error {
+	return e.agregar(ruta, tipo, func(w io.Writer) error {
+		f, err := os.Open(origen)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+}
+
+// rutaLibre devuelve exports/<nombre>, con un prefijo numérico si dos
+// exports de directorios distintos comparten nombre
+func (e *escritorBundle) rutaLibre(nombre string) string {
+	ruta := path.Join("exports", nombre)
+	for i := 2; e.rutas[ruta]; i++ {
+		ruta = path.Join("exports", fmt.Sprintf("%d-%s", i, nombre))
+	}
+	return ruta
+}
+
+// escribirBundle empaqueta el contenido en el zip destino. Los exports deben
+// estar cerrados. El zip se arma en un archivo temporal junto a destino y
+// solo se renombra al terminar, así que nunca queda un bundle a medio escribir.
+func escribirBundle(destino string, c *contenidoBundle) error {
+	tmp, err := os.CreateTemp(filepath.Dir(destino), ".bundle-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	buf := bufio.NewWriter(tmp)
+	e := &escritorBundle{
+		zw:     zip.NewWriter(buf),
+		rutas:  make(map[string]bool),
+		indice: IndiceBundle{Version: VersionBundle, Generado: time.Now().UTC(), RunID: c.registro.ID, Parcial: c.parcial},
+	}
+	if err := e.escribirEntradas(c); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := e.zw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), destino)
+}
+
+// escribirEntradas agrega todos los archivos y, al final, el índice que los lista
+func (e *escritorBundle) escribirEntradas(c *contenidoBundle) error {
+	b := c.benchmark
+	if err := e.agregarJSON("config.json", "config", c.config); err != nil {
+		return err
+	}
+	metadatos := metadatosBundle{
+		Registro:  c.registro,
+		Inicio:    b.StartTime.UTC(),
+		Fin:       b.EndTime.UTC(),
+		Parcial:   c.parcial,
+		GoVersion: runtime.Version(),
+	}
+	if err := e.agregarJSON("metadata.json", "metadatos", metadatos); err != nil {
+		return err
+	}
+	if err := e.agregar("report.md", "reporte-markdown", func(w io.Writer) error {
+		return escribirReporte(w, c)
+	}); err != nil {
+		return err
+	}
+	if err := e.agregar("failures.ndjson", "ndjson-tramas-fallidas", func(w io.Writer) error {
+		return escribirTramasFallidas(w, b.Results)
+	}); err != nil {
+		return err
+	}
+
+	// Los exports van bajo exports/ y el manifiesto usa sus rutas en el zip
+	manifiesto := c.exports.Manifiesto()
+	for i, a := range manifiesto.Archivos {
+		ruta := e.rutaLibre(filepath.Base(a.Ruta))
+		if err := e.agregarArchivo(ruta, a.Tipo, a.Ruta); err != nil {
+			return err
+		}
+		manifiesto.Archivos[i].Ruta = ruta
+	}
+	if err := e.agregarJSON("manifest.json", "manifiesto", manifiesto); err != nil {
+		return err
+	}
+
+	w, err := e.zw.CreateHeader(&zip.FileHeader{Name: "index.json", Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	datos, err := json.MarshalIndent(e.indice, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(datos, '\n'))
+	return err
+}
+
+// escribirTramasFallidas escribe una línea por iteración fallida con la trama
+// enviada y, si llegó a aplicarse el ruido, la trama tal como se transmitió
+func escribirTramasFallidas(w io.Writer, results []*TransmissionResult) error {
+	enc := json.NewEncoder(w)
+	for i, r := range results {
+		if r.Success {
+			continue
+		}
+		linea := tramaFallida{
+			Iteracion:  i + 1,
+			Secuencia:  r.Secuencia,
+			Error:      r.Error,
+			Trama:      hex.EncodeToString(r.FrameBytes),
+			Posiciones: r.ErrorPositions,
+		}
+		if len(r.NoisyFrameBits) > 0 {
+			linea.TramaRuido = hex.EncodeToString(frame.BitsToBytes(r.NoisyFrameBits))
+		}
+		if err := enc.Encode(linea); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escribirReporte resume la corrida en Markdown, en el orden en que la
+// muestra analizarBenchmark
+func escribirReporte(w io.Writer, c *contenidoBundle) error {
+	b, reg := c.benchmark, c.registro
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Benchmark %s\n\n", reg.ID)
+	if c.parcial {
+		fmt.Fprintf(bw, "> ⚠️ Corrida interrumpida: el reporte cubre solo las %d iteraciones completadas.\n\n", len(b.Results))
+	}
+
+	fmt.Fprintln(bw, "## Configuración")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "| Parámetro | Valor |")
+	fmt.Fprintln(bw, "|---|---|")
+	fmt.Fprintf(bw, "| Mensaje | `%s` |\n", b.Config.Text)
+	fmt.Fprintf(bw, "| Algoritmo | %s |\n", b.Config.Algorithm)
+	fmt.Fprintf(bw, "| BER | %g |\n", b.Config.BER)
+	if b.LargoRafaga > 0 {
+		fmt.Fprintf(bw, "| Ráfagas | %d bits |\n", b.LargoRafaga)
+	}
+	fmt.Fprintf(bw, "| Hash de configuración | `%s` |\n", reg.ConfigHash)
+	fmt.Fprintln(bw)
+
+	fmt.Fprintln(bw, "## Resultados")
+	fmt.Fprintln(bw)
+	fmt.Fprintf(bw, "- Iteraciones: %d (%d exitosas, %d fallidas)\n", len(b.Results), b.Successful, b.Failed)
+	fmt.Fprintf(bw, "- Tasa de éxito: %.2f%%\n", b.SuccessRate*100)
+	if reg.BERObservado > 0 {
+		fmt.Fprintf(bw, "- BER observado: %.6f\n", reg.BERObservado)
+	}
+	fmt.Fprintf(bw, "- Transmisión promedio: %.3f ms\n", reg.TransmisionMediaMs)
+	fmt.Fprintf(bw, "- Duración total: %v\n", b.TotalTime)
+	if b.CorreccionBloques != nil {
+		fmt.Fprintf(bw, "- %s\n", formatearCorreccionBloques(*b.CorreccionBloques))
+	}
+	if b.CorreccionRS != nil {
+		fmt.Fprintf(bw, "- %s\n", formatearCorreccionRS(*b.CorreccionRS))
+	}
+	if b.Veredictos != nil {
+		fmt.Fprintf(bw, "- %s\n", formatearVeredictos(*b.Veredictos))
+	}
+	if len(b.Advertencias) > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "## Advertencias")
+		fmt.Fprintln(bw)
+		for _, a := range b.Advertencias {
+			fmt.Fprintf(bw, "- %s\n", a)
+		}
+	}
+	if b.Failed > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintf(bw, "Las %d tramas fallidas están en `failures.ndjson`.\n", b.Failed)
+	}
+	return bw.Flush()
+}
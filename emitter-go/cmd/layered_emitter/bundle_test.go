@@ -0,0 +1,225 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+)
+
+// leerBundle abre el zip en path, verifica que index.json liste cada entrada
+// con su tamaño y hash reales y devuelve el índice y el contenido por ruta
+func leerBundle(t *testing.T, path string) (IndiceBundle, map[string][]byte) {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+
+	contenido := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		datos, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", f.Name, err)
+		}
+		contenido[f.Name] = datos
+	}
+
+	var indice IndiceBundle
+	if err := json.Unmarshal(contenido["index.json"], &indice); err != nil {
+		t.Fatalf("index.json: %v", err)
+	}
+	listados := map[string]bool{"index.json": true}
+	for _, e := range indice.Archivos {
+		datos, ok := contenido[e.Ruta]
+		if !ok {
+			t.Errorf("el índice lista %s, que no está en el zip", e.Ruta)
+			continue
+		}
+		sum := sha256.Sum256(datos)
+		if int64(len(datos)) != e.Bytes || hex.EncodeToString(sum[:]) != e.SHA256 {
+			t.Errorf("%s: %d bytes y sha256 %s, el índice dice %d y %s",
+				e.Ruta, len(datos), hex.EncodeToString(sum[:]), e.Bytes, e.SHA256)
+		}
+		listados[e.Ruta] = true
+	}
+	for ruta := range contenido {
+		if !listados[ruta] {
+			t.Errorf("%s está en el zip pero no en el índice", ruta)
+		}
+	}
+	return indice, contenido
+}
+
+// benchmarkConExports corre un benchmark de count iteraciones que falla en
+// las iteraciones pares, con --hook-ndjson en dir, y cierra los exports. Con
+// cancelarTras > 0 cancela la corrida al recibir esa cantidad de resultados.
+func benchmarkConExports(t *testing.T, dir string, count, cancelarTras int) (*opciones, *export.Conjunto, *BenchmarkResult, error) {
+	t.Helper()
+	bundle := filepath.Join(dir, "lab.zip")
+	o, err := parsearLinea([]string{"bench", "--hook-ndjson", filepath.Join(dir, "it.ndjson"), "--bundle", bundle}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envios := 0
+	le := newTestEmitter(func(url string, f []byte) error {
+		if envios++; envios%2 == 0 {
+			return errors.New("receptor caído")
+		}
+		return nil
+	})
+	le.watchdogIteraciones = 0
+	exports := export.NuevoConjunto()
+	if _, err := abrirExports(exports, o, le); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "benchmark", Count: count}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := le.RunBenchmarkStream(ctx, config)
+	recibidos := 0
+	for range s.Results {
+		if recibidos++; recibidos == cancelarTras {
+			cancel()
+		}
+	}
+	b, err := s.Benchmark(), <-s.Err
+	if err := exports.Cerrar(); err != nil {
+		t.Fatal(err)
+	}
+	return o, exports, b, err
+}
+
+func TestEscribirBundle_IndiceCubreTodo(t *testing.T) {
+	dir := t.TempDir()
+	o, exports, b, err := benchmarkConExports(t, dir, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := nuevoRegistroHistorial(b, []string{*o.bundle})
+	if err := escribirBundle(*o.bundle, nuevoContenidoBundle(o, b, reg, exports, false)); err != nil {
+		t.Fatal(err)
+	}
+
+	indice, contenido := leerBundle(t, *o.bundle)
+	if indice.Version != VersionBundle || indice.Parcial || indice.RunID != reg.ID {
+		t.Errorf("índice %+v", indice)
+	}
+	for _, ruta := range []string{"config.json", "metadata.json", "report.md", "failures.ndjson", "manifest.json", "exports/it.ndjson"} {
+		if _, ok := contenido[ruta]; !ok {
+			t.Errorf("falta %s en el bundle", ruta)
+		}
+	}
+
+	// El export se copia íntegro y el manifiesto lo referencia dentro del zip
+	original, _ := os.ReadFile(filepath.Join(dir, "it.ndjson"))
+	if string(contenido["exports/it.ndjson"]) != string(original) {
+		t.Error("exports/it.ndjson no coincide con el archivo exportado")
+	}
+	var m export.Manifiesto
+	if err := json.Unmarshal(contenido["manifest.json"], &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Archivos) != 1 || m.Archivos[0].Ruta != "exports/it.ndjson" || m.Archivos[0].Bytes != int64(len(original)) {
+		t.Errorf("manifiesto %+v", m.Archivos)
+	}
+
+	var config configuracionBundle
+	if err := json.Unmarshal(contenido["config.json"], &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Subcomando != "bench" || config.Flags["bundle"] != *o.bundle || config.Flags["checksum"] != "crc32" || config.Iteraciones != 10 {
+		t.Errorf("config %+v", config)
+	}
+
+	// Una línea por iteración fallida, con la trama enviada
+	lineas := 0
+	sc := bufio.NewScanner(strings.NewReader(string(contenido["failures.ndjson"])))
+	for sc.Scan() {
+		var f tramaFallida
+		if err := json.Unmarshal(sc.Bytes(), &f); err != nil {
+			t.Fatal(err)
+		}
+		if f.Iteracion%2 != 0 || f.Trama == "" || f.TramaRuido == "" {
+			t.Errorf("trama fallida %+v", f)
+		}
+		lineas++
+	}
+	if lineas != b.Failed || b.Failed != 5 {
+		t.Errorf("%d tramas fallidas en el bundle, el benchmark tuvo %d", lineas, b.Failed)
+	}
+	if !strings.Contains(string(contenido["report.md"]), "Tasa de éxito: 50.00%") {
+		t.Errorf("reporte:\n%s", contenido["report.md"])
+	}
+	if _, err := os.Stat(*o.bundle); err != nil {
+		t.Fatal(err)
+	}
+	if temporales, _ := filepath.Glob(filepath.Join(dir, ".bundle-*")); len(temporales) > 0 {
+		t.Errorf("quedaron temporales: %v", temporales)
+	}
+}
+
+func TestEscribirBundle_Parcial(t *testing.T) {
+	dir := t.TempDir()
+	o, exports, b, err := benchmarkConExports(t, dir, 1000, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, se esperaba la cancelación", err)
+	}
+	reg := nuevoRegistroHistorial(b, nil)
+	if err := escribirBundle(*o.bundle, nuevoContenidoBundle(o, b, reg, exports, true)); err != nil {
+		t.Fatal(err)
+	}
+
+	indice, contenido := leerBundle(t, *o.bundle)
+	var metadatos metadatosBundle
+	if err := json.Unmarshal(contenido["metadata.json"], &metadatos); err != nil {
+		t.Fatal(err)
+	}
+	if !indice.Parcial || !metadatos.Parcial || metadatos.Registro.Iteraciones != len(b.Results) {
+		t.Errorf("índice parcial %v, metadatos %+v", indice.Parcial, metadatos)
+	}
+	if want := fmt.Sprintf("solo las %d iteraciones", len(b.Results)); !strings.Contains(string(contenido["report.md"]), want) {
+		t.Errorf("el reporte no avisa la interrupción:\n%s", contenido["report.md"])
+	}
+}
+
+func TestRutaLibre_NombresRepetidos(t *testing.T) {
+	e := &escritorBundle{rutas: map[string]bool{"exports/it.ndjson": true, "exports/2-it.ndjson": true}}
+	if got := e.rutaLibre("it.ndjson"); got != "exports/3-it.ndjson" {
+		t.Errorf("rutaLibre = %s", got)
+	}
+	if got := e.rutaLibre("mapa.png"); got != "exports/mapa.png" {
+		t.Errorf("rutaLibre = %s", got)
+	}
+}
+
+func TestAbrirExports_BundleReservado(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lab.zip")
+	o := parsearOpciones(t, "--mode", "benchmark", "--bundle", path, "--hook-ndjson", path)
+
+	c := export.NuevoConjunto()
+	defer c.Cerrar()
+	if _, err := abrirExports(c, o, newTestEmitter(nil)); err == nil {
+		t.Error("--bundle y --hook-ndjson en la misma ruta deberían fallar antes de correr")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import "github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+
+// CorreccionBloques cuenta, en las iteraciones de un código corrector del
+// benchmark, los bloques con a lo sumo un bit invertido (los que el receptor
+// corrige) y los payloads con todos sus bloques corregibles
+type CorreccionBloques struct {
+	Codigo              string // "Hamming(7,4)" o "Repetición (3,1)"
+	Bloques             int
+	BloquesCorregibles  int
+	Payloads            int
+	PayloadsCorregibles int
+}
+
+// estructuraBloques devuelve el código, el byte de la trama donde empiezan
+// sus bloques, su tamaño y cuántos hay para los algoritmos que corrigen un
+// error por bloque; ok es false con los demás
+func estructuraBloques(r *TransmissionResult) (codigo string, inicio, tam, bloques int, ok bool) {
+	switch r.Config.Algorithm {
+	case "hamming":
+		return "Hamming(7,4)", frame.HammingBlocksOffset(r.FrameBytes), 7, (len(r.TextBits) + 3) / 4, true
+	case "repetition":
+		return "Repetición (3,1)", frame.HeaderLen(r.FrameBytes), 3, len(r.TextBits), true
+	default:
+		return "", 0, 0, 0, false
+	}
+}
+
+// contarCorreccionBloques mide la corrección de los resultados a partir de
+// sus posiciones de error, ya en el orden de los bloques. Quedan fuera las
+// iteraciones fragmentadas, con posiciones muestreadas o sin ruido aplicado;
+// nil si ninguna usó hamming o repetition.
+func contarCorreccionBloques(results []*TransmissionResult) *CorreccionBloques {
+	var c *CorreccionBloques
+	for _, r := range results {
+		if r.NoisyFrameBits == nil || r.Fragmentos != nil || r.PosicionesTruncadas {
+			continue
+		}
+		codigo, inicio, tam, bloques, ok := estructuraBloques(r)
+		if !ok {
+			continue
+		}
+		if c == nil {
+			c = &CorreccionBloques{Codigo: codigo}
+		}
+		recuperables := bloquesRecuperables(posicionesEnBloques(r.FrameBytes, r.ErrorPositions), inicio, tam, bloques)
+		c.Bloques += bloques
+		c.BloquesCorregibles += recuperables
+		c.Payloads++
+		if recuperables == bloques {
+			c.PayloadsCorregibles++
+		}
+	}
+	return c
+}
+
+func formatearCorreccionBloques(c CorreccionBloques) string {
+	return msgs.T("resumen.correccion", c.Codigo, c.BloquesCorregibles, c.Bloques, porcentaje(c.BloquesCorregibles, c.Bloques),
+		c.PayloadsCorregibles, c.Payloads, porcentaje(c.PayloadsCorregibles, c.Payloads))
+}
+
+func porcentaje(parte, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(parte) / float64(total) * 100
+}
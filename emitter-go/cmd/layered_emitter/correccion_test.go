@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestProcessMessage_Repeticion(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "repetition", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := frame.ParseFrame(enviada)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != frame.MsgTypeRepetition || len(result.FrameBytes) != frame.HeaderSize+3*len("Hola")+4 {
+		t.Fatalf("trama de %d bytes decodificada como %+v", len(result.FrameBytes), f)
+	}
+	data, _, err := frame.Repetition3DecodePayload(f.Payload)
+	if err != nil || string(data) != "Hola" {
+		t.Errorf("payload %q, %v", data, err)
+	}
+}
+
+func TestContarCorreccionBloques_Repeticion(t *testing.T) {
+	trama, _ := frame.BuildFrameWithRepetition([]byte("A"))
+	inicio := frame.HeaderSize * 8
+	r := &TransmissionResult{
+		Config:         &application.MessageConfig{Algorithm: "repetition"},
+		TextBits:       frame.BytesToBits([]byte("A")),
+		FrameBytes:     trama,
+		NoisyFrameBits: frame.BytesToBits(trama),
+		// Un error en la tripleta 0 (se corrige) y dos en la 2 (no)
+		ErrorPositions: []int{inicio + 1, inicio + 6, inicio + 8},
+	}
+	c := contarCorreccionBloques([]*TransmissionResult{r})
+	want := CorreccionBloques{Codigo: "Repetición (3,1)", Bloques: 8, BloquesCorregibles: 7, Payloads: 1}
+	if c == nil || *c != want {
+		t.Errorf("corrección = %+v, se esperaba %+v", c, want)
+	}
+	r.Config.Algorithm = "crc"
+	if c := contarCorreccionBloques([]*TransmissionResult{r}); c != nil {
+		t.Errorf("con crc no debería contarse la corrección: %+v", c)
+	}
+}
+
+func TestRunBenchmark_RepeticionHammingCRC(t *testing.T) {
+	// Fracción de payloads que el receptor recupera: sin errores para crc,
+	// todos los bloques corregibles para los códigos correctores
+	aceptacion := func(algoritmo string) float64 {
+		le := newTestEmitter(func(url string, f []byte) error { return nil })
+		le.noise = noise.NewNoiseLayerWithSeed(13)
+		le.watchdogIteraciones = 0
+
+		config := &application.MessageConfig{Text: "Hola", Algorithm: algoritmo, BER: 0.01, Mode: "benchmark", Count: 3000}
+		benchmark, err := le.RunBenchmark(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := benchmark.CorreccionBloques; c != nil {
+			return float64(c.PayloadsCorregibles) / float64(c.Payloads)
+		}
+		intactas := 0
+		for _, r := range benchmark.Results {
+			if r.ErrorsInjected == 0 {
+				intactas++
+			}
+		}
+		return float64(intactas) / float64(len(benchmark.Results))
+	}
+
+	crc, hamming, repeticion := aceptacion("crc"), aceptacion("hamming"), aceptacion("repetition")
+	// Teoría con "Hola": 0.99^104 ≈ 0.35, 8 bloques de 7 ≈ 0.98, 32 tripletas ≈ 0.99
+	if crc > 0.45 || hamming < 0.96 || repeticion < 0.98 || repeticion < hamming {
+		t.Errorf("aceptación crc %.3f, hamming %.3f, repetition %.3f", crc, hamming, repeticion)
+	}
+}
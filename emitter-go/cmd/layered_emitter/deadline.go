@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+)
+
+// Puntualidad de una iteración con --deadline. El plazo cuenta desde que
+// empieza la codificación hasta que termina el envío; una entrega tardía
+// cuenta como fallida aunque el receptor haya aceptado la trama.
+const (
+	PuntualidadATiempo = "a-tiempo" // Entregada dentro del plazo
+	PuntualidadTarde   = "tarde"    // Entregada después del plazo
+	PuntualidadFallida = "fallida"  // El envío falló dentro del plazo
+	PuntualidadVencida = "vencida"  // El plazo venció antes de enviar
+)
+
+// contextoEnvio devuelve el contexto del envío de result: sin --deadline no
+// tiene plazo; con él, vence cuando se agota lo que queda del presupuesto
+// según le.clock.
+// vencido indica que el presupuesto ya se agotó y no conviene enviar.
+func (le *LayeredEmitter) contextoEnvio(parent context.Context, result *TransmissionResult) (ctx context.Context, cancel context.CancelFunc, vencido bool) {
+	if le.deadline <= 0 {
+		return parent, func() {}, false
+	}
+	restante := le.deadline - le.clock.Since(result.StartTime)
+	if restante <= 0 {
+		return nil, nil, true
+	}
+	ctx, cancel = clock.WithTimeout(parent, le.clock, restante)
+	return ctx, cancel, false
+}
+
+// marcarVencida registra en result que el plazo se agotó antes de enviar
+func (le *LayeredEmitter) marcarVencida(result *TransmissionResult) {
+	result.Puntualidad = PuntualidadVencida
+	result.Retraso = le.clock.Since(result.StartTime) - le.deadline
+	result.Success = false
+	result.Error = fmt.Sprintf("plazo de %v vencido antes de enviar (+%v)", le.deadline, result.Retraso)
+}
+
+// clasificarPuntualidad compara el tiempo total de result con --deadline; una
+// entrega tardía pasa a fallida
+func (le *LayeredEmitter) clasificarPuntualidad(result *TransmissionResult) {
+	if le.deadline <= 0 {
+		return
+	}
+	switch {
+	case !result.Success:
+		result.Puntualidad = PuntualidadFallida
+	case result.TotalTime > le.deadline:
+		result.Puntualidad = PuntualidadTarde
+		result.Retraso = result.TotalTime - le.deadline
+		result.Success = false
+		result.Error = fmt.Sprintf("entregada fuera de plazo (+%v sobre %v)", result.Retraso, le.deadline)
+	default:
+		result.Puntualidad = PuntualidadATiempo
+	}
+}
+
+// ResumenPuntualidad cuenta las iteraciones de un benchmark con --deadline
+// por puntualidad y describe la distribución del retraso de las tardías
+type ResumenPuntualidad struct {
+	Deadline time.Duration
+	ATiempo  int
+	Tarde    int
+	Fallidas int
+	Vencidas int
+	// Retraso sobre el plazo de las entregas tardías (cero si no hubo)
+	RetrasoMin time.Duration
+	RetrasoP50 time.Duration
+	RetrasoP95 time.Duration
+	RetrasoMax time.Duration
+}
+
+// resumirPuntualidad agrega la puntualidad de results; las iteraciones sin
+// clasificar (fallos de procesamiento) cuentan como fallidas
+func resumirPuntualidad(deadline time.Duration, results []*TransmissionResult) *ResumenPuntualidad {
+	r := &ResumenPuntualidad{Deadline: deadline}
+	var retrasos []time.Duration
+	for _, res := range results {
+		switch res.Puntualidad {
+		case PuntualidadATiempo:
+			r.ATiempo++
+		case PuntualidadTarde:
+			r.Tarde++
+			retrasos = append(retrasos, res.Retraso)
+		case PuntualidadVencida:
+			r.Vencidas++
+		default:
+			r.Fallidas++
+		}
+	}
+
+	if len(retrasos) > 0 {
+		sort.Slice(retrasos, func(i, j int) bool { return retrasos[i] < retrasos[j] })
+		percentil := func(p float64) time.Duration {
+			return retrasos[int(p*float64(len(retrasos)-1))]
+		}
+		r.RetrasoMin = retrasos[0]
+		r.RetrasoP50 = percentil(0.5)
+		r.RetrasoP95 = percentil(0.95)
+		r.RetrasoMax = retrasos[len(retrasos)-1]
+	}
+	return r
+}
+
+// mostrarPuntualidad imprime el resumen de --deadline
+func mostrarPuntualidad(r *ResumenPuntualidad) {
+	fmt.Print(msgs.T("resumen.plazo", r.Deadline))
+	fmt.Print(msgs.T("resumen.puntualidad", r.ATiempo, r.Tarde, r.Fallidas, r.Vencidas))
+	if r.Tarde > 0 {
+		fmt.Print(msgs.T("resumen.retraso", r.RetrasoMin, r.RetrasoP50, r.RetrasoP95, r.RetrasoMax))
+	}
+}
+
+// agregarPuntualidad completa benchmark.Puntualidad cuando hay --deadline
+func (le *LayeredEmitter) agregarPuntualidad(benchmark *BenchmarkResult) {
+	if le.deadline > 0 {
+		benchmark.Puntualidad = resumirPuntualidad(le.deadline, benchmark.Results)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+)
+
+// relojPorPaso avanza paso en cada lectura, simulando que cada capa consume
+// tiempo antes del envío
+type relojPorPaso struct {
+	*clock.Manual
+	paso time.Duration
+}
+
+func (r relojPorPaso) Now() time.Time {
+	r.Advance(r.paso)
+	return r.Manual.Now()
+}
+
+func (r relojPorPaso) Since(t time.Time) time.Duration {
+	r.Advance(r.paso)
+	return r.Manual.Since(t)
+}
+
+// newEmisorConPlazo crea un emisor con --deadline cuyo envío tarda demora en
+// el reloj manual
+func newEmisorConPlazo(deadline, demora time.Duration) (*LayeredEmitter, *clock.Manual, *int) {
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	envios := 0
+	le := NewLayeredEmitter("ws://test")
+	le.clock = reloj
+	le.deadline = deadline
+	le.sendFrame = func(ctx context.Context, url string, frame []byte) (*wsclient.ConnStats, error) {
+		envios++
+		reloj.Advance(demora)
+		return &wsclient.ConnStats{}, nil
+	}
+	return le, reloj, &envios
+}
+
+func TestProcessMessage_DeadlineATiempo(t *testing.T) {
+	le, _, _ := newEmisorConPlazo(50*time.Millisecond, 10*time.Millisecond)
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.Puntualidad != PuntualidadATiempo || result.Retraso != 0 {
+		t.Errorf("se esperaba entrega a tiempo: success=%v puntualidad=%q retraso=%v",
+			result.Success, result.Puntualidad, result.Retraso)
+	}
+}
+
+func TestProcessMessage_DeadlineTarde(t *testing.T) {
+	le, _, envios := newEmisorConPlazo(50*time.Millisecond, 80*time.Millisecond)
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *envios != 1 {
+		t.Fatalf("la trama debería enviarse: %d envíos", *envios)
+	}
+	if result.Success || result.Puntualidad != PuntualidadTarde {
+		t.Errorf("una entrega tardía debería contar como fallida: success=%v puntualidad=%q",
+			result.Success, result.Puntualidad)
+	}
+	if result.Retraso != 30*time.Millisecond {
+		t.Errorf("retraso %v, se esperaba 30ms", result.Retraso)
+	}
+}
+
+func TestProcessMessage_DeadlineVencidoAntesDeEnviar(t *testing.T) {
+	le, reloj, envios := newEmisorConPlazo(50*time.Millisecond, 0)
+	// Cada lectura del reloj consume 60ms: el plazo se agota durante las capas
+	// previas al envío
+	le.clock = relojPorPaso{Manual: reloj, paso: 60 * time.Millisecond}
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *envios != 0 {
+		t.Errorf("con el plazo vencido no debería enviarse: %d envíos", *envios)
+	}
+	if result.Success || result.Puntualidad != PuntualidadVencida || result.Retraso < 0 {
+		t.Errorf("se esperaba plazo vencido: success=%v puntualidad=%q retraso=%v",
+			result.Success, result.Puntualidad, result.Retraso)
+	}
+}
+
+func TestProcessMessage_DeadlineAcotaContexto(t *testing.T) {
+	le, reloj, _ := newEmisorConPlazo(50*time.Millisecond, 0)
+	var restante time.Duration
+	var errCtx error
+	le.sendFrame = func(ctx context.Context, url string, frame []byte) (*wsclient.ConnStats, error) {
+		d, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("el contexto del envío debería tener deadline")
+		}
+		restante = d.Sub(reloj.Now())
+		// El plazo corre en el reloj del emisor: avanzarlo vence el contexto
+		// sin esperas reales
+		reloj.Advance(restante)
+		<-ctx.Done()
+		errCtx = ctx.Err()
+		return &wsclient.ConnStats{}, ctx.Err()
+	}
+
+	if _, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"}); err != nil {
+		t.Fatal(err)
+	}
+	if restante <= 0 || restante > 50*time.Millisecond {
+		t.Errorf("el envío debería disponer a lo sumo del plazo restante: %v", restante)
+	}
+	if !errors.Is(errCtx, context.DeadlineExceeded) {
+		t.Errorf("el contexto del envío terminó con %v, se esperaba DeadlineExceeded", errCtx)
+	}
+}
+
+func TestRunBenchmark_ResumenPuntualidad(t *testing.T) {
+	le, reloj, _ := newEmisorConPlazo(50*time.Millisecond, 0)
+	// Demoras de 10, 60, 90 y 120ms: una a tiempo y tres tardías
+	demoras := []time.Duration{10, 60, 90, 120}
+	i := 0
+	le.sendFrame = func(ctx context.Context, url string, frame []byte) (*wsclient.ConnStats, error) {
+		reloj.Advance(demoras[i] * time.Millisecond)
+		i++
+		return &wsclient.ConnStats{}, nil
+	}
+
+	benchmark, err := le.RunBenchmark(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := benchmark.Puntualidad
+	if p == nil {
+		t.Fatal("falta el resumen de puntualidad")
+	}
+	if p.ATiempo != 1 || p.Tarde != 3 || p.Fallidas != 0 || p.Vencidas != 0 {
+		t.Errorf("conteos inesperados: %+v", p)
+	}
+	if p.RetrasoMin != 10*time.Millisecond || p.RetrasoP50 != 40*time.Millisecond || p.RetrasoMax != 70*time.Millisecond {
+		t.Errorf("distribución del retraso inesperada: %+v", p)
+	}
+	if benchmark.Successful != 1 || benchmark.Failed != 3 {
+		t.Errorf("las tardías deberían contar como fallidas: %d exitosas, %d fallidas", benchmark.Successful, benchmark.Failed)
+	}
+}
+
+func TestRunBenchmark_SinDeadlineNoResume(t *testing.T) {
+	le, _, _ := newEmisorConPlazo(0, time.Second)
+
+	benchmark, err := le.RunBenchmark(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if benchmark.Puntualidad != nil || benchmark.Results[0].Puntualidad != "" {
+		t.Error("sin --deadline no debería clasificarse la puntualidad")
+	}
+}
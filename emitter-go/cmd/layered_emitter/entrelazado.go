@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+// codificarHamming arma el payload Hamming de textBits con un código de
+// bloques de n bits (4 de datos) y, con --interleave, entrelaza los bloques
+func (le *LayeredEmitter) codificarHamming(textBits []byte, n int, encode func([]byte) ([]byte, error)) ([]byte, error) {
+	encoded, err := frame.EncodeHammingPayload(textBits, n, 4, encode)
+	if err != nil || le.entrelazado == 0 {
+		return encoded, err
+	}
+	return frame.InterleaveHammingPayload(encoded, le.entrelazado)
+}
+
+// descripcionEntrelazado completa la descripción del algoritmo con la
+// profundidad de --interleave (vacía sin entrelazar)
+func (le *LayeredEmitter) descripcionEntrelazado() string {
+	if le.entrelazado == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" entrelazado %d", le.entrelazado)
+}
+
+// aplicarRuido aplica el canal configurado: ráfagas con --burst, borrados con
+// --erasure o errores independientes por bit
+func (le *LayeredEmitter) aplicarRuido(bits []byte, ber float64) (*noise.ErrorResult, error) {
+	if le.largoRafaga > 0 {
+		return le.noise.AplicarRafagas(bits, ber, le.largoRafaga)
+	}
+	if le.borrado {
+		return le.noise.AplicarBorrado(bits, ber, 0)
+	}
+	return le.noise.AplicarRuido(bits, ber)
+}
+
+// mascaraBorrados devuelve las posiciones borradas que puede usar un
+// decodificador: nil fuera del canal de borrado o si la lista es solo una
+// muestra, y entonces los borrados se corrigen como errores desconocidos
+func mascaraBorrados(r *noise.ErrorResult) []int {
+	if r.BorradosTruncados {
+		return nil
+	}
+	return r.ErasurePositions
+}
+
+// posicionesEnBloques traduce posiciones de error de la trama al orden de
+// los bloques Hamming: si el payload está entrelazado, cada posición dentro
+// de los bloques pasa a la que ocupa tras deshacer el entrelazado. Sin
+// entrelazado devuelve las mismas posiciones.
+func posicionesEnBloques(trama []byte, posiciones []int) []int {
+	inicioPayload := frame.HeaderLen(trama)
+	if inicioPayload > len(trama) {
+		return posiciones
+	}
+	depth := frame.HammingInterleaveDepth(trama[inicioPayload:])
+	if depth == 0 {
+		return posiciones
+	}
+
+	inicio := frame.HammingBlocksOffset(trama) * 8
+	fin := (len(trama) - frame.ChecksumKindOf(trama).Size()) * 8
+	traducidas := make([]int, len(posiciones))
+	for i, p := range posiciones {
+		if p >= inicio && p < fin {
+			p = inicio + frame.DeinterleavedIndex(p-inicio, fin-inicio, depth)
+		}
+		traducidas[i] = p
+	}
+	return traducidas
+}
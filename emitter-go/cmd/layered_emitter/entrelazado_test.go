@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestProcessMessage_EntrelazadoSeDecodifica(t *testing.T) {
+	for _, algoritmo := range []string{"hamming", "hamming-secded"} {
+		var enviada []byte
+		le := newTestEmitter(func(url string, f []byte) error {
+			enviada = f
+			return nil
+		})
+		le.entrelazado = 7
+
+		config := &application.MessageConfig{Text: "Hola mundo", Algorithm: algoritmo, Mode: "manual"}
+		if _, err := le.ProcessMessage(config); err != nil {
+			t.Fatal(err)
+		}
+		f, err := frame.ParseFrame(enviada)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if frame.HammingInterleaveDepth(f.Payload) != 7 {
+			t.Fatalf("%s: el payload no declara la profundidad: % x", algoritmo, f.Payload[:2])
+		}
+		var bits []byte
+		if algoritmo == "hamming" {
+			bits, _, err = frame.DecodeHammingPayload(f.Payload, 7, frame.Hamming74Decode)
+		} else {
+			bits, _, err = frame.Hamming84DecodePayload(f.Payload)
+		}
+		if err != nil || string(frame.BitsToBytes(bits)) != "Hola mundo" {
+			t.Errorf("%s: se decodificó %q, %v", algoritmo, frame.BitsToBytes(bits), err)
+		}
+	}
+}
+
+func TestProcessMessage_EntrelazadoRequiereHamming(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.entrelazado = 7
+	if _, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"}); err == nil {
+		t.Error("se esperaba error con crc y --interleave")
+	}
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+	if _, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "hamming", Mode: "manual"}); err == nil {
+		t.Error("se esperaba error con --inject y --interleave")
+	}
+}
+
+func TestPosicionesEnBloques_RafagaEnBloquesDistintos(t *testing.T) {
+	trama, err := frame.BuildFrameWithHamming([]byte("Hola mundo"), frame.InterleaveDepth(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inicio := frame.HammingBlocksOffset(trama) * 8
+	rafaga := []int{inicio + 30, inicio + 31, inicio + 32}
+
+	bloques := map[int]bool{}
+	for _, p := range posicionesEnBloques(trama, rafaga) {
+		bloques[(p-inicio)/7] = true
+	}
+	if len(bloques) != 3 {
+		t.Errorf("la ráfaga cayó en %d bloques, se esperaban 3 distintos", len(bloques))
+	}
+
+	// Sin entrelazado y fuera de los bloques las posiciones no cambian
+	plana, _ := frame.BuildFrameWithHamming([]byte("Hola mundo"))
+	if got := posicionesEnBloques(plana, rafaga); got[0] != rafaga[0] || got[2] != rafaga[2] {
+		t.Errorf("sin entrelazado las posiciones cambiaron: %v", got)
+	}
+	if got := posicionesEnBloques(trama, []int{3}); got[0] != 3 {
+		t.Errorf("la posición del header cambió: %v", got)
+	}
+}
+
+func TestRunBenchmark_EntrelazadoMejoraCorreccionConRafagas(t *testing.T) {
+	correccion := func(profundidad int) CorreccionBloques {
+		le := newTestEmitter(func(url string, f []byte) error { return nil })
+		le.noise = noise.NewNoiseLayerWithSeed(21)
+		le.watchdogIteraciones = 0
+		le.largoRafaga = 3
+		le.entrelazado = profundidad
+
+		config := &application.MessageConfig{Text: "Hola mundo", Algorithm: "hamming", BER: 0.01, Mode: "benchmark", Count: 400}
+		benchmark, err := le.RunBenchmark(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if benchmark.CorreccionBloques == nil || benchmark.CorreccionBloques.Payloads != config.Count {
+			t.Fatalf("profundidad %d: corrección %+v", profundidad, benchmark.CorreccionBloques)
+		}
+		if benchmark.LargoRafaga != 3 {
+			t.Errorf("LargoRafaga = %d, se esperaba 3", benchmark.LargoRafaga)
+		}
+		return *benchmark.CorreccionBloques
+	}
+
+	plana, entrelazada := correccion(0), correccion(7)
+	tasa := func(c CorreccionBloques) float64 { return float64(c.PayloadsCorregibles) / float64(c.Payloads) }
+	if tasa(entrelazada) < tasa(plana)+0.2 {
+		t.Errorf("payloads corregibles: %.3f entrelazado vs %.3f sin entrelazar, se esperaba una mejora clara",
+			tasa(entrelazada), tasa(plana))
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+)
+
+// DefaultMuestrasEstimacion es la cantidad de iteraciones reales que corre --estimate
+const DefaultMuestrasEstimacion = 5
+
+// overheadResultado aproxima los bytes fijos de un TransmissionResult (struct,
+// cabeceras de slices y strings) además de sus datos
+const overheadResultado = 400
+
+// CalibracionEstimacion resume las iteraciones de muestra de --estimate
+type CalibracionEstimacion struct {
+	Iteraciones            int
+	Tiempo                 time.Duration // Tiempo total de las iteraciones de muestra
+	BytesPorResultado      int           // Memoria promedio retenida por TransmissionResult
+	BytesNDJSONPorRegistro int           // Tamaño promedio de una línea de --hook-ndjson
+}
+
+// Estimacion es la proyección de una corrida completa
+type Estimacion struct {
+	Iteraciones int
+	Duracion    time.Duration
+	MemoriaPico int64 // Los resultados de todas las iteraciones quedan en memoria hasta el resumen
+	BytesNDJSON int64
+}
+
+// proyectarEstimacion extrapola la calibración a la configuración completa.
+// Con Duration la cantidad de iteraciones es la que entra en ese tiempo.
+func proyectarEstimacion(cal CalibracionEstimacion, config *application.MessageConfig) (*Estimacion, error) {
+	if cal.Iteraciones <= 0 {
+		return nil, fmt.Errorf("la calibración no completó iteraciones")
+	}
+	porIteracion := cal.Tiempo / time.Duration(cal.Iteraciones)
+
+	e := &Estimacion{}
+	if config.Duration > 0 {
+		e.Duracion = config.Duration
+		if porIteracion > 0 {
+			e.Iteraciones = int(config.Duration / porIteracion)
+		}
+	} else {
+		e.Iteraciones = config.Count
+		e.Duracion = porIteracion * time.Duration(config.Count)
+	}
+	e.MemoriaPico = int64(e.Iteraciones) * int64(cal.BytesPorResultado)
+	e.BytesNDJSON = int64(e.Iteraciones) * int64(cal.BytesNDJSONPorRegistro)
+	return e, nil
+}
+
+// calibrarEstimacion corre muestras iteraciones reales de config y mide su
+// tiempo, la memoria retenida por resultado y el tamaño de sus registros NDJSON
+func (le *LayeredEmitter) calibrarEstimacion(config *application.MessageConfig, muestras int) (CalibracionEstimacion, error) {
+	muestra := *config
+	muestra.Count, muestra.Duration = muestras, 0
+
+	// Las muestras no deben disparar el watchdog ni escribir en los hooks de la corrida real
+	watchdog, hooks := le.watchdogIteraciones, le.hooks
+	le.watchdogIteraciones, le.hooks = 0, nil
+	defer func() { le.watchdogIteraciones, le.hooks = watchdog, hooks }()
+
+	benchmark, err := le.RunBenchmark(&muestra)
+	if err != nil {
+		return CalibracionEstimacion{}, err
+	}
+
+	var ndjson contadorBytes
+	hook := NuevoHookNDJSON(&ndjson)
+	// Los slices internados entre iteraciones se cuentan una sola vez
+	vistos := make(map[*byte]bool)
+	retenido := func(s []byte) int {
+		if len(s) == 0 || vistos[&s[0]] {
+			return 0
+		}
+		vistos[&s[0]] = true
+		return len(s)
+	}
+	var memoria int
+	for _, r := range benchmark.Results {
+		hook(r)
+		memoria += overheadResultado + len(r.OriginalMessage) + retenido(r.TextBits) + retenido(r.FrameBytes) +
+			retenido(r.OriginalFrameBits) + len(r.NoisyFrameBits) + 8*len(r.ErrorPositions) + len(r.Error)
+	}
+
+	n := len(benchmark.Results)
+	if n == 0 {
+		return CalibracionEstimacion{}, fmt.Errorf("la calibración no completó iteraciones")
+	}
+	return CalibracionEstimacion{
+		Iteraciones:            n,
+		Tiempo:                 benchmark.TotalTime,
+		BytesPorResultado:      memoria / n,
+		BytesNDJSONPorRegistro: int(ndjson) / n,
+	}, nil
+}
+
+// contadorBytes es un io.Writer que solo cuenta lo escrito
+type contadorBytes int
+
+func (c *contadorBytes) Write(p []byte) (int, error) {
+	*c += contadorBytes(len(p))
+	return len(p), nil
+}
+
+// escribirEstimacion muestra el plan proyectado
+func escribirEstimacion(w io.Writer, cal CalibracionEstimacion, e *Estimacion, conNDJSON bool) {
+	fmt.Fprintln(w, "🧮 Estimación de la corrida:")
+	fmt.Fprintf(w, "   Calibración: %d iteraciones en %v (%v por iteración)\n",
+		cal.Iteraciones, cal.Tiempo, cal.Tiempo/time.Duration(cal.Iteraciones))
+	fmt.Fprintf(w, "   Iteraciones proyectadas: %d\n", e.Iteraciones)
+	fmt.Fprintf(w, "   Duración proyectada: %v\n", e.Duracion.Round(time.Millisecond))
+	fmt.Fprintf(w, "   Memoria pico de resultados: %.1f MiB\n", float64(e.MemoriaPico)/(1<<20))
+	if conNDJSON {
+		fmt.Fprintf(w, "   Tamaño de --hook-ndjson: %.1f MiB\n", float64(e.BytesNDJSON)/(1<<20))
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+)
+
+func TestProyectarEstimacion(t *testing.T) {
+	cal := CalibracionEstimacion{
+		Iteraciones:            5,
+		Tiempo:                 50 * time.Millisecond,
+		BytesPorResultado:      1000,
+		BytesNDJSONPorRegistro: 200,
+	}
+
+	e, err := proyectarEstimacion(cal, &application.MessageConfig{Count: 3600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Iteraciones != 3600 || e.Duracion != 36*time.Second || e.MemoriaPico != 3600000 || e.BytesNDJSON != 720000 {
+		t.Errorf("proyección por cantidad inesperada: %+v", e)
+	}
+
+	e, err = proyectarEstimacion(cal, &application.MessageConfig{Duration: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Iteraciones != 360000 || e.Duracion != time.Hour || e.MemoriaPico != 360000000 {
+		t.Errorf("proyección por duración inesperada: %+v", e)
+	}
+
+	if _, err := proyectarEstimacion(CalibracionEstimacion{}, &application.MessageConfig{Count: 1}); err == nil {
+		t.Error("una calibración sin iteraciones debería fallar")
+	}
+}
+
+func TestCalibrarEstimacion_Loopback(t *testing.T) {
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	envios := 0
+	le := newTestEmitter(func(url string, frame []byte) error {
+		envios++
+		reloj.Advance(10 * time.Millisecond)
+		return nil
+	})
+	le.clock = reloj
+	hookLlamado := false
+	le.RegistrarHook(func(*TransmissionResult) { hookLlamado = true })
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "benchmark", Count: 12000}
+	cal, err := le.calibrarEstimacion(config, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envios != 5 || cal.Iteraciones != 5 {
+		t.Errorf("la calibración debería enviar 5 tramas, envió %d", envios)
+	}
+	if hookLlamado {
+		t.Error("la calibración no debería invocar los hooks de la corrida")
+	}
+	if config.Count != 12000 {
+		t.Error("la calibración no debe modificar la configuración")
+	}
+
+	e, err := proyectarEstimacion(cal, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Duracion != 2*time.Minute {
+		t.Errorf("Duración proyectada = %v, se esperaba 2m (12000 × 10ms)", e.Duracion)
+	}
+	if cal.BytesPorResultado <= overheadResultado || cal.BytesNDJSONPorRegistro == 0 {
+		t.Errorf("la calibración debería medir memoria y NDJSON: %+v", cal)
+	}
+
+	var out bytes.Buffer
+	escribirEstimacion(&out, cal, e, true)
+	if !strings.Contains(out.String(), "Iteraciones proyectadas: 12000") || !strings.Contains(out.String(), "--hook-ndjson") {
+		t.Errorf("plan inesperado:\n%s", out.String())
+	}
+}
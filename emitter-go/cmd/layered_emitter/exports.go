@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+)
+
+// Versiones de esquema de los exports del emisor; se incrementan cuando cambia
+// el formato de cada archivo y figuran en el manifiesto. Desde la v2 cada línea
+// NDJSON lleva además su schema_version; una línea sin ese campo es v1. La v3
+// agrega frame_stats con el costo de la trama.
+const (
+	VersionNDJSONIteraciones = 3
+	VersionMapaErroresPNG    = 1
+)
+
+// destinosExport son los exports que se escriben al terminar el benchmark
+// (nil si no se pidieron o el modo no es benchmark, el único que los produce)
+type destinosExport struct {
+	mapaPNG        io.Writer
+	teoriaCSV      io.Writer
+	iteracionesCSV io.Writer
+}
+
+// abrirExports abre en c los archivos pedidos por los flags y registra el hook
+// NDJSON en le
+func abrirExports(c *export.Conjunto, o *opciones, le *LayeredEmitter) (*destinosExport, error) {
+	d := &destinosExport{}
+	if *o.manifest != "" {
+		if err := c.Reservar(*o.manifest); err != nil {
+			return nil, fmt.Errorf("--manifest: %v", err)
+		}
+	}
+	if *o.bundle != "" && *o.mode == "benchmark" {
+		if err := c.Reservar(*o.bundle); err != nil {
+			return nil, fmt.Errorf("--bundle: %v", err)
+		}
+	}
+	if *o.hookNDJSON != "" {
+		a, err := c.Abrir(*o.hookNDJSON, "ndjson-iteraciones", VersionNDJSONIteraciones)
+		if err != nil {
+			return nil, fmt.Errorf("--hook-ndjson: %v", err)
+		}
+		le.RegistrarHook(NuevoHookNDJSON(a))
+	}
+	if *o.errorPNG != "" && *o.mode == "benchmark" {
+		a, err := c.Abrir(*o.errorPNG, "png-mapa-errores", VersionMapaErroresPNG)
+		if err != nil {
+			return nil, fmt.Errorf("--error-png: %v", err)
+		}
+		d.mapaPNG = a
+	}
+	if *o.theoryCSV != "" && *o.mode == "benchmark" {
+		e, _ := export.EsquemaCSVActual(export.TipoCSVTeoria)
+		a, err := c.Abrir(*o.theoryCSV, e.Tipo, e.Version)
+		if err != nil {
+			return nil, fmt.Errorf("--theory-csv: %v", err)
+		}
+		d.teoriaCSV = a
+	}
+	if *o.iterCSV != "" && *o.mode == "benchmark" {
+		e, _ := export.EsquemaCSVActual(export.TipoCSVIteraciones)
+		a, err := c.Abrir(*o.iterCSV, e.Tipo, e.Version)
+		if err != nil {
+			return nil, fmt.Errorf("--iterations-csv: %v", err)
+		}
+		d.iteracionesCSV = a
+	}
+	return d, nil
+}
+
+// cerrarExports cierra los exports y, si se pidió, escribe el manifiesto
+func cerrarExports(c *export.Conjunto, manifiesto string) error {
+	if manifiesto == "" {
+		return c.Cerrar()
+	}
+	if err := c.EscribirManifiesto(manifiesto); err != nil {
+		return err
+	}
+	fmt.Print(msgs.T("export.manifiesto", manifiesto))
+	return nil
+}
+
+// exportarBenchmark escribe los exports de benchmark en d, registra la corrida
+// en el historial y arma el bundle si se pidió. Intenta todos los exports
+// aunque uno falle, para no perder resultados parciales, y devuelve el primer
+// error
+func exportarBenchmark(o *opciones, d *destinosExport, exports *export.Conjunto, benchmark *BenchmarkResult, parcial bool) (*contenidoBundle, error) {
+	var primero error
+	fallo := func(err error) {
+		if primero == nil {
+			primero = err
+		}
+	}
+
+	if d.mapaPNG != nil {
+		if err := exportarMapaErrores(d.mapaPNG, benchmark, *o.pngMaxIter, *o.pngMaxBits); err != nil {
+			fallo(errors.New(msgs.T("export.error.mapa", err)))
+		} else {
+			fmt.Print(msgs.T("export.mapa", *o.errorPNG))
+			if n := contarTruncadas(benchmark, *o.pngMaxIter); n > 0 {
+				fmt.Print(msgs.T("export.mapa_truncadas", n))
+			}
+		}
+	}
+
+	if d.teoriaCSV != nil {
+		comparacion, err := compararTeoria(benchmark)
+		if err == nil && comparacion == nil {
+			err = errors.New(msgs.T("export.error.sin_teoria"))
+		}
+		if err == nil {
+			err = exportarTeoria(d.teoriaCSV, comparacion.Prediccion)
+		}
+		if err != nil {
+			fallo(errors.New(msgs.T("export.error.teoria", err)))
+		} else {
+			fmt.Print(msgs.T("export.teoria", *o.theoryCSV))
+		}
+	}
+
+	if d.iteracionesCSV != nil {
+		if err := exportarIteracionesCSV(d.iteracionesCSV, benchmark); err != nil {
+			fallo(errors.New(msgs.T("export.error.iteraciones", err)))
+		} else {
+			fmt.Print(msgs.T("export.iteraciones", *o.iterCSV))
+		}
+	}
+
+	var rutas []string
+	for _, e := range []string{*o.errorPNG, *o.hookNDJSON, *o.theoryCSV, *o.iterCSV, *o.bundle} {
+		if e != "" {
+			rutas = append(rutas, e)
+		}
+	}
+	reg := nuevoRegistroHistorial(benchmark, rutas)
+	var bundle *contenidoBundle
+	if *o.bundle != "" {
+		bundle = nuevoContenidoBundle(o, benchmark, reg, exports, parcial)
+	}
+	if !*o.noHistory {
+		if err := agregarHistorial(*o.historyFile, reg); err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("export.historial_error", *o.historyFile, err))
+		} else {
+			fmt.Print(msgs.T("export.historial", reg.ID, os.Args[0]))
+		}
+	}
+	return bundle, primero
+}
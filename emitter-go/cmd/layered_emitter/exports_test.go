@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+)
+
+func parsearOpciones(t *testing.T, args ...string) *opciones {
+	t.Helper()
+	fs := flag.NewFlagSet("layered_emitter", flag.ContinueOnError)
+	o := registrarFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestAbrirExports_ConflictoDeRutas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "salida")
+	o := parsearOpciones(t, "--mode", "benchmark", "--hook-ndjson", path, "--error-png", path)
+
+	c := export.NuevoConjunto()
+	defer c.Cerrar()
+	if _, err := abrirExports(c, o, newTestEmitter(nil)); err == nil {
+		t.Error("--hook-ndjson y --error-png en la misma ruta deberían fallar antes de correr")
+	}
+}
+
+func TestAbrirExports_PNGSoloEnBenchmark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errores.png")
+	o := parsearOpciones(t, "--mode", "manual", "--error-png", path)
+
+	c := export.NuevoConjunto()
+	defer c.Cerrar()
+	d, err := abrirExports(c, o, newTestEmitter(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.mapaPNG != nil {
+		t.Error("el modo manual no produce mapa de errores")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("no debería crearse un PNG vacío fuera del modo benchmark")
+	}
+}
+
+func TestCerrarExports_Manifiesto(t *testing.T) {
+	dir := t.TempDir()
+	ndjson := filepath.Join(dir, "it.ndjson")
+	png := filepath.Join(dir, "errores.png")
+	manifiesto := filepath.Join(dir, "manifest.json")
+	o := parsearOpciones(t, "--mode", "benchmark", "--hook-ndjson", ndjson, "--error-png", png, "--manifest", manifiesto)
+
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+	c := export.NuevoConjunto()
+	d, err := abrirExports(c, o, le)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.05, Mode: "benchmark", Count: 10}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exportarMapaErrores(d.mapaPNG, benchmark, 10, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := cerrarExports(c, *o.manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	var m export.Manifiesto
+	datos, err := os.ReadFile(manifiesto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(datos, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Archivos) != 2 {
+		t.Fatalf("el manifiesto lista %d archivos, se esperaban 2", len(m.Archivos))
+	}
+	for _, e := range m.Archivos {
+		info, err := os.Stat(filepath.Join(dir, e.Ruta))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Bytes == 0 || e.Bytes != info.Size() {
+			t.Errorf("%s: el manifiesto dice %d bytes, el archivo tiene %d", e.Ruta, e.Bytes, info.Size())
+		}
+	}
+}
+
+func TestExportarBenchmark_ParcialTrasWatchdog(t *testing.T) {
+	dir := t.TempDir()
+	csv := filepath.Join(dir, "iteraciones.csv")
+	manifiesto := filepath.Join(dir, "manifest.json")
+	o := parsearOpciones(t, "--mode", "benchmark", "--iterations-csv", csv, "--manifest", manifiesto, "--no-history")
+
+	le := newTestEmitter(func(url string, frame []byte) error { return fmt.Errorf("connection refused") })
+	le.watchdogIteraciones = 4
+	c := export.NuevoConjunto()
+	d, err := abrirExports(c, o, le)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 20}
+	benchmark, err := le.RunBenchmark(config)
+	var werr *WatchdogError
+	if !errors.As(err, &werr) {
+		t.Fatalf("se esperaba WatchdogError, se obtuvo %v", err)
+	}
+	if _, err := exportarBenchmark(o, d, c, benchmark, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := cerrarExports(c, *o.manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(csv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tabla, err := export.LeerCSV(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Una fila por cada iteración completada antes del aborto
+	if len(tabla.Filas) != werr.Iteraciones {
+		t.Errorf("el CSV tiene %d iteraciones, se esperaban las %d parciales", len(tabla.Filas), werr.Iteraciones)
+	}
+
+	var m export.Manifiesto
+	datos, err := os.ReadFile(manifiesto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(datos, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Archivos) != 1 || m.Archivos[0].Bytes == 0 {
+		t.Errorf("el manifiesto debería listar el CSV de iteraciones no vacío: %+v", m.Archivos)
+	}
+}
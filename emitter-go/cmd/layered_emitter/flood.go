@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+)
+
+// FloodConfig configura el modo flood (generador de carga para el receptor)
+type FloodConfig struct {
+	Conexiones int           // Conexiones paralelas persistentes
+	Duracion   time.Duration // Tiempo total de envío
+}
+
+// FloodResult contiene solo contadores: el modo flood no retiene resultados
+// por trama para que la memoria no crezca con la duración
+type FloodResult struct {
+	Conexiones int
+	Enviadas   int64
+	Errores    int64
+	Bytes      int64
+	Duracion   time.Duration
+}
+
+// FramesPorSegundo devuelve el throughput alcanzado en tramas por segundo
+func (r *FloodResult) FramesPorSegundo() float64 {
+	if r.Duracion <= 0 {
+		return 0
+	}
+	return float64(r.Enviadas) / r.Duracion.Seconds()
+}
+
+// BytesPorSegundo devuelve el throughput alcanzado en bytes por segundo
+func (r *FloodResult) BytesPorSegundo() float64 {
+	if r.Duracion <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Duracion.Seconds()
+}
+
+// TasaError devuelve la fracción de envíos que fallaron
+func (r *FloodResult) TasaError() float64 {
+	total := r.Enviadas + r.Errores
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Errores) / float64(total)
+}
+
+// RunFlood envía la misma trama pre-construida tan rápido como sea posible
+// sobre varias conexiones paralelas hasta que se cumple la duración. No aplica
+// ruido ni lleva contabilidad por iteración.
+func (le *LayeredEmitter) RunFlood(frameBytes []byte, cfg FloodConfig) (*FloodResult, error) {
+	if cfg.Conexiones <= 0 {
+		return nil, fmt.Errorf("conexiones debe ser mayor a 0: %d", cfg.Conexiones)
+	}
+	if cfg.Duracion <= 0 {
+		return nil, fmt.Errorf("duración debe ser mayor a 0: %v", cfg.Duracion)
+	}
+
+	// Abrir todas las conexiones antes de empezar a medir
+	conns := make([]*wsclient.Conn, 0, cfg.Conexiones)
+	for i := 0; i < cfg.Conexiones; i++ {
+		conn, err := wsclient.Dial(le.wsURL)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return nil, fmt.Errorf("error abriendo conexión %d: %v", i+1, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	result := &FloodResult{Conexiones: cfg.Conexiones}
+	var enviadas, errores int64
+	start := le.clock.Now()
+	deadline := start.Add(cfg.Duracion)
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *wsclient.Conn) {
+			defer wg.Done()
+			defer conn.Close()
+			for le.clock.Now().Before(deadline) {
+				if err := conn.Send(frameBytes); err != nil {
+					atomic.AddInt64(&errores, 1)
+					return // la conexión quedó inutilizable
+				}
+				atomic.AddInt64(&enviadas, 1)
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	result.Duracion = le.clock.Since(start)
+	result.Enviadas = enviadas
+	result.Errores = errores
+	result.Bytes = enviadas * int64(len(frameBytes))
+	return result, nil
+}
+
+// obtenerContadorReceptor consulta el endpoint de estadísticas del receptor y
+// devuelve su contador total_received
+func obtenerContadorReceptor(statsURL string) (int64, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(statsURL)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("estado HTTP inesperado: %s", resp.Status)
+	}
+
+	var stats struct {
+		TotalReceived *int64 `json:"total_received"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return 0, fmt.Errorf("respuesta de estadísticas inválida: %v", err)
+	}
+	if stats.TotalReceived == nil {
+		return 0, fmt.Errorf("la respuesta no contiene total_received")
+	}
+	return *stats.TotalReceived, nil
+}
+
+func mostrarResultadoFlood(result *FloodResult) {
+	fmt.Println(msgs.T("flood.titulo"))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Print(msgs.T("flood.conexiones", result.Conexiones))
+	fmt.Print(msgs.T("flood.duracion", result.Duracion))
+	fmt.Print(msgs.T("flood.enviadas", result.Enviadas))
+	fmt.Print(msgs.T("flood.throughput", result.FramesPorSegundo(), result.BytesPorSegundo()))
+	fmt.Print(msgs.T("flood.errores", result.Errores, result.TasaError()*100))
+	fmt.Println()
+}
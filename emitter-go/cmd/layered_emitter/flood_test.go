@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newLoopbackReceiver levanta un receptor WebSocket que solo cuenta tramas y
+// expone el contador en /stats con el mismo campo que el receptor Python
+func newLoopbackReceiver(t *testing.T) (*httptest.Server, *int64) {
+	t.Helper()
+	var recibidas int64
+	upgrader := websocket.Upgrader{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int64{"total_received": atomic.LoadInt64(&recibidas)})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			atomic.AddInt64(&recibidas, 1)
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &recibidas
+}
+
+func TestRunFlood_Loopback(t *testing.T) {
+	srv, _ := newLoopbackReceiver(t)
+	le := NewLayeredEmitter("ws" + strings.TrimPrefix(srv.URL, "http"))
+
+	frameBytes, _, err := le.construirTrama("crc", []byte{0, 1, 0, 0, 0, 0, 0, 1}, 0, nil)
+	if err != nil {
+		t.Fatalf("error construyendo trama: %v", err)
+	}
+
+	result, err := le.RunFlood(frameBytes, FloodConfig{Conexiones: 2, Duracion: time.Second})
+	if err != nil {
+		t.Fatalf("error en flood: %v", err)
+	}
+	if result.Enviadas < 100 {
+		t.Errorf("throughput demasiado bajo: %d tramas en %v", result.Enviadas, result.Duracion)
+	}
+	if result.Errores != 0 {
+		t.Errorf("errores de envío inesperados: %d", result.Errores)
+	}
+	if result.Bytes != result.Enviadas*int64(len(frameBytes)) {
+		t.Errorf("bytes = %d, se esperaba %d", result.Bytes, result.Enviadas*int64(len(frameBytes)))
+	}
+
+	// El receptor puede seguir leyendo tramas en vuelo tras el cierre
+	var recibidas int64
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		recibidas, err = obtenerContadorReceptor(srv.URL + "/stats")
+		if err != nil {
+			t.Fatalf("error consultando stats: %v", err)
+		}
+		if recibidas == result.Enviadas {
+			break
+		}
+	}
+	if recibidas != result.Enviadas {
+		t.Errorf("discrepancia: emisor envió %d, receptor contó %d", result.Enviadas, recibidas)
+	}
+}
+
+func TestRunFlood_ConfigInvalida(t *testing.T) {
+	le := NewLayeredEmitter("ws://localhost:1")
+	if _, err := le.RunFlood([]byte{0x01}, FloodConfig{Conexiones: 0, Duracion: time.Second}); err == nil {
+		t.Error("se esperaba error con 0 conexiones")
+	}
+	if _, err := le.RunFlood([]byte{0x01}, FloodConfig{Conexiones: 1, Duracion: 0}); err == nil {
+		t.Error("se esperaba error con duración 0")
+	}
+}
+
+func TestObtenerContadorReceptor_SinCampo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"successful": 3}`))
+	}))
+	defer srv.Close()
+
+	if _, err := obtenerContadorReceptor(srv.URL); err == nil {
+		t.Error("se esperaba error si falta total_received")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+)
+
+// BenchmarkStream entrega los resultados de RunBenchmarkStream a medida que
+// se completan. Quien lo usa debe leer Results hasta que se cierre o
+// cancelar el contexto; si no, el benchmark queda esperando al lector.
+type BenchmarkStream struct {
+	// Results recibe cada iteración al completarse y se cierra al terminar
+	Results <-chan *TransmissionResult
+	// Err recibe un único valor tras cerrarse Results: nil, el
+	// *WatchdogError que abortó la corrida o el error del contexto cancelado
+	Err <-chan error
+
+	benchmark *BenchmarkResult
+	listo     chan struct{}
+}
+
+// Benchmark espera a que termine la corrida y devuelve el agregado, con las
+// iteraciones completadas hasta un aborto o una cancelación
+func (s *BenchmarkStream) Benchmark() *BenchmarkResult {
+	<-s.listo
+	return s.benchmark
+}
+
+// RunBenchmarkStream ejecuta el benchmark en una goroutine y emite cada
+// resultado apenas se completa. Cancelar ctx interrumpe el envío en curso y
+// detiene la corrida; el agregado queda disponible en Benchmark.
+func (le *LayeredEmitter) RunBenchmarkStream(ctx context.Context, config *application.MessageConfig) *BenchmarkStream {
+	results := make(chan *TransmissionResult)
+	errc := make(chan error, 1)
+	s := &BenchmarkStream{Results: results, Err: errc, listo: make(chan struct{})}
+
+	go func() {
+		benchmark, err := le.correrBenchmark(ctx, config, func(r *TransmissionResult) bool {
+			select {
+			case results <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		s.benchmark = benchmark
+		close(s.listo)
+		close(results)
+		errc <- err
+		close(errc)
+	}()
+	return s
+}
+
+// RunBenchmark ejecuta múltiples transmisiones para análisis y devuelve el
+// agregado al terminar (RunBenchmarkStream sin cancelación)
+func (le *LayeredEmitter) RunBenchmark(config *application.MessageConfig) (*BenchmarkResult, error) {
+	s := le.RunBenchmarkStream(context.Background(), config)
+	for range s.Results {
+	}
+	return s.Benchmark(), <-s.Err
+}
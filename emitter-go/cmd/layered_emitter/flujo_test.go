@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+)
+
+// verificarSinFugas falla si al cabo de un segundo siguen vivas más
+// goroutines que antes de empezar la prueba
+func verificarSinFugas(t *testing.T, antes int) {
+	t.Helper()
+	limite := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > antes {
+		if time.Now().After(limite) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines vivas, había %d:\n%s", runtime.NumGoroutine(), antes, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func configStream(count int) *application.MessageConfig {
+	return &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: count}
+}
+
+func TestRunBenchmarkStream_EmiteCadaResultado(t *testing.T) {
+	antes := runtime.NumGoroutine()
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.secuencia = true
+
+	s := le.RunBenchmarkStream(context.Background(), configStream(30))
+	var recibidos []*TransmissionResult
+	for r := range s.Results {
+		recibidos = append(recibidos, r)
+	}
+	if err := <-s.Err; err != nil {
+		t.Fatal(err)
+	}
+
+	b := s.Benchmark()
+	if len(recibidos) != 30 || len(b.Results) != 30 || b.Successful != 30 {
+		t.Fatalf("%d recibidos, agregado con %d resultados y %d exitosos", len(recibidos), len(b.Results), b.Successful)
+	}
+	for i, r := range recibidos {
+		if r != b.Results[i] || *r.Secuencia != uint16(i) {
+			t.Fatalf("el resultado %d no coincide con el agregado", i)
+		}
+	}
+	verificarSinFugas(t, antes)
+}
+
+func TestRunBenchmarkStream_CancelarConsumiendo(t *testing.T) {
+	antes := runtime.NumGoroutine()
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := le.RunBenchmarkStream(ctx, configStream(10000))
+	leidos := 0
+	hecho := make(chan struct{})
+	go func() {
+		defer close(hecho)
+		for range s.Results {
+			if leidos++; leidos == 5 {
+				cancel()
+			}
+		}
+	}()
+	<-hecho
+
+	if err := <-s.Err; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Err = %v, se esperaba context.Canceled", err)
+	}
+	b := s.Benchmark()
+	if n := len(b.Results); n < 5 || n > 7 || b.EndTime.IsZero() {
+		t.Errorf("agregado con %d resultados (fin %v), se esperaban los ~5 completados", n, b.EndTime)
+	}
+	verificarSinFugas(t, antes)
+}
+
+func TestRunBenchmarkStream_CancelarSinLector(t *testing.T) {
+	antes := runtime.NumGoroutine()
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Nadie lee Results: el benchmark queda bloqueado en el primer resultado
+	s := le.RunBenchmarkStream(ctx, configStream(100))
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if b := s.Benchmark(); len(b.Results) != 1 {
+		t.Errorf("agregado con %d resultados, se esperaba 1", len(b.Results))
+	}
+	for range s.Results {
+	}
+	if err := <-s.Err; !errors.Is(err, context.Canceled) {
+		t.Errorf("Err = %v", err)
+	}
+	verificarSinFugas(t, antes)
+}
+
+func TestRunBenchmarkStream_CancelarInterrumpeElEnvio(t *testing.T) {
+	antes := runtime.NumGoroutine()
+	le := newTestEmitter(nil)
+	enviando := make(chan struct{}, 1)
+	le.sendFrame = func(ctx context.Context, url string, f []byte) (*wsclient.ConnStats, error) {
+		enviando <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := le.RunBenchmarkStream(ctx, configStream(100))
+	<-enviando
+	cancel()
+	for range s.Results {
+	}
+	if err := <-s.Err; !errors.Is(err, context.Canceled) {
+		t.Errorf("Err = %v", err)
+	}
+	if b := s.Benchmark(); len(b.Results) != 1 || b.Results[0].Success {
+		t.Errorf("se esperaba un único resultado fallido, hay %d", len(b.Results))
+	}
+	verificarSinFugas(t, antes)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+// ResultadoFragmento es el resultado de transmitir un fragmento con --max-fragment
+type ResultadoFragmento struct {
+	Indice  int // Desde 0
+	Bytes   int // Tamaño de la trama del fragmento
+	Errores int // Errores inyectados en el fragmento
+	Exito   bool
+	Error   string
+	Tiempo  time.Duration
+}
+
+// construirFragmentos aplica el algoritmo de enlace al payload y lo parte en
+// tramas MsgTypeFragment de a lo sumo le.maxFragmento bytes de datos
+func (le *LayeredEmitter) construirFragmentos(algorithm string, textBits []byte) ([][]byte, error) {
+	payload := le.presentation.ConvertirBitsABytes(textBits)
+	tipo := frame.MsgTypeData
+	switch algorithm {
+	case "crc":
+		if le.entrelazado > 0 {
+			return nil, fmt.Errorf("--interleave requiere el algoritmo hamming (actual: crc)")
+		}
+	case "hamming":
+		encoded, err := le.codificarHamming(textBits, 7, frame.Hamming74Encode)
+		if err != nil {
+			return nil, fmt.Errorf("error codificando Hamming: %v", err)
+		}
+		payload = encoded
+		tipo = frame.MsgTypeHamming
+	default:
+		return nil, fmt.Errorf("algoritmo no soportado: %s", algorithm)
+	}
+
+	fragmentos, err := frame.BuildFragmentedFramesWithType(payload, le.maxFragmento, tipo)
+	if err != nil {
+		return nil, fmt.Errorf("error fragmentando: %v", err)
+	}
+	return fragmentos, nil
+}
+
+// transmitirFragmentos aplica ruido y envía cada fragmento por separado (capas
+// 4 y 5 con --max-fragment). result queda con la concatenación de las tramas
+// y sus bits, como si fueran una sola, y con el detalle por fragmento en
+// Fragmentos; es exitoso solo si todos los fragmentos lo fueron.
+func (le *LayeredEmitter) transmitirFragmentos(parent context.Context, result *TransmissionResult, fragmentos [][]byte, ber float64) error {
+	var fallidos int
+	result.Success = true
+
+	for i, f := range fragmentos {
+		ruido, err := le.aplicarRuido(le.presentation.ConvertirBytesABits(f), ber)
+		if err != nil {
+			return fmt.Errorf("error aplicando ruido al fragmento %d: %v", i, err)
+		}
+
+		offset := len(result.OriginalFrameBits)
+		result.FrameBytes = append(result.FrameBytes, f...)
+		result.OriginalFrameBits = append(result.OriginalFrameBits, ruido.OriginalBits...)
+		result.NoisyFrameBits = append(result.NoisyFrameBits, ruido.NoisyBits...)
+		for _, p := range ruido.ErrorPositions {
+			result.ErrorPositions = append(result.ErrorPositions, offset+p)
+		}
+		result.ErrorsInjected += ruido.ErrorsInjected
+		result.PosicionesTruncadas = result.PosicionesTruncadas || ruido.PosicionesTruncadas
+
+		// Con --deadline los fragmentos que no alcanzan a salir no se envían
+		ctx, cancel, vencido := le.contextoEnvio(parent, result)
+		if vencido {
+			le.marcarVencida(result)
+			fmt.Printf("   ⏰ %s (fragmento %d/%d)\n", result.Error, i+1, len(fragmentos))
+			break
+		}
+
+		inicio := le.clock.Now()
+		le.bufEnvio = frame.AppendBytes(le.bufEnvio[:0], ruido.NoisyBits)
+		connStats, err := le.sendFrame(ctx, le.wsURL, le.bufEnvio)
+		cancel()
+		frag := ResultadoFragmento{Indice: i, Bytes: len(f), Errores: ruido.ErrorsInjected, Exito: err == nil,
+			Tiempo: le.clock.Since(inicio)}
+		if connStats != nil {
+			result.ConnStats = connStats
+		}
+		if err != nil {
+			frag.Error = err.Error()
+			result.causa = err
+			fallidos++
+			fmt.Printf("   ❌ Fragmento %d/%d: %d errores, %v\n", i+1, len(fragmentos), ruido.ErrorsInjected, err)
+		} else {
+			fmt.Printf("   ✅ Fragmento %d/%d: %d bytes, %d errores (%v)\n", i+1, len(fragmentos), len(f), ruido.ErrorsInjected, frag.Tiempo)
+		}
+		result.TransmissionTime += frag.Tiempo
+		result.Fragmentos = append(result.Fragmentos, frag)
+	}
+
+	if len(result.OriginalFrameBits) > 0 {
+		result.ActualBER = float64(result.ErrorsInjected) / float64(len(result.OriginalFrameBits))
+	}
+	if fallidos > 0 {
+		result.Success = false
+		result.Error = fmt.Sprintf("%d/%d fragmentos fallaron (último: %s)", fallidos, len(fragmentos), ultimoErrorFragmento(result.Fragmentos))
+	}
+
+	result.EndTime = le.clock.Now()
+	result.TotalTime = result.EndTime.Sub(result.StartTime)
+	if result.Puntualidad != PuntualidadVencida {
+		le.clasificarPuntualidad(result)
+	}
+	return nil
+}
+
+func ultimoErrorFragmento(fragmentos []ResultadoFragmento) string {
+	for i := len(fragmentos) - 1; i >= 0; i-- {
+		if fragmentos[i].Error != "" {
+			return fragmentos[i].Error
+		}
+	}
+	return ""
+}
+
+// mostrarFragmentos imprime la tabla de resultados por fragmento
+func mostrarFragmentos(fragmentos []ResultadoFragmento) {
+	fmt.Printf("Fragmentos: %d\n", len(fragmentos))
+	for _, f := range fragmentos {
+		estado := "✅"
+		if !f.Exito {
+			estado = "❌ " + f.Error
+		}
+		fmt.Printf("  #%-3d %5d bytes  %3d errores  %v  %s\n", f.Indice+1, f.Bytes, f.Errores, f.Tiempo, estado)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestProcessMessage_MaxFragment(t *testing.T) {
+	for _, algoritmo := range []string{"crc", "hamming"} {
+		t.Run(algoritmo, func(t *testing.T) {
+			var enviadas [][]byte
+			le := newTestEmitter(func(url string, f []byte) error {
+				enviadas = append(enviadas, f)
+				return nil
+			})
+			le.maxFragmento = 4
+
+			texto := "Mensaje largo que no entra en un solo fragmento"
+			result, err := le.ProcessMessage(&application.MessageConfig{Text: texto, Algorithm: algoritmo, BER: 0, Mode: "manual"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Success {
+				t.Fatalf("la transmisión falló: %s", result.Error)
+			}
+			if len(result.Fragmentos) != len(enviadas) || len(enviadas) < 2 {
+				t.Fatalf("%d fragmentos registrados, %d enviados", len(result.Fragmentos), len(enviadas))
+			}
+			if !bytes.Equal(result.FrameBytes, bytes.Join(enviadas, nil)) {
+				t.Error("FrameBytes no es la concatenación de los fragmentos enviados")
+			}
+
+			payload, err := frame.ReassembleFrames(enviadas)
+			if err != nil {
+				t.Fatalf("ReassembleFrames: %v", err)
+			}
+			if algoritmo == "hamming" {
+				bits, _, err := frame.DecodeHammingPayload(payload, 7, frame.Hamming74Decode)
+				if err != nil {
+					t.Fatal(err)
+				}
+				payload = frame.BitsToBytes(bits)
+			}
+			if string(payload) != texto {
+				t.Errorf("payload reensamblado = %q, se esperaba %q", payload, texto)
+			}
+		})
+	}
+}
+
+func TestProcessMessage_MaxFragmentFallaParcial(t *testing.T) {
+	n := 0
+	le := newTestEmitter(func(url string, f []byte) error {
+		n++
+		if n == 2 {
+			return fmt.Errorf("conexión cerrada")
+		}
+		return nil
+	})
+	le.maxFragmento = 2
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola mundo", Algorithm: "crc", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success {
+		t.Fatal("se esperaba transmisión fallida")
+	}
+	if !strings.Contains(result.Error, "1/5 fragmentos fallaron") {
+		t.Errorf("Error = %q", result.Error)
+	}
+	for i, f := range result.Fragmentos {
+		if f.Exito == (i == 1) {
+			t.Errorf("fragmento %d: Exito = %v", i, f.Exito)
+		}
+	}
+}
+
+func TestProcessMessage_MaxFragmentPosiciones(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.maxFragmento = 3
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola mundo", Algorithm: "crc", BER: 0.2, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ErrorPositions) != result.ErrorsInjected {
+		t.Fatalf("%d posiciones para %d errores", len(result.ErrorPositions), result.ErrorsInjected)
+	}
+	for _, p := range result.ErrorPositions {
+		if result.OriginalFrameBits[p] == result.NoisyFrameBits[p] {
+			t.Errorf("la posición %d no cambió en la concatenación", p)
+		}
+	}
+}
+
+func TestProcessMessage_MaxFragmentConInyeccion(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.maxFragmento = 4
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+
+	if _, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0, Mode: "manual"}); err == nil {
+		t.Error("se esperaba error al combinar --max-fragment con --inject")
+	}
+}
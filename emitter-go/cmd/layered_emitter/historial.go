@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RegistroHistorial resume una corrida completada en el historial local
+type RegistroHistorial struct {
+	ID                 string    `json:"id"`
+	Fecha              time.Time `json:"timestamp"`
+	ConfigHash         string    `json:"config_hash"`
+	Mensaje            string    `json:"message"`
+	Algoritmo          string    `json:"algorithm"`
+	BER                float64   `json:"ber"`
+	Iteraciones        int       `json:"iterations"`
+	TasaExito          float64   `json:"success_rate"`
+	BERObservado       float64   `json:"observed_ber"`
+	TransmisionMediaMs float64   `json:"avg_transmission_ms"`
+	DuracionMs         float64   `json:"duration_ms"`
+	// Tasa del código y eficiencia de las tramas de la corrida (ver
+	// frame.FrameStats; 0 si no tienen estadísticas de código)
+	TasaCodigo float64  `json:"code_rate,omitempty"`
+	Eficiencia float64  `json:"efficiency,omitempty"`
+	Exports    []string `json:"exports,omitempty"`
+}
+
+// rutaHistorialPorDefecto devuelve ~/.rlab2/history.jsonl
+func rutaHistorialPorDefecto() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".rlab2", "history.jsonl")
+	}
+	return filepath.Join(home, ".rlab2", "history.jsonl")
+}
+
+// hashConfiguracion identifica los parámetros que determinan una corrida
+func hashConfiguracion(b *BenchmarkResult) string {
+	c := b.Config
+	datos, _ := json.Marshal(struct {
+		Text      string
+		Algorithm string
+		BER       float64
+		Count     int
+		Duration  time.Duration
+	}{c.Text, c.Algorithm, c.BER, c.Count, c.Duration})
+	sum := sha256.Sum256(datos)
+	return hex.EncodeToString(sum[:6])
+}
+
+// nuevoRegistroHistorial resume el benchmark para el historial
+func nuevoRegistroHistorial(b *BenchmarkResult, exports []string) RegistroHistorial {
+	hash := hashConfiguracion(b)
+	reg := RegistroHistorial{
+		ID:                 b.StartTime.UTC().Format("20060102T150405") + "-" + hash[:6],
+		Fecha:              b.StartTime.UTC(),
+		ConfigHash:         hash,
+		Mensaje:            b.Config.Text,
+		Algoritmo:          b.Config.Algorithm,
+		BER:                b.Config.BER,
+		Iteraciones:        len(b.Results),
+		TasaExito:          b.SuccessRate,
+		TransmisionMediaMs: float64(b.AverageTransmissionTime) / float64(time.Millisecond),
+		DuracionMs:         float64(b.TotalTime) / float64(time.Millisecond),
+		Exports:            exports,
+	}
+	if c := calibrarBenchmark(b, 0); c.Aplica {
+		reg.BERObservado = c.ObservedBER
+	}
+	if s := b.Estadisticas; s != nil {
+		reg.TasaCodigo, reg.Eficiencia = s.CodeRate(), s.Efficiency()
+	}
+	return reg
+}
+
+// agregarHistorial agrega reg al final del historial en path. Cada registro se
+// escribe con una única llamada a Write sobre un archivo abierto con
+// O_APPEND, así que corridas concurrentes no intercalan líneas.
+func agregarHistorial(path string, reg RegistroHistorial) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	linea, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(linea, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// leerHistorial lee todos los registros de path, en orden de escritura
+func leerHistorial(path string) ([]RegistroHistorial, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var registros []RegistroHistorial
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	linea := 0
+	for scanner.Scan() {
+		linea++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var reg RegistroHistorial
+		if err := json.Unmarshal(scanner.Bytes(), &reg); err != nil {
+			return nil, fmt.Errorf("%s:%d: registro inválido: %v", path, linea, err)
+		}
+		registros = append(registros, reg)
+	}
+	return registros, scanner.Err()
+}
+
+// filtrarHistorial conserva los registros del algoritmo y BER dados (vacío o
+// negativo no filtran) y devuelve los últimos limite (0 = todos)
+func filtrarHistorial(registros []RegistroHistorial, algoritmo string, ber float64, limite int) []RegistroHistorial {
+	var out []RegistroHistorial
+	for _, r := range registros {
+		if algoritmo != "" && r.Algoritmo != algoritmo {
+			continue
+		}
+		if ber >= 0 && r.BER != ber {
+			continue
+		}
+		out = append(out, r)
+	}
+	if limite > 0 && len(out) > limite {
+		out = out[len(out)-limite:]
+	}
+	return out
+}
+
+func buscarRegistro(registros []RegistroHistorial, id string) (*RegistroHistorial, error) {
+	for i := range registros {
+		if registros[i].ID == id {
+			return &registros[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no hay ninguna corrida con id %s", id)
+}
+
+// escribirDiffHistorial compara las métricas clave de dos corridas
+func escribirDiffHistorial(w io.Writer, a, b *RegistroHistorial) {
+	fmt.Fprintf(w, "Comparación %s → %s\n", a.ID, b.ID)
+	if a.ConfigHash != b.ConfigHash {
+		fmt.Fprintf(w, "⚠️  Configuraciones distintas: %s BER=%.4f vs %s BER=%.4f\n",
+			a.Algoritmo, a.BER, b.Algoritmo, b.BER)
+	}
+	metricas := []struct {
+		nombre string
+		va, vb float64
+	}{
+		{"Iteraciones", float64(a.Iteraciones), float64(b.Iteraciones)},
+		{"Tasa de éxito", a.TasaExito, b.TasaExito},
+		{"BER observado", a.BERObservado, b.BERObservado},
+		{"Transmisión media (ms)", a.TransmisionMediaMs, b.TransmisionMediaMs},
+		{"Duración (ms)", a.DuracionMs, b.DuracionMs},
+		{"Tasa del código", a.TasaCodigo, b.TasaCodigo},
+		{"Eficiencia", a.Eficiencia, b.Eficiencia},
+	}
+	for _, m := range metricas {
+		fmt.Fprintf(w, "  %-24s %12.4f %12.4f %+12.4f\n", m.nombre, m.va, m.vb, m.vb-m.va)
+	}
+}
+
+// runHistory implementa "layered_emitter history": lista las corridas
+// registradas o compara dos con --diff
+func runHistory(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	path := fs.String("file", rutaHistorialPorDefecto(), "Archivo de historial")
+	algoritmo := fs.String("algorithm", "", "Mostrar solo corridas de este algoritmo")
+	ber := fs.Float64("ber", -1, "Mostrar solo corridas con este BER")
+	limite := fs.Int("limit", 20, "Cantidad de corridas recientes a mostrar (0 = todas)")
+	diff := fs.Bool("diff", false, "Comparar las dos corridas cuyos id se pasan como argumentos")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	registros, err := leerHistorial(*path)
+	if err != nil {
+		return err
+	}
+
+	if *diff {
+		if fs.NArg() != 2 {
+			return fmt.Errorf("--diff requiere dos id de corrida")
+		}
+		a, err := buscarRegistro(registros, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		b, err := buscarRegistro(registros, fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		escribirDiffHistorial(out, a, b)
+		return nil
+	}
+
+	filtrados := filtrarHistorial(registros, *algoritmo, *ber, *limite)
+	fmt.Fprintf(out, "%-24s %-8s %-8s %-6s %-8s %s\n", "ID", "Algo", "BER", "Iter", "Éxito", "Mensaje")
+	for _, r := range filtrados {
+		fmt.Fprintf(out, "%-24s %-8s %-8.4f %-6d %-7.1f%% %q\n",
+			r.ID, r.Algoritmo, r.BER, r.Iteraciones, r.TasaExito*100, r.Mensaje)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+)
+
+const historialFixture = "testdata/history.jsonl"
+
+func TestAgregarHistorial_Concurrente(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "history.jsonl")
+
+	const corridas = 50
+	var wg sync.WaitGroup
+	for i := 0; i < corridas; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reg := RegistroHistorial{ID: fmt.Sprintf("run-%d", i), Mensaje: strings.Repeat("x", 2000)}
+			if err := agregarHistorial(path, reg); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	registros, err := leerHistorial(path)
+	if err != nil {
+		t.Fatalf("el historial quedó corrupto: %v", err)
+	}
+	if len(registros) != corridas {
+		t.Errorf("se esperaban %d registros, se leyeron %d", corridas, len(registros))
+	}
+}
+
+func TestNuevoRegistroHistorial(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.02, Mode: "benchmark", Count: 10}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := nuevoRegistroHistorial(benchmark, []string{"mapa.png"})
+	if reg.Iteraciones != 10 || reg.TasaExito != 1 || reg.Algoritmo != "crc" || reg.BER != 0.02 {
+		t.Errorf("registro inesperado: %+v", reg)
+	}
+	if !strings.HasSuffix(reg.ID, reg.ConfigHash[:6]) {
+		t.Errorf("el id %s debería terminar con el hash de la configuración %s", reg.ID, reg.ConfigHash)
+	}
+
+	// La misma configuración produce el mismo hash aunque cambien los resultados
+	otra, _ := le.RunBenchmark(config)
+	if hashConfiguracion(otra) != reg.ConfigHash {
+		t.Error("el hash debería depender solo de la configuración")
+	}
+	config2 := *config
+	config2.BER = 0.03
+	if hashConfiguracion(&BenchmarkResult{Config: &config2}) == reg.ConfigHash {
+		t.Error("configuraciones distintas deberían tener hashes distintos")
+	}
+}
+
+func TestRunHistory_Filtros(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		ids  []string
+	}{
+		{"todas", nil, []string{"aaaaaa", "bbbbbb", "cccccc"}},
+		{"por algoritmo", []string{"--algorithm", "hamming"}, []string{"bbbbbb", "cccccc"}},
+		{"por BER", []string{"--ber", "0.01"}, []string{"aaaaaa", "bbbbbb"}},
+		{"ambos", []string{"--algorithm", "hamming", "--ber", "0.05"}, []string{"cccccc"}},
+		{"límite", []string{"--limit", "1"}, []string{"cccccc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := runHistory(append([]string{"--file", historialFixture}, tt.args...), &out); err != nil {
+				t.Fatal(err)
+			}
+			lineas := strings.Split(strings.TrimSpace(out.String()), "\n")[1:]
+			if len(lineas) != len(tt.ids) {
+				t.Fatalf("se esperaban %d corridas, se listaron %d:\n%s", len(tt.ids), len(lineas), out.String())
+			}
+			for i, id := range tt.ids {
+				if !strings.Contains(lineas[i], id) {
+					t.Errorf("fila %d = %q, se esperaba %s", i, lineas[i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestRunHistory_Diff(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"--file", historialFixture, "--diff", "20240301T100000-aaaaaa", "20240301T110000-bbbbbb"}
+	if err := runHistory(args, &out); err != nil {
+		t.Fatal(err)
+	}
+	salida := out.String()
+	if !strings.Contains(salida, "Configuraciones distintas") {
+		t.Error("el diff debería advertir que las configuraciones difieren")
+	}
+	if !strings.Contains(salida, "+0.1500") {
+		t.Errorf("el diff debería mostrar la mejora de la tasa de éxito:\n%s", salida)
+	}
+
+	if err := runHistory([]string{"--file", historialFixture, "--diff", "nada"}, &out); err == nil {
+		t.Error("--diff con un solo id debería fallar")
+	}
+	if err := runHistory([]string{"--file", historialFixture, "--diff", "20240301T100000-aaaaaa", "nada"}, &out); err == nil {
+		t.Error("--diff con un id inexistente debería fallar")
+	}
+}
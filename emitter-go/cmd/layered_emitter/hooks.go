@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultHookBudget es el tiempo máximo que se espera a cada hook por iteración
+const DefaultHookBudget = time.Second
+
+// ResultHook procesa el resultado de cada iteración completada. Los hooks se
+// invocan en orden de finalización de las iteraciones (en los modos actuales,
+// que son secuenciales, coincide con el orden de iteración), una sola vez por
+// resultado y en el orden en que fueron registrados.
+type ResultHook func(*TransmissionResult)
+
+// RegistrarHook agrega un hook que se invocará tras cada iteración
+func (le *LayeredEmitter) RegistrarHook(h ResultHook) {
+	le.hooks = append(le.hooks, h)
+}
+
+// ejecutarHooks invoca cada hook de forma síncrona. Un hook que entra en
+// pánico o excede hookBudget se reporta y no detiene la corrida; si se excede
+// el presupuesto, el hook sigue corriendo en segundo plano.
+func (le *LayeredEmitter) ejecutarHooks(result *TransmissionResult) {
+	for i, h := range le.hooks {
+		done := make(chan interface{}, 1)
+		go func(h ResultHook) {
+			defer func() { done <- recover() }()
+			h(result)
+		}(h)
+
+		if le.hookBudget <= 0 {
+			if p := <-done; p != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Hook %d entró en pánico: %v\n", i+1, p)
+			}
+			continue
+		}
+		select {
+		case p := <-done:
+			if p != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Hook %d entró en pánico: %v\n", i+1, p)
+			}
+		case <-le.clock.After(le.hookBudget):
+			fmt.Fprintf(os.Stderr, "⚠️  Hook %d excedió el presupuesto de %v\n", i+1, le.hookBudget)
+		}
+	}
+}
+
+// registroNDJSON es la línea que escribe el hook --hook-ndjson
+type registroNDJSON struct {
+	Version           int     `json:"schema_version"`
+	Iteracion         int     `json:"iteration"`
+	Mensaje           string  `json:"message"`
+	Algoritmo         string  `json:"algorithm"`
+	BER               float64 `json:"ber"`
+	Exito             bool    `json:"success"`
+	Error             string  `json:"error,omitempty"`
+	TamTrama          int     `json:"frame_bytes"`
+	ErroresInyectados int     `json:"errors_injected"`
+	BERReal           float64 `json:"actual_ber"`
+	DesviacionBER     float64 `json:"ber_deviation"`
+	TransmisionMs     float64 `json:"transmission_ms"`
+	Inyeccion         string  `json:"inject,omitempty"`
+	PosicionesError   []int   `json:"error_positions,omitempty"`
+	// PosicionesTruncadas indica que error_positions es una muestra
+	PosicionesTruncadas bool `json:"error_positions_truncated,omitempty"`
+	// Puntualidad respecto a --deadline y retraso sobre el plazo
+	Puntualidad string  `json:"deadline_outcome,omitempty"`
+	RetrasoMs   float64 `json:"lateness_ms,omitempty"`
+	// Secuencia del header de la trama, para cruzar con el receptor
+	Secuencia *uint16 `json:"seq,omitempty"`
+	// Costo de la trama (ausente si no tiene estadísticas de código)
+	Costo *costoNDJSON `json:"frame_stats,omitempty"`
+}
+
+// costoNDJSON es frame.FrameStats en los registros NDJSON, con las tasas ya
+// calculadas
+type costoNDJSON struct {
+	BitsDatos      int     `json:"data_bits"`
+	BytesHeader    int     `json:"header_bytes"`
+	BytesSubheader int     `json:"subheader_bytes"`
+	BytesPayload   int     `json:"payload_bytes"`
+	BytesChecksum  int     `json:"checksum_bytes"`
+	BitsRelleno    int     `json:"pad_bits"`
+	TasaCodigo     float64 `json:"code_rate"`
+	Eficiencia     float64 `json:"efficiency"`
+}
+
+// NuevoHookNDJSON devuelve un hook que escribe una línea JSON por iteración en
+// w, enriquecida con el número de iteración y la desviación del BER real
+// respecto al objetivo. Cada línea se vacía al escribirse.
+func NuevoHookNDJSON(w io.Writer) ResultHook {
+	var mu sync.Mutex
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	iteracion := 0
+
+	return func(r *TransmissionResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		iteracion++
+
+		reg := registroNDJSON{
+			Version:             VersionNDJSONIteraciones,
+			Iteracion:           iteracion,
+			Mensaje:             r.OriginalMessage,
+			Exito:               r.Success,
+			Error:               r.Error,
+			TamTrama:            len(r.FrameBytes),
+			ErroresInyectados:   r.ErrorsInjected,
+			BERReal:             r.ActualBER,
+			TransmisionMs:       float64(r.TransmissionTime) / float64(time.Millisecond),
+			Inyeccion:           r.Inyeccion,
+			PosicionesError:     r.ErrorPositions,
+			PosicionesTruncadas: r.PosicionesTruncadas,
+			Puntualidad:         r.Puntualidad,
+			RetrasoMs:           float64(r.Retraso) / float64(time.Millisecond),
+			Secuencia:           r.Secuencia,
+		}
+		if s := r.Estadisticas; s != nil {
+			reg.Costo = &costoNDJSON{
+				BitsDatos:      s.DataBits,
+				BytesHeader:    s.HeaderBytes,
+				BytesSubheader: s.SubheaderBytes,
+				BytesPayload:   s.EncodedBytes,
+				BytesChecksum:  s.ChecksumBytes,
+				BitsRelleno:    s.PadBits,
+				TasaCodigo:     s.CodeRate(),
+				Eficiencia:     s.Efficiency(),
+			}
+		}
+		if r.Config != nil {
+			reg.Algoritmo = r.Config.Algorithm
+			reg.BER = r.Config.BER
+			reg.DesviacionBER = r.ActualBER - r.Config.BER
+		}
+		if err := enc.Encode(reg); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Error escribiendo NDJSON: %v\n", err)
+			return
+		}
+		bw.Flush()
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestRunBenchmark_HookUnaVezPorIteracion(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+
+	vistos := make(map[*TransmissionResult]int)
+	var orden []*TransmissionResult
+	le.RegistrarHook(func(r *TransmissionResult) {
+		vistos[r]++
+		orden = append(orden, r)
+	})
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "benchmark", Count: 25}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orden) != len(benchmark.Results) {
+		t.Fatalf("el hook se invocó %d veces para %d iteraciones", len(orden), len(benchmark.Results))
+	}
+	for i, r := range benchmark.Results {
+		if vistos[r] != 1 {
+			t.Errorf("iteración %d: el hook se invocó %d veces", i, vistos[r])
+		}
+		if orden[i] != r {
+			t.Errorf("iteración %d: el hook no se invocó en orden de finalización", i)
+		}
+	}
+}
+
+func TestEjecutarHooks_PanicoNoDetieneLaCorrida(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+
+	llamadas := 0
+	le.RegistrarHook(func(r *TransmissionResult) { panic("hook roto") })
+	le.RegistrarHook(func(r *TransmissionResult) { llamadas++ })
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 3}
+	if _, err := le.RunBenchmark(config); err != nil {
+		t.Fatal(err)
+	}
+	if llamadas != 3 {
+		t.Errorf("el segundo hook debería correr en cada iteración: %d llamadas", llamadas)
+	}
+}
+
+func TestEjecutarHooks_PresupuestoExcedido(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.hookBudget = 20 * time.Millisecond
+
+	bloqueo := make(chan struct{})
+	defer close(bloqueo)
+	le.RegistrarHook(func(r *TransmissionResult) { <-bloqueo })
+
+	start := time.Now()
+	le.ejecutarHooks(&TransmissionResult{})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("un hook bloqueado detuvo la iteración %v", elapsed)
+	}
+}
+
+func TestNuevoHookNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	hook := NuevoHookNDJSON(&out)
+	config := &application.MessageConfig{Algorithm: "hamming", BER: 0.05}
+
+	hook(&TransmissionResult{Config: config, OriginalMessage: "Hola", Success: true, ActualBER: 0.04,
+		TransmissionTime: 2 * time.Millisecond, FrameBytes: make([]byte, 10)})
+	hook(&TransmissionResult{Config: config, OriginalMessage: "Hola", Error: "fallo de red"})
+	hook(&TransmissionResult{Config: config, OriginalMessage: "Hola", ErrorPositions: []int{3}, PosicionesTruncadas: true})
+
+	lineas := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lineas) != 3 {
+		t.Fatalf("se esperaban 3 líneas, se obtuvieron %d", len(lineas))
+	}
+
+	var reg registroNDJSON
+	if err := json.Unmarshal([]byte(lineas[0]), &reg); err != nil {
+		t.Fatal(err)
+	}
+	if reg.Version != VersionNDJSONIteraciones || reg.Iteracion != 1 || reg.Algoritmo != "hamming" || reg.TamTrama != 10 || reg.TransmisionMs != 2 {
+		t.Errorf("registro inesperado: %+v", reg)
+	}
+	if d := reg.DesviacionBER; d > -0.0099 || d < -0.0101 {
+		t.Errorf("DesviacionBER = %v, se esperaba -0.01", d)
+	}
+
+	if err := json.Unmarshal([]byte(lineas[1]), &reg); err != nil {
+		t.Fatal(err)
+	}
+	if reg.Iteracion != 2 || reg.Exito || reg.Error != "fallo de red" || reg.PosicionesTruncadas {
+		t.Errorf("registro inesperado: %+v", reg)
+	}
+
+	if reg.Costo != nil || strings.Contains(lineas[0], "frame_stats") {
+		t.Errorf("sin estadísticas no debería haber frame_stats: %s", lineas[0])
+	}
+
+	if !strings.Contains(lineas[2], `"error_positions_truncated":true`) {
+		t.Errorf("falta la marca de posiciones truncadas: %s", lineas[2])
+	}
+}
+
+func TestNuevoHookNDJSON_Estadisticas(t *testing.T) {
+	var out bytes.Buffer
+	s := frame.FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 8, ChecksumBytes: 4, Frames: 1}
+	NuevoHookNDJSON(&out)(&TransmissionResult{Config: &application.MessageConfig{Algorithm: "hamming"}, Estadisticas: &s})
+
+	var reg registroNDJSON
+	if err := json.Unmarshal(out.Bytes(), &reg); err != nil {
+		t.Fatal(err)
+	}
+	want := costoNDJSON{BitsDatos: 32, BytesHeader: 3, BytesSubheader: 1, BytesPayload: 8, BytesChecksum: 4, TasaCodigo: 0.5, Eficiencia: 32.0 / 120}
+	if reg.Costo == nil || *reg.Costo != want {
+		t.Errorf("frame_stats = %+v, se esperaba %+v", reg.Costo, want)
+	}
+	if !strings.Contains(out.String(), `"pad_bits":0`) {
+		t.Errorf("pad_bits debería figurar aunque sea 0: %s", out.String())
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"hash/fnv"
+)
+
+// DefaultMaxInternados limita los contenidos distintos que guarda la tabla de
+// internado de un benchmark; pasado el límite los resultados nuevos conservan
+// sus propios slices
+const DefaultMaxInternados = 1024
+
+// tablaInternado guarda una sola copia de cada contenido de bytes que se
+// repite entre iteraciones, indexada por su hash
+type tablaInternado struct {
+	max      int
+	porHash  map[uint64][]byte
+	reusados int   // Slices reemplazados por una copia ya guardada
+	ahorro   int64 // Bytes que dejaron de retenerse
+}
+
+func nuevaTablaInternado(max int) *tablaInternado {
+	return &tablaInternado{max: max, porHash: make(map[uint64][]byte)}
+}
+
+// internar devuelve la copia guardada con el contenido de s, o guarda s si
+// todavía no hay una y queda lugar. El slice devuelto tiene cap == len, así
+// que un append sobre él siempre reasigna y nunca pisa a otro resultado. Ante
+// una colisión de hash con otro contenido, s se devuelve sin compartir.
+func (t *tablaInternado) internar(s []byte) []byte {
+	if len(s) == 0 {
+		return s
+	}
+	h := fnv.New64a()
+	h.Write(s)
+	clave := h.Sum64()
+
+	if guardado, ok := t.porHash[clave]; ok {
+		if !bytes.Equal(guardado, s) {
+			return s
+		}
+		t.reusados++
+		t.ahorro += int64(len(s))
+		return guardado
+	}
+	s = s[:len(s):len(s)]
+	if len(t.porHash) < t.max {
+		t.porHash[clave] = s
+	}
+	return s
+}
+
+// internarResultado comparte los slices de r que no dependen del ruido: los
+// bits del texto y la trama original. NoisyFrameBits y ErrorPositions son
+// propios de cada iteración.
+func (t *tablaInternado) internarResultado(r *TransmissionResult) {
+	r.TextBits = t.internar(r.TextBits)
+	r.FrameBytes = t.internar(r.FrameBytes)
+	r.OriginalFrameBits = t.internar(r.OriginalFrameBits)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestInternar_CompartenContenidoSinPisarse(t *testing.T) {
+	tabla := nuevaTablaInternado(DefaultMaxInternados)
+	a := tabla.internar(append(make([]byte, 0, 16), 1, 0, 1))
+	b := tabla.internar([]byte{1, 0, 1})
+	if &a[0] != &b[0] {
+		t.Fatal("contenidos iguales deberían compartir el slice")
+	}
+	if cap(a) != len(a) {
+		t.Fatalf("cap %d, se esperaba %d para que append reasigne", cap(a), len(a))
+	}
+	if tabla.reusados != 1 || tabla.ahorro != 3 {
+		t.Errorf("reusados %d, ahorro %d", tabla.reusados, tabla.ahorro)
+	}
+
+	// Un append sobre un resultado no modifica al otro
+	a = append(a, 1)
+	if !bytes.Equal(b, []byte{1, 0, 1}) {
+		t.Errorf("el slice compartido cambió: %v", b)
+	}
+
+	c := tabla.internar([]byte{0, 0, 1})
+	if &c[0] == &b[0] {
+		t.Error("contenidos distintos no deberían compartirse")
+	}
+}
+
+func TestInternar_TablaAcotada(t *testing.T) {
+	tabla := nuevaTablaInternado(2)
+	for i := byte(0); i < 4; i++ {
+		tabla.internar([]byte{i})
+	}
+	if len(tabla.porHash) != 2 {
+		t.Fatalf("la tabla guarda %d contenidos, el límite es 2", len(tabla.porHash))
+	}
+
+	// Lo que quedó fuera de la tabla se devuelve sin compartir
+	x, y := tabla.internar([]byte{3}), tabla.internar([]byte{3})
+	if &x[0] == &y[0] {
+		t.Error("un contenido fuera de la tabla no debería compartirse")
+	}
+}
+
+func TestRunBenchmark_InternaBitsDelTexto(t *testing.T) {
+	config := &application.MessageConfig{Text: "Hola mundo", Algorithm: "crc", BER: 0.05, Mode: "benchmark", Count: 10}
+
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	primero := benchmark.Results[0].TextBits
+	for i, r := range benchmark.Results[1:] {
+		if &r.TextBits[0] != &primero[0] {
+			t.Errorf("iteración %d: TextBits no comparte el slice", i+1)
+		}
+	}
+	if benchmark.SlicesCompartidos < 9 || benchmark.BytesCompartidos < int64(9*len(primero)) {
+		t.Errorf("compartidos %d slices, %d bytes", benchmark.SlicesCompartidos, benchmark.BytesCompartidos)
+	}
+
+	// El ruido de una iteración no alcanza a la trama original compartida
+	for i, r := range benchmark.Results {
+		if !bytes.Equal(r.OriginalFrameBits, frame.BytesToBits(r.FrameBytes)) {
+			t.Errorf("iteración %d: la trama original no coincide con FrameBytes", i)
+		}
+	}
+
+	le = newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.paranoico = true
+	paranoico, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paranoico.SlicesCompartidos != 0 {
+		t.Errorf("--paranoid compartió %d slices", paranoico.SlicesCompartidos)
+	}
+	a, b := paranoico.Results[0].TextBits, paranoico.Results[1].TextBits
+	if &a[0] == &b[0] {
+		t.Error("--paranoid no debería compartir slices")
+	}
+	if !bytes.Equal(a, primero) {
+		t.Error("el contenido de los resultados no debería depender del internado")
+	}
+}
+
+func BenchmarkRunBenchmark_Internado(b *testing.B) {
+	config := &application.MessageConfig{Text: "Hola mundo, mensaje de prueba", Algorithm: "hamming", BER: 0.01, Mode: "benchmark", Count: 200}
+	for _, paranoico := range []bool{false, true} {
+		nombre := "compartido"
+		if paranoico {
+			nombre = "paranoico"
+		}
+		b.Run(nombre, func(b *testing.B) {
+			le := newTestEmitter(func(url string, frame []byte) error { return nil })
+			le.paranoico = paranoico
+			var retenido int
+			for i := 0; i < b.N; i++ {
+				benchmark, err := le.RunBenchmark(config)
+				if err != nil {
+					b.Fatal(err)
+				}
+				vistos := make(map[*byte]bool)
+				retenido = 0
+				for _, r := range benchmark.Results {
+					for _, s := range [][]byte{r.TextBits, r.FrameBytes, r.OriginalFrameBits} {
+						if len(s) > 0 && !vistos[&s[0]] {
+							vistos[&s[0]] = true
+							retenido += len(s)
+						}
+					}
+				}
+			}
+			b.ReportMetric(float64(retenido), "bytes-retenidos")
+		})
+	}
+}
@@ -1,41 +1,143 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/flagutil"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/presentation"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
 	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+	"github.com/gorilla/websocket"
 )
 
+// DefaultWatchdogIteraciones es la cantidad de iteraciones que el watchdog
+// observa antes de decidir si el benchmark está mal configurado
+const DefaultWatchdogIteraciones = 50
+
+// DefaultFilasParidad son las filas de la matriz de parity2d sin --parity-rows
+const DefaultFilasParidad = 8
+
 // LayeredEmitter implementa la arquitectura de capas completa
 type LayeredEmitter struct {
 	app          *application.ApplicationLayer
 	presentation *presentation.PresentationLayer
 	noise        *noise.NoiseLayer
 	wsURL        string
+
+	// sendFrame envía una trama al receptor (reemplazable en tests). frame
+	// solo es válido durante la llamada: el emisor reutiliza su arreglo.
+	sendFrame func(ctx context.Context, url string, frame []byte) (*wsclient.ConnStats, error)
+	// bufPayload y bufEnvio se reutilizan entre iteraciones para las
+	// conversiones de bits a bytes que no sobreviven a la iteración (el
+	// payload antes de codificarlo y la trama ruidosa que se envía)
+	bufPayload []byte
+	bufEnvio   []byte
+	// sendFrameReply envía una trama y espera la respuesta ACK/NACK del
+	// receptor cuando esperaRespuesta es mayor que 0 (reemplazable en tests)
+	sendFrameReply  func(ctx context.Context, url string, trama []byte, timeout time.Duration) (*wsclient.ConnStats, *frame.Reply, error)
+	esperaRespuesta time.Duration
+	// watchdogIteraciones es el tamaño de la ventana inicial del watchdog (0 lo desactiva)
+	watchdogIteraciones int
+	// inyeccion reemplaza el ruido por BER con errores dirigidos a bloques Hamming
+	inyeccion []noise.DirectivaInyeccion
+	// clock mide tiempos y plazos (reemplazable por un reloj manual en tests)
+	clock clock.Clock
+	// hooks se invocan tras cada iteración (ver RegistrarHook)
+	hooks      []ResultHook
+	hookBudget time.Duration
+	// planBER reemplaza el BER fijo del benchmark por un canal no estacionario
+	planBER *noise.PlanBER
+	// normalizacion convierte el texto a ASCII antes de codificarlo (nil la desactiva)
+	normalizacion *presentation.OpcionesNormalizacion
+	// deadline es el plazo de cada mensaje, de la codificación al envío (0 sin plazo)
+	deadline time.Duration
+	// checksum verifica las tramas (CRC-32 por defecto; los fragmentos siempre usan CRC-32)
+	checksum frame.ChecksumKind
+	// contenido se declara en el header de las tramas (ContentUnspecified no
+	// agrega el byte de contenido; los fragmentos nunca lo llevan)
+	contenido frame.ContentType
+	// secuencia numera cada trama del benchmark en el header (FlagSeq). Es
+	// opcional porque el receptor Python lee el header fijo de 3 bytes; con
+	// esperaRespuesta se numeran siempre, para cruzar cada ACK/NACK
+	secuencia bool
+	// filasParidad son las filas de la matriz del algoritmo parity2d
+	filasParidad int
+	// paridadRS son los símbolos de paridad por bloque del algoritmo rs
+	paridadRS int
+	// maxFragmento parte la trama en fragmentos de a lo sumo estos bytes de datos (0 sin fragmentar)
+	maxFragmento int
+	// paranoico desactiva el internado: cada resultado del benchmark conserva
+	// sus propios slices aunque repitan el contenido de otra iteración
+	paranoico bool
+	// entrelazado es la profundidad con la que se entrelazan los bloques
+	// Hamming tras codificarlos (0 sin entrelazar)
+	entrelazado int
+	// largoRafaga reemplaza el canal binario simétrico por ráfagas de errores
+	// de ese largo (0 con errores independientes por bit)
+	largoRafaga int
+	// borrado reemplaza el canal binario simétrico por el canal de borrado:
+	// el BER es la probabilidad de borrado y rs decodifica con la máscara
+	borrado bool
 }
 
 // NewLayeredEmitter crea una nueva instancia
 func NewLayeredEmitter(wsURL string) *LayeredEmitter {
 	return &LayeredEmitter{
-		app:          application.NewApplicationLayer(),
-		presentation: presentation.NewPresentationLayer(),
-		noise:        noise.NewNoiseLayer(),
-		wsURL:        wsURL,
+		app:                 application.NewApplicationLayer(),
+		presentation:        presentation.NewPresentationLayer(),
+		noise:               noise.NewNoiseLayer(),
+		wsURL:               wsURL,
+		sendFrame:           wsclient.SendFrameContext,
+		sendFrameReply:      wsclient.SendFrameAwaitReplyContext,
+		watchdogIteraciones: DefaultWatchdogIteraciones,
+		clock:               clock.Real(),
+		hookBudget:          DefaultHookBudget,
+		filasParidad:        DefaultFilasParidad,
+		paridadRS:           frame.DefaultRSParity,
 	}
 }
 
+// WatchdogError indica que el watchdog abortó el benchmark porque todas las
+// iteraciones observadas fallaron de la misma forma
+type WatchdogError struct {
+	Iteraciones int    // Iteraciones observadas antes de abortar
+	Motivo      string // "transporte" o "procesamiento"
+	Diagnostico string // Causa probable de la mala configuración
+	UltimoError string // Último error observado
+}
+
+func (e *WatchdogError) Error() string {
+	return fmt.Sprintf("watchdog: %d/%d iteraciones fallaron por %s: %s (último error: %s)",
+		e.Iteraciones, e.Iteraciones, e.Motivo, e.Diagnostico, e.UltimoError)
+}
+
 // ProcessMessage procesa un mensaje a través de todas las capas
 func (le *LayeredEmitter) ProcessMessage(config *application.MessageConfig) (*TransmissionResult, error) {
+	return le.procesarMensaje(context.Background(), config, nil)
+}
+
+// procesarMensaje es ProcessMessage; con seq distinto de nil la trama lleva
+// ese número de secuencia en el header (ver frame.FlagSeq). Cancelar ctx
+// interrumpe el envío.
+func (le *LayeredEmitter) procesarMensaje(ctx context.Context, config *application.MessageConfig, seq *uint16) (*TransmissionResult, error) {
 	result := &TransmissionResult{
 		Config:    config,
-		StartTime: time.Now(),
+		StartTime: le.clock.Now(),
 	}
 
 	fmt.Printf("🚀 Iniciando transmisión de: \"%s\"\n", config.Text)
@@ -46,7 +148,18 @@ func (le *LayeredEmitter) ProcessMessage(config *application.MessageConfig) (*Tr
 
 	// CAPA 2: PRESENTACIÓN - ASCII → bits
 	fmt.Println("📝 Capa de Presentación - Codificando mensaje...")
-	textBits, err := le.presentation.CodificarMensaje(config.Text)
+	texto := config.Text
+	if le.normalizacion != nil {
+		texto, result.Normalizacion = le.presentation.NormalizarTexto(texto, *le.normalizacion)
+		if len(result.Normalizacion) > 0 {
+			result.TextoEnviado = texto
+			fmt.Printf("   Normalización: %d sustituciones, se envía \"%s\"\n", len(result.Normalizacion), texto)
+			for _, sust := range result.Normalizacion {
+				fmt.Printf("     %s\n", sust)
+			}
+		}
+	}
+	textBits, err := le.presentation.CodificarMensaje(texto)
 	if err != nil {
 		return nil, fmt.Errorf("error en presentación: %v", err)
 	}
@@ -55,60 +168,133 @@ func (le *LayeredEmitter) ProcessMessage(config *application.MessageConfig) (*Tr
 
 	// CAPA 3: ENLACE - Aplicar detección/corrección
 	fmt.Println("🔗 Capa de Enlace - Aplicando algoritmo...")
-	var frameBytes []byte
-
-	switch config.Algorithm {
-	case "crc":
-		// Para CRC: bits → bytes → frame con CRC
-		payloadBytes := le.presentation.ConvertirBitsABytes(textBits)
-		frameBytes, err = frame.BuildFrame(payloadBytes)
-		if err != nil {
-			return nil, fmt.Errorf("error construyendo frame CRC: %v", err)
+	if le.maxFragmento > 0 {
+		if len(le.inyeccion) > 0 {
+			return nil, fmt.Errorf("--inject no admite tramas fragmentadas")
 		}
-		fmt.Printf("   CRC-32 aplicado, frame de %d bytes\n", len(frameBytes))
-
-	case "hamming":
-		// Para Hamming: bits → hamming encode → bytes → frame con CRC
-		frameBytes, err = frame.BuildFrameWithHamming(le.presentation.ConvertirBitsABytes(textBits))
+		fragmentos, err := le.construirFragmentos(config.Algorithm, textBits)
 		if err != nil {
-			return nil, fmt.Errorf("error construyendo frame Hamming: %v", err)
+			return nil, err
 		}
-		fmt.Printf("   Hamming(7,4) + CRC-32 aplicado, frame de %d bytes\n", len(frameBytes))
+		fmt.Printf("   %s aplicado, %d fragmentos de hasta %d bytes de datos\n", config.Algorithm, len(fragmentos), le.maxFragmento)
 
-	default:
-		return nil, fmt.Errorf("algoritmo no soportado: %s", config.Algorithm)
+		// CAPAS 4 y 5: ruido y envío por fragmento
+		fmt.Println("📡🌐 Capas de Ruido y Transmisión - Enviando fragmentos...")
+		if err := le.transmitirFragmentos(ctx, result, fragmentos, config.BER); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	frameBytes, descripcion, err := le.construirTrama(config.Algorithm, textBits, config.BER, seq)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("   %s aplicado, frame de %d bytes\n", descripcion, len(frameBytes))
+	if seq != nil {
+		fmt.Printf("   Secuencia: %d\n", *seq)
 	}
 
 	result.FrameBytes = frameBytes
+	result.Secuencia = seq
+	result.Estadisticas = estadisticasTrama(frameBytes, len(textBits))
+	if config.Algorithm == "auto-hamming" {
+		result.VarianteHamming = elegirVarianteHamming(len(textBits), config.BER).Name
+	}
 
 	// CAPA 4: RUIDO - Inyectar errores
 	fmt.Println("📡 Capa de Ruido - Simulando canal ruidoso...")
 	frameBits := le.presentation.ConvertirBytesABits(frameBytes)
-	noiseResult, err := le.noise.AplicarRuido(frameBits, config.BER)
+	var noiseResult *noise.ErrorResult
+	if len(le.inyeccion) > 0 {
+		noiseResult, err = le.aplicarInyeccion(config.Algorithm, textBits, frameBits, frame.HammingBlocksOffset(frameBytes))
+		if err != nil {
+			return nil, fmt.Errorf("error aplicando inyección: %v", err)
+		}
+		result.Inyeccion = formatearInyeccion(le.inyeccion)
+		fmt.Printf("   Inyección dirigida: %s → posiciones %v\n", result.Inyeccion, noiseResult.ErrorPositions)
+	} else {
+		noiseResult, err = le.aplicarRuido(frameBits, config.BER)
+		if err != nil {
+			return nil, fmt.Errorf("error aplicando ruido: %v", err)
+		}
+	}
+
+	switch config.Algorithm {
+	case "hamming-secded":
+		result.BloquesSECDED = contarBloquesSECDED(frameBytes, noiseResult.NoisyBits)
+	case "rs":
+		result.CorreccionRS = contarCorreccionRS(frameBytes, noiseResult.NoisyBits, mascaraBorrados(noiseResult))
+	}
+	le.transmitir(ctx, result, noiseResult)
+	return result, nil
+}
+
+// ProcessFrame envía una trama ya construida (por ejemplo de --frame-hex):
+// salta las capas de presentación y enlace, así que el resultado no tiene
+// mensaje ni bits de texto, y solo aplica ruido y transmisión
+func (le *LayeredEmitter) ProcessFrame(frameBytes []byte, ber float64) (*TransmissionResult, error) {
+	result := &TransmissionResult{
+		Config:       &application.MessageConfig{Algorithm: algoritmoDeTrama(frameBytes), BER: ber, Mode: "manual"},
+		FrameBytes:   frameBytes,
+		TramaExterna: true,
+		StartTime:    le.clock.Now(),
+	}
+
+	fmt.Printf("🚀 Iniciando transmisión de trama pre-construida (%d bytes)\n", len(frameBytes))
+	fmt.Printf("   BER: %.3f\n\n", ber)
+
+	fmt.Println("📡 Capa de Ruido - Simulando canal ruidoso...")
+	noiseResult, err := le.aplicarRuido(le.presentation.ConvertirBytesABits(frameBytes), ber)
 	if err != nil {
 		return nil, fmt.Errorf("error aplicando ruido: %v", err)
 	}
 
+	le.transmitir(context.Background(), result, noiseResult)
+	return result, nil
+}
+
+// transmitir registra el ruido aplicado en result y envía la trama ruidosa
+// (capas 4 y 5, comunes a mensajes y tramas pre-construidas)
+func (le *LayeredEmitter) transmitir(parent context.Context, result *TransmissionResult, noiseResult *noise.ErrorResult) {
 	result.OriginalFrameBits = noiseResult.OriginalBits
 	result.NoisyFrameBits = noiseResult.NoisyBits
 	result.ErrorPositions = noiseResult.ErrorPositions
 	result.ErrorsInjected = noiseResult.ErrorsInjected
 	result.ActualBER = noiseResult.ActualBER
+	result.PosicionesTruncadas = noiseResult.PosicionesTruncadas
+	result.ResumenPosiciones = noiseResult.Resumen
 
 	fmt.Printf("   %d errores inyectados en %d bits (BER real: %.4f)\n",
-		noiseResult.ErrorsInjected, len(frameBits), noiseResult.ActualBER)
+		noiseResult.ErrorsInjected, noiseResult.TotalBits, noiseResult.ActualBER)
+	if noiseResult.PosicionesTruncadas {
+		fmt.Printf("   Posiciones guardadas: muestra de %d (%s)\n", len(noiseResult.ErrorPositions), noiseResult.Resumen)
+	}
 
 	// CAPA 5: TRANSMISIÓN - Enviar por WebSocket
 	fmt.Println("🌐 Capa de Transmisión - Enviando por WebSocket...")
-	noisyFrameBytes := le.presentation.ConvertirBitsABytes(noiseResult.NoisyBits)
+	le.bufEnvio = frame.AppendBytes(le.bufEnvio[:0], noiseResult.NoisyBits)
+	noisyFrameBytes := le.bufEnvio
+
+	// Con --deadline el envío solo dispone de lo que queda del plazo
+	ctx, cancel, vencido := le.contextoEnvio(parent, result)
+	if vencido {
+		le.marcarVencida(result)
+		fmt.Printf("   ⏰ %s\n", result.Error)
+		result.EndTime = le.clock.Now()
+		result.TotalTime = result.EndTime.Sub(result.StartTime)
+		return
+	}
+	defer cancel()
 
-	transmissionStart := time.Now()
-	err = wsclient.SendFrame(le.wsURL, noisyFrameBytes)
-	transmissionDuration := time.Since(transmissionStart)
+	transmissionStart := le.clock.Now()
+	connStats, err := le.enviarTrama(ctx, result, noisyFrameBytes)
+	transmissionDuration := le.clock.Since(transmissionStart)
+	result.ConnStats = connStats
 
 	if err != nil {
 		result.Success = false
 		result.Error = err.Error()
+		result.causa = err
 		fmt.Printf("   ❌ Error de transmisión: %v\n", err)
 	} else {
 		result.Success = true
@@ -116,42 +302,298 @@ func (le *LayeredEmitter) ProcessMessage(config *application.MessageConfig) (*Tr
 	}
 
 	result.TransmissionTime = transmissionDuration
-	result.EndTime = time.Now()
+	result.EndTime = le.clock.Now()
 	result.TotalTime = result.EndTime.Sub(result.StartTime)
 
-	return result, nil
+	le.clasificarPuntualidad(result)
+	if result.Puntualidad == PuntualidadTarde {
+		fmt.Printf("   ⏰ %s\n", result.Error)
+	}
+}
+
+// construirTrama aplica el algoritmo de enlace a los bits de texto y devuelve
+// la trama junto con una descripción legible del algoritmo aplicado. ber solo
+// interviene en la elección de auto-hamming. Con seq distinto de nil el header
+// lleva ese número de secuencia.
+func (le *LayeredEmitter) construirTrama(algorithm string, textBits []byte, ber float64, seq *uint16) ([]byte, string, error) {
+	// Los constructores copian el payload a la trama, así que el buffer se
+	// puede reutilizar en la próxima iteración
+	le.bufPayload = frame.AppendBytes(le.bufPayload[:0], textBits)
+	payloadBytes := le.bufPayload
+	opts := frame.FrameOptions{Checksum: le.checksum, Seq: seq, Content: le.contenido}
+	checksum := nombreChecksum(le.checksum)
+	if le.entrelazado > 0 && algorithm != "hamming" && algorithm != "hamming-secded" {
+		return nil, "", fmt.Errorf("--interleave requiere el algoritmo hamming o hamming-secded (actual: %s)", algorithm)
+	}
+
+	switch algorithm {
+	case "crc":
+		// Para CRC: bits → bytes → frame con CRC
+		opts.MsgType = frame.MsgTypeData
+		frameBytes, err := frame.BuildFrameWithOptions(payloadBytes, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame CRC: %w", err)
+		}
+		return frameBytes, checksum, nil
+
+	case "fletcher":
+		// Como crc, pero verificado con Fletcher-16 en lugar de --checksum
+		if le.checksum != frame.ChecksumCRC32 {
+			return nil, "", fmt.Errorf("el algoritmo fletcher ya fija el checksum (--checksum %s)", le.checksum)
+		}
+		opts.MsgType = frame.MsgTypeData
+		opts.Checksum = frame.ChecksumFletcher16
+		frameBytes, err := frame.BuildFrameWithOptions(payloadBytes, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Fletcher-16: %w", err)
+		}
+		return frameBytes, "Fletcher-16", nil
+
+	case "hamming":
+		// Para Hamming: bits → hamming encode → [relleno] + bytes → frame con CRC
+		encoded, err := le.codificarHamming(textBits, 7, frame.Hamming74Encode)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Hamming: %w", err)
+		}
+		opts.MsgType = frame.MsgTypeHamming
+		frameBytes, err := frame.BuildFrameWithOptions(encoded, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Hamming: %w", err)
+		}
+		return frameBytes, "Hamming(7,4)" + le.descripcionEntrelazado() + " + " + checksum, nil
+
+	case "hamming-secded":
+		// Como hamming, con una paridad global por bloque que detecta los errores dobles
+		encoded, err := le.codificarHamming(textBits, 8, frame.Hamming84Encode)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Hamming SEC-DED: %w", err)
+		}
+		opts.MsgType = frame.MsgTypeHamming84
+		frameBytes, err := frame.BuildFrameWithOptions(encoded, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Hamming SEC-DED: %w", err)
+		}
+		return frameBytes, "Hamming(8,4) SEC-DED" + le.descripcionEntrelazado() + " + " + checksum, nil
+
+	case "parity2d":
+		// Paridad de filas y columnas sobre el payload, con el CRC para lo que no corrige
+		frameBytes, err := frame.BuildFrameWithParity2D(payloadBytes, le.filasParidad, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame de paridad 2D: %w", err)
+		}
+		return frameBytes, fmt.Sprintf("Paridad 2D (%d filas) + %s", le.filasParidad, checksum), nil
+
+	case "repetition":
+		// Cada bit tres veces: la corrección más simple, como línea base
+		opts.MsgType = frame.MsgTypeRepetition
+		frameBytes, err := frame.BuildFrameWithOptions(frame.BitsToBytes(frame.Repetition3Encode(textBits)), opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame de repetición: %w", err)
+		}
+		return frameBytes, "Repetición (3,1) + " + checksum, nil
+
+	case "rs":
+		// Bloques sobre bytes: corrige ráfagas dentro de un byte mejor que Hamming
+		encoded, err := frame.EncodeRSPayload(payloadBytes, le.paridadRS)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Reed-Solomon: %w", err)
+		}
+		opts.MsgType = frame.MsgTypeReedSolomon
+		frameBytes, err := frame.BuildFrameWithOptions(encoded, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Reed-Solomon: %w", err)
+		}
+		return frameBytes, fmt.Sprintf("Reed-Solomon (%d símbolos de paridad) + %s", le.paridadRS, checksum), nil
+
+	case "golay":
+		// Bloques de 23 bits que corrigen hasta 3 errores: sigue la curva
+		// donde Hamming(7,4) ya pierde casi todas las tramas
+		frameBytes, err := frame.BuildFrameWithGolay(payloadBytes, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame Golay: %w", err)
+		}
+		return frameBytes, "Golay(23,12) + " + checksum, nil
+
+	case "auto-hamming":
+		// La variante va en el tipo de mensaje, así el receptor sabe cuál decodificar
+		v := elegirVarianteHamming(len(textBits), ber)
+		frameBytes, err := v.EncodeFrame(payloadBytes, opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("error construyendo frame %s: %w", v.Name, err)
+		}
+		return frameBytes, fmt.Sprintf("%s (auto) + %s", v.Name, checksum), nil
+
+	default:
+		return nil, "", fmt.Errorf("algoritmo no soportado: %s", algorithm)
+	}
+}
+
+// nombreChecksum es el nombre legible de k para los resúmenes
+func nombreChecksum(k frame.ChecksumKind) string {
+	switch k {
+	case frame.ChecksumCRC8:
+		return "CRC-8"
+	case frame.ChecksumCRC16CCITT:
+		return "CRC-16-CCITT"
+	case frame.ChecksumFletcher16:
+		return "Fletcher-16"
+	case frame.ChecksumCRC32C:
+		return "CRC-32C"
+	default:
+		return "CRC-32"
+	}
 }
 
-// RunBenchmark ejecuta múltiples transmisiones para análisis
-func (le *LayeredEmitter) RunBenchmark(config *application.MessageConfig) (*BenchmarkResult, error) {
-	fmt.Printf("🎯 Iniciando benchmark: %d iteraciones\n", config.Count)
+// contenidoPresentacion es el tipo de contenido que produce la capa de
+// presentación: CodificarMensaje solo acepta ASCII, y --normalize convierte a
+// ASCII antes de codificar
+func contenidoPresentacion() frame.ContentType {
+	return frame.ContentASCII
+}
+
+// nombresChecksum son los valores aceptados por --checksum
+var nombresChecksum = func() []string {
+	nombres := make([]string, len(frame.ChecksumKinds))
+	for i, k := range frame.ChecksumKinds {
+		nombres[i] = k.String()
+	}
+	return nombres
+}()
+
+// aplicarInyeccion ubica los bloques Hamming dentro de la trama (desde el byte
+// inicioBloques, ver frame.HammingBlocksOffset) e invierte los bits pedidos
+// por las directivas de inyección
+func (le *LayeredEmitter) aplicarInyeccion(algorithm string, textBits, frameBits []byte, inicioBloques int) (*noise.ErrorResult, error) {
+	tamBloque := 7
+	switch algorithm {
+	case "hamming":
+	case "hamming-secded":
+		tamBloque = 8
+	default:
+		return nil, fmt.Errorf("la inyección por bloques requiere el algoritmo hamming o hamming-secded (actual: %s)", algorithm)
+	}
+	if le.entrelazado > 0 {
+		return nil, fmt.Errorf("la inyección por bloques no admite bloques entrelazados (--interleave)")
+	}
+	numBloques := (len(textBits) + 3) / 4
+	return le.noise.AplicarInyeccion(frameBits, le.inyeccion, inicioBloques*8, tamBloque, numBloques)
+}
+
+// secuenciaIteracion es el número de secuencia de la iteración i (desde 0)
+// del benchmark: i módulo 65536
+func secuenciaIteracion(i int) uint16 {
+	return uint16(i)
+}
+
+// formatearInyeccion devuelve las directivas en el formato de --inject
+func formatearInyeccion(directivas []noise.DirectivaInyeccion) string {
+	partes := make([]string, len(directivas))
+	for i, d := range directivas {
+		partes[i] = d.String()
+	}
+	return strings.Join(partes, ";")
+}
+
+// correrBenchmark ejecuta las transmisiones del benchmark y pasa cada
+// resultado a emitir apenas se completa; si emitir devuelve false, o se
+// cancela ctx, el benchmark se detiene con ctx.Err() y resume lo completado
+func (le *LayeredEmitter) correrBenchmark(ctx context.Context, config *application.MessageConfig, emitir func(*TransmissionResult) bool) (*BenchmarkResult, error) {
+	if config.Duration > 0 {
+		fmt.Printf("🎯 Iniciando benchmark: %v de duración\n", config.Duration)
+	} else {
+		fmt.Printf("🎯 Iniciando benchmark: %d iteraciones\n", config.Count)
+	}
 	fmt.Printf("   Mensaje: \"%s\"\n", config.Text)
 	fmt.Printf("   Algoritmo: %s, BER: %.3f\n\n", config.Algorithm, config.BER)
 
+	if le.planBER != nil {
+		fmt.Printf("   Plan de BER: %d segmentos, %v por pasada (repetir: %v)\n\n",
+			len(le.planBER.Segmentos), le.planBER.DuracionTotal(), le.planBER.Repetir)
+	}
+
 	benchmark := &BenchmarkResult{
-		Config:    config,
-		StartTime: time.Now(),
-		Results:   make([]*TransmissionResult, 0, config.Count),
+		Config:       config,
+		Plan:         le.planBER,
+		LargoRafaga:  le.largoRafaga,
+		Advertencias: application.AdvertirConfiguracion(config),
+		StartTime:    le.clock.Now(),
+		Results:      make([]*TransmissionResult, 0, config.Count),
+	}
+	var internado *tablaInternado
+	if !le.paranoico {
+		internado = nuevaTablaInternado(DefaultMaxInternados)
+		defer func() {
+			benchmark.SlicesCompartidos, benchmark.BytesCompartidos = internado.reusados, internado.ahorro
+		}()
 	}
 
 	var successful, failed int
 	var totalTransmissionTime time.Duration
 
-	for i := 0; i < config.Count; i++ {
+	// Con duración fija el deadline cubre toda la corrida y la cantidad de
+	// iteraciones es la que alcance a completarse
+	deadline := benchmark.StartTime.Add(config.Duration)
+	continuar := func(i int) bool {
+		if config.Duration > 0 {
+			return le.clock.Now().Before(deadline)
+		}
+		return i < config.Count
+	}
+	// terminar resume las iteraciones completadas, también al abortar
+	terminar := func(err error) (*BenchmarkResult, error) {
+		le.agregarPuntualidad(benchmark)
+		resumirBenchmark(benchmark, successful, failed, totalTransmissionTime, le.clock.Now())
+		return benchmark, err
+	}
+
+	for i := 0; continuar(i); i++ {
+		if ctx.Err() != nil {
+			fmt.Printf("   Benchmark cancelado tras %d iteraciones\n", i)
+			return terminar(ctx.Err())
+		}
 		if i%100 == 0 && i > 0 {
-			fmt.Printf("   Progreso: %d/%d (%.1f%%)\n", i, config.Count, float64(i)/float64(config.Count)*100)
+			if config.Duration > 0 {
+				elapsed := le.clock.Since(benchmark.StartTime)
+				fmt.Printf("   Progreso: %d iteraciones, %v/%v (%.1f%%)\n", i, elapsed.Round(time.Second),
+					config.Duration, float64(elapsed)/float64(config.Duration)*100)
+			} else {
+				fmt.Printf("   Progreso: %d/%d (%.1f%%)\n", i, config.Count, float64(i)/float64(config.Count)*100)
+			}
+		}
+
+		// Con plan de BER cada iteración usa el BER del segmento activo
+		iterConfig := config
+		segmento := 0
+		if le.planBER != nil {
+			indice, ok := le.planBER.SegmentoEn(le.clock.Since(benchmark.StartTime))
+			if !ok {
+				fmt.Printf("   Plan de BER agotado tras %d iteraciones\n", i)
+				break
+			}
+			c := *config
+			c.BER = le.planBER.Segmentos[indice].BER
+			iterConfig = &c
+			segmento = indice + 1
 		}
 
-		result, err := le.ProcessMessage(config)
+		// La secuencia (--seq) permite cruzar cada trama con lo que vio el
+		// receptor; tras 65535 vuelve a 0
+		var seq *uint16
+		if le.secuencia || le.esperaRespuesta > 0 {
+			s := secuenciaIteracion(i)
+			seq = &s
+		}
+		result, err := le.procesarMensaje(ctx, iterConfig, seq)
 		if err != nil {
 			failed++
 			// Crear resultado de error
 			result = &TransmissionResult{
-				Config:    config,
+				Config:    iterConfig,
 				Success:   false,
 				Error:     err.Error(),
-				StartTime: time.Now(),
-				EndTime:   time.Now(),
+				causa:     err,
+				StartTime: le.clock.Now(),
+				EndTime:   le.clock.Now(),
 			}
 		} else if result.Success {
 			successful++
@@ -160,29 +602,180 @@ func (le *LayeredEmitter) RunBenchmark(config *application.MessageConfig) (*Benc
 			failed++
 		}
 
+		result.Segmento = segmento
+		if internado != nil {
+			internado.internarResultado(result)
+		}
 		benchmark.Results = append(benchmark.Results, result)
+		le.ejecutarHooks(result)
+		if !emitir(result) {
+			fmt.Print(msgs.T("emisor.cancelado", i+1))
+			return terminar(ctx.Err())
+		}
+
+		// El watchdog revisa una sola vez, al completar la ventana inicial
+		if le.watchdogIteraciones > 0 && len(benchmark.Results) == le.watchdogIteraciones {
+			if werr := diagnosticarWatchdog(benchmark.Results); werr != nil {
+				return terminar(werr)
+			}
+		}
 	}
+	return terminar(nil)
+}
 
-	benchmark.EndTime = time.Now()
+// resumirBenchmark calcula los agregados sobre las iteraciones completadas y muestra el resumen
+func resumirBenchmark(benchmark *BenchmarkResult, successful, failed int, totalTransmissionTime time.Duration, fin time.Time) {
+	total := len(benchmark.Results)
+
+	benchmark.EndTime = fin
 	benchmark.TotalTime = benchmark.EndTime.Sub(benchmark.StartTime)
 	benchmark.Successful = successful
 	benchmark.Failed = failed
-	benchmark.SuccessRate = float64(successful) / float64(config.Count)
+	if total > 0 {
+		benchmark.SuccessRate = float64(successful) / float64(total)
+	}
 
 	if successful > 0 {
 		benchmark.AverageTransmissionTime = totalTransmissionTime / time.Duration(successful)
 	}
 
 	// Mostrar resumen
-	fmt.Printf("\n📊 Resumen del Benchmark:\n")
-	fmt.Printf("   Total: %d transmisiones\n", config.Count)
-	fmt.Printf("   Exitosas: %d (%.1f%%)\n", successful, benchmark.SuccessRate*100)
-	fmt.Printf("   Fallidas: %d (%.1f%%)\n", failed, float64(failed)/float64(config.Count)*100)
-	fmt.Printf("   Tiempo total: %v\n", benchmark.TotalTime)
-	fmt.Printf("   Tiempo promedio por transmisión: %v\n", benchmark.AverageTransmissionTime)
+	fmt.Print(msgs.T("resumen.titulo"))
+	fmt.Print(msgs.T("resumen.total", total))
+	fmt.Print(msgs.T("resumen.exitosas", successful, benchmark.SuccessRate*100))
+	if total > 0 {
+		fmt.Print(msgs.T("resumen.fallidas", failed, float64(failed)/float64(total)*100))
+	}
+	fmt.Print(msgs.T("resumen.tiempo_total", benchmark.TotalTime))
+	fmt.Print(msgs.T("resumen.tiempo_promedio", benchmark.AverageTransmissionTime))
+	mostrarEstadisticasConexion(benchmark)
+	benchmark.VariantesHamming = contarVariantesHamming(benchmark.Results)
+	if benchmark.VariantesHamming != nil {
+		mostrarVariantesHamming(benchmark.VariantesHamming)
+	}
+	benchmark.BloquesSECDED = sumarBloquesSECDED(benchmark.Results)
+	if benchmark.BloquesSECDED != nil {
+		fmt.Printf("   %s\n", formatearBloquesSECDED(*benchmark.BloquesSECDED))
+	}
+	benchmark.CorreccionBloques = contarCorreccionBloques(benchmark.Results)
+	if benchmark.CorreccionBloques != nil {
+		fmt.Printf("   %s\n", formatearCorreccionBloques(*benchmark.CorreccionBloques))
+	}
+	benchmark.CorreccionRS = sumarCorreccionRS(benchmark.Results)
+	if benchmark.CorreccionRS != nil {
+		fmt.Printf("   %s\n", formatearCorreccionRS(*benchmark.CorreccionRS))
+	}
+	benchmark.Estadisticas = sumarEstadisticas(benchmark.Results)
+	if benchmark.Estadisticas != nil {
+		fmt.Printf("   %s\n", formatearEstadisticas(*benchmark.Estadisticas))
+	}
+	benchmark.Veredictos = contarVeredictos(benchmark.Results)
+	if benchmark.Veredictos != nil {
+		fmt.Printf("   %s\n", formatearVeredictos(*benchmark.Veredictos))
+	}
+	if benchmark.Puntualidad != nil {
+		mostrarPuntualidad(benchmark.Puntualidad)
+	}
 	fmt.Println()
+}
+
+// mostrarEstadisticasConexion resume los tiempos de dial y handshake TLS de
+// cada conexión abierta durante el benchmark
+func mostrarEstadisticasConexion(benchmark *BenchmarkResult) {
+	var conexiones, conTLS, reanudadas int
+	var totalDial, totalTLS time.Duration
 
-	return benchmark, nil
+	for _, r := range benchmark.Results {
+		if r.ConnStats == nil || r.ConnStats.Dial == 0 {
+			continue
+		}
+		conexiones++
+		totalDial += r.ConnStats.Dial
+		if r.ConnStats.TLSHandshake > 0 {
+			conTLS++
+			totalTLS += r.ConnStats.TLSHandshake
+		}
+		if r.ConnStats.TLSResumed {
+			reanudadas++
+		}
+	}
+
+	if conexiones == 0 {
+		return
+	}
+	fmt.Print(msgs.T("resumen.conexiones", conexiones, conexiones-1))
+	fmt.Print(msgs.T("resumen.dial", totalDial/time.Duration(conexiones)))
+	if conTLS > 0 {
+		fmt.Print(msgs.T("resumen.tls", totalTLS/time.Duration(conTLS), reanudadas, conTLS))
+	}
+}
+
+// diagnosticarWatchdog devuelve un *WatchdogError si todas las iteraciones
+// observadas fallaron por la misma causa, o nil si alguna tuvo éxito
+func diagnosticarWatchdog(results []*TransmissionResult) error {
+	var transporte, procesamiento int
+	var ultimoError string
+	var causa error
+
+	for _, r := range results {
+		if r.Success {
+			return nil
+		}
+		// Sin trama construida el fallo ocurrió antes de llegar al transporte
+		if len(r.FrameBytes) == 0 {
+			procesamiento++
+		} else {
+			transporte++
+		}
+		ultimoError, causa = r.Error, r.causa
+	}
+
+	werr := &WatchdogError{Iteraciones: len(results), UltimoError: ultimoError}
+	switch {
+	case transporte == len(results):
+		werr.Motivo = "transporte"
+		werr.Diagnostico = diagnosticarTransporte(causa)
+	case procesamiento == len(results):
+		werr.Motivo = "procesamiento"
+		werr.Diagnostico = msgs.T("watchdog.procesamiento")
+	default:
+		werr.Motivo = "transporte y procesamiento"
+		werr.Diagnostico = msgs.T("watchdog.mixto")
+	}
+	return werr
+}
+
+// motivoWatchdog describe el Motivo de un *WatchdogError para el usuario
+func motivoWatchdog(motivo string) string {
+	switch motivo {
+	case "transporte":
+		return msgs.T("watchdog.motivo.transporte")
+	case "procesamiento":
+		return msgs.T("watchdog.motivo.procesamiento")
+	default:
+		return msgs.T("watchdog.motivo.mixto")
+	}
+}
+
+// diagnosticarTransporte traduce el error de WebSocket a una causa probable
+func diagnosticarTransporte(err error) string {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return msgs.T("diagnostico.sin_receptor")
+	case errors.As(err, &dnsErr):
+		return msgs.T("diagnostico.dns")
+	case errors.Is(err, websocket.ErrBadHandshake):
+		return msgs.T("diagnostico.handshake")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return msgs.T("diagnostico.timeout")
+	case errors.Is(err, errRechazada):
+		return msgs.T("diagnostico.nack")
+	case errors.Is(err, wsclient.ErrNoReply):
+		return msgs.T("diagnostico.sin_respuesta")
+	default:
+		return msgs.T("diagnostico.transporte")
+	}
 }
 
 // TransmissionResult contiene el resultado de una transmisión
@@ -202,11 +795,50 @@ type TransmissionResult struct {
 	EndTime           time.Time
 	TotalTime         time.Duration
 	TransmissionTime  time.Duration
+	ConnStats         *wsclient.ConnStats        // Tiempos de conexión (nil si no se llegó a transmitir)
+	Inyeccion         string                     // Directivas de --inject aplicadas (vacío si se usó BER)
+	Segmento          int                        // Segmento del plan de BER activo (desde 1; 0 sin plan)
+	TextoEnviado      string                     // Texto tras --normalize (vacío si no hubo cambios)
+	Normalizacion     []presentation.Sustitucion // Sustituciones hechas por --normalize
+	TramaExterna      bool                       // La trama vino de --frame-hex/--frame-file (sin mensaje ni TextBits)
+	// PosicionesTruncadas indica que ErrorPositions es solo una muestra (ver
+	// noise.ErrorResult); ResumenPosiciones describe entonces todas
+	PosicionesTruncadas bool
+	ResumenPosiciones   *noise.ResumenPosiciones
+	// Puntualidad respecto a --deadline (vacío sin plazo) y cuánto se pasó
+	// del plazo una iteración tardía o vencida
+	Puntualidad string
+	Retraso     time.Duration
+	// Secuencia es el número de secuencia del header (nil si la trama no lleva)
+	Secuencia *uint16
+	// VarianteHamming es la variante elegida por auto-hamming (vacío con otros algoritmos)
+	VarianteHamming string
+	// BloquesSECDED cuenta los bloques que el receptor corregirá y los que
+	// detectará sin poder corregir (nil con otros algoritmos)
+	BloquesSECDED *frame.SECDEDCounts
+	// CorreccionRS cuenta los bytes que el receptor corregirá y los bloques
+	// que no podrá recuperar (nil con otros algoritmos)
+	CorreccionRS *frame.RSCounts
+	// Fragmentos tiene el resultado de cada fragmento con --max-fragment (nil
+	// sin fragmentar); el resto de campos describe la concatenación de todos
+	Fragmentos []ResultadoFragmento
+	// Estadisticas desglosa el costo de la trama: header, subheader,
+	// checksum, relleno y tasa del código (nil con tramas externas, con
+	// --max-fragment y con tipos sin estadísticas de código)
+	Estadisticas *frame.FrameStats
+	// ReceiverVerdict es la respuesta del receptor con --await-reply (nil sin
+	// esperarla); la transmisión solo es exitosa si el receptor confirmó
+	ReceiverVerdict *VeredictoReceptor
+	// causa es el error detrás de Error, que el watchdog clasifica con
+	// errors.Is/As (nil si falló sin un error de transporte)
+	causa error
 }
 
 // BenchmarkResult contiene resultados de múltiples transmisiones
 type BenchmarkResult struct {
 	Config                  *application.MessageConfig
+	Plan                    *noise.PlanBER            // Plan de BER usado (nil si el BER fue fijo)
+	Advertencias            []application.Advertencia // Advertencias de configuración vigentes en la corrida
 	Results                 []*TransmissionResult
 	StartTime               time.Time
 	EndTime                 time.Time
@@ -215,71 +847,617 @@ type BenchmarkResult struct {
 	Failed                  int
 	SuccessRate             float64
 	AverageTransmissionTime time.Duration
+	Puntualidad             *ResumenPuntualidad // Resumen de --deadline (nil sin plazo)
+	VariantesHamming        map[string]int      // Iteraciones por variante de auto-hamming (nil con otros algoritmos)
+	BloquesSECDED           *frame.SECDEDCounts // Bloques SEC-DED de todas las iteraciones (nil con otros algoritmos)
+	CorreccionBloques       *CorreccionBloques  // Bloques corregibles de hamming o repetition (nil con otros algoritmos)
+	CorreccionRS            *frame.RSCounts     // Bytes corregidos por rs en todas las iteraciones (nil con otros algoritmos)
+	Veredictos              *ResumenVeredictos  // Respuestas del receptor con --await-reply (nil sin esperarlas)
+	Estadisticas            *frame.FrameStats   // Costo de las tramas de todas las iteraciones (nil si ninguna lo tiene)
+	LargoRafaga             int                 // Largo de las ráfagas de --burst (0 con errores independientes)
+	// Slices de resultados que comparten el contenido de otra iteración y los
+	// bytes que eso evita retener (0 con --paranoid)
+	SlicesCompartidos int
+	BytesCompartidos  int64
+}
+
+// modosSoportados lista los valores aceptados por --mode
+var modosSoportados = []string{"manual", "benchmark", "flood"}
+
+// opciones agrupa los flags del emisor
+type opciones struct {
+	mode         *string
+	wsURL        *string
+	noWatchdog   *bool
+	watchdogIter *int
+	berTolerance *float64
+	inject       *string
+	duration     *time.Duration
+	floodConns   *int
+	floodDur     *time.Duration
+	statsURL     *string
+	hookNDJSON   *string
+	berSchedule  *string
+	scheduleLoop *bool
+	normalize    *bool
+	stripDiacr   *bool
+	strictAdvice *bool
+	errorPNG     *string
+	pngMaxIter   *int
+	pngMaxBits   *int
+	historyFile  *string
+	estimate     *bool
+	estimateN    *int
+	manifest     *string
+	bundle       *string
+	noHistory    *bool
+	lang         *string
+	fullPos      *bool
+	deadline     *time.Duration
+	awaitReply   *time.Duration
+	dumpFrame    *bool
+	maxFragment  *int
+	checksum     *string
+	contentType  *bool
+	seq          *bool
+	parityRows   *int
+	rsParity     *int
+	paranoid     *bool
+	interleave   *int
+	burst        *int
+	erasure      *bool
+	theoryCSV    *string
+	iterCSV      *string
+	frameHex     *string
+	frameFile    *string
+	frameBER     *float64
+	force        *bool
+	help         *bool
+
+	subcomando string            // Subcomando usado (vacío con la forma --mode)
+	flags      map[string]string // Valor resuelto de cada flag registrado (para --bundle)
+}
+
+// Grupos de flags: cada subcomando registra los globales y los suyos; la
+// invocación con --mode registra todos
+type grupoFlags int
+
+const (
+	grupoGlobal grupoFlags = 1 << iota // Transporte, idioma y exports
+	grupoSend
+	grupoBench
+	grupoFlood
+	grupoLegacy // Solo --mode
+
+	todosLosGrupos = grupoGlobal | grupoSend | grupoBench | grupoFlood | grupoLegacy
+)
+
+// registrarFlags declara todos los flags del emisor en fs (invocación con --mode)
+func registrarFlags(fs *flag.FlagSet) *opciones {
+	return registrarFlagsGrupos(fs, todosLosGrupos)
+}
+
+// registrarFlagsGrupos declara en fs los flags de grupos. Los demás quedan en
+// un FlagSet descartado, así que todos los campos de opciones apuntan al menos
+// a su valor por defecto.
+func registrarFlagsGrupos(fs *flag.FlagSet, grupos grupoFlags) *opciones {
+	sinUso := flag.NewFlagSet("sin-uso", flag.ContinueOnError)
+	en := func(g grupoFlags) *flag.FlagSet {
+		if grupos&g != 0 {
+			return fs
+		}
+		return sinUso
+	}
+
+	return &opciones{
+		mode:         en(grupoLegacy).String("mode", "manual", "Modo de operación: manual, benchmark o flood"),
+		wsURL:        en(grupoGlobal).String("ws-url", "ws://localhost:9000", "URL del servidor WebSocket receptor"),
+		noWatchdog:   en(grupoBench).Bool("no-watchdog", false, "Desactivar el watchdog del benchmark"),
+		watchdogIter: en(grupoBench).Int("watchdog-iter", DefaultWatchdogIteraciones, "Iteraciones iniciales que revisa el watchdog"),
+		berTolerance: en(grupoBench).Float64("ber-tolerance", 0.1, "Desviación relativa máxima del BER observado respecto al objetivo"),
+		inject:       en(grupoSend|grupoBench).String("inject", "", "Inyección dirigida a bloques Hamming (ej: 'block=3:bits=2;block=5:bits=1')"),
+		duration:     flagutil.Duration(en(grupoBench), "duration", 0, "Duración del benchmark (reemplaza la cantidad de iteraciones)"),
+		floodConns:   en(grupoFlood).Int("flood-conns", 4, "Conexiones paralelas en modo flood"),
+		floodDur:     flagutil.Duration(en(grupoFlood), "flood-duration", 10*time.Second, "Duración del modo flood"),
+		statsURL:     en(grupoFlood).String("stats-url", "", "Endpoint de estadísticas del receptor a consultar tras el flood"),
+		hookNDJSON:   en(grupoSend|grupoBench).String("hook-ndjson", "", "Escribir un registro NDJSON por iteración en este archivo"),
+		berSchedule:  en(grupoBench).String("ber-schedule", "", "Archivo con el plan de BER por tramos ('<duración> <ber>' por línea)"),
+		scheduleLoop: en(grupoBench).Bool("schedule-loop", false, "Repetir el plan de BER al terminar en lugar de detener el benchmark"),
+		normalize:    en(grupoSend|grupoBench).Bool("normalize", false, "Convertir puntuación y espacios Unicode a ASCII antes de codificar"),
+		stripDiacr:   en(grupoSend|grupoBench).Bool("strip-diacritics", false, "Con --normalize, reemplazar letras acentuadas por su base (á → a)"),
+		strictAdvice: en(grupoGlobal).Bool("strict-advice", false, "Tratar las advertencias de configuración como errores"),
+		errorPNG:     en(grupoBench).String("error-png", "", "Exportar el mapa de bits invertidos del benchmark a este PNG"),
+		theoryCSV:    en(grupoBench).String("theory-csv", "", "Exportar a este CSV la curva teórica de la trama del benchmark por BER"),
+		iterCSV:      en(grupoBench).String("iterations-csv", "", "Exportar a este CSV el BER, el resultado y el costo de la trama de cada iteración"),
+		pngMaxIter:   en(grupoBench).Int("error-png-max-iter", DefaultMapaMaxIteraciones, "Iteraciones (filas) máximas del mapa de errores"),
+		pngMaxBits:   en(grupoBench).Int("error-png-max-bits", DefaultMapaMaxBits, "Bits (columnas) máximos del mapa de errores"),
+		historyFile:  en(grupoBench).String("history-file", rutaHistorialPorDefecto(), "Historial de corridas donde registrar el benchmark"),
+		noHistory:    en(grupoBench).Bool("no-history", false, "No registrar el benchmark en el historial"),
+		estimate:     en(grupoBench).Bool("estimate", false, "Correr unas iteraciones de muestra, proyectar duración y memoria del benchmark y salir"),
+		estimateN:    en(grupoBench).Int("estimate-samples", DefaultMuestrasEstimacion, "Iteraciones de muestra de --estimate"),
+		manifest:     en(grupoGlobal).String("manifest", "", "Escribir en esta ruta un manifiesto JSON con los exports producidos"),
+		bundle:       en(grupoBench).String("bundle", "", "Empaquetar al terminar el benchmark configuración, metadatos, reporte, tramas fallidas y exports en este zip"),
+		frameHex:     en(grupoSend).String("frame-hex", "", "Enviar esta trama en hexadecimal tal cual, sin capas de presentación ni enlace"),
+		frameFile:    en(grupoSend).String("frame-file", "", "Como --frame-hex, leyendo el hexadecimal de este archivo"),
+		frameBER:     flagutil.Probability(en(grupoSend), "frame-ber", 0, "BER aplicado a la trama de --frame-hex/--frame-file"),
+		force:        en(grupoSend).Bool("force", false, "Enviar la trama de --frame-hex/--frame-file aunque su longitud o CRC no coincidan"),
+		fullPos:      en(grupoSend|grupoBench).Bool("full-positions", false, fmt.Sprintf("Guardar todas las posiciones de error aunque superen %d por iteración", noise.DefaultMaxPosiciones)),
+		deadline:     flagutil.Duration(en(grupoSend|grupoBench), "deadline", 0, "Plazo de cada mensaje, de la codificación al envío; una entrega tardía cuenta como fallida (ej: 50ms)"),
+		awaitReply:   flagutil.Duration(en(grupoSend|grupoBench), "await-reply", 0, "Esperar hasta este plazo el ACK/NACK del receptor; solo una trama confirmada cuenta como exitosa (0: no esperar)"),
+		dumpFrame:    en(grupoSend).Bool("dump-frame", false, "Mostrar header, payload (hex y ASCII) y checksum de la trama enviada"),
+		checksum:     en(grupoSend|grupoBench).String("checksum", "crc32", "Verificación de la trama: crc8, crc16 (CCITT), fletcher16, crc32 o crc32c (Castagnoli)"),
+		contentType:  en(grupoSend|grupoBench).Bool("content-type", false, "Declarar en el header el tipo de contenido de la capa de presentación (ascii)"),
+		seq:          en(grupoBench).Bool("seq", false, "Numerar cada trama del benchmark en el header (FlagSeq); implícito con --await-reply"),
+		parityRows:   en(grupoSend|grupoBench).Int("parity-rows", DefaultFilasParidad, "Filas de la matriz del algoritmo parity2d (1-255)"),
+		rsParity:     en(grupoSend|grupoBench).Int("rs-parity", frame.DefaultRSParity, "Símbolos de paridad por bloque del algoritmo rs (1-254; corrige la mitad en bytes)"),
+		paranoid:     en(grupoBench).Bool("paranoid", false, "No compartir entre iteraciones los slices de contenido idéntico"),
+		interleave:   en(grupoSend|grupoBench).Int("interleave", 0, "Entrelazar los bloques Hamming con esta profundidad (0: sin entrelazar; desde el tamaño de bloque separa las ráfagas)"),
+		burst:        en(grupoSend|grupoBench).Int("burst", 0, "Invertir bits en ráfagas de este largo con el BER medio pedido (0: errores independientes)"),
+		erasure:      en(grupoSend|grupoBench).Bool("erasure", false, "Canal de borrado: el BER es la probabilidad de borrar cada bit (relleno 0) y rs decodifica conociendo los borrados"),
+		maxFragment:  en(grupoSend|grupoBench).Int("max-fragment", 0, "Partir cada trama en fragmentos de hasta n bytes de datos, cada uno con su header y CRC (0: sin fragmentar)"),
+		lang:         en(grupoGlobal).String("lang", "", "Idioma de los mensajes: es o en (default: según LANG, si no es)"),
+		help:         en(grupoGlobal).Bool("help", false, "Mostrar ayuda"),
+	}
+}
+
+// restriccionesFlags son los valores permitidos de los flags, compartidos por
+// el schema completo y el de cada subcomando
+var restriccionesFlags = map[string]schema.Restriccion{
+	"mode":               schema.Valores(modosSoportados),
+	"lang":               schema.Valores(i18n.Idiomas),
+	"checksum":           schema.Valores(nombresChecksum),
+	"parity-rows":        schema.Rango(1, 255),
+	"rs-parity":          schema.Rango(1, 254),
+	"frame-ber":          schema.Rango(0, 1),
+	"watchdog-iter":      schema.Minimo(0),
+	"ber-tolerance":      schema.Minimo(0),
+	"flood-conns":        schema.Minimo(1),
+	"error-png-max-iter": schema.Minimo(1),
+	"error-png-max-bits": schema.Minimo(1),
+	"estimate-samples":   schema.Minimo(1),
+	"max-fragment":       schema.Minimo(0),
+	"interleave":         schema.Rango(0, frame.MaxInterleaveDepth),
+	"burst":              schema.Minimo(0),
+}
+
+// construirSchema describe los flags y la configuración interactiva del
+// emisor. Se genera desde los mismos flags y registros que usa main, así que
+// no puede desviarse de lo que el binario acepta.
+func construirSchema() *schema.Schema {
+	fs := flag.NewFlagSet("layered_emitter", flag.ContinueOnError)
+	registrarFlags(fs)
+	s := schema.DesdeFlags("layered_emitter", fs, restriccionesFlags)
+	s.Config = application.CamposConfiguracion()
+	s.Enums = map[string][]string{
+		"mode":        modosSoportados,
+		"lang":        i18n.Idiomas,
+		"checksum":    nombresChecksum,
+		"algorithm":   application.Algoritmos,
+		"noise_model": noise.ModelosSoportados,
+	}
+	return s
 }
 
+// msgs es el catálogo de los textos que ve el usuario; main lo elige con
+// --lang (o LANG) y nil usa el idioma por defecto
+var msgs *i18n.Catalogo
+
 func main() {
-	// Flags de línea de comandos
-	var (
-		mode  = flag.String("mode", "manual", "Modo de operación: manual o benchmark")
-		wsURL = flag.String("ws-url", "ws://localhost:9000", "URL del servidor WebSocket receptor")
-		help  = flag.Bool("help", false, "Mostrar ayuda")
-	)
-	flag.Parse()
-
-	if *help {
-		mostrarAyuda()
+	// "layered_emitter schema [subcomando]" imprime la descripción JSON de los flags
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		s := construirSchema()
+		if len(os.Args) > 2 {
+			sub, ok := buscarSubcomando(os.Args[2])
+			if !ok {
+				fmt.Fprintf(os.Stderr, "❌ subcomando desconocido: %s\n", os.Args[2])
+				os.Exit(1)
+			}
+			s = sub.schema()
+		}
+		if err := s.Escribir(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error escribiendo schema: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	// "layered_emitter history" lista o compara corridas anteriores
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		if err := runHistory(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 
-	fmt.Println("🚀 Emisor por Capas - Lab 2")
+	// "layered_emitter verify-files <paths...>" verifica exports y manifiestos
+	if len(os.Args) > 1 && os.Args[1] == "verify-files" {
+		if err := runVerifyFiles(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// "layered_emitter synth --out dir" genera un dataset sintético
+	if len(os.Args) > 1 && os.Args[1] == "synth" {
+		err := runSynth(os.Args[2:], os.Stdout)
+		if err == flag.ErrHelp {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Flags de línea de comandos: subcomando o la forma anterior con --mode
+	o, err := parsearLinea(os.Args[1:], os.Stderr)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(2)
+	}
+
+	if *o.help {
+		if s, ok := buscarSubcomando(o.subcomando); ok {
+			escribirAyudaSubcomando(os.Stdout, s)
+		} else {
+			mostrarAyuda()
+		}
+		return
+	}
+
+	// Idioma de los textos para el usuario: --lang, si no LANG
+	idioma := *o.lang
+	if idioma == "" {
+		idioma = i18n.DesdeEntorno()
+	}
+	msgs, err = i18n.Nuevo(idioma)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ --lang: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(msgs.T("emisor.titulo"))
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Modo: %s\n", *mode)
-	fmt.Printf("Receptor: %s\n\n", *wsURL)
+	fmt.Print(msgs.T("emisor.modo", *o.mode))
+	fmt.Print(msgs.T("emisor.receptor", *o.wsURL))
 
 	// Crear emisor
-	emitter := NewLayeredEmitter(*wsURL)
-
-	// Solicitar configuración
-	config, err := emitter.app.SolicitarMensaje(*mode)
+	emitter := NewLayeredEmitter(*o.wsURL)
+	emitter.app.FijarCatalogo(msgs)
+	emitter.watchdogIteraciones = *o.watchdogIter
+	if *o.inject != "" {
+		directivas, err := noise.ParseInyeccion(*o.inject)
+		if err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "inject", err))
+			os.Exit(1)
+		}
+		emitter.inyeccion = directivas
+	}
+	if *o.duration < 0 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "duration", *o.duration))
+		os.Exit(1)
+	}
+	if *o.duration > 0 {
+		if *o.mode != "benchmark" {
+			fmt.Fprintln(os.Stderr, msgs.T("error.solo_benchmark", "duration"))
+			os.Exit(1)
+		}
+		emitter.app.FijarDuracion(*o.duration)
+	}
+	if *o.berSchedule != "" {
+		if *o.mode != "benchmark" {
+			fmt.Fprintln(os.Stderr, msgs.T("error.solo_benchmark", "ber-schedule"))
+			os.Exit(1)
+		}
+		plan, err := cargarPlanBER(*o.berSchedule)
+		if err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "ber-schedule", err))
+			os.Exit(1)
+		}
+		plan.Repetir = *o.scheduleLoop
+		emitter.planBER = plan
+	}
+	if *o.deadline < 0 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "deadline", *o.deadline))
+		os.Exit(1)
+	}
+	emitter.deadline = *o.deadline
+	if *o.maxFragment < 0 || *o.maxFragment > 0xFFFF-frame.FragmentHeaderSize {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_rango", "max-fragment", *o.maxFragment, 0, 0xFFFF-frame.FragmentHeaderSize))
+		os.Exit(1)
+	}
+	if *o.maxFragment > 0 && *o.inject != "" {
+		fmt.Fprintln(os.Stderr, msgs.T("error.incompatibles", "max-fragment", "inject"))
+		os.Exit(1)
+	}
+	emitter.maxFragmento = *o.maxFragment
+	if *o.awaitReply < 0 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "await-reply", *o.awaitReply))
+		os.Exit(1)
+	}
+	if *o.awaitReply > 0 && *o.maxFragment > 0 {
+		fmt.Fprintln(os.Stderr, msgs.T("error.incompatibles", "await-reply", "max-fragment"))
+		os.Exit(1)
+	}
+	emitter.esperaRespuesta = *o.awaitReply
+	emitter.secuencia = *o.seq
+	if *o.dumpFrame && *o.maxFragment > 0 {
+		fmt.Fprintln(os.Stderr, msgs.T("error.incompatibles", "dump-frame", "max-fragment"))
+		os.Exit(1)
+	}
+	checksum, err := frame.ParseChecksumKind(*o.checksum)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Error en configuración: %v\n", err)
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "checksum", err))
+		os.Exit(1)
+	}
+	emitter.checksum = checksum
+	if *o.contentType {
+		emitter.contenido = contenidoPresentacion()
+	}
+	if *o.parityRows < 1 || *o.parityRows > 255 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_rango", "parity-rows", *o.parityRows, 1, 255))
+		os.Exit(1)
+	}
+	emitter.filasParidad = *o.parityRows
+	if *o.rsParity < 1 || *o.rsParity > 254 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_rango", "rs-parity", *o.rsParity, 1, 254))
+		os.Exit(1)
+	}
+	emitter.paridadRS = *o.rsParity
+	emitter.paranoico = *o.paranoid
+	if *o.interleave < 0 || *o.interleave > frame.MaxInterleaveDepth {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_rango", "interleave", *o.interleave, 0, frame.MaxInterleaveDepth))
+		os.Exit(1)
+	}
+	if *o.interleave > 0 && *o.inject != "" {
+		fmt.Fprintln(os.Stderr, msgs.T("error.incompatibles", "interleave", "inject"))
+		os.Exit(1)
+	}
+	emitter.entrelazado = *o.interleave
+	if *o.burst < 0 {
+		fmt.Fprint(os.Stderr, msgs.T("error.flag_invalido", "burst", *o.burst))
 		os.Exit(1)
 	}
+	emitter.largoRafaga = *o.burst
+	if *o.erasure && (*o.burst > 0 || *o.inject != "") {
+		fmt.Fprintln(os.Stderr, msgs.T("error.erasure"))
+		os.Exit(1)
+	}
+	emitter.borrado = *o.erasure
+	if *o.stripDiacr && !*o.normalize {
+		fmt.Fprintln(os.Stderr, msgs.T("error.requiere", "strip-diacritics", "normalize"))
+		os.Exit(1)
+	}
+	if *o.normalize {
+		emitter.normalizacion = &presentation.OpcionesNormalizacion{QuitarDiacriticos: *o.stripDiacr}
+	}
+
+	// Abrir todos los exports antes de empezar, para fallar rápido ante rutas
+	// repetidas, y cerrarlos aunque la corrida se interrumpa
+	exports := export.NuevoConjunto()
+	destinos := &destinosExport{}
+	if !*o.estimate {
+		var err error
+		destinos, err = abrirExports(exports, o, emitter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+	defer exports.CerrarSiPanico()
+	detener := exports.CerrarAlInterrumpir(*o.manifest)
+	defer detener()
+
+	// salir cierra los exports y escribe el manifiesto antes de terminar con
+	// un error, ya que os.Exit no ejecuta los defer
+	salir := func(codigo int) {
+		detener()
+		manifiesto := *o.manifest
+		if *o.estimate {
+			manifiesto = ""
+		}
+		if err := cerrarExports(exports, manifiesto); err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.cerrar_exports", err))
+		}
+		os.Exit(codigo)
+	}
+
+	if *o.noWatchdog {
+		emitter.watchdogIteraciones = 0
+	}
+	if *o.fullPos {
+		emitter.noise.FijarMaxPosiciones(0)
+	}
+
+	// Una trama pre-construida no necesita configuración interactiva
+	if *o.frameHex != "" || *o.frameFile != "" {
+		result, err := enviarTramaExterna(emitter, o)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			salir(1)
+		}
+		mostrarResultadoDetallado(result)
+		if *o.dumpFrame {
+			mostrarVolcado(result)
+		}
+		if err := cerrarExports(exports, *o.manifest); err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.cerrar_exports", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Solicitar configuración (flood pide los mismos datos que manual)
+	promptMode := *o.mode
+	if *o.mode == "flood" {
+		promptMode = "manual"
+	}
+	config, err := emitter.app.SolicitarMensaje(promptMode)
+	if err != nil {
+		fmt.Fprint(os.Stderr, msgs.T("error.configuracion", err))
+		salir(1)
+	}
+	config.Mode = *o.mode
 
 	// Validar configuración
 	err = emitter.app.ValidarConfiguracion(config)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Configuración inválida: %v\n", err)
-		os.Exit(1)
+		fmt.Fprint(os.Stderr, msgs.T("error.config_invalida", err))
+		salir(1)
 	}
 
 	// Mostrar configuración
 	emitter.app.MostrarConfiguracion(config)
 
-	// Ejecutar según el modo
-	switch *mode {
+	// Advertir combinaciones que no producen resultados significativos
+	if advertencias := emitter.app.AdvertirConfiguracion(config); len(advertencias) > 0 {
+		fmt.Println(msgs.T("advertencia.titulo"))
+		for _, a := range advertencias {
+			fmt.Printf("   %s\n", a)
+		}
+		fmt.Println()
+		if *o.strictAdvice {
+			fmt.Fprintln(os.Stderr, msgs.T("advertencia.estricto"))
+			salir(1)
+		}
+	}
+
+	// Ejecutar según el modo; el benchmark deja aquí su bundle, que se escribe
+	// después de cerrar los exports, y si el watchdog lo abortó la corrida
+	// termina con error tras exportar los resultados parciales
+	var bundle *contenidoBundle
+	abortado := false
+	switch *o.mode {
 	case "manual":
 		result, err := emitter.ProcessMessage(config)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error en transmisión: %v\n", err)
-			os.Exit(1)
+			fmt.Fprint(os.Stderr, msgs.T("error.transmision", err))
+			if d := diagnosticarTrama(err); d != "" {
+				fmt.Fprint(os.Stderr, msgs.T("error.diagnostico", d))
+			}
+			salir(1)
 		}
 
+		emitter.ejecutarHooks(result)
+
 		// Mostrar resultado detallado
 		mostrarResultadoDetallado(result)
+		if *o.dumpFrame {
+			mostrarVolcado(result)
+		}
 
 	case "benchmark":
-		benchmark, err := emitter.RunBenchmark(config)
+		if *o.estimate {
+			cal, err := emitter.calibrarEstimacion(config, *o.estimateN)
+			if err != nil {
+				fmt.Fprint(os.Stderr, msgs.T("error.calibracion", err))
+				salir(1)
+			}
+			estimacion, err := proyectarEstimacion(cal, config)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				salir(1)
+			}
+			escribirEstimacion(os.Stdout, cal, estimacion, *o.hookNDJSON != "")
+			return
+		}
+
+		// Ctrl+C (o SIGTERM) detiene el benchmark y se analizan las iteraciones
+		// completadas; los exports se cierran al final como en una corrida completa
+		detener()
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		stream := emitter.RunBenchmarkStream(ctx, config)
+		for range stream.Results {
+		}
+		benchmark, err := stream.Benchmark(), <-stream.Err
+		stop()
+		interrumpido := errors.Is(err, context.Canceled)
+		if interrumpido {
+			fmt.Fprint(os.Stderr, msgs.T("emisor.interrumpido", len(benchmark.Results)))
+			err = nil
+		}
+		var werr *WatchdogError
+		abortado = errors.As(err, &werr)
+		if err != nil && !abortado {
+			fmt.Fprint(os.Stderr, msgs.T("error.benchmark", err))
+			salir(1)
+		}
+
+		// Analizar y mostrar estadísticas; si el watchdog abortó la corrida se
+		// analizan y exportan las iteraciones parciales antes de salir con error
+		analizarBenchmark(benchmark, *o.berTolerance)
+		if abortado {
+			fmt.Fprint(os.Stderr, msgs.T("watchdog.abortado", werr.Iteraciones))
+			fmt.Fprintln(os.Stderr, motivoWatchdog(werr.Motivo))
+			fmt.Fprint(os.Stderr, msgs.T("error.diagnostico", werr.Diagnostico))
+			fmt.Fprint(os.Stderr, msgs.T("watchdog.ultimo_error", werr.UltimoError))
+			fmt.Fprintln(os.Stderr, msgs.T("watchdog.pista"))
+		}
+
+		bundle, err = exportarBenchmark(o, destinos, exports, benchmark, interrumpido || abortado)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error en benchmark: %v\n", err)
-			os.Exit(1)
+			fmt.Fprint(os.Stderr, msgs.T("error.exports", err))
+			salir(1)
+		}
+
+	case "flood":
+		textBits, err := emitter.presentation.CodificarMensaje(config.Text)
+		if err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.presentacion", err))
+			salir(1)
+		}
+		frameBytes, _, err := emitter.construirTrama(config.Algorithm, textBits, config.BER, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			if d := diagnosticarTrama(err); d != "" {
+				fmt.Fprint(os.Stderr, msgs.T("error.diagnostico", d))
+			}
+			salir(1)
+		}
+
+		fmt.Print(msgs.T("emisor.flood", *o.floodConns, *o.floodDur, len(frameBytes)))
+		result, err := emitter.RunFlood(frameBytes, FloodConfig{Conexiones: *o.floodConns, Duracion: *o.floodDur})
+		if err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.flood", err))
+			salir(1)
 		}
+		mostrarResultadoFlood(result)
 
-		// Analizar y mostrar estadísticas
-		analizarBenchmark(benchmark)
+		if *o.statsURL != "" {
+			recibidas, err := obtenerContadorReceptor(*o.statsURL)
+			if err != nil {
+				fmt.Fprint(os.Stderr, msgs.T("emisor.contador_error", *o.statsURL, err))
+			} else {
+				fmt.Print(msgs.T("emisor.contador", recibidas, result.Enviadas-recibidas))
+			}
+		}
 
 	default:
-		fmt.Fprintf(os.Stderr, "❌ Modo inválido: %s (usar 'manual' o 'benchmark')\n", *mode)
+		fmt.Fprint(os.Stderr, msgs.T("error.modo", *o.mode))
+		os.Exit(1)
+	}
+
+	if err := cerrarExports(exports, *o.manifest); err != nil {
+		fmt.Fprint(os.Stderr, msgs.T("error.cerrar_exports", err))
+		os.Exit(1)
+	}
+	if bundle != nil {
+		if err := escribirBundle(*o.bundle, bundle); err != nil {
+			fmt.Fprint(os.Stderr, msgs.T("error.bundle", err))
+			os.Exit(1)
+		}
+		if bundle.parcial {
+			fmt.Print(msgs.T("emisor.bundle_parcial", *o.bundle))
+		} else {
+			fmt.Print(msgs.T("emisor.bundle", *o.bundle))
+		}
+	}
+	if abortado {
 		os.Exit(1)
 	}
 }
@@ -290,20 +1468,76 @@ func mostrarAyuda() {
 	fmt.Println("Implementa arquitectura de 5 capas para transmisión con detección/corrección de errores.")
 	fmt.Println()
 	fmt.Println("Uso:")
-	fmt.Printf("  %s [flags]\n\n", os.Args[0])
+	for _, s := range subcomandos {
+		fmt.Printf("  %s %-6s [flags]  %s\n", os.Args[0], s.nombre, s.descripcion)
+	}
+	fmt.Printf("                    ('%s <subcomando> --help' lista solo sus flags)\n", os.Args[0])
+	fmt.Printf("  %s [flags]        Forma anterior: --mode elige el modo y se aceptan todos los flags\n", os.Args[0])
+	fmt.Printf("  %s schema [sub]   Imprimir en JSON los flags, sus tipos y valores permitidos\n", os.Args[0])
+	fmt.Printf("  %s history [--algorithm a] [--ber b] [--limit n] [--diff id1 id2]\n", os.Args[0])
+	fmt.Println("                    Listar o comparar corridas registradas (--file para otro historial)")
+	fmt.Printf("  %s verify-files f...  Verificar manifiestos (.json), NDJSON, CSV y PNG exportados\n", os.Args[0])
+	fmt.Printf("  %s synth --out dir    Generar un dataset sintético con parámetros conocidos en los metadatos\n", os.Args[0])
+	fmt.Println()
 	fmt.Println("Flags:")
-	fmt.Println("  --mode string     Modo de operación: 'manual' o 'benchmark' (default: manual)")
+	fmt.Println("  --mode string     Obsoleto, usar subcomandos: 'manual', 'benchmark' o 'flood' (default: manual)")
 	fmt.Println("  --ws-url string   URL del receptor WebSocket (default: ws://localhost:9000)")
+	fmt.Println("  --frame-hex h     Enviar una trama ya construida (hex), solo con ruido y transmisión")
+	fmt.Println("  --frame-file f    Igual que --frame-hex, leyendo el hexadecimal de f")
+	fmt.Println("  --frame-ber p     BER aplicado a la trama de --frame-hex/--frame-file (default: 0)")
+	fmt.Println("  --force           Enviar la trama aunque su longitud o CRC no coincidan")
+	fmt.Printf("  --full-positions  Guardar todas las posiciones de error (por defecto, muestra de %d por iteración)\n", noise.DefaultMaxPosiciones)
+	fmt.Println("  --deadline d      Plazo por mensaje de la codificación al envío (ej: 50ms); tarde cuenta como fallida")
+	fmt.Println("  --await-reply d   Esperar hasta d el ACK/NACK del receptor; sin confirmación la trama cuenta como fallida")
+	fmt.Println("  --dump-frame      Mostrar la trama como hexdump -C con los campos del header y el checksum (solo manual)")
+	fmt.Println("  --content-type    Declarar en el header el tipo de contenido (ascii); el receptor Python no lo interpreta")
+	fmt.Println("  --seq             Numerar las tramas del benchmark en el header; el receptor Python no lo interpreta")
+	fmt.Println("  --checksum k      Verificación de la trama: crc8, crc16 (CCITT), fletcher16, crc32 o crc32c (Castagnoli, default: crc32)")
+	fmt.Println("  --parity-rows n   Filas de la matriz de paridad 2D del algoritmo parity2d (default: 8)")
+	fmt.Println("  --rs-parity n     Símbolos de paridad por bloque del algoritmo rs; corrige n/2 bytes por bloque (default: 32)")
+	fmt.Println("  --interleave n    Entrelazar los bloques Hamming con profundidad n (>= 7 reparte ráfagas entre bloques)")
+	fmt.Println("  --burst n         Errores en ráfagas de n bits con el mismo BER medio (0: independientes)")
+	fmt.Println("  --erasure         Canal de borrado con relleno 0; rs corrige el doble de bytes borrados que erróneos")
+	fmt.Println("  --max-fragment n  Partir cada trama en fragmentos de hasta n bytes de datos con CRC propio (0: sin fragmentar)")
+	fmt.Println("  --lang es|en      Idioma de prompts y resúmenes (default: según LANG, si no es)")
+	fmt.Println("  --duration d      Correr el benchmark durante d (ej: 10m) en lugar de pedir iteraciones")
+	fmt.Println("  --ber-tolerance t Desviación relativa aceptada en la calibración del BER (default: 0.1)")
+	fmt.Println("  --inject spec     Invertir bits en bloques Hamming concretos en lugar de usar BER")
+	fmt.Println("                    (ej: 'block=3:bits=2;block=5:bits=1', bloques desde 1)")
+	fmt.Println("  --paranoid        Cada resultado del benchmark guarda sus propios bits aunque repitan los de otra iteración")
+	fmt.Println("  --no-watchdog     No abortar el benchmark aunque las primeras iteraciones fallen todas")
+	fmt.Println("  --watchdog-iter n Iteraciones iniciales que revisa el watchdog (default: 50)")
+	fmt.Println("  --flood-conns n   Conexiones paralelas en modo flood (default: 4)")
+	fmt.Println("  --flood-duration  Duración del modo flood (default: 10s)")
+	fmt.Println("  --stats-url url   Endpoint JSON del receptor con total_received, consultado tras el flood")
+	fmt.Println("  --hook-ndjson f   Escribir un registro JSON por iteración en f (manual y benchmark)")
+	fmt.Println("  --ber-schedule f  Variar el BER del benchmark según el plan en f ('30s 0.01' por línea)")
+	fmt.Println("  --schedule-loop   Repetir el plan al terminar (por defecto el benchmark se detiene)")
+	fmt.Println("  --normalize       Convertir comillas, guiones y espacios Unicode a ASCII e informar los cambios")
+	fmt.Println("  --strip-diacritics Con --normalize, quitar también los acentos (á → a)")
+	fmt.Println("  --strict-advice   Abortar si la configuración genera advertencias (ej: Hamming con BER alto)")
+	fmt.Println("  --error-png f     Exportar un PNG con una fila por iteración y los bits invertidos en negro")
+	fmt.Println("  --theory-csv f    Exportar la curva teórica (trama intacta, bloque y payload Hamming) por BER")
+	fmt.Println("  --iterations-csv f Exportar por iteración BER, resultado y costo de la trama (header, relleno, tasa del código)")
+	fmt.Println("  --error-png-max-iter n / --error-png-max-bits n  Recortar el mapa (default: 1000 / 4096)")
+	fmt.Println("  --manifest f      Escribir en f un JSON con ruta, tipo, versión, tamaño y SHA-256 de cada export")
+	fmt.Println("  --bundle f.zip    Empaquetar la corrida (config, metadatos, reporte, tramas fallidas, exports) con un index.json")
+	fmt.Println("  --history-file f  Historial donde se registra cada benchmark (default: ~/.rlab2/history.jsonl)")
+	fmt.Println("  --no-history      No registrar el benchmark en el historial")
+	fmt.Println("  --estimate        Proyectar duración, memoria y tamaño de exports con unas iteraciones de muestra")
+	fmt.Println("  --estimate-samples n  Iteraciones de muestra de --estimate (default: 5)")
 	fmt.Println("  --help           Mostrar esta ayuda")
 	fmt.Println()
 	fmt.Println("Modos:")
 	fmt.Println("  manual    - Transmisión interactiva de un mensaje")
 	fmt.Println("  benchmark - Múltiples transmisiones para análisis estadístico")
+	fmt.Println("  flood     - Generador de carga: misma trama sin ruido por conexiones paralelas")
 	fmt.Println()
 	fmt.Println("Capas implementadas:")
 	fmt.Println("  1. Aplicación    - Input del usuario")
 	fmt.Println("  2. Presentación  - ASCII ↔ bits")
-	fmt.Println("  3. Enlace        - CRC-32 / Hamming(7,4)")
+	fmt.Println("  3. Enlace        - Checksum (--checksum) y códigos: Hamming(7,4), SEC-DED, paridad 2D,")
+	fmt.Println("                     repetición, Reed-Solomon y Golay (--algorithm)")
 	fmt.Println("  4. Ruido         - Inyección de errores (BER)")
 	fmt.Println("  5. Transmisión   - WebSocket")
 }
@@ -311,13 +1545,45 @@ func mostrarAyuda() {
 func mostrarResultadoDetallado(result *TransmissionResult) {
 	fmt.Println("📋 Resultado Detallado:")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Mensaje original: \"%s\"\n", result.OriginalMessage)
-	fmt.Printf("Bits de texto: %d\n", len(result.TextBits))
+	if result.TramaExterna {
+		fmt.Println("Trama pre-construida (sin capas de presentación ni enlace)")
+	} else {
+		fmt.Printf("Mensaje original: \"%s\"\n", result.OriginalMessage)
+		if result.TextoEnviado != "" {
+			fmt.Printf("Mensaje enviado (normalizado): \"%s\" (%d sustituciones)\n", result.TextoEnviado, len(result.Normalizacion))
+		}
+		fmt.Printf("Bits de texto: %d\n", len(result.TextBits))
+	}
 	fmt.Printf("Tamaño de frame: %d bytes\n", len(result.FrameBytes))
+	if result.Estadisticas != nil {
+		fmt.Println(formatearEstadisticas(*result.Estadisticas))
+	}
 	fmt.Printf("Errores inyectados: %d\n", result.ErrorsInjected)
+	if result.Inyeccion != "" {
+		fmt.Printf("Inyección dirigida: %s (posiciones %v)\n", result.Inyeccion, result.ErrorPositions)
+	}
+	if result.PosicionesTruncadas && result.ResumenPosiciones != nil {
+		fmt.Printf("Posiciones de error: muestra de %d, %s (usar --full-positions para todas)\n",
+			len(result.ErrorPositions), result.ResumenPosiciones)
+	}
 	fmt.Printf("BER real: %.4f\n", result.ActualBER)
 	fmt.Printf("Tiempo total: %v\n", result.TotalTime)
 	fmt.Printf("Tiempo transmisión: %v\n", result.TransmissionTime)
+	if result.Puntualidad != "" {
+		fmt.Printf("Puntualidad: %s\n", result.Puntualidad)
+	}
+	if result.BloquesSECDED != nil {
+		fmt.Println(formatearBloquesSECDED(*result.BloquesSECDED))
+	}
+	if result.CorreccionRS != nil {
+		fmt.Println(formatearCorreccionRS(*result.CorreccionRS))
+	}
+	if result.ReceiverVerdict != nil {
+		fmt.Printf("Receptor: %s\n", result.ReceiverVerdict)
+	}
+	if result.Fragmentos != nil {
+		mostrarFragmentos(result.Fragmentos)
+	}
 
 	if result.Success {
 		fmt.Println("✅ Estado: EXITOSA")
@@ -327,17 +1593,23 @@ func mostrarResultadoDetallado(result *TransmissionResult) {
 	fmt.Println()
 }
 
-func analizarBenchmark(benchmark *BenchmarkResult) {
-	fmt.Println("📊 Análisis del Benchmark:")
+func mostrarVolcado(result *TransmissionResult) {
+	if err := volcarTramas(os.Stdout, result); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error mostrando la trama: %v\n", err)
+	}
+}
+
+func analizarBenchmark(benchmark *BenchmarkResult, tolerancia float64) {
+	fmt.Println(msgs.T("analisis.titulo"))
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	// Estadísticas básicas
-	fmt.Printf("Configuración: %s, BER=%.3f, %d iteraciones\n",
-		benchmark.Config.Algorithm, benchmark.Config.BER, benchmark.Config.Count)
-	fmt.Printf("Tasa de éxito: %.2f%% (%d/%d)\n",
-		benchmark.SuccessRate*100, benchmark.Successful, benchmark.Config.Count)
-	fmt.Printf("Tiempo total: %v (promedio: %v por transmisión)\n",
-		benchmark.TotalTime, benchmark.AverageTransmissionTime)
+	fmt.Print(msgs.T("analisis.configuracion", benchmark.Config.Algorithm, benchmark.Config.BER, len(benchmark.Results)))
+	if benchmark.Config.Duration > 0 {
+		fmt.Print(msgs.T("analisis.duracion", benchmark.Config.Duration, len(benchmark.Results)))
+	}
+	fmt.Print(msgs.T("analisis.tasa", benchmark.SuccessRate*100, benchmark.Successful, len(benchmark.Results)))
+	fmt.Print(msgs.T("analisis.tiempo", benchmark.TotalTime, benchmark.AverageTransmissionTime))
 
 	// Análisis de errores
 	if len(benchmark.Results) > 0 {
@@ -357,11 +1629,110 @@ func analizarBenchmark(benchmark *BenchmarkResult) {
 			avgErrors := float64(totalErrors) / float64(successful)
 			avgBER := totalBER / float64(successful)
 
-			fmt.Printf("Errores promedio por transmisión: %.1f\n", avgErrors)
-			fmt.Printf("BER promedio: %.4f (objetivo: %.4f)\n", avgBER, benchmark.Config.BER)
+			fmt.Print(msgs.T("analisis.errores", avgErrors))
+			fmt.Print(msgs.T("analisis.ber", avgBER, benchmark.Config.BER))
 		}
 	}
 
 	fmt.Println()
-	fmt.Println("💡 Para análisis más detallado, implementar exportación a CSV")
+	if benchmark.Plan != nil {
+		// Con BER variable la calibración global no tiene un objetivo único
+		analizarSegmentos(benchmark, tolerancia)
+	} else {
+		calibrarBenchmark(benchmark, tolerancia).EscribirReporte(os.Stdout, msgs)
+	}
+
+	if comparacion, err := compararTeoria(benchmark); err != nil {
+		fmt.Print(msgs.T("analisis.sin_teoria", err))
+	} else if comparacion != nil {
+		fmt.Println()
+		mostrarComparacionTeoria(os.Stdout, comparacion)
+	}
+
+	fmt.Println()
+	fmt.Println(msgs.T("analisis.pista_exports"))
+}
+
+// calibrarBenchmark agrega los bits y errores de todas las iteraciones que
+// pasaron por la capa de ruido, exitosas o no. Las iteraciones con inyección
+// dirigida no siguen un BER y quedan fuera de la calibración.
+func calibrarBenchmark(benchmark *BenchmarkResult, tolerancia float64) *noise.CalibracionBER {
+	return calibrarResultados(benchmark.Results, benchmark.Config.BER, tolerancia)
+}
+
+func calibrarResultados(results []*TransmissionResult, targetBER, tolerancia float64) *noise.CalibracionBER {
+	var totalBits, totalErrors int
+	for _, result := range results {
+		if result.NoisyFrameBits == nil || result.Inyeccion != "" {
+			continue
+		}
+		totalBits += len(result.OriginalFrameBits)
+		totalErrors += result.ErrorsInjected
+	}
+	return noise.CalibrarBER(totalBits, totalErrors, targetBER, tolerancia)
+}
+
+// ResumenSegmento agrega los resultados de un segmento del plan de BER
+type ResumenSegmento struct {
+	Segmento    int
+	BER         float64
+	Iteraciones int
+	Exitosas    int
+	Calibracion *noise.CalibracionBER
+}
+
+// resumirSegmentos agrupa los resultados por segmento del plan, en el orden del plan
+func resumirSegmentos(benchmark *BenchmarkResult, tolerancia float64) []ResumenSegmento {
+	porSegmento := make([][]*TransmissionResult, len(benchmark.Plan.Segmentos))
+	for _, r := range benchmark.Results {
+		if r.Segmento > 0 {
+			porSegmento[r.Segmento-1] = append(porSegmento[r.Segmento-1], r)
+		}
+	}
+
+	resumen := make([]ResumenSegmento, len(porSegmento))
+	for i, results := range porSegmento {
+		ber := benchmark.Plan.Segmentos[i].BER
+		resumen[i] = ResumenSegmento{
+			Segmento:    i + 1,
+			BER:         ber,
+			Iteraciones: len(results),
+			Calibracion: calibrarResultados(results, ber, tolerancia),
+		}
+		for _, r := range results {
+			if r.Success {
+				resumen[i].Exitosas++
+			}
+		}
+	}
+	return resumen
+}
+
+func analizarSegmentos(benchmark *BenchmarkResult, tolerancia float64) {
+	fmt.Println("📈 Resultados por segmento del plan de BER:")
+	fmt.Printf("   %-4s %-9s %-8s %-10s %-11s %s\n", "Seg", "BER", "Iter", "Éxito", "BER real", "Calibración")
+	for _, s := range resumirSegmentos(benchmark, tolerancia) {
+		exito, berReal, calibracion := "-", "-", "-"
+		if s.Iteraciones > 0 {
+			exito = fmt.Sprintf("%.1f%%", float64(s.Exitosas)/float64(s.Iteraciones)*100)
+		}
+		if c := s.Calibracion; c.Aplica {
+			berReal = fmt.Sprintf("%.6f", c.ObservedBER)
+			calibracion = "✅"
+			if !c.Aprobado {
+				calibracion = "❌"
+			}
+		}
+		fmt.Printf("   %-4d %-9.4f %-8d %-10s %-11s %s\n", s.Segmento, s.BER, s.Iteraciones, exito, berReal, calibracion)
+	}
+}
+
+// cargarPlanBER lee el plan de BER desde path
+func cargarPlanBER(path string) (*noise.PlanBER, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return noise.ParsePlanBER(f)
 }
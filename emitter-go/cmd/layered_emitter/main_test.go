@@ -0,0 +1,761 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/presentation"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+	"github.com/gorilla/websocket"
+)
+
+// newTestEmitter crea un emisor cuyo transporte es la función dada
+func newTestEmitter(send func(url string, frame []byte) error) *LayeredEmitter {
+	le := NewLayeredEmitter("ws://test")
+	le.sendFrame = func(_ context.Context, url string, frame []byte) (*wsclient.ConnStats, error) {
+		// Los tests guardan las tramas y el emisor reutiliza el arreglo
+		return &wsclient.ConnStats{}, send(url, append([]byte(nil), frame...))
+	}
+	return le
+}
+
+func TestRunBenchmark_WatchdogAbortaPorTransporte(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}
+	})
+	le.watchdogIteraciones = 5
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: 20}
+	benchmark, err := le.RunBenchmark(config)
+
+	var werr *WatchdogError
+	if !errors.As(err, &werr) {
+		t.Fatalf("se esperaba WatchdogError, se obtuvo %v", err)
+	}
+	if werr.Motivo != "transporte" || !strings.Contains(werr.Diagnostico, "no está escuchando") {
+		t.Errorf("Motivo = %q (%s), se esperaba transporte con el receptor sin escuchar", werr.Motivo, werr.Diagnostico)
+	}
+	if werr.Iteraciones != 5 || len(benchmark.Results) != 5 {
+		t.Errorf("se esperaban 5 iteraciones, se obtuvieron %d (resultados %d)", werr.Iteraciones, len(benchmark.Results))
+	}
+	if benchmark.Failed != 5 || benchmark.SuccessRate != 0 {
+		t.Errorf("agregados parciales incorrectos: failed=%d rate=%.2f", benchmark.Failed, benchmark.SuccessRate)
+	}
+}
+
+func TestRunBenchmark_WatchdogAbortaPorProcesamiento(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 3
+
+	// Un algoritmo desconocido hace fallar ProcessMessage antes del transporte
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "desconocido", Mode: "benchmark", Count: 10}
+	_, err := le.RunBenchmark(config)
+
+	var werr *WatchdogError
+	if !errors.As(err, &werr) {
+		t.Fatalf("se esperaba WatchdogError, se obtuvo %v", err)
+	}
+	if werr.Motivo != "procesamiento" {
+		t.Errorf("Motivo = %q, se esperaba procesamiento", werr.Motivo)
+	}
+}
+
+func TestRunBenchmark_WatchdogNoAbortaConExitos(t *testing.T) {
+	calls := 0
+	le := newTestEmitter(func(url string, frame []byte) error {
+		calls++
+		if calls%2 == 0 {
+			return fmt.Errorf("fallo intermitente")
+		}
+		return nil
+	})
+	le.watchdogIteraciones = 4
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", Mode: "benchmark", Count: 10}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(benchmark.Results) != 10 || benchmark.Successful != 5 {
+		t.Errorf("resultados = %d, exitosos = %d; se esperaban 10 y 5", len(benchmark.Results), benchmark.Successful)
+	}
+}
+
+func TestRunBenchmark_WatchdogDesactivado(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error {
+		return fmt.Errorf("connection refused")
+	})
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 8}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("error inesperado con watchdog desactivado: %v", err)
+	}
+	if len(benchmark.Results) != 8 {
+		t.Errorf("se esperaban 8 resultados, se obtuvieron %d", len(benchmark.Results))
+	}
+}
+
+func TestDiagnosticarTransporte(t *testing.T) {
+	rechazada := &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{rechazada, "el receptor no está escuchando; revisar --ws-url o iniciar el receptor"},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "foo", IsNotFound: true}}, "el host de --ws-url no existe"},
+		{websocket.ErrBadHandshake, "el servidor no acepta WebSocket en esa ruta; revisar --ws-url"},
+		{fmt.Errorf("enviando trama: %w", context.DeadlineExceeded), "el receptor no responde a tiempo; revisar red o carga del receptor"},
+		// El texto del error no cuenta, solo su cadena de errores envueltos
+		{errors.New("connection refused"), "todas las transmisiones fallaron; revisar --ws-url y el estado del receptor"},
+		{nil, "todas las transmisiones fallaron; revisar --ws-url y el estado del receptor"},
+	}
+
+	for _, tt := range tests {
+		if got := diagnosticarTransporte(tt.err); got != tt.want {
+			t.Errorf("diagnosticarTransporte(%v) = %q, se esperaba %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDiagnosticos_Idioma(t *testing.T) {
+	en, err := i18n.Nuevo("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs = en
+	defer func() { msgs = nil }()
+
+	rechazada := &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}
+	if got := diagnosticarTransporte(rechazada); got != "the receiver is not listening; check --ws-url or start the receiver" {
+		t.Errorf("diagnosticarTransporte con --lang en = %q", got)
+	}
+	var werr *WatchdogError
+	if err := diagnosticarWatchdog([]*TransmissionResult{{Error: "x"}}); !errors.As(err, &werr) || !strings.HasPrefix(werr.Diagnostico, "the message could not be encoded") {
+		t.Errorf("diagnosticarWatchdog con --lang en = %v", err)
+	}
+	if got := motivoWatchdog(werr.Motivo); got != "   Reason: processing failure" {
+		t.Errorf("motivoWatchdog(%q) = %q", werr.Motivo, got)
+	}
+	if got := formatearVeredictos(ResumenVeredictos{Ack: 2, Nack: 1}); got != "Receiver: 2 ACK, 1 NACK, 0 without reply" {
+		t.Errorf("formatearVeredictos con --lang en = %q", got)
+	}
+}
+
+func TestRunBenchmark_Duracion(t *testing.T) {
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	// Cada envío consume 10ms de tiempo virtual
+	le := newTestEmitter(func(url string, frame []byte) error {
+		reloj.Advance(10 * time.Millisecond)
+		return nil
+	})
+	le.clock = reloj
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Duration: 100 * time.Millisecond}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	// El corte es exacto: 100ms de deadline / 10ms por iteración
+	if len(benchmark.Results) != 10 {
+		t.Errorf("se esperaban 10 iteraciones, se completaron %d", len(benchmark.Results))
+	}
+	if benchmark.TotalTime != config.Duration {
+		t.Errorf("TotalTime = %v, se esperaba %v", benchmark.TotalTime, config.Duration)
+	}
+	if benchmark.AverageTransmissionTime != 10*time.Millisecond {
+		t.Errorf("AverageTransmissionTime = %v, se esperaba 10ms", benchmark.AverageTransmissionTime)
+	}
+	if benchmark.Successful+benchmark.Failed != len(benchmark.Results) {
+		t.Errorf("contabilidad inconsistente: %d exitosas + %d fallidas != %d resultados",
+			benchmark.Successful, benchmark.Failed, len(benchmark.Results))
+	}
+	if benchmark.SuccessRate != 1 {
+		t.Errorf("SuccessRate = %.2f, se esperaba 1", benchmark.SuccessRate)
+	}
+}
+
+func TestCalibrarBenchmark_AgregaTodasLasIteraciones(t *testing.T) {
+	calls := 0
+	le := newTestEmitter(func(url string, frame []byte) error {
+		calls++
+		if calls%3 == 0 {
+			return fmt.Errorf("fallo de transporte")
+		}
+		return nil
+	})
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola mundo", Algorithm: "hamming", BER: 0.05, Mode: "benchmark", Count: 30}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	var wantBits, wantErrors int
+	for _, r := range benchmark.Results {
+		wantBits += len(r.OriginalFrameBits)
+		wantErrors += r.ErrorsInjected
+	}
+
+	c := calibrarBenchmark(benchmark, 0.1)
+	if c.TotalBits != wantBits || c.TotalErrors != wantErrors {
+		t.Errorf("totales = %d/%d, se esperaba %d/%d (incluyendo iteraciones fallidas)",
+			c.TotalErrors, c.TotalBits, wantErrors, wantBits)
+	}
+	if !c.Aplica {
+		t.Error("la calibración debería aplicar")
+	}
+}
+
+func TestCalibrarBenchmark_SinRuidoNoAplica(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+
+	// Todas las iteraciones fallan antes de la capa de ruido
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "desconocido", BER: 0.05, Mode: "benchmark", Count: 3}
+	benchmark, _ := le.RunBenchmark(config)
+
+	if c := calibrarBenchmark(benchmark, 0.1); c.Aplica {
+		t.Error("sin bits ruidosos la calibración no debería aplicar")
+	}
+}
+
+func TestProcessMessage_InyeccionDirigida(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 3, Bits: 2}, {Bloque: 5, Bits: 1}}
+
+	// "Hola" = 32 bits de texto = 8 bloques Hamming(7,4)
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.5, Mode: "manual", Count: 1}
+	result, err := le.ProcessMessage(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if result.Inyeccion != "block=3:bits=2;block=5:bits=1" {
+		t.Errorf("Inyeccion = %q", result.Inyeccion)
+	}
+	if result.ErrorsInjected != 3 {
+		t.Fatalf("se esperaban 3 errores (el BER se ignora), se obtuvieron %d", result.ErrorsInjected)
+	}
+
+	offset := (frame.HeaderSize + frame.HammingPadSize) * 8
+	porBloque := make(map[int]int)
+	for _, pos := range result.ErrorPositions {
+		porBloque[(pos-offset)/7+1]++
+	}
+	if porBloque[3] != 2 || porBloque[5] != 1 || len(porBloque) != 2 {
+		t.Errorf("errores por bloque = %v, se esperaba map[3:2 5:1]", porBloque)
+	}
+}
+
+func TestProcessMessage_InyeccionValidaRango(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 9, Bits: 1}}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", Mode: "manual", Count: 1}
+	if _, err := le.ProcessMessage(config); err == nil {
+		t.Error("se esperaba error: \"Hola\" solo tiene 8 bloques")
+	}
+
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+	config.Algorithm = "crc"
+	if _, err := le.ProcessMessage(config); err == nil {
+		t.Error("se esperaba error: la inyección por bloques requiere hamming")
+	}
+}
+
+func TestCalibrarBenchmark_InyeccionNoAplica(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.05, Mode: "benchmark", Count: 3}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if c := calibrarBenchmark(benchmark, 0.1); c.Aplica {
+		t.Error("con inyección dirigida la calibración no debería aplicar")
+	}
+}
+
+func TestConstruirSchema_CubreFlagsYRegistros(t *testing.T) {
+	var out bytes.Buffer
+	if err := construirSchema().Escribir(&out); err != nil {
+		t.Fatal(err)
+	}
+	var s schema.Schema
+	if err := json.Unmarshal(out.Bytes(), &s); err != nil {
+		t.Fatalf("schema no es JSON válido: %v", err)
+	}
+
+	fs := flag.NewFlagSet("layered_emitter", flag.ContinueOnError)
+	registrarFlags(fs)
+	fs.VisitAll(func(f *flag.Flag) {
+		if s.Buscar(f.Name) == nil {
+			t.Errorf("el flag --%s no aparece en el schema", f.Name)
+		}
+	})
+
+	esperados := map[string][]string{
+		"mode":        modosSoportados,
+		"algorithm":   application.Algoritmos,
+		"noise_model": noise.ModelosSoportados,
+	}
+	for enum, valores := range esperados {
+		if fmt.Sprint(s.Enums[enum]) != fmt.Sprint(valores) {
+			t.Errorf("enum %s = %v, se esperaba %v", enum, s.Enums[enum], valores)
+		}
+	}
+	if !slices.Contains(s.Enums["noise_model"], "erasure") {
+		t.Errorf("noise_model = %v, debería incluir erasure", s.Enums["noise_model"])
+	}
+	if got := s.Buscar("mode").Allowed; fmt.Sprint(got) != fmt.Sprint(modosSoportados) {
+		t.Errorf("--mode permite %v, se esperaba %v", got, modosSoportados)
+	}
+	if len(s.Config) != len(application.CamposConfiguracion()) {
+		t.Errorf("el schema debería incluir los campos de MessageConfig")
+	}
+}
+
+// El schema publica application.Algoritmos: cada uno debe poder construir
+// una trama que el propio paquete frame decodifique
+func TestConstruirTrama_TodosLosAlgoritmos(t *testing.T) {
+	textBits := frame.BytesToBits([]byte("Hola"))
+	for _, algoritmo := range application.Algoritmos {
+		le := newTestEmitter(func(string, []byte) error { return nil })
+		trama, _, err := le.construirTrama(algoritmo, textBits, 0, nil)
+		if err != nil {
+			t.Errorf("%s está en Algoritmos pero no construye trama: %v", algoritmo, err)
+			continue
+		}
+		if _, err := frame.ParseFrame(trama); err != nil {
+			t.Errorf("%s: trama inválida: %v", algoritmo, err)
+		}
+	}
+}
+
+func TestRunBenchmark_PlanBER(t *testing.T) {
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	le := newTestEmitter(func(url string, frame []byte) error {
+		reloj.Advance(time.Second)
+		return nil
+	})
+	le.clock = reloj
+	le.watchdogIteraciones = 0
+	le.planBER = &noise.PlanBER{Segmentos: []noise.SegmentoBER{
+		{Duracion: 3 * time.Second, BER: 0},
+		{Duracion: 2 * time.Second, BER: 0.5},
+	}}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 100}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sin repetición el benchmark termina con el plan: 3 + 2 iteraciones de 1s
+	segmentos := make([]int, len(benchmark.Results))
+	for i, r := range benchmark.Results {
+		segmentos[i] = r.Segmento
+		if r.Config.BER != le.planBER.Segmentos[r.Segmento-1].BER {
+			t.Errorf("iteración %d: BER %.2f no corresponde al segmento %d", i, r.Config.BER, r.Segmento)
+		}
+		if r.Segmento == 1 && r.ErrorsInjected != 0 {
+			t.Errorf("iteración %d: el segmento con BER 0 no debería tener errores", i)
+		}
+	}
+	if fmt.Sprint(segmentos) != "[1 1 1 2 2]" {
+		t.Errorf("segmentos = %v, se esperaba [1 1 1 2 2]", segmentos)
+	}
+	if config.BER != 0 {
+		t.Error("el plan no debe modificar la configuración original")
+	}
+
+	resumen := resumirSegmentos(benchmark, 0.1)
+	total := 0
+	for _, s := range resumen {
+		total += s.Iteraciones
+	}
+	if total != len(benchmark.Results) || resumen[0].Iteraciones != 3 || resumen[1].Iteraciones != 2 {
+		t.Errorf("resumen por segmento inesperado: %+v", resumen)
+	}
+}
+
+func TestRunBenchmark_PlanBERRepetido(t *testing.T) {
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	le := newTestEmitter(func(url string, frame []byte) error {
+		reloj.Advance(time.Second)
+		return nil
+	})
+	le.clock = reloj
+	le.watchdogIteraciones = 0
+	le.planBER = &noise.PlanBER{Repetir: true, Segmentos: []noise.SegmentoBER{
+		{Duracion: time.Second, BER: 0},
+		{Duracion: time.Second, BER: 0.01},
+	}}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "benchmark", Count: 6}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var segmentos []int
+	for _, r := range benchmark.Results {
+		segmentos = append(segmentos, r.Segmento)
+	}
+	if fmt.Sprint(segmentos) != "[1 2 1 2 1 2]" {
+		t.Errorf("segmentos = %v, se esperaba [1 2 1 2 1 2]", segmentos)
+	}
+}
+
+func TestProcessMessage_Normalizacion(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, frame []byte) error {
+		enviada = frame
+		return nil
+	})
+	config := &application.MessageConfig{Text: "“Hola” — mundo", Algorithm: "crc", Mode: "manual"}
+
+	if _, err := le.ProcessMessage(config); err == nil {
+		t.Fatal("sin --normalize el texto no-ASCII debería rechazarse")
+	}
+
+	le.normalizacion = &presentation.OpcionesNormalizacion{}
+	result, err := le.ProcessMessage(config)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if result.OriginalMessage != config.Text {
+		t.Errorf("OriginalMessage = %q, debe conservar el texto ingresado", result.OriginalMessage)
+	}
+	if result.TextoEnviado != `"Hola" - mundo` {
+		t.Errorf("TextoEnviado = %q", result.TextoEnviado)
+	}
+	if len(result.Normalizacion) != 3 {
+		t.Errorf("se esperaban 3 sustituciones, se obtuvieron %v", result.Normalizacion)
+	}
+	if payload := string(enviada[frame.HeaderSize : len(enviada)-4]); payload != result.TextoEnviado {
+		t.Errorf("payload enviado %q, se esperaba el texto normalizado", payload)
+	}
+
+	// Un texto ASCII no registra sustituciones
+	result, err = le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TextoEnviado != "" || result.Normalizacion != nil {
+		t.Errorf("no debería registrarse normalización: %+v", result.Normalizacion)
+	}
+}
+
+func TestRunBenchmark_RegistraAdvertencias(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.2, Mode: "benchmark", Count: 5}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benchmark.Advertencias) != 2 {
+		t.Errorf("se esperaban 2 advertencias en el resultado, se obtuvieron %v", benchmark.Advertencias)
+	}
+}
+
+func TestProcessMessage_MensajeLargo(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+
+	// Más de 255 caracteres: el largo del header es de 16 bits
+	texto := strings.Repeat("mensaje largo ", 30)
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: texto, Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatalf("un mensaje de %d caracteres debería transmitirse: %v", len(texto), err)
+	}
+	if !result.Success || len(enviada) != frame.HeaderSize+len(texto)+frame.CRCSize {
+		t.Errorf("trama inesperada: éxito=%v, %d bytes", result.Success, len(enviada))
+	}
+}
+
+// emitter_crc y emitter_hamming arman sus tramas con BuildFrameFromBits y
+// BuildFrameWithHammingBits: con las opciones por defecto los algoritmos crc y
+// hamming tienen que producir exactamente las mismas (crc, con bytes completos
+// como los de un texto; con bits sueltos emitter_crc registra el relleno)
+func TestConstruirTrama_IgualQueLosEmisoresSimples(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	for n := 0; n <= 40; n++ {
+		textBits := make([]byte, n)
+		for i := range textBits {
+			textBits[i] = byte(i*5/3) & 1
+		}
+		crc, _, err := le.construirTrama("crc", textBits, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := frame.BuildFrameFromBits(textBits); n%8 == 0 && !bytes.Equal(crc, want) {
+			t.Errorf("crc, %d bits: % x, emitter_crc arma % x", n, crc, want)
+		}
+		hamming, _, err := le.construirTrama("hamming", textBits, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := frame.BuildFrameWithHammingBits(textBits, frame.HammingSEC); !bytes.Equal(hamming, want) {
+			t.Errorf("hamming, %d bits: % x, emitter_hamming arma % x", n, hamming, want)
+		}
+	}
+}
+
+func TestRunBenchmark_SecuenciaPorIteracion(t *testing.T) {
+	var enviadas [][]byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviadas = append(enviadas, f)
+		return nil
+	})
+	le.watchdogIteraciones = 0
+	le.secuencia = true
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0, Mode: "benchmark", Count: 5}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, trama := range enviadas {
+		f, err := frame.ParseFrame(trama)
+		if err != nil {
+			t.Fatalf("iteración %d: %v", i, err)
+		}
+		if !f.HasSeq || int(f.Seq) != i || f.MsgType != frame.MsgTypeHamming {
+			t.Errorf("iteración %d: trama con tipo %#02x, secuencia %d (HasSeq %v)", i, f.MsgType, f.Seq, f.HasSeq)
+		}
+		if r := benchmark.Results[i]; r.Secuencia == nil || int(*r.Secuencia) != i {
+			t.Errorf("iteración %d: Secuencia = %v", i, r.Secuencia)
+		}
+	}
+
+	// Fuera del benchmark las tramas no llevan secuencia
+	enviadas = nil
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Secuencia != nil || frame.HeaderLen(enviadas[0]) != frame.HeaderSize {
+		t.Errorf("ProcessMessage no debería agregar secuencia: %v", result.Secuencia)
+	}
+
+	// Sin --seq el benchmark envía el header de 3 bytes que lee el receptor
+	// Python (tipo 0x01/0x02 sin FlagSeq)
+	enviadas = nil
+	le.secuencia = false
+	if _, err := le.RunBenchmark(&application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: 2}); err != nil {
+		t.Fatal(err)
+	}
+	for i, trama := range enviadas {
+		if trama[0] != frame.MsgTypeData || frame.HeaderLen(trama) != frame.HeaderSize {
+			t.Errorf("iteración %d sin --seq: header %x", i, trama[:frame.HeaderLen(trama)])
+		}
+	}
+}
+
+func TestSecuenciaIteracion_DaLaVuelta(t *testing.T) {
+	for _, c := range []struct {
+		iteracion int
+		want      uint16
+	}{{0, 0}, {65535, 65535}, {65536, 0}, {65537, 1}, {3 * 65536, 0}} {
+		if got := secuenciaIteracion(c.iteracion); got != c.want {
+			t.Errorf("secuenciaIteracion(%d) = %d, se esperaba %d", c.iteracion, got, c.want)
+		}
+	}
+}
+
+func TestProcessMessage_Checksum(t *testing.T) {
+	for _, kind := range frame.ChecksumKinds {
+		var enviada []byte
+		le := newTestEmitter(func(url string, f []byte) error {
+			enviada = f
+			return nil
+		})
+		le.checksum = kind
+
+		result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0, Mode: "manual"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := frame.ParseFrame(enviada)
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if f.Checksum != kind || f.MsgType != frame.MsgTypeHamming || len(result.FrameBytes) != frame.HeaderSize+frame.HammingPadSize+7+kind.Size() {
+			t.Errorf("%s: trama de %d bytes decodificada como %+v", kind, len(result.FrameBytes), f)
+		}
+	}
+}
+
+func TestConstruirTrama_Contenido(t *testing.T) {
+	if o := parsearOpciones(t); *o.contentType {
+		t.Fatal("--content-type debe estar desactivado por defecto: el receptor Python no lee el byte de contenido")
+	}
+	textBits := frame.BytesToBits([]byte("Hola"))
+	for _, algoritmo := range []string{"crc", "fletcher", "hamming", "hamming-secded", "parity2d", "repetition", "rs", "golay", "auto-hamming"} {
+		le := newTestEmitter(func(string, []byte) error { return nil })
+		sin, _, err := le.construirTrama(algoritmo, textBits, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", algoritmo, err)
+		}
+		le.contenido = contenidoPresentacion()
+		con, _, err := le.construirTrama(algoritmo, textBits, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", algoritmo, err)
+		}
+		f, err := frame.ParseFrame(con)
+		if err != nil {
+			t.Fatalf("%s: %v", algoritmo, err)
+		}
+		if f.Content != frame.ContentASCII || f.MsgType != frame.MsgTypeOf(sin) || len(con) != len(sin)+frame.ContentHeaderSize {
+			t.Errorf("%s: trama con contenido decodificada como %v", algoritmo, f)
+		}
+		if algoritmoDeTrama(con) != algoritmoDeTrama(sin) {
+			t.Errorf("%s: algoritmoDeTrama = %q, sin contenido %q", algoritmo, algoritmoDeTrama(con), algoritmoDeTrama(sin))
+		}
+	}
+}
+
+func TestConstruirTrama_Golay(t *testing.T) {
+	le := newTestEmitter(func(string, []byte) error { return nil })
+	trama, descripcion, err := le.construirTrama("golay", frame.BytesToBits([]byte("Hola")), 0.05, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if descripcion != "Golay(23,12) + CRC-32" {
+		t.Errorf("descripción %q", descripcion)
+	}
+
+	// Tres bits invertidos en cada bloque no impiden recuperar el texto
+	bits := frame.BytesToBits(trama)
+	inicio := frame.HammingBlocksOffset(trama) * 8
+	for b := 0; b < 3; b++ {
+		for _, p := range []int{1, 9, 20} {
+			bits[inicio+b*23+p] ^= 1
+		}
+	}
+	f, err := frame.ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Payload = frame.BitsToBytes(bits)[frame.HeaderSize : len(trama)-frame.CRCSize]
+	data, err := f.Data()
+	if f.MsgType != frame.MsgTypeGolay || err != nil || string(data) != "Hola" {
+		t.Errorf("tipo %#02x, Data = %q, %v", f.MsgType, data, err)
+	}
+}
+
+func TestRunBenchmark_Fletcher(t *testing.T) {
+	var enviadas [][]byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviadas = append(enviadas, f)
+		return nil
+	})
+	le.noise = noise.NewNoiseLayerWithSeed(7)
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "fletcher", BER: 0.01, Mode: "benchmark", Count: 50}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if benchmark.Successful != 50 {
+		t.Fatalf("%d iteraciones exitosas, se esperaban 50", benchmark.Successful)
+	}
+	for i, r := range benchmark.Results {
+		// 3 de header + 4 de payload + 2 de Fletcher-16
+		if frame.ChecksumKindOf(r.FrameBytes) != frame.ChecksumFletcher16 || len(enviadas[i]) != 9 {
+			t.Fatalf("iteración %d: trama %x", i, r.FrameBytes)
+		}
+		if err := frame.VerifyFletcher16Frame(r.FrameBytes); err != nil {
+			t.Fatalf("iteración %d: %v", i, err)
+		}
+	}
+
+	le.checksum = frame.ChecksumCRC8
+	if _, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "fletcher", Mode: "manual"}); err == nil {
+		t.Error("se esperaba error al combinar fletcher con --checksum")
+	}
+}
+
+func TestProcessMessage_Parity2D(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+	le.filasParidad = 4
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "parity2d", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Un bit invertido en los datos: el CRC lo detecta y la paridad 2D lo corrige
+	ruidosa := append([]byte(nil), enviada...)
+	ruidosa[frame.HeaderSize+frame.Parity2DHeaderSize+1] ^= 0x10
+	if err := frame.VerifyFrame(ruidosa); !errors.Is(err, frame.ErrCRCMismatch) {
+		t.Fatalf("el CRC debería detectar el error: %v", err)
+	}
+	payload := ruidosa[frame.HeaderSize : len(ruidosa)-frame.CRCSize]
+	data, corrected, err := frame.Parity2DDecode(payload)
+	if err != nil || !corrected || string(data) != "Hola" {
+		t.Errorf("decodificado %q, corregido %v, %v", data, corrected, err)
+	}
+	if !result.Success || result.FrameBytes[0] != frame.MsgTypeParity2D {
+		t.Errorf("resultado inesperado: tipo %#02x, %s", result.FrameBytes[0], result.Error)
+	}
+}
+
+func TestRunBenchmark_ReutilizaElBufferDeEnvio(t *testing.T) {
+	le := NewLayeredEmitter("ws://test")
+	le.watchdogIteraciones = 0
+	var arreglos []*byte
+	var enviadas [][]byte
+	le.sendFrame = func(_ context.Context, url string, f []byte) (*wsclient.ConnStats, error) {
+		arreglos = append(arreglos, &f[0])
+		enviadas = append(enviadas, append([]byte(nil), f...))
+		return &wsclient.ConnStats{}, nil
+	}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: 4}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range benchmark.Results {
+		if arreglos[i] != arreglos[0] {
+			t.Errorf("iteración %d: la trama se envió desde un arreglo nuevo", i)
+		}
+		// Sin ruido, lo enviado es la trama construida
+		if !bytes.Equal(enviadas[i], r.FrameBytes) {
+			t.Errorf("iteración %d: enviada % x, construida % x", i, enviadas[i], r.FrameBytes)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+// Límites por defecto del mapa de errores, para que la imagen sea manejable
+const (
+	DefaultMapaMaxIteraciones = 1000
+	DefaultMapaMaxBits        = 4096
+)
+
+// datosMapaErrores extrae las posiciones de error de hasta maxIteraciones
+// resultados y las guías de región (inicio del payload y del CRC) a partir de
+// la primera trama. El ancho es el de la trama más larga, recortado a maxBits.
+func datosMapaErrores(benchmark *BenchmarkResult, maxIteraciones, maxBits int) (posiciones [][]int, ancho int, guias []int) {
+	for _, r := range benchmark.Results {
+		if len(posiciones) == maxIteraciones {
+			break
+		}
+		posiciones = append(posiciones, r.ErrorPositions)
+		if bits := len(r.FrameBytes) * 8; bits > ancho {
+			ancho = bits
+		}
+		if guias == nil && len(r.FrameBytes) > 0 {
+			fin := len(r.FrameBytes) - frame.ChecksumKindOf(r.FrameBytes).Size()
+			guias = []int{frame.HeaderLen(r.FrameBytes) * 8, fin * 8}
+		}
+	}
+	if ancho > maxBits {
+		ancho = maxBits
+	}
+	return posiciones, ancho, guias
+}
+
+// contarTruncadas cuenta cuántas de las primeras maxIteraciones filas del mapa
+// tienen solo una muestra de sus posiciones de error
+func contarTruncadas(benchmark *BenchmarkResult, maxIteraciones int) int {
+	n := 0
+	for i, r := range benchmark.Results {
+		if i == maxIteraciones {
+			break
+		}
+		if r.PosicionesTruncadas {
+			n++
+		}
+	}
+	return n
+}
+
+// exportarMapaErrores escribe el mapa de bits invertidos del benchmark como PNG en w
+func exportarMapaErrores(w io.Writer, benchmark *BenchmarkResult, maxIteraciones, maxBits int) error {
+	posiciones, ancho, guias := datosMapaErrores(benchmark, maxIteraciones, maxBits)
+	if len(posiciones) == 0 || ancho == 0 {
+		return fmt.Errorf("no hay iteraciones con tramas para dibujar")
+	}
+	return noise.EscribirMapaErroresPNG(w, posiciones, ancho, guias)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestExportarMapaErrores(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.noise = noise.NewNoiseLayerWithSeed(5)
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.05, Mode: "benchmark", Count: 30}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := exportarMapaErrores(&buf, benchmark, 20, 1000); err != nil {
+		t.Fatalf("exportarMapaErrores falló: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frameBits := len(benchmark.Results[0].FrameBytes) * 8
+	if b := img.Bounds(); b.Dx() != frameBits || b.Dy() != 20 {
+		t.Fatalf("tamaño %v, se esperaba %dx20 (recortado a 20 iteraciones)", b, frameBits)
+	}
+	for y, r := range benchmark.Results[:20] {
+		for _, x := range r.ErrorPositions {
+			if r, g, b, _ := img.At(x, y).RGBA(); r != 0 || g != 0 || b != 0 {
+				t.Fatalf("el bit invertido (%d,%d) no está en negro", x, y)
+			}
+		}
+	}
+
+	// La guía del inicio del payload se dibuja en las columnas sin error
+	guia := frame.HeaderSize * 8
+	for y, r := range benchmark.Results[:20] {
+		if !contienePosicion(r.ErrorPositions, guia) {
+			if got := color.GrayModel.Convert(img.At(guia, y)).(color.Gray); got.Y != 200 {
+				t.Errorf("la guía en la fila %d tiene gris %d, se esperaba 200", y, got.Y)
+			}
+			break
+		}
+	}
+}
+
+func TestDatosMapaErrores_RecortaAncho(t *testing.T) {
+	// El tipo 0 anunciaría el byte de contenido (ver frame.MsgTypeContent)
+	trama := make([]byte, 20)
+	trama[0] = frame.MsgTypeData
+	benchmark := &BenchmarkResult{Results: []*TransmissionResult{
+		{FrameBytes: trama, ErrorPositions: []int{1, 150}},
+	}}
+	posiciones, ancho, guias := datosMapaErrores(benchmark, 10, 64)
+	if ancho != 64 || len(posiciones) != 1 {
+		t.Errorf("ancho %d y %d filas, se esperaba 64 y 1", ancho, len(posiciones))
+	}
+	if len(guias) != 2 || guias[0] != frame.HeaderSize*8 || guias[1] != 16*8 {
+		t.Errorf("guías inesperadas: %v", guias)
+	}
+}
+
+func contienePosicion(posiciones []int, x int) bool {
+	for _, p := range posiciones {
+		if p == x {
+			return true
+		}
+	}
+	return false
+}
+
+func TestContarTruncadas(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.noise = noise.NewNoiseLayerWithSeed(2)
+	le.noise.FijarMaxPosiciones(10)
+	le.watchdogIteraciones = 0
+
+	// "Hola" en CRC son 88 bits: con BER 0.5 casi todas superan 10 errores
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.5, Mode: "benchmark", Count: 20}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := 0
+	for _, r := range benchmark.Results[:5] {
+		if r.PosicionesTruncadas {
+			if len(r.ErrorPositions) != 10 || r.ResumenPosiciones == nil {
+				t.Errorf("iteración truncada sin muestra o resumen: %d posiciones", len(r.ErrorPositions))
+			}
+			want++
+		}
+	}
+	if want == 0 {
+		t.Fatal("se esperaba al menos una iteración truncada")
+	}
+	if got := contarTruncadas(benchmark, 5); got != want {
+		t.Errorf("contarTruncadas = %d, want %d", got, want)
+	}
+
+	// El mapa se dibuja igual con la muestra
+	var buf bytes.Buffer
+	if err := exportarMapaErrores(&buf, benchmark, 5, 1000); err != nil {
+		t.Errorf("el mapa debería aceptar posiciones truncadas: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+// estadisticasTrama desglosa el costo de la trama construida para textBits
+// bits de texto; nil si su tipo no tiene estadísticas de código
+func estadisticasTrama(trama []byte, textBits int) *frame.FrameStats {
+	s, err := frame.ComputeFrameStats(trama, textBits)
+	if err != nil {
+		return nil
+	}
+	return &s
+}
+
+// sumarEstadisticas acumula las estadísticas de trama de los resultados; nil
+// si ninguno las tiene
+func sumarEstadisticas(results []*TransmissionResult) *frame.FrameStats {
+	var total *frame.FrameStats
+	for _, r := range results {
+		if r.Estadisticas == nil {
+			continue
+		}
+		if total == nil {
+			total = &frame.FrameStats{}
+		}
+		total.Add(*r.Estadisticas)
+	}
+	return total
+}
+
+func formatearEstadisticas(s frame.FrameStats) string {
+	return msgs.T("resumen.overhead", s.HeaderBytes, s.SubheaderBytes, s.ChecksumBytes, s.PadBits, s.CodeRate(), s.Expansion(), s.Efficiency())
+}
+
+// exportarIteracionesCSV escribe en csv-iteraciones una fila por iteración
+// con el BER, el resultado y el costo de su trama; las columnas de costo
+// quedan vacías en las iteraciones sin estadísticas
+func exportarIteracionesCSV(w io.Writer, b *BenchmarkResult) error {
+	formatear := func(v float64) string { return strconv.FormatFloat(v, 'g', 10, 64) }
+	rows := make([][]string, 0, len(b.Results))
+	for i, r := range b.Results {
+		ber := 0.0
+		if r.Config != nil {
+			ber = r.Config.BER
+		}
+		fila := []string{strconv.Itoa(i + 1), formatear(ber), formatear(r.ActualBER), strconv.FormatBool(r.Success),
+			"", "", "", "", "", "", "", ""}
+		if s := r.Estadisticas; s != nil {
+			copy(fila[4:], []string{
+				strconv.Itoa(s.DataBits), strconv.Itoa(s.HeaderBytes), strconv.Itoa(s.SubheaderBytes),
+				strconv.Itoa(s.EncodedBytes), strconv.Itoa(s.ChecksumBytes), strconv.Itoa(s.PadBits),
+				formatear(s.CodeRate()), formatear(s.Efficiency()),
+			})
+		}
+		rows = append(rows, fila)
+	}
+	e, _ := export.EsquemaCSVActual(export.TipoCSVIteraciones)
+	return e.Escribir(w, rows)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestProcessMessage_Estadisticas(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+
+	// "Hola" = 32 bits: 8 bloques Hamming(7,4) en 7 bytes más el subheader
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "hamming", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := frame.FrameStats{DataBits: 32, HeaderBytes: frame.HeaderSize, SubheaderBytes: 1, EncodedBytes: 8, ChecksumBytes: 4, Frames: 1}
+	if result.Estadisticas == nil || *result.Estadisticas != want {
+		t.Fatalf("Estadisticas = %+v, se esperaba %+v", result.Estadisticas, want)
+	}
+	if result.Estadisticas.FrameBytes() != len(result.FrameBytes) {
+		t.Errorf("las estadísticas describen %d bytes, la trama tiene %d", result.Estadisticas.FrameBytes(), len(result.FrameBytes))
+	}
+	if got := formatearEstadisticas(*result.Estadisticas); !strings.Contains(got, "tasa del código 0.500 (expansión x2.00)") {
+		t.Errorf("formato inesperado: %s", got)
+	}
+
+	// Sin estadísticas con fragmentos y con tramas pre-construidas
+	le.maxFragmento = 2
+	result, err = le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Estadisticas != nil {
+		t.Errorf("una transmisión fragmentada no debería tener estadísticas: %+v", result.Estadisticas)
+	}
+	le.maxFragmento = 0
+	result, err = enviarTramaExterna(le, parsearOpciones(t, "--frame-hex", tramaHola(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Estadisticas != nil {
+		t.Error("una trama externa no tiene bits de datos conocidos")
+	}
+}
+
+func TestRunBenchmark_EstadisticasYCSV(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.watchdogIteraciones = 0
+	benchmark, err := le.RunBenchmark(&application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "benchmark", Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sin --seq cada iteración lleva 3 bytes de header, 4 de datos y 4 de CRC
+	s := benchmark.Estadisticas
+	if s == nil || s.Frames != 5 || s.DataBits != 5*32 || s.HeaderBytes != 5*frame.HeaderSize || s.FrameBytes() != 5*11 {
+		t.Fatalf("Estadisticas = %+v", s)
+	}
+	if reg := nuevoRegistroHistorial(benchmark, nil); reg.TasaCodigo != 1 || reg.Eficiencia != 32.0/88 {
+		t.Errorf("el historial registra tasa %v y eficiencia %v", reg.TasaCodigo, reg.Eficiencia)
+	}
+
+	var out bytes.Buffer
+	if err := exportarIteracionesCSV(&out, benchmark); err != nil {
+		t.Fatal(err)
+	}
+	tabla, err := export.LeerCSV(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tabla.Tipo != export.TipoCSVIteraciones || len(tabla.Filas) != 5 {
+		t.Fatalf("CSV inesperado: %+v", tabla)
+	}
+	want := "1,0.01," + tabla.Filas[0][2] + ",true,32,3,0,4,4,0,1,0.3636363636"
+	if got := strings.Join(tabla.Filas[0], ","); got != want {
+		t.Errorf("fila %s, se esperaba %s", got, want)
+	}
+
+	// Las iteraciones sin estadísticas dejan vacías las columnas de costo
+	benchmark.Results[0].Estadisticas = nil
+	out.Reset()
+	if err := exportarIteracionesCSV(&out, benchmark); err != nil {
+		t.Fatal(err)
+	}
+	if tabla, _ = export.LeerCSV(&out); !strings.HasSuffix(strings.Join(tabla.Filas[0], ","), ",true,,,,,,,,") {
+		t.Errorf("fila sin estadísticas: %v", tabla.Filas[0])
+	}
+}
+
+func TestEstadisticasTrama_TipoSinCodigo(t *testing.T) {
+	ack, _ := frame.BuildAckFrame(1)
+	if estadisticasTrama(ack, 0) != nil {
+		t.Errorf("una respuesta %s no debería tener estadísticas", hex.EncodeToString(ack))
+	}
+	if sumarEstadisticas([]*TransmissionResult{{}, {}}) != nil {
+		t.Error("sin estadísticas la suma debería ser nil")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+)
+
+// errRechazada encabeza el error de una trama que el receptor rechazó
+var errRechazada = errors.New("el receptor rechazó la trama")
+
+// VeredictoReceptor es lo que contestó el receptor a una trama con
+// --await-reply: su respuesta o, si no hubo una válida, por qué
+type VeredictoReceptor struct {
+	Respuesta *frame.Reply
+	Error     string
+}
+
+func (v *VeredictoReceptor) String() string {
+	if v.Respuesta == nil {
+		return "sin respuesta (" + v.Error + ")"
+	}
+	return v.Respuesta.String()
+}
+
+// enviarTrama envía la trama ruidosa de result. Con --await-reply espera la
+// respuesta del receptor, la guarda en result.ReceiverVerdict y devuelve error
+// si no confirmó la trama: un NACK, un ACK a otra secuencia o ninguna
+// respuesta válida dentro del plazo.
+func (le *LayeredEmitter) enviarTrama(ctx context.Context, result *TransmissionResult, trama []byte) (*wsclient.ConnStats, error) {
+	if le.esperaRespuesta <= 0 {
+		return le.sendFrame(ctx, le.wsURL, trama)
+	}
+
+	connStats, reply, err := le.sendFrameReply(ctx, le.wsURL, trama, le.esperaRespuesta)
+	if err != nil {
+		// Si la trama no llegó a enviarse no hubo respuesta que esperar
+		if errors.Is(err, wsclient.ErrNoReply) || errors.Is(err, wsclient.ErrInvalidReply) {
+			result.ReceiverVerdict = &VeredictoReceptor{Error: err.Error()}
+		}
+		return connStats, err
+	}
+	result.ReceiverVerdict = &VeredictoReceptor{Respuesta: reply}
+	switch {
+	case !reply.Ack:
+		return connStats, fmt.Errorf("%w: %v", errRechazada, reply)
+	case result.Secuencia != nil && reply.Seq != *result.Secuencia:
+		return connStats, fmt.Errorf("el receptor confirmó la secuencia %d, se envió la %d", reply.Seq, *result.Secuencia)
+	}
+	return connStats, nil
+}
+
+// ResumenVeredictos cuenta las respuestas del receptor en un benchmark
+type ResumenVeredictos struct {
+	Ack          int
+	Nack         int
+	SinRespuesta int
+	// Motivos cuenta los NACK por código (ver frame.ReplyCode)
+	Motivos map[string]int
+}
+
+// contarVeredictos resume las respuestas de los resultados; nil si ninguno
+// esperó respuesta
+func contarVeredictos(results []*TransmissionResult) *ResumenVeredictos {
+	var r *ResumenVeredictos
+	for _, res := range results {
+		v := res.ReceiverVerdict
+		if v == nil {
+			continue
+		}
+		if r == nil {
+			r = &ResumenVeredictos{Motivos: map[string]int{}}
+		}
+		switch {
+		case v.Respuesta == nil:
+			r.SinRespuesta++
+		case v.Respuesta.Ack:
+			r.Ack++
+		default:
+			r.Nack++
+			r.Motivos[v.Respuesta.Code.String()]++
+		}
+	}
+	return r
+}
+
+func formatearVeredictos(r ResumenVeredictos) string {
+	s := msgs.T("resumen.veredictos", r.Ack, r.Nack, r.SinRespuesta)
+	if len(r.Motivos) == 0 {
+		return s
+	}
+	motivos := make([]string, 0, len(r.Motivos))
+	for m := range r.Motivos {
+		motivos = append(motivos, m)
+	}
+	sort.Strings(motivos)
+	for i, m := range motivos {
+		motivos[i] = fmt.Sprintf("%s: %d", m, r.Motivos[m])
+	}
+	return s + " (" + strings.Join(motivos, ", ") + ")"
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+)
+
+// receptorQueResponde es un sendFrameReply que contesta cada trama con
+// responder, como lo haría el receptor
+func receptorQueResponde(responder func(f *frame.Frame, err error) (*frame.Reply, error)) func(context.Context, string, []byte, time.Duration) (*wsclient.ConnStats, *frame.Reply, error) {
+	return func(_ context.Context, _ string, trama []byte, _ time.Duration) (*wsclient.ConnStats, *frame.Reply, error) {
+		reply, err := responder(frame.ParseFrame(trama))
+		return &wsclient.ConnStats{}, reply, err
+	}
+}
+
+func TestRunBenchmark_AwaitReplyCuentaVeredictos(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error {
+		t.Error("con --await-reply no debe usarse el envío sin respuesta")
+		return nil
+	})
+	le.watchdogIteraciones = 0
+	le.esperaRespuesta = time.Second
+	le.sendFrameReply = receptorQueResponde(func(f *frame.Frame, err error) (*frame.Reply, error) {
+		switch {
+		case err != nil:
+			return nil, err
+		case f.Seq == 2:
+			return nil, fmt.Errorf("%w en 1s", wsclient.ErrNoReply)
+		case f.Seq%2 == 1:
+			return &frame.Reply{Seq: f.Seq, Code: frame.ReplyCRCMismatch}, nil
+		default:
+			return &frame.Reply{Ack: true, Seq: f.Seq}, nil
+		}
+	})
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: 6}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Secuencias 0 a 5: ACK 0 y 4, NACK 1, 3 y 5, sin respuesta 2
+	v := benchmark.Veredictos
+	if v == nil || v.Ack != 2 || v.Nack != 3 || v.SinRespuesta != 1 || v.Motivos["crc"] != 3 {
+		t.Fatalf("veredictos = %+v", v)
+	}
+	if benchmark.Successful != 2 {
+		t.Errorf("%d exitosas, solo cuentan las confirmadas", benchmark.Successful)
+	}
+	for _, r := range benchmark.Results {
+		if r.ReceiverVerdict == nil {
+			t.Fatalf("secuencia %d sin veredicto", *r.Secuencia)
+		}
+		if !r.Success && *r.Secuencia%2 == 1 && !strings.Contains(r.Error, errRechazada.Error()) {
+			t.Errorf("secuencia %d: error %q", *r.Secuencia, r.Error)
+		}
+	}
+	if got := formatearVeredictos(*v); got != "Receptor: 2 ACK, 3 NACK, 1 sin respuesta (crc: 3)" {
+		t.Errorf("resumen %q", got)
+	}
+}
+
+func TestProcessMessage_AwaitReplyOtraSecuencia(t *testing.T) {
+	le := newTestEmitter(nil)
+	le.watchdogIteraciones = 0
+	le.esperaRespuesta = time.Second
+	le.sendFrameReply = receptorQueResponde(func(f *frame.Frame, err error) (*frame.Reply, error) {
+		return &frame.Reply{Ack: true, Seq: f.Seq + 1}, nil
+	})
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "benchmark", Count: 1}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := benchmark.Results[0]; r.Success || !strings.Contains(r.Error, "secuencia 1") {
+		t.Errorf("un ACK a otra secuencia no confirma la trama: éxito=%v, %q", r.Success, r.Error)
+	}
+}
+
+func TestProcessMessage_SinAwaitReplyNoHayVeredicto(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.sendFrameReply = func(context.Context, string, []byte, time.Duration) (*wsclient.ConnStats, *frame.Reply, error) {
+		t.Error("sin --await-reply no debe esperarse respuesta")
+		return nil, nil, nil
+	}
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.ReceiverVerdict != nil {
+		t.Errorf("éxito=%v, veredicto %v", result.Success, result.ReceiverVerdict)
+	}
+	if contarVeredictos([]*TransmissionResult{result}) != nil {
+		t.Error("sin respuestas esperadas no hay resumen de veredictos")
+	}
+}
+
+func TestDiagnosticarTransporte_Veredictos(t *testing.T) {
+	if d := diagnosticarTransporte(fmt.Errorf("%w: NACK seq 3 (crc)", errRechazada)); !strings.Contains(d, "NACK") {
+		t.Errorf("diagnóstico de un NACK: %q", d)
+	}
+	if d := diagnosticarTransporte(fmt.Errorf("%w en 1s", wsclient.ErrNoReply)); !strings.Contains(d, "--await-reply") {
+		t.Errorf("diagnóstico sin respuesta: %q", d)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+
+// contarCorreccionRS decodifica el payload ruidoso de una trama rs como lo
+// hará el receptor y cuenta los bytes corregidos y los bloques irrecuperables.
+// Si el ruido alteró el byte con la paridad, el receptor no puede decodificar
+// ningún bloque y todos cuentan como irrecuperables. borrados son posiciones
+// de bit en la trama que el canal marcó como borradas: los bytes que tocan se
+// decodifican como borrados, que cuestan la mitad de paridad que un error.
+// Devuelve nil si los bits no cubren la trama.
+func contarCorreccionRS(trama, bitsRuidosos []byte, borrados []int) *frame.RSCounts {
+	inicio := frame.HeaderLen(trama)
+	fin := len(trama) - frame.ChecksumKindOf(trama).Size()
+	if inicio >= fin || fin*8 > len(bitsRuidosos) {
+		return nil
+	}
+	payload := frame.BitsToBytes(bitsRuidosos[inicio*8 : fin*8])
+	nsym := payload[0]
+	payload[0] = trama[inicio]
+	_, c, _ := frame.RSDecodePayloadErasures(payload, bytesBorrados(borrados, inicio, fin))
+	if nsym != trama[inicio] {
+		c = frame.RSCounts{Blocks: c.Blocks, Uncorrectable: c.Blocks}
+	}
+	return &c
+}
+
+// bytesBorrados traduce posiciones de bit de la trama a índices de byte del
+// payload entre inicio y fin. El byte con nsym queda fuera: el receptor lo
+// necesita intacto y contarCorreccionRS ya trata su alteración.
+func bytesBorrados(borrados []int, inicio, fin int) []int {
+	var out []int
+	for _, p := range borrados {
+		b := p/8 - inicio
+		if b <= 0 || p/8 >= fin || (len(out) > 0 && out[len(out)-1] == b) {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// sumarCorreccionRS acumula la corrección Reed-Solomon de los resultados;
+// nil si ninguno usó rs
+func sumarCorreccionRS(results []*TransmissionResult) *frame.RSCounts {
+	var total *frame.RSCounts
+	for _, r := range results {
+		if r.CorreccionRS == nil {
+			continue
+		}
+		if total == nil {
+			total = &frame.RSCounts{}
+		}
+		total.Blocks += r.CorreccionRS.Blocks
+		total.Corrected += r.CorreccionRS.Corrected
+		total.Uncorrectable += r.CorreccionRS.Uncorrectable
+	}
+	return total
+}
+
+func formatearCorreccionRS(c frame.RSCounts) string {
+	return msgs.T("resumen.rs", c.Corrected, c.Blocks, c.Uncorrectable)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestProcessMessage_ReedSolomon(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+	le.paridadRS = 8
+
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "rs", BER: 0, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := frame.ParseFrame(enviada)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != frame.MsgTypeReedSolomon || len(f.Payload) != 1+len("Hola")+8 {
+		t.Fatalf("trama decodificada como %+v", f)
+	}
+	data, _, err := frame.RSDecodePayload(f.Payload)
+	if err != nil || string(data) != "Hola" {
+		t.Errorf("payload %q, %v", data, err)
+	}
+	if result.CorreccionRS == nil || *result.CorreccionRS != (frame.RSCounts{Blocks: 1}) {
+		t.Errorf("corrección sin ruido = %+v", result.CorreccionRS)
+	}
+}
+
+func TestContarCorreccionRS(t *testing.T) {
+	trama, _ := frame.BuildFrameWithRS([]byte("Hola mundo"), 4)
+	inicio := frame.HeaderSize * 8
+	ruidosa := func(bytesErroneos ...int) []byte {
+		bits := frame.BytesToBits(trama)
+		for _, b := range bytesErroneos {
+			// Dos bits del mismo byte cuentan como un único símbolo erróneo
+			bits[inicio+8*b] ^= 1
+			bits[inicio+8*b+5] ^= 1
+		}
+		return bits
+	}
+
+	if c := contarCorreccionRS(trama, ruidosa(1, 7), nil); c == nil || *c != (frame.RSCounts{Blocks: 1, Corrected: 2}) {
+		t.Errorf("con 2 bytes erróneos: %+v", c)
+	}
+	if c := contarCorreccionRS(trama, ruidosa(1, 4, 7), nil); c == nil || c.Uncorrectable != 1 {
+		t.Errorf("con 3 bytes erróneos y 4 de paridad: %+v", c)
+	}
+	paridad := frame.BytesToBits(trama)
+	paridad[inicio+6] ^= 1
+	if c := contarCorreccionRS(trama, paridad, nil); c == nil || *c != (frame.RSCounts{Blocks: 1, Uncorrectable: 1}) {
+		t.Errorf("con el byte de paridad alterado: %+v", c)
+	}
+	if c := contarCorreccionRS(trama, frame.BytesToBits(trama[:5]), nil); c != nil {
+		t.Errorf("con bits incompletos: %+v", c)
+	}
+}
+
+func TestContarCorreccionRS_ConBorrados(t *testing.T) {
+	trama, _ := frame.BuildFrameWithRS([]byte("Hola mundo"), 4)
+	inicio := frame.HeaderSize * 8
+	bits := frame.BytesToBits(trama)
+	var borrados []int
+	for _, b := range []int{1, 4, 7, 12} {
+		// El canal borra con relleno 0 los dos primeros bits de cada byte
+		for j := 0; j < 2; j++ {
+			bits[inicio+8*b+j] = 0
+			borrados = append(borrados, inicio+8*b+j)
+		}
+	}
+
+	// Cuatro bytes borrados con 4 de paridad: sin la máscara son el doble
+	// de lo que RS corrige
+	if c := contarCorreccionRS(trama, bits, nil); c == nil || c.Uncorrectable != 1 {
+		t.Errorf("sin máscara: %+v", c)
+	}
+	c := contarCorreccionRS(trama, bits, borrados)
+	if c == nil || c.Uncorrectable != 0 || c.Corrected > 4 {
+		t.Errorf("con máscara: %+v", c)
+	}
+}
+
+func TestRunBenchmark_ReedSolomonConBorrados(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.noise = noise.NewNoiseLayerWithSeed(8)
+	le.watchdogIteraciones = 0
+	le.paridadRS = 4
+	le.borrado = true
+
+	config := &application.MessageConfig{Text: "Hola mundo", Algorithm: "rs", BER: 0.02, Mode: "benchmark", Count: 200}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Las mismas tramas ruidosas decodificadas sin la máscara: los bytes
+	// borrados pasan a ser errores desconocidos y se pierden más bloques
+	var sinMascara frame.RSCounts
+	for _, r := range benchmark.Results {
+		c := contarCorreccionRS(r.FrameBytes, r.NoisyFrameBits, nil)
+		sinMascara.Uncorrectable += c.Uncorrectable
+	}
+	con := benchmark.CorreccionRS
+	if con == nil || con.Blocks != config.Count || con.Uncorrectable >= sinMascara.Uncorrectable {
+		t.Errorf("irrecuperables: %+v con máscara, %d sin máscara", con, sinMascara.Uncorrectable)
+	}
+}
+
+func TestRunBenchmark_ReedSolomonSumaCorreccion(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.noise = noise.NewNoiseLayerWithSeed(5)
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "rs", BER: 0.01, Mode: "benchmark", Count: 200}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := benchmark.CorreccionRS
+	if c == nil || c.Blocks != 200 || c.Corrected == 0 {
+		t.Fatalf("corrección = %+v", c)
+	}
+	suma := 0
+	for _, r := range benchmark.Results {
+		suma += r.CorreccionRS.Corrected
+	}
+	if suma != c.Corrected {
+		t.Errorf("el total %d no coincide con la suma por trama %d", c.Corrected, suma)
+	}
+}
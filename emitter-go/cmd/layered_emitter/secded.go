@@ -0,0 +1,46 @@
+package main
+
+import "github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+
+// contarBloquesSECDED decodifica el payload ruidoso de una trama
+// hamming-secded como lo hará el receptor, deshaciendo el entrelazado si lo
+// hay, y cuenta los bloques corregidos y los detectados sin corregir.
+// Devuelve nil si los bits no cubren la trama.
+func contarBloquesSECDED(trama, bitsRuidosos []byte) *frame.SECDEDCounts {
+	inicio := frame.HammingBlocksOffset(trama) * 8
+	fin := (len(trama) - frame.ChecksumKindOf(trama).Size()) * 8
+	if inicio > fin || fin > len(bitsRuidosos) {
+		return nil
+	}
+	bloques := bitsRuidosos[inicio:fin]
+	if depth := frame.HammingInterleaveDepth(trama[frame.HeaderLen(trama):]); depth > 0 {
+		bloques = frame.Deinterleave(bloques, depth)
+	}
+	_, estados, err := frame.Hamming84Decode(bloques)
+	if err != nil {
+		return nil
+	}
+	c := frame.CountBlockStatus(estados)
+	return &c
+}
+
+// sumarBloquesSECDED acumula los bloques SEC-DED de los resultados; nil si
+// ninguno usó hamming-secded
+func sumarBloquesSECDED(results []*TransmissionResult) *frame.SECDEDCounts {
+	var total *frame.SECDEDCounts
+	for _, r := range results {
+		if r.BloquesSECDED == nil {
+			continue
+		}
+		if total == nil {
+			total = &frame.SECDEDCounts{}
+		}
+		total.Corrected += r.BloquesSECDED.Corrected
+		total.Uncorrectable += r.BloquesSECDED.Uncorrectable
+	}
+	return total
+}
+
+func formatearBloquesSECDED(c frame.SECDEDCounts) string {
+	return msgs.T("resumen.secded", c.Corrected, c.Uncorrectable)
+}
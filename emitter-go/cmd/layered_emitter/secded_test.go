@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestProcessMessage_HammingSECDEDCuentaBloques(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 3, Bits: 2}, {Bloque: 5, Bits: 1}}
+
+	// "Hola" = 8 bloques de 8 bits, uno por byte del payload
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming-secded", Mode: "manual"}
+	result, err := le.ProcessMessage(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.FrameBytes[0] != frame.MsgTypeHamming84 || len(result.FrameBytes) != frame.HeaderSize+frame.HammingPadSize+8+4 {
+		t.Fatalf("trama inesperada: % x", result.FrameBytes)
+	}
+
+	offset := (frame.HeaderSize + frame.HammingPadSize) * 8
+	for _, pos := range result.ErrorPositions {
+		if b := (pos-offset)/8 + 1; b != 3 && b != 5 {
+			t.Errorf("error en el bloque %d, se esperaban los bloques 3 y 5", b)
+		}
+	}
+	want := frame.SECDEDCounts{Corrected: 1, Uncorrectable: 1}
+	if result.BloquesSECDED == nil || *result.BloquesSECDED != want {
+		t.Errorf("BloquesSECDED = %+v, se esperaba %+v", result.BloquesSECDED, want)
+	}
+}
+
+func TestRunBenchmark_SumaBloquesSECDED(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming-secded", Mode: "benchmark", Count: 4}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := frame.SECDEDCounts{Corrected: 4}
+	if benchmark.BloquesSECDED == nil || *benchmark.BloquesSECDED != want {
+		t.Errorf("BloquesSECDED = %+v, se esperaba %+v", benchmark.BloquesSECDED, want)
+	}
+
+	config.Algorithm = "hamming"
+	if benchmark, _ := le.RunBenchmark(config); benchmark.BloquesSECDED != nil {
+		t.Errorf("hamming no debería contar bloques SEC-DED: %+v", benchmark.BloquesSECDED)
+	}
+}
+
+func TestContarBloquesSECDED_BitsIncompletos(t *testing.T) {
+	trama, err := frame.BuildFrameWithHamming([]byte("Hola"), frame.HammingSECDED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := frame.BytesToBits(trama)
+	if c := contarBloquesSECDED(trama, bits); c == nil || *c != (frame.SECDEDCounts{}) {
+		t.Errorf("trama intacta: %+v", c)
+	}
+	if c := contarBloquesSECDED(trama, bits[:40]); c != nil {
+		t.Errorf("se esperaba nil con bits incompletos, se obtuvo %+v", c)
+	}
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/stats"
+)
+
+// Archivos que escribe synth en --out; las curvas teóricas van en
+// teoria-<algoritmo>.csv
+const (
+	archivoSinteticoNDJSON       = "iteraciones.ndjson"
+	archivoSinteticoBER          = "ber.csv"
+	archivoSinteticoDistribucion = "distribucion.csv"
+	archivoSinteticoManifiesto   = "manifest.json"
+)
+
+// algoritmosSinteticos son los algoritmos con curva de aceptación en pkg/stats
+var algoritmosSinteticos = []string{"crc", "hamming"}
+
+// ModeloSintetico son los parámetros con los que synth genera un dataset. Se
+// guardan en los metadatos del manifiesto junto con la verdad de cada punto,
+// para contrastarla con lo que recupere un análisis de los archivos.
+type ModeloSintetico struct {
+	Semilla       int64     `json:"seed"`
+	Mensaje       string    `json:"message"`
+	Algoritmos    []string  `json:"algorithms"`
+	BERs          []float64 `json:"bers"`
+	Iteraciones   int       `json:"iterations_per_point"`
+	LatenciaMs    float64   `json:"latency_median_ms"` // Mediana de la latencia log-normal
+	LatenciaSigma float64   `json:"latency_sigma"`     // Desvío del logaritmo de la latencia
+	TasaFallos    float64   `json:"transport_failure_rate"`
+	// LatenciaMediaMs es la media de la latencia log-normal:
+	// mediana * e^(sigma²/2)
+	LatenciaMediaMs float64          `json:"latency_mean_ms"`
+	Puntos          []PuntoSintetico `json:"points"`
+}
+
+// PuntoSintetico es la verdad de un algoritmo y BER del modelo
+type PuntoSintetico struct {
+	Algoritmo string  `json:"algorithm"`
+	BER       float64 `json:"ber"`
+	BitsTrama int     `json:"frame_bits"`
+	Bloques   int     `json:"hamming_blocks,omitempty"`
+	// Aceptacion es la probabilidad de que el receptor acepte la trama:
+	// trama intacta en crc, todos los bloques corregibles en hamming
+	Aceptacion    float64 `json:"acceptance"`
+	ErroresMedios float64 `json:"mean_errors"` // BitsTrama * BER
+}
+
+// validar revisa el modelo antes de generar y completa la latencia media
+func (m *ModeloSintetico) validar() error {
+	if m.Iteraciones <= 0 {
+		return fmt.Errorf("--iterations debe ser positivo (actual: %d)", m.Iteraciones)
+	}
+	if len(m.Algoritmos) == 0 || len(m.BERs) == 0 {
+		return fmt.Errorf("se requiere al menos un algoritmo y un BER")
+	}
+	for _, a := range m.Algoritmos {
+		if !contiene(algoritmosSinteticos, a) {
+			return fmt.Errorf("algoritmo sin modelo de aceptación: %s (opciones: %s)", a, strings.Join(algoritmosSinteticos, ", "))
+		}
+	}
+	for _, ber := range m.BERs {
+		if ber < 0 || ber > 1 {
+			return fmt.Errorf("BER fuera de rango: %g (debe estar entre 0 y 1)", ber)
+		}
+	}
+	if m.LatenciaMs <= 0 || m.LatenciaSigma < 0 {
+		return fmt.Errorf("latencia inválida: mediana %gms, sigma %g", m.LatenciaMs, m.LatenciaSigma)
+	}
+	if m.TasaFallos < 0 || m.TasaFallos > 1 {
+		return fmt.Errorf("--failure-rate fuera de rango: %g (debe estar entre 0 y 1)", m.TasaFallos)
+	}
+	m.LatenciaMediaMs = m.LatenciaMs * math.Exp(m.LatenciaSigma*m.LatenciaSigma/2)
+	return nil
+}
+
+func contiene(lista []string, s string) bool {
+	for _, v := range lista {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// generarSintetico escribe en dir un dataset con los mismos formatos y
+// versiones de esquema que una corrida real de bench con --hook-ndjson, más
+// los CSV de noise_sim y la curva teórica de cada algoritmo. Las tramas y el
+// ruido son los del emisor con la semilla del modelo; solo la transmisión es
+// simulada (latencia log-normal y fallos con probabilidad TasaFallos). La
+// misma semilla produce los mismos archivos salvo la fecha del manifiesto.
+func generarSintetico(dir string, m *ModeloSintetico) error {
+	if err := m.validar(); err != nil {
+		return err
+	}
+
+	le := NewLayeredEmitter("")
+	le.noise = noise.NewNoiseLayerWithSeed(m.Semilla)
+	le.noise.FijarMaxPosiciones(0)
+	// El ruido usa la semilla y la siguiente; la transmisión, una aparte
+	rng := rand.New(rand.NewSource(m.Semilla + 2))
+
+	textBits, err := le.presentation.CodificarMensaje(m.Mensaje)
+	if err != nil {
+		return fmt.Errorf("error en presentación: %v", err)
+	}
+
+	c := export.NuevoConjunto()
+	defer c.CerrarSiPanico()
+	manifiesto := filepath.Join(dir, archivoSinteticoManifiesto)
+	if err := c.Reservar(manifiesto); err != nil {
+		return err
+	}
+	ndjson, err := c.Abrir(filepath.Join(dir, archivoSinteticoNDJSON), "ndjson-iteraciones", VersionNDJSONIteraciones)
+	if err != nil {
+		c.Cerrar()
+		return err
+	}
+	hook := NuevoHookNDJSON(ndjson)
+
+	distribucion := make(map[int]int)
+	var filasBER [][]string
+	curvas := make(map[string]*stats.Prediccion)
+	m.Puntos = nil
+	iteracion := 0
+	for _, algoritmo := range m.Algoritmos {
+		bloques := 0
+		if algoritmo == "hamming" {
+			bloques = (len(textBits) + 3) / 4
+		}
+		for _, ber := range m.BERs {
+			config := &application.MessageConfig{Text: m.Mensaje, Algorithm: algoritmo, BER: ber, Mode: "benchmark", Count: m.Iteraciones}
+			var bitsTrama int
+			for i := 0; i < m.Iteraciones; i++ {
+				seq := secuenciaIteracion(iteracion)
+				trama, _, err := le.construirTrama(algoritmo, textBits, ber, &seq)
+				if err != nil {
+					c.Cerrar()
+					return err
+				}
+				ruido, err := le.noise.AplicarRuido(frame.BytesToBits(trama), ber)
+				if err != nil {
+					c.Cerrar()
+					return err
+				}
+				bitsTrama = len(trama) * 8
+
+				r := &TransmissionResult{
+					Config:            config,
+					OriginalMessage:   m.Mensaje,
+					TextBits:          textBits,
+					FrameBytes:        trama,
+					OriginalFrameBits: ruido.OriginalBits,
+					NoisyFrameBits:    ruido.NoisyBits,
+					ErrorPositions:    ruido.ErrorPositions,
+					ErrorsInjected:    ruido.ErrorsInjected,
+					ActualBER:         ruido.ActualBER,
+					Success:           true,
+					TransmissionTime:  latenciaSintetica(rng, m.LatenciaMs, m.LatenciaSigma),
+					Secuencia:         &seq,
+					Estadisticas:      estadisticasTrama(trama, len(textBits)),
+				}
+				if rng.Float64() < m.TasaFallos {
+					r.Success = false
+					r.Error = "transmisión sintética: conexión rechazada"
+					r.TransmissionTime = 0
+				}
+				hook(r)
+
+				distribucion[ruido.ErrorsInjected]++
+				filasBER = append(filasBER, []string{strconv.Itoa(iteracion), strconv.FormatFloat(ruido.ActualBER, 'f', 6, 64),
+					strconv.Itoa(ruido.ErrorsInjected)})
+				iteracion++
+			}
+
+			p, err := stats.Predecir(algoritmo, bitsTrama, bloques, ber)
+			if err != nil {
+				c.Cerrar()
+				return err
+			}
+			p.FijarBitsCRC(frame.ChecksumCRC32.Size() * 8)
+			curvas[algoritmo] = p
+			punto := PuntoSintetico{Algoritmo: algoritmo, BER: ber, BitsTrama: bitsTrama, Bloques: bloques,
+				Aceptacion: p.TramaIntacta, ErroresMedios: float64(bitsTrama) * ber}
+			if bloques > 0 {
+				punto.Aceptacion = p.PayloadRecuperable
+			}
+			m.Puntos = append(m.Puntos, punto)
+		}
+	}
+
+	escribir := func(nombre, tipo string, filas func(io.Writer) error) error {
+		e, _ := export.EsquemaCSVActual(tipo)
+		a, err := c.Abrir(filepath.Join(dir, nombre), e.Tipo, e.Version)
+		if err != nil {
+			return err
+		}
+		return filas(a)
+	}
+	err = escribir(archivoSinteticoBER, export.TipoCSVBER, func(w io.Writer) error {
+		e, _ := export.EsquemaCSVActual(export.TipoCSVBER)
+		return e.Escribir(w, filasBER)
+	})
+	if err == nil {
+		err = escribir(archivoSinteticoDistribucion, export.TipoCSVDistribucion, func(w io.Writer) error {
+			return escribirDistribucion(w, distribucion)
+		})
+	}
+	for _, algoritmo := range m.Algoritmos {
+		if err != nil {
+			break
+		}
+		p := curvas[algoritmo]
+		err = escribir("teoria-"+algoritmo+".csv", export.TipoCSVTeoria, func(w io.Writer) error {
+			return escribirCurvaTeoria(w, p, m.BERs)
+		})
+	}
+	if err != nil {
+		c.Cerrar()
+		return err
+	}
+
+	if err := c.FijarMetadatos(m); err != nil {
+		c.Cerrar()
+		return err
+	}
+	return c.EscribirManifiesto(manifiesto)
+}
+
+// latenciaSintetica sortea una latencia log-normal de mediana medianaMs
+func latenciaSintetica(rng *rand.Rand, medianaMs, sigma float64) time.Duration {
+	ms := medianaMs * math.Exp(sigma*rng.NormFloat64())
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// escribirDistribucion escribe errores,frecuencia ordenado por cantidad de
+// errores, como --csv-dist de noise_sim
+func escribirDistribucion(w io.Writer, distribucion map[int]int) error {
+	maximo := -1
+	for e := range distribucion {
+		if e > maximo {
+			maximo = e
+		}
+	}
+	var filas [][]string
+	for e := 0; e <= maximo; e++ {
+		if f, ok := distribucion[e]; ok {
+			filas = append(filas, []string{strconv.Itoa(e), strconv.Itoa(f)})
+		}
+	}
+	e, _ := export.EsquemaCSVActual(export.TipoCSVDistribucion)
+	return e.Escribir(w, filas)
+}
+
+// parsearListaFloat interpreta valores separados por coma
+func parsearListaFloat(s string) ([]float64, error) {
+	var valores []float64
+	for _, campo := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(campo), 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor inválido %q: %v", campo, err)
+		}
+		valores = append(valores, v)
+	}
+	return valores, nil
+}
+
+// runSynth implementa "layered_emitter synth --out dir": genera un dataset
+// sintético con verdad conocida para probar análisis sin transmitir
+func runSynth(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("synth", flag.ContinueOnError)
+	fs.SetOutput(out)
+	dir := fs.String("out", "", "Directorio donde escribir el dataset (se crea si no existe)")
+	semilla := fs.Int64("seed", 1, "Semilla del ruido y de la transmisión simulada")
+	mensaje := fs.String("message", "Hello World", "Mensaje de cada iteración")
+	algoritmos := fs.String("algorithms", "crc,hamming", "Algoritmos, separados por coma (crc, hamming)")
+	bers := fs.String("bers", "0.001,0.01,0.05", "BER de cada punto, separados por coma")
+	iteraciones := fs.Int("iterations", 1000, "Iteraciones por algoritmo y BER")
+	latencia := fs.Float64("latency-ms", 5, "Mediana de la latencia de transmisión en ms (log-normal)")
+	sigma := fs.Float64("latency-sigma", 0.5, "Desvío del logaritmo de la latencia")
+	fallos := fs.Float64("failure-rate", 0, "Probabilidad de que una transmisión falle")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("uso: synth --out <directorio> [flags]")
+	}
+
+	m := &ModeloSintetico{
+		Semilla:       *semilla,
+		Mensaje:       *mensaje,
+		Iteraciones:   *iteraciones,
+		LatenciaMs:    *latencia,
+		LatenciaSigma: *sigma,
+		TasaFallos:    *fallos,
+	}
+	for _, a := range strings.Split(*algoritmos, ",") {
+		m.Algoritmos = append(m.Algoritmos, strings.TrimSpace(a))
+	}
+	var err error
+	if m.BERs, err = parsearListaFloat(*bers); err != nil {
+		return fmt.Errorf("--bers: %v", err)
+	}
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+	if err := generarSintetico(*dir, m); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "🧪 Dataset sintético en %s: %d iteraciones (%d algoritmos × %d BER), semilla %d\n",
+		*dir, len(m.Algoritmos)*len(m.BERs)*m.Iteraciones, len(m.Algoritmos), len(m.BERs), m.Semilla)
+	fmt.Fprintf(out, "   Parámetros y aceptación esperada en los metadatos de %s\n", filepath.Join(*dir, archivoSinteticoManifiesto))
+	return nil
+}
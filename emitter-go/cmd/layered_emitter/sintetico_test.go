@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func modeloDePrueba() *ModeloSintetico {
+	return &ModeloSintetico{
+		Semilla:       42,
+		Mensaje:       "Hola",
+		Algoritmos:    []string{"crc", "hamming"},
+		BERs:          []float64{0.005, 0.03},
+		Iteraciones:   3000,
+		LatenciaMs:    4,
+		LatenciaSigma: 0.4,
+		TasaFallos:    0.1,
+	}
+}
+
+// agregadoSintetico es lo que un análisis recupera de un punto del NDJSON
+type agregadoSintetico struct {
+	iteraciones, aceptadas, fallidas int
+	sumaBER, sumaLatencia            float64
+}
+
+func leerNDJSONSintetico(t *testing.T, path string, bloques int) map[PuntoSintetico]*agregadoSintetico {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	puntos := make(map[PuntoSintetico]*agregadoSintetico)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var reg registroNDJSON
+		if err := json.Unmarshal(sc.Bytes(), &reg); err != nil {
+			t.Fatal(err)
+		}
+		if reg.Version != VersionNDJSONIteraciones {
+			t.Fatalf("schema_version %d, se esperaba %d", reg.Version, VersionNDJSONIteraciones)
+		}
+		clave := PuntoSintetico{Algoritmo: reg.Algoritmo, BER: reg.BER}
+		a := puntos[clave]
+		if a == nil {
+			a = &agregadoSintetico{}
+			puntos[clave] = a
+		}
+		a.iteraciones++
+		a.sumaBER += reg.BERReal
+		if !reg.Exito {
+			a.fallidas++
+			continue
+		}
+		a.sumaLatencia += reg.TransmisionMs
+
+		// Aceptación del receptor a partir de las posiciones, como compararTeoria
+		aceptada := reg.ErroresInyectados == 0
+		if reg.Algoritmo == "hamming" {
+			aceptada = bloquesRecuperables(reg.PosicionesError, frame.SeqHeaderSize+frame.HammingPadSize, 7, bloques) == bloques
+		}
+		if aceptada {
+			a.aceptadas++
+		}
+	}
+	return puntos
+}
+
+func TestGenerarSintetico_RecuperaParametros(t *testing.T) {
+	dir := t.TempDir()
+	m := modeloDePrueba()
+	if err := generarSintetico(dir, m); err != nil {
+		t.Fatal(err)
+	}
+
+	// El manifiesto cubre todos los archivos y guarda el modelo
+	manifiesto := filepath.Join(dir, archivoSinteticoManifiesto)
+	if err := export.VerificarManifiesto(manifiesto); err != nil {
+		t.Fatal(err)
+	}
+	var man export.Manifiesto
+	datos, _ := os.ReadFile(manifiesto)
+	if err := json.Unmarshal(datos, &man); err != nil {
+		t.Fatal(err)
+	}
+	if len(man.Archivos) != 5 {
+		t.Errorf("el manifiesto lista %d archivos, se esperaban 5", len(man.Archivos))
+	}
+	for _, e := range man.Archivos {
+		if _, err := export.Verificar(filepath.Join(dir, e.Ruta)); err != nil {
+			t.Errorf("%s: %v", e.Ruta, err)
+		}
+	}
+	var meta ModeloSintetico
+	if err := json.Unmarshal(man.Metadatos, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Semilla != 42 || len(meta.Puntos) != 4 {
+		t.Fatalf("metadatos inesperados: %+v", meta)
+	}
+
+	bloques := (len("Hola")*8 + 3) / 4
+	puntos := leerNDJSONSintetico(t, filepath.Join(dir, archivoSinteticoNDJSON), bloques)
+	for _, p := range meta.Puntos {
+		a := puntos[PuntoSintetico{Algoritmo: p.Algoritmo, BER: p.BER}]
+		if a == nil || a.iteraciones != m.Iteraciones {
+			t.Fatalf("%s BER %g: iteraciones %+v", p.Algoritmo, p.BER, a)
+		}
+		enviadas := a.iteraciones - a.fallidas
+
+		// Cuatro desvíos de la proporción binomial
+		aceptacion := float64(a.aceptadas) / float64(enviadas)
+		if tol := 4 * math.Sqrt(p.Aceptacion*(1-p.Aceptacion)/float64(enviadas)); math.Abs(aceptacion-p.Aceptacion) > tol {
+			t.Errorf("%s BER %g: aceptación %.4f, el modelo dice %.4f (±%.4f)", p.Algoritmo, p.BER, aceptacion, p.Aceptacion, tol)
+		}
+		if ber := a.sumaBER / float64(a.iteraciones); math.Abs(ber-p.BER) > 0.1*p.BER {
+			t.Errorf("%s BER %g: BER medio %.5f", p.Algoritmo, p.BER, ber)
+		}
+		if lat := a.sumaLatencia / float64(enviadas); math.Abs(lat-meta.LatenciaMediaMs) > 0.05*meta.LatenciaMediaMs {
+			t.Errorf("%s BER %g: latencia media %.3fms, el modelo dice %.3fms", p.Algoritmo, p.BER, lat, meta.LatenciaMediaMs)
+		}
+		if tasa := float64(a.fallidas) / float64(a.iteraciones); math.Abs(tasa-m.TasaFallos) > 0.03 {
+			t.Errorf("%s BER %g: tasa de fallos %.3f", p.Algoritmo, p.BER, tasa)
+		}
+	}
+
+	// El histograma de errores suma todas las iteraciones
+	f, err := os.Open(filepath.Join(dir, archivoSinteticoDistribucion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tabla, err := export.LeerCSV(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, fila := range tabla.Filas {
+		n, _ := strconv.Atoi(fila[1])
+		total += n
+	}
+	if total != 4*m.Iteraciones {
+		t.Errorf("la distribución suma %d iteraciones, se esperaban %d", total, 4*m.Iteraciones)
+	}
+}
+
+func TestGenerarSintetico_Determinista(t *testing.T) {
+	generar := func(semilla int64) map[string][]byte {
+		dir := t.TempDir()
+		m := modeloDePrueba()
+		m.Iteraciones, m.Semilla = 50, semilla
+		if err := generarSintetico(dir, m); err != nil {
+			t.Fatal(err)
+		}
+		archivos := make(map[string][]byte)
+		for _, nombre := range []string{archivoSinteticoNDJSON, archivoSinteticoBER, archivoSinteticoDistribucion, "teoria-crc.csv"} {
+			datos, err := os.ReadFile(filepath.Join(dir, nombre))
+			if err != nil {
+				t.Fatal(err)
+			}
+			archivos[nombre] = datos
+		}
+		return archivos
+	}
+
+	a, b := generar(7), generar(7)
+	for nombre := range a {
+		if !bytes.Equal(a[nombre], b[nombre]) {
+			t.Errorf("%s difiere entre dos corridas con la misma semilla", nombre)
+		}
+	}
+	if c := generar(8); bytes.Equal(a[archivoSinteticoNDJSON], c[archivoSinteticoNDJSON]) {
+		t.Error("otra semilla debería generar otras iteraciones")
+	}
+}
+
+func TestGenerarSintetico_ModeloInvalido(t *testing.T) {
+	casos := map[string]func(m *ModeloSintetico){
+		"algoritmo sin modelo": func(m *ModeloSintetico) { m.Algoritmos = []string{"fletcher"} },
+		"BER fuera de rango":   func(m *ModeloSintetico) { m.BERs = []float64{1.5} },
+		"sin iteraciones":      func(m *ModeloSintetico) { m.Iteraciones = 0 },
+		"latencia":             func(m *ModeloSintetico) { m.LatenciaMs = 0 },
+	}
+	for nombre, modificar := range casos {
+		m := modeloDePrueba()
+		modificar(m)
+		if err := generarSintetico(t.TempDir(), m); err == nil {
+			t.Errorf("%s: se esperaba error", nombre)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
+)
+
+// subcomando es una forma de ejecutar el emisor con solo los flags que le
+// corresponden, además de los globales
+type subcomando struct {
+	nombre      string
+	modo        string // Modo equivalente de --mode
+	descripcion string
+	grupos      grupoFlags
+}
+
+// subcomandos lista los modos del emisor como subcomandos
+var subcomandos = []subcomando{
+	{"send", "manual", "Transmitir un mensaje interactivo o una trama con --frame-hex", grupoGlobal | grupoSend},
+	{"bench", "benchmark", "Múltiples transmisiones para análisis estadístico", grupoGlobal | grupoBench},
+	{"flood", "flood", "Generador de carga: misma trama sin ruido por conexiones paralelas", grupoGlobal | grupoFlood},
+}
+
+func buscarSubcomando(nombre string) (subcomando, bool) {
+	for _, s := range subcomandos {
+		if s.nombre == nombre {
+			return s, true
+		}
+	}
+	return subcomando{}, false
+}
+
+// flagSet declara los flags del subcomando en un FlagSet propio
+func (s subcomando) flagSet() (*flag.FlagSet, *opciones) {
+	fs := flag.NewFlagSet("layered_emitter "+s.nombre, flag.ContinueOnError)
+	o := registrarFlagsGrupos(fs, s.grupos)
+	return fs, o
+}
+
+// schema describe los flags del subcomando con las mismas restricciones que
+// el schema completo
+func (s subcomando) schema() *schema.Schema {
+	fs, _ := s.flagSet()
+	return schema.DesdeFlags("layered_emitter "+s.nombre, fs, restriccionesFlags)
+}
+
+// escribirAyudaSubcomando genera la ayuda de s desde su schema, así que lista
+// exactamente los flags que el subcomando acepta
+func escribirAyudaSubcomando(w io.Writer, s subcomando) {
+	fmt.Fprintf(w, "%s\n\nUso:\n  %s %s [flags]\n\nFlags:\n", s.descripcion, os.Args[0], s.nombre)
+	for _, f := range s.schema().Flags {
+		fmt.Fprintf(w, "  --%s %s\n", f.Name, f.Type)
+		detalles := []string{}
+		if f.Default != "" {
+			detalles = append(detalles, "default: "+f.Default)
+		}
+		if len(f.Allowed) > 0 {
+			detalles = append(detalles, "valores: "+strings.Join(f.Allowed, ", "))
+		}
+		if f.Min != nil && f.Max != nil {
+			detalles = append(detalles, fmt.Sprintf("rango: %g-%g", *f.Min, *f.Max))
+		} else if f.Min != nil {
+			detalles = append(detalles, fmt.Sprintf("mínimo: %g", *f.Min))
+		}
+		if len(detalles) > 0 {
+			fmt.Fprintf(w, "      %s (%s)\n", f.Help, strings.Join(detalles, "; "))
+		} else {
+			fmt.Fprintf(w, "      %s\n", f.Help)
+		}
+	}
+}
+
+// parsearLinea interpreta la línea de comandos: "<subcomando> [flags]" o la
+// forma anterior "[--mode m] [flags]", que acepta todos los flags y se
+// mantiene por compatibilidad. Los errores de flags se escriben en salida.
+func parsearLinea(args []string, salida io.Writer) (*opciones, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		s, ok := buscarSubcomando(args[0])
+		if !ok {
+			return nil, fmt.Errorf("subcomando desconocido: %s (disponibles: send, bench, flood, schema, history, verify-files, synth)", args[0])
+		}
+		fs, o := s.flagSet()
+		fs.SetOutput(salida)
+		fs.Usage = func() { escribirAyudaSubcomando(salida, s) }
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			return nil, fmt.Errorf("argumento inesperado: %s", fs.Arg(0))
+		}
+		*o.mode = s.modo
+		o.subcomando = s.nombre
+		o.flags = valoresFlags(fs)
+		return o, nil
+	}
+
+	fs := flag.NewFlagSet("layered_emitter", flag.ContinueOnError)
+	fs.SetOutput(salida)
+	fs.Usage = mostrarAyuda
+	o := registrarFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "mode" {
+			fmt.Fprintf(salida, "⚠️  --mode está obsoleto y se quitará en la próxima versión; usar '%s %s'\n\n",
+				os.Args[0], subcomandoDeModo(*o.mode))
+		}
+	})
+	o.flags = valoresFlags(fs)
+	return o, nil
+}
+
+// subcomandoDeModo devuelve el subcomando equivalente a un valor de --mode
+func subcomandoDeModo(modo string) string {
+	for _, s := range subcomandos {
+		if s.modo == modo {
+			return s.nombre
+		}
+	}
+	return "send|bench|flood"
+}
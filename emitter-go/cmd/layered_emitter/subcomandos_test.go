@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsearLinea_Subcomandos(t *testing.T) {
+	var salida bytes.Buffer
+	o, err := parsearLinea([]string{"bench", "--duration", "5s", "--ws-url", "ws://x:1"}, &salida)
+	if err != nil {
+		t.Fatalf("parsearLinea falló: %v", err)
+	}
+	if *o.mode != "benchmark" || *o.duration != 5*time.Second || *o.wsURL != "ws://x:1" || o.subcomando != "bench" {
+		t.Errorf("opciones inesperadas: mode=%s duration=%v ws=%s", *o.mode, *o.duration, *o.wsURL)
+	}
+
+	o, err = parsearLinea([]string{"send", "--frame-hex", "01"}, &salida)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Los flags de otros subcomandos quedan en su valor por defecto
+	if *o.mode != "manual" || *o.frameHex != "01" || *o.floodConns != 4 || *o.duration != 0 {
+		t.Errorf("opciones inesperadas para send: %+v", o)
+	}
+
+	o, err = parsearLinea([]string{"flood", "--flood-conns", "8"}, &salida)
+	if err != nil || *o.mode != "flood" || *o.floodConns != 8 {
+		t.Errorf("flood: mode=%v conns=%v err=%v", *o.mode, *o.floodConns, err)
+	}
+	if salida.Len() != 0 {
+		t.Errorf("los subcomandos no deberían advertir nada: %s", salida.String())
+	}
+}
+
+func TestParsearLinea_FlagsDeOtroSubcomando(t *testing.T) {
+	tests := [][]string{
+		{"send", "--duration", "5s"},
+		{"bench", "--frame-hex", "01"},
+		{"flood", "--error-png", "x.png"},
+		{"bench", "--mode", "manual"},
+	}
+	for _, args := range tests {
+		if _, err := parsearLinea(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v: se esperaba error por flag ajeno al subcomando", args)
+		}
+	}
+}
+
+func TestParsearLinea_Legacy(t *testing.T) {
+	var salida bytes.Buffer
+	o, err := parsearLinea([]string{"--mode", "benchmark", "--duration", "5s", "--flood-conns", "2"}, &salida)
+	if err != nil {
+		t.Fatalf("la forma con --mode debe seguir funcionando: %v", err)
+	}
+	if *o.mode != "benchmark" || *o.duration != 5*time.Second || o.subcomando != "" {
+		t.Errorf("opciones inesperadas: mode=%s duration=%v", *o.mode, *o.duration)
+	}
+	if !strings.Contains(salida.String(), "obsoleto") || !strings.Contains(salida.String(), " bench'") {
+		t.Errorf("se esperaba el aviso de --mode obsoleto con el subcomando equivalente: %q", salida.String())
+	}
+
+	// Sin --mode no hay aviso: el modo por defecto sigue siendo manual
+	salida.Reset()
+	o, err = parsearLinea([]string{"--ws-url", "ws://x:1"}, &salida)
+	if err != nil || *o.mode != "manual" || salida.Len() != 0 {
+		t.Errorf("sin --mode: mode=%s salida=%q err=%v", *o.mode, salida.String(), err)
+	}
+	if o, err = parsearLinea(nil, &salida); err != nil || *o.mode != "manual" {
+		t.Errorf("sin argumentos: err=%v", err)
+	}
+}
+
+func TestParsearLinea_SubcomandoDesconocido(t *testing.T) {
+	if _, err := parsearLinea([]string{"sweep"}, &bytes.Buffer{}); err == nil {
+		t.Error("se esperaba error por subcomando desconocido")
+	}
+	if _, err := parsearLinea([]string{"send", "extra"}, &bytes.Buffer{}); err == nil {
+		t.Error("se esperaba error por argumento sobrante")
+	}
+}
+
+func TestAyudaSubcomando(t *testing.T) {
+	var salida bytes.Buffer
+	_, err := parsearLinea([]string{"flood", "-h"}, &salida)
+	if err != flag.ErrHelp {
+		t.Fatalf("err = %v, se esperaba flag.ErrHelp", err)
+	}
+	ayuda := salida.String()
+
+	// La ayuda sale del schema del subcomando: todos sus flags y ningún otro
+	s, _ := buscarSubcomando("flood")
+	for _, f := range s.schema().Flags {
+		if !strings.Contains(ayuda, "--"+f.Name+" ") {
+			t.Errorf("falta --%s en la ayuda de flood", f.Name)
+		}
+	}
+	for _, ajeno := range []string{"--duration", "--frame-hex", "--mode"} {
+		if strings.Contains(ayuda, ajeno+" ") {
+			t.Errorf("la ayuda de flood no debería listar %s", ajeno)
+		}
+	}
+	if !strings.Contains(ayuda, "mínimo: 1") {
+		t.Errorf("la ayuda debería incluir las restricciones del schema:\n%s", ayuda)
+	}
+}
+
+func TestSubcomandos_CubrenTodosLosFlags(t *testing.T) {
+	// Cada flag de la forma --mode debe estar en algún subcomando
+	enSubcomandos := map[string]bool{}
+	for _, s := range subcomandos {
+		for _, f := range s.schema().Flags {
+			enSubcomandos[f.Name] = true
+		}
+	}
+	for _, f := range construirSchema().Flags {
+		if f.Name != "mode" && !enSubcomandos[f.Name] {
+			t.Errorf("--%s no está disponible en ningún subcomando", f.Name)
+		}
+	}
+}
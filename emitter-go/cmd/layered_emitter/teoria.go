@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/stats"
+)
+
+// ComparacionTeoria enfrenta lo medido en un benchmark con la predicción
+// teórica para la misma trama y BER
+type ComparacionTeoria struct {
+	Prediccion  *stats.Prediccion
+	Iteraciones int // Iteraciones comparadas
+	// Fracciones medidas, comparables con los campos homónimos de Prediccion
+	TramaIntacta       float64
+	BloqueRecuperable  float64
+	PayloadRecuperable float64
+	// IteracionesMuestreadas quedaron fuera de las métricas por bloque porque
+	// solo guardaron una muestra de sus posiciones (ver --full-positions)
+	IteracionesMuestreadas int
+}
+
+// compararTeoria mide en las iteraciones del benchmark las mismas
+// probabilidades que predice pkg/stats. Devuelve nil si no hay iteraciones
+// comparables: con plan de BER no hay un BER único, con --burst los errores no
+// son independientes, las de --inject no son aleatorias y las de
+// --max-fragment no tienen una trama única.
+func compararTeoria(benchmark *BenchmarkResult) (*ComparacionTeoria, error) {
+	if benchmark.Plan != nil || benchmark.LargoRafaga > 0 {
+		return nil, nil
+	}
+	// auto-hamming puede cambiar de variante entre iteraciones
+	if a := benchmark.Config.Algorithm; a != "crc" && a != "hamming" {
+		return nil, nil
+	}
+
+	var comparables []*TransmissionResult
+	for _, r := range benchmark.Results {
+		if r.NoisyFrameBits != nil && r.Inyeccion == "" && r.Fragmentos == nil {
+			comparables = append(comparables, r)
+		}
+	}
+	if len(comparables) == 0 {
+		return nil, nil
+	}
+
+	algoritmo := benchmark.Config.Algorithm
+	bloques := 0
+	if algoritmo == "hamming" {
+		bloques = (len(comparables[0].TextBits) + 3) / 4
+	}
+	pred, err := stats.Predecir(algoritmo, len(comparables[0].OriginalFrameBits), bloques, benchmark.Config.BER)
+	if err != nil {
+		return nil, err
+	}
+	pred.FijarBitsCRC(frame.ChecksumKindOf(comparables[0].FrameBytes).Size() * 8)
+
+	c := &ComparacionTeoria{Prediccion: pred, Iteraciones: len(comparables)}
+	var intactas, bloquesOK, bloquesTotales, payloadsOK, conPosiciones int
+	for _, r := range comparables {
+		if r.ErrorsInjected == 0 {
+			intactas++
+		}
+		if bloques == 0 {
+			continue
+		}
+		if r.PosicionesTruncadas {
+			c.IteracionesMuestreadas++
+			continue
+		}
+		conPosiciones++
+		ok := bloquesRecuperables(posicionesEnBloques(r.FrameBytes, r.ErrorPositions), frame.HammingBlocksOffset(r.FrameBytes), 7, bloques)
+		bloquesOK += ok
+		bloquesTotales += bloques
+		if ok == bloques {
+			payloadsOK++
+		}
+	}
+
+	c.TramaIntacta = float64(intactas) / float64(len(comparables))
+	if bloquesTotales > 0 {
+		c.BloqueRecuperable = float64(bloquesOK) / float64(bloquesTotales)
+		c.PayloadRecuperable = float64(payloadsOK) / float64(conPosiciones)
+	}
+	return c, nil
+}
+
+// bloquesRecuperables cuenta los bloques de tamBloque bits del payload con a
+// lo sumo un bit invertido, los que corrigen Hamming y la repetición (3,1).
+// Los bloques empiezan en el byte inicioBloques de la trama (ver
+// frame.HammingBlocksOffset).
+func bloquesRecuperables(posiciones []int, inicioBloques, tamBloque, bloques int) int {
+	inicio := inicioBloques * 8
+	errores := make([]int, bloques)
+	for _, p := range posiciones {
+		if b := (p - inicio) / tamBloque; p >= inicio && b < bloques {
+			errores[b]++
+		}
+	}
+	ok := 0
+	for _, e := range errores {
+		if e <= 1 {
+			ok++
+		}
+	}
+	return ok
+}
+
+// mostrarComparacionTeoria imprime medido, predicho y desviación relativa
+func mostrarComparacionTeoria(w io.Writer, c *ComparacionTeoria) {
+	p := c.Prediccion
+	fmt.Fprintf(w, "🧮 Comparación con la teoría (BER %.4f, trama de %d bits, %d iteraciones):\n", p.BER, p.BitsTrama, c.Iteraciones)
+	fmt.Fprintf(w, "   %-22s %10s %10s %11s\n", "Métrica", "Medido", "Predicho", "Desviación")
+	fila := func(nombre string, medido, predicho float64) {
+		fmt.Fprintf(w, "   %-22s %10.4f %10.4f %10.1f%%\n", nombre, medido, predicho,
+			stats.DesviacionRelativa(medido, predicho)*100)
+	}
+	fila("Trama intacta", c.TramaIntacta, p.TramaIntacta)
+	if p.Bloques > 0 && c.Iteraciones > c.IteracionesMuestreadas {
+		fila("Bloque Hamming", c.BloqueRecuperable, p.BloqueRecuperable)
+		fila(fmt.Sprintf("Payload (%d bloques)", p.Bloques), c.PayloadRecuperable, p.PayloadRecuperable)
+	}
+	if c.IteracionesMuestreadas > 0 {
+		fmt.Fprintf(w, "   (%d iteraciones con posiciones muestreadas no cuentan por bloque; usar --full-positions)\n",
+			c.IteracionesMuestreadas)
+	}
+	fmt.Fprintf(w, "   Detección CRC-%d de errores aleatorios: 1 - 2^-%d ≈ %.10f\n", p.BitsCRC, p.BitsCRC, p.DeteccionCRC)
+}
+
+// bersTeoria son los BER de la curva de --theory-csv, además del configurado
+var bersTeoria = []float64{0.0001, 0.0002, 0.0005, 0.001, 0.002, 0.005, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5}
+
+// exportarTeoria escribe la curva teórica de la trama de p sobre bersTeoria y
+// el BER del benchmark, para superponerla a lo medido
+func exportarTeoria(w io.Writer, p *stats.Prediccion) error {
+	return escribirCurvaTeoria(w, p, append([]float64{p.BER}, bersTeoria...))
+}
+
+// escribirCurvaTeoria escribe en csv-teoria la predicción para la trama de p
+// en cada BER de bers, ordenados y sin repetir
+func escribirCurvaTeoria(w io.Writer, p *stats.Prediccion, bers []float64) error {
+	bers = append([]float64(nil), bers...)
+	sort.Float64s(bers)
+
+	formatear := func(v float64) string { return strconv.FormatFloat(v, 'g', 10, 64) }
+	var rows [][]string
+	for i, ber := range bers {
+		if i > 0 && ber == bers[i-1] {
+			continue
+		}
+		q, err := stats.Predecir(p.Algoritmo, p.BitsTrama, p.Bloques, ber)
+		if err != nil {
+			return err
+		}
+		q.FijarBitsCRC(p.BitsCRC)
+		fila := []string{formatear(ber), formatear(q.TramaIntacta), formatear(q.DeteccionCRC), "", ""}
+		if q.Bloques > 0 {
+			fila[3], fila[4] = formatear(q.BloqueRecuperable), formatear(q.PayloadRecuperable)
+		}
+		rows = append(rows, fila)
+	}
+
+	e, _ := export.EsquemaCSVActual(export.TipoCSVTeoria)
+	return e.Escribir(w, rows)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func TestBloquesRecuperables(t *testing.T) {
+	inicio := 24 // Los bloques empiezan tras el header
+	posiciones := []int{
+		3,                  // Header: no cuenta
+		inicio, inicio + 6, // Bloque 1: dos errores
+		inicio + 7,       // Bloque 2: uno
+		inicio + 3*7 + 2, // Bloque 4: uno
+		inicio + 8*7,     // Después del payload: no cuenta
+	}
+	if got := bloquesRecuperables(posiciones, 3, 7, 8); got != 7 {
+		t.Errorf("bloquesRecuperables = %d, se esperaban 7", got)
+	}
+}
+
+func TestCompararTeoria_DentroDeLaTolerancia(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	le.noise = noise.NewNoiseLayerWithSeed(42)
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.02, Mode: "benchmark", Count: 3000}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := compararTeoria(benchmark)
+	if err != nil || c == nil {
+		t.Fatalf("comparación = %v, %v", c, err)
+	}
+
+	p := c.Prediccion
+	if p.BitsTrama != 120 || p.Bloques != 8 { // Header (3) + relleno (1) + 7 + CRC (4)
+		t.Fatalf("estructura de trama inesperada: %d bits, %d bloques", p.BitsTrama, p.Bloques)
+	}
+	for _, m := range []struct {
+		nombre           string
+		medido, predicho float64
+	}{
+		{"trama intacta", c.TramaIntacta, p.TramaIntacta},
+		{"bloque", c.BloqueRecuperable, p.BloqueRecuperable},
+		{"payload", c.PayloadRecuperable, p.PayloadRecuperable},
+	} {
+		if math.Abs(m.medido-m.predicho) > 0.03 {
+			t.Errorf("%s: medido %.4f, predicho %.4f", m.nombre, m.medido, m.predicho)
+		}
+	}
+
+	var out bytes.Buffer
+	mostrarComparacionTeoria(&out, c)
+	if !bytes.Contains(out.Bytes(), []byte("Payload (8 bloques)")) {
+		t.Errorf("falta la fila del payload:\n%s", out.String())
+	}
+}
+
+func TestCompararTeoria_NoAplica(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.02, Mode: "benchmark", Count: 3}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	benchmark.Plan = &noise.PlanBER{}
+	if c, _ := compararTeoria(benchmark); c != nil {
+		t.Error("con plan de BER no hay un BER único que predecir")
+	}
+
+	benchmark.Plan = nil
+	for _, r := range benchmark.Results {
+		r.Inyeccion = "block=1:bits=1"
+	}
+	if c, _ := compararTeoria(benchmark); c != nil {
+		t.Error("las iteraciones con --inject no son comparables")
+	}
+}
+
+func TestExportarTeoria(t *testing.T) {
+	le := newTestEmitter(func(url string, frame []byte) error { return nil })
+	config := &application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.03, Mode: "benchmark", Count: 2}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := compararTeoria(benchmark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := exportarTeoria(&buf, c.Prediccion); err != nil {
+		t.Fatal(err)
+	}
+	tabla, err := export.LeerCSV(&buf)
+	if err != nil {
+		t.Fatalf("el CSV no se puede leer con su esquema: %v", err)
+	}
+	if tabla.Tipo != export.TipoCSVTeoria || len(tabla.Filas) != len(bersTeoria)+1 {
+		t.Errorf("tabla inesperada: %s con %d filas", tabla.Tipo, len(tabla.Filas))
+	}
+	for _, f := range tabla.Filas {
+		if f[3] == "" || f[4] == "" {
+			t.Errorf("fila sin probabilidades Hamming: %v", f)
+		}
+	}
+}
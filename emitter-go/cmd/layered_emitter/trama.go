@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+// leerTramaExterna obtiene los bytes de la trama desde --frame-hex o desde el
+// archivo de --frame-file, que contiene el mismo hexadecimal (se ignoran los
+// espacios y saltos de línea, como en los fixtures golden)
+func leerTramaExterna(frameHex, frameFile string) ([]byte, error) {
+	if frameHex != "" && frameFile != "" {
+		return nil, fmt.Errorf("--frame-hex y --frame-file son mutuamente excluyentes")
+	}
+	texto := frameHex
+	if frameFile != "" {
+		datos, err := os.ReadFile(frameFile)
+		if err != nil {
+			return nil, err
+		}
+		texto = string(datos)
+	}
+
+	texto = strings.Join(strings.Fields(texto), "")
+	if texto == "" {
+		return nil, fmt.Errorf("la trama está vacía")
+	}
+	trama, err := hex.DecodeString(texto)
+	if err != nil {
+		return nil, fmt.Errorf("hexadecimal inválido: %v", err)
+	}
+	return trama, nil
+}
+
+// algoritmoDeTrama deduce el algoritmo a partir del tipo de mensaje del
+// header; vacío si el tipo no es conocido
+func algoritmoDeTrama(trama []byte) string {
+	if len(trama) == 0 {
+		return ""
+	}
+	switch frame.MsgTypeOf(trama) {
+	case frame.MsgTypeData:
+		return "crc"
+	case frame.MsgTypeHamming:
+		return "hamming"
+	default:
+		return ""
+	}
+}
+
+// enviarTramaExterna lee la trama de --frame-hex/--frame-file, la valida y la
+// envía con ProcessFrame. Una trama inválida solo se envía con --force.
+func enviarTramaExterna(le *LayeredEmitter, o *opciones) (*TransmissionResult, error) {
+	if *o.mode != "manual" {
+		return nil, fmt.Errorf("--frame-hex/--frame-file solo aplican al modo manual")
+	}
+	if len(le.inyeccion) > 0 {
+		return nil, fmt.Errorf("--inject no aplica a tramas pre-construidas")
+	}
+
+	trama, err := leerTramaExterna(*o.frameHex, *o.frameFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := frame.VerifyFrame(trama); err != nil {
+		if d := diagnosticarTrama(err); d != "" {
+			err = fmt.Errorf("%w; %s", err, d)
+		}
+		if !*o.force {
+			return nil, fmt.Errorf("trama inválida: %w (usar --force para enviarla igualmente)", err)
+		}
+		fmt.Printf("⚠️  Trama inválida, se envía por --force: %v\n\n", err)
+	}
+
+	result, err := le.ProcessFrame(trama, *o.frameBER)
+	if err != nil {
+		return nil, err
+	}
+	le.ejecutarHooks(result)
+	return result, nil
+}
+
+// diagnosticarTrama traduce un error de pkg/frame a una causa probable; vacío
+// si err no es de un tipo conocido
+func diagnosticarTrama(err error) string {
+	var crc *frame.CRCMismatchError
+	switch {
+	case errors.As(err, &crc):
+		return msgs.T("diagnostico.trama.checksum", nombreChecksum(crc.Kind))
+	case errors.Is(err, frame.ErrFrameTooShort):
+		return msgs.T("diagnostico.trama.corta")
+	case errors.Is(err, frame.ErrTruncated):
+		return msgs.T("diagnostico.trama.truncada")
+	case errors.Is(err, frame.ErrLengthMismatch):
+		return msgs.T("diagnostico.trama.largo")
+	case errors.Is(err, frame.ErrChecksumKind):
+		return msgs.T("diagnostico.trama.tipo_checksum")
+	case errors.Is(err, frame.ErrPayloadTooLarge):
+		return msgs.T("diagnostico.trama.payload_grande")
+	case errors.Is(err, frame.ErrInvalidBit):
+		return msgs.T("diagnostico.trama.bit_invalido")
+	default:
+		return ""
+	}
+}
+
+// volcarTramas escribe con --dump-frame el volcado de la trama construida y,
+// si el ruido la alteró, el de la trama que efectivamente se envió
+func volcarTramas(w io.Writer, result *TransmissionResult) error {
+	fmt.Fprintln(w, "🔎 Trama construida:")
+	if err := frame.Dump(w, result.FrameBytes); err != nil {
+		return err
+	}
+	if result.ErrorsInjected == 0 || result.NoisyFrameBits == nil {
+		return nil
+	}
+	fmt.Fprintf(w, "\n🔎 Trama enviada (%d bits alterados):\n", result.ErrorsInjected)
+	if err := frame.Dump(w, frame.BitsToBytes(result.NoisyFrameBits)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
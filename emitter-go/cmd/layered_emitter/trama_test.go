@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+// tramaHola es BuildFrame("Hola") en hexadecimal
+func tramaHola(t *testing.T) string {
+	t.Helper()
+	trama, err := frame.BuildFrame([]byte("Hola"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(trama)
+}
+
+func TestEnviarTramaExterna_Valida(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+	h := tramaHola(t)
+
+	result, err := enviarTramaExterna(le, parsearOpciones(t, "--frame-hex", h))
+	if err != nil {
+		t.Fatalf("enviarTramaExterna falló: %v", err)
+	}
+	if hex.EncodeToString(enviada) != h {
+		t.Errorf("sin ruido la trama debe enviarse intacta: %x", enviada)
+	}
+	if !result.Success || !result.TramaExterna || result.Config.Algorithm != "crc" {
+		t.Errorf("resultado inesperado: %+v", result)
+	}
+	if result.TextBits != nil || result.OriginalMessage != "" {
+		t.Error("una trama externa no tiene mensaje ni bits de texto")
+	}
+}
+
+func TestEnviarTramaExterna_Archivo(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	h := tramaHola(t)
+	// Los fixtures suelen venir partidos en líneas
+	path := filepath.Join(t.TempDir(), "trama.hex")
+	os.WriteFile(path, []byte(h[:8]+"\n"+h[8:]+"\n"), 0644)
+
+	result, err := enviarTramaExterna(le, parsearOpciones(t, "--frame-file", path, "--frame-ber", "1"))
+	if err != nil {
+		t.Fatalf("enviarTramaExterna falló: %v", err)
+	}
+	if result.ErrorsInjected != len(result.FrameBytes)*8 {
+		t.Errorf("con --frame-ber 1 se esperaban todos los bits invertidos: %d", result.ErrorsInjected)
+	}
+}
+
+func TestEnviarTramaExterna_Invalida(t *testing.T) {
+	h := tramaHola(t)
+	corrupta := h[:len(h)-2] + "00"
+	if corrupta == h {
+		corrupta = h[:len(h)-2] + "ff"
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"hex inválido", []string{"--frame-hex", "zz01"}, "hexadecimal inválido"},
+		{"hex impar", []string{"--frame-hex", "abc"}, "hexadecimal inválido"},
+		{"CRC incorrecto", []string{"--frame-hex", corrupta}, "--force"},
+		{"longitud incorrecta", []string{"--frame-hex", "010005" + h[6:]}, "declara 5"},
+		{"demasiado corta", []string{"--frame-hex", "0100"}, "mínimo"},
+		{"dos fuentes", []string{"--frame-hex", h, "--frame-file", "x"}, "mutuamente excluyentes"},
+		{"modo benchmark", []string{"--frame-hex", h, "--mode", "benchmark"}, "modo manual"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enviadas := 0
+			le := newTestEmitter(func(url string, f []byte) error {
+				enviadas++
+				return nil
+			})
+			_, err := enviarTramaExterna(le, parsearOpciones(t, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, se esperaba que mencione %q", err, tt.wantErr)
+			}
+			if enviadas != 0 {
+				t.Error("una trama rechazada no debe enviarse")
+			}
+		})
+	}
+}
+
+func TestEnviarTramaExterna_Force(t *testing.T) {
+	var enviada []byte
+	le := newTestEmitter(func(url string, f []byte) error {
+		enviada = f
+		return nil
+	})
+	h := tramaHola(t)
+	corrupta, _ := hex.DecodeString(h)
+	corrupta[len(corrupta)-1] ^= 0xff
+
+	result, err := enviarTramaExterna(le, parsearOpciones(t, "--frame-hex", hex.EncodeToString(corrupta), "--force"))
+	if err != nil {
+		t.Fatalf("--force debería enviar la trama inválida: %v", err)
+	}
+	if !bytes.Equal(enviada, corrupta) || !result.Success {
+		t.Errorf("la trama enviada no es la pedida: %x", enviada)
+	}
+}
+
+func TestDiagnosticarTrama(t *testing.T) {
+	base, _ := frame.BuildFrameWithChecksum([]byte("Hola"), frame.ChecksumCRC16CCITT)
+	corrupta := append([]byte(nil), base...)
+	corrupta[len(corrupta)-1] ^= 1
+	_, errCRC := frame.ParseFrame(corrupta)
+	_, errCorta := frame.ParseFrame(base[:2])
+	_, errGrande := frame.BuildFrame(make([]byte, 0x10000))
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"checksum", fmt.Errorf("envuelto: %w", errCRC), "CRC-16"},
+		{"demasiado corta", errCorta, "ni para el header"},
+		{"payload demasiado grande", errGrande, "--max-fragment"},
+		{"desconocido", errors.New("otro"), ""},
+	}
+	for _, tt := range tests {
+		got := diagnosticarTrama(tt.err)
+		if (tt.want == "") != (got == "") || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: diagnóstico %q, se esperaba que mencione %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVolcarTramas(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	result, err := le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "crc", Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := volcarTramas(&out, result); err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	frame.Dump(&want, result.FrameBytes)
+	if !strings.Contains(out.String(), want.String()) || strings.Contains(out.String(), "Trama enviada") {
+		t.Errorf("sin ruido solo se vuelca la trama construida:\n%s", out.String())
+	}
+
+	// Con errores también se vuelca la trama alterada, que no verifica
+	le.inyeccion = []noise.DirectivaInyeccion{{Bloque: 1, Bits: 1}}
+	result, err = le.ProcessMessage(&application.MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.5, Mode: "manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := volcarTramas(&out, result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Trama enviada (1 bits alterados)") || !strings.Contains(out.String(), "✗") {
+		t.Errorf("falta el volcado de la trama alterada:\n%s", out.String())
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/stats"
+)
+
+// elegirVarianteHamming elige para auto-hamming la variante registrada con
+// mejor rendimiento teórico para bitsDatos bits con BER ber (ver
+// stats.ElegirHamming). Es determinista: el mismo mensaje y BER eligen
+// siempre la misma variante.
+func elegirVarianteHamming(bitsDatos int, ber float64) frame.HammingVariant {
+	codigos := make([]stats.CodigoHamming, len(frame.HammingVariants))
+	for i, v := range frame.HammingVariants {
+		codigos[i] = stats.CodigoHamming{N: v.N, K: v.K}
+	}
+	return frame.HammingVariants[stats.ElegirHamming(codigos, bitsDatos, ber)]
+}
+
+// contarVariantesHamming cuenta las iteraciones por variante de auto-hamming;
+// nil si ninguna la usó
+func contarVariantesHamming(results []*TransmissionResult) map[string]int {
+	var uso map[string]int
+	for _, r := range results {
+		if r.VarianteHamming == "" {
+			continue
+		}
+		if uso == nil {
+			uso = make(map[string]int)
+		}
+		uso[r.VarianteHamming]++
+	}
+	return uso
+}
+
+// mostrarVariantesHamming imprime el uso de cada variante en el orden de
+// frame.HammingVariants
+func mostrarVariantesHamming(uso map[string]int) {
+	var partes []string
+	for _, v := range frame.HammingVariants {
+		partes = append(partes, fmt.Sprintf("%s: %d", v.Name, uso[v.Name]))
+	}
+	fmt.Print(msgs.T("resumen.variantes_hamming", strings.Join(partes, ", ")))
+}
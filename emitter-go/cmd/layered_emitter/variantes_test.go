@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/application"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestElegirVarianteHamming(t *testing.T) {
+	if v := elegirVarianteHamming(8, 0); v.Name != frame.Hamming74.Name {
+		t.Errorf("un byte eligió %s, se esperaba Hamming(7,4)", v.Name)
+	}
+	if v := elegirVarianteHamming(88, 0.01); v.Name != frame.Hamming1511.Name {
+		t.Errorf("11 bytes con BER 0.01 eligieron %s, se esperaba Hamming(15,11)", v.Name)
+	}
+	if v := elegirVarianteHamming(88, 0.1); v.Name != frame.Hamming74.Name {
+		t.Errorf("11 bytes con BER 0.1 eligieron %s, se esperaba Hamming(7,4)", v.Name)
+	}
+}
+
+// El receptor decodifica cada trama con la variante de su tipo de mensaje
+func TestProcessMessage_AutoHammingExtremoAExtremo(t *testing.T) {
+	for _, c := range []struct {
+		texto    string
+		variante string
+	}{
+		{"H", frame.Hamming74.Name},
+		{"Hola mundo, mensaje largo", frame.Hamming1511.Name},
+	} {
+		var enviada []byte
+		le := newTestEmitter(func(url string, f []byte) error {
+			enviada = f
+			return nil
+		})
+		result, err := le.ProcessMessage(&application.MessageConfig{Text: c.texto, Algorithm: "auto-hamming", BER: 0, Mode: "manual"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.VarianteHamming != c.variante {
+			t.Errorf("%q: VarianteHamming = %q, se esperaba %q", c.texto, result.VarianteHamming, c.variante)
+		}
+
+		f, err := frame.ParseFrame(enviada)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, ok := frame.HammingVariantForType(f.MsgType)
+		if !ok || v.Name != c.variante {
+			t.Fatalf("%q: el tipo %#02x no identifica a %s", c.texto, f.MsgType, c.variante)
+		}
+		bits, _, err := v.DecodePayload(f.Payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(frame.BitsToBytes(bits)[:len(c.texto)]); got != c.texto {
+			t.Errorf("decodificado %q, se esperaba %q", got, c.texto)
+		}
+	}
+}
+
+func TestRunBenchmark_UsoVariantesHamming(t *testing.T) {
+	le := newTestEmitter(func(url string, f []byte) error { return nil })
+	le.watchdogIteraciones = 0
+
+	config := &application.MessageConfig{Text: "Hola mundo", Algorithm: "auto-hamming", BER: 0, Mode: "benchmark", Count: 4}
+	benchmark, err := le.RunBenchmark(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benchmark.VariantesHamming) != 1 || benchmark.VariantesHamming[frame.Hamming1511.Name] != 4 {
+		t.Errorf("VariantesHamming = %v", benchmark.VariantesHamming)
+	}
+	if c, err := compararTeoria(benchmark); c != nil || err != nil {
+		t.Errorf("auto-hamming no debería compararse con la teoría: %v, %v", c, err)
+	}
+
+	config.Algorithm = "crc"
+	if benchmark, _ := le.RunBenchmark(config); benchmark.VariantesHamming != nil {
+		t.Errorf("crc no debería contar variantes: %v", benchmark.VariantesHamming)
+	}
+}
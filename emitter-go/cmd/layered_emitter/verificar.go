@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+)
+
+// runVerifyFiles implementa "layered_emitter verify-files <paths...>":
+// verifica cada archivo, informa una línea por archivo y falla si alguno
+// no pasa la verificación
+func runVerifyFiles(paths []string, out io.Writer) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("uso: verify-files <archivos...>")
+	}
+
+	fallidos := 0
+	for _, path := range paths {
+		tipo, err := export.Verificar(path)
+		if tipo == "" {
+			tipo = "?"
+		}
+		if err != nil {
+			fallidos++
+			fmt.Fprintf(out, "❌ %s (%s): %v\n", path, tipo, err)
+			continue
+		}
+		fmt.Fprintf(out, "✅ %s (%s)\n", path, tipo)
+	}
+
+	if fallidos > 0 {
+		return fmt.Errorf("%d de %d archivos no pasaron la verificación", fallidos, len(paths))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunVerifyFiles(t *testing.T) {
+	dir := t.TempDir()
+	bueno := filepath.Join(dir, "it.ndjson")
+	malo := filepath.Join(dir, "d.csv")
+	os.WriteFile(bueno, []byte("{\"iteration\":1}\n"), 0644)
+	os.WriteFile(malo, []byte("errores,frecuencia\n0,"), 0644)
+
+	var out bytes.Buffer
+	if err := runVerifyFiles([]string{bueno}, &out); err != nil {
+		t.Fatalf("archivo válido rechazado: %v", err)
+	}
+
+	out.Reset()
+	err := runVerifyFiles([]string{bueno, malo}, &out)
+	if err == nil {
+		t.Fatal("se esperaba error con un archivo corrupto")
+	}
+	lineas := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lineas) != 2 || !strings.HasPrefix(lineas[0], "✅") || !strings.HasPrefix(lineas[1], "❌") {
+		t.Errorf("se esperaba un resultado por archivo:\n%s", out.String())
+	}
+
+	if err := runVerifyFiles(nil, &out); err == nil {
+		t.Error("sin archivos debería fallar")
+	}
+}
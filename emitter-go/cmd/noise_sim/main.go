@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/flagutil"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/presentation"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// opciones agrupa los flags de la simulación
+type opciones struct {
+	bits       *string
+	nbits      *int
+	hexPayload *string
+	message    *string
+	algorithm  *string
+	ber        *float64
+	iterations *int
+	model      *string
+	fill       *int
+	burst      *int
+	seed       *int64
+	csvDist    *string
+	csvBER     *string
+	manifest   *string
+}
+
+// registrarFlags declara los flags de noise_sim en fs
+func registrarFlags(fs *flag.FlagSet) *opciones {
+	return &opciones{
+		bits:       fs.String("bits", "", "Patrón binario a transmitir (ej: '110101')"),
+		nbits:      fs.Int("nbits", 0, "Cantidad de bits a transmitir (todos en cero)"),
+		hexPayload: fs.String("hex", "", "Payload en hexadecimal a transmitir tal cual"),
+		message:    fs.String("message", "", "Mensaje con el que construir una trama real"),
+		algorithm:  fs.String("algorithm", "crc", "Algoritmo de la trama construida desde --message: crc o hamming"),
+		ber:        flagutil.Probability(fs, "ber", 0.01, "Bit Error Rate (0.0-1.0 o porcentaje, ej: 1%)"),
+		iterations: fs.Int("iterations", 1000, "Cantidad de transmisiones simuladas"),
+		model:      fs.String("model", "bsc", "Modelo de ruido: bsc (canal binario simétrico), erasure (canal de borrado, --ber es la probabilidad de borrado) o burst (errores en ráfagas de --burst-length bits)"),
+		fill:       fs.Int("erasure-fill", 0, "Valor (0 o 1) que reciben los bits borrados en el modelo erasure"),
+		burst:      fs.Int("burst-length", 4, "Bits contiguos invertidos por ráfaga en el modelo burst"),
+		seed:       fs.Int64("seed", 0, "Semilla para resultados reproducibles (0 = aleatoria)"),
+		csvDist:    fs.String("csv-dist", "", "Exportar la distribución de errores a este CSV"),
+		csvBER:     fs.String("csv-ber", "", "Exportar el BER de cada iteración a este CSV"),
+		manifest:   fs.String("manifest", "", "Escribir en esta ruta un manifiesto JSON con los CSV producidos"),
+	}
+}
+
+// construirSchema describe los flags de noise_sim para herramientas externas
+func construirSchema() *schema.Schema {
+	fs := flag.NewFlagSet("noise_sim", flag.ContinueOnError)
+	registrarFlags(fs)
+	s := schema.DesdeFlags("noise_sim", fs, map[string]schema.Restriccion{
+		"algorithm":    schema.Valores(algoritmosTrama),
+		"model":        schema.Valores(noise.ModelosSoportados),
+		"ber":          schema.Rango(0, 1),
+		"iterations":   schema.Minimo(1),
+		"nbits":        schema.Minimo(0),
+		"erasure-fill": schema.Valores([]string{"0", "1"}),
+		"burst-length": schema.Minimo(1),
+	})
+	s.Enums = map[string][]string{
+		"algorithm": algoritmosTrama,
+		"model":     noise.ModelosSoportados,
+	}
+	return s
+}
+
+// run ejecuta la simulación con los argumentos dados y escribe el resumen en out.
+// "noise_sim schema" imprime el schema JSON de los flags en lugar de simular.
+func run(args []string, out io.Writer) error {
+	if len(args) > 0 && args[0] == "schema" {
+		return construirSchema().Escribir(out)
+	}
+
+	fs := flag.NewFlagSet("noise_sim", flag.ContinueOnError)
+	o := registrarFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !modeloSoportado(*o.model) {
+		return fmt.Errorf("modelo de ruido no soportado: %s (disponibles: %v)", *o.model, noise.ModelosSoportados)
+	}
+
+	input, descripcion, err := construirEntrada(*o.bits, *o.nbits, *o.hexPayload, *o.message, *o.algorithm)
+	if err != nil {
+		return err
+	}
+
+	var layer *noise.NoiseLayer
+	if *o.seed != 0 {
+		layer = noise.NewNoiseLayerWithSeed(*o.seed)
+	} else {
+		layer = noise.NewNoiseLayer()
+	}
+
+	if err := layer.ValidarConfiguracion(*o.ber, input); err != nil {
+		return err
+	}
+
+	// Los CSV se abren antes de simular para detectar rutas repetidas o
+	// directorios inexistentes sin esperar a que termine la simulación
+	exports := export.NuevoConjunto()
+	defer exports.Cerrar()
+	csvDist, csvBER, err := abrirCSVs(exports, o)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "🔬 Simulación de canal (%s): %s, %d bits, %d iteraciones\n\n",
+		*o.model, descripcion, len(input), *o.iterations)
+
+	var stats *noise.ChannelStats
+	switch *o.model {
+	case "erasure":
+		if *o.fill != 0 && *o.fill != 1 {
+			return fmt.Errorf("--erasure-fill debe ser 0 o 1: %d", *o.fill)
+		}
+		stats, err = layer.SimularCanalConBorrado(input, *o.ber, byte(*o.fill), *o.iterations)
+	case "burst":
+		stats, err = layer.SimularCanalConRafagas(input, *o.ber, *o.burst, *o.iterations)
+	default:
+		stats, err = layer.SimularCanalRuidoso(input, *o.ber, *o.iterations)
+	}
+	if err != nil {
+		return err
+	}
+	stats.EscribirEstadisticas(out)
+
+	if csvDist != nil {
+		if err := exportarDistribucion(csvDist, stats); err != nil {
+			return fmt.Errorf("error exportando distribución: %v", err)
+		}
+		fmt.Fprintf(out, "💾 Distribución exportada a %s\n", *o.csvDist)
+	}
+	if csvBER != nil {
+		if err := exportarBERPorIteracion(csvBER, stats); err != nil {
+			return fmt.Errorf("error exportando BER por iteración: %v", err)
+		}
+		fmt.Fprintf(out, "💾 BER por iteración exportado a %s\n", *o.csvBER)
+	}
+	if *o.manifest != "" {
+		if err := exports.EscribirManifiesto(*o.manifest); err != nil {
+			return fmt.Errorf("error escribiendo manifiesto: %v", err)
+		}
+		fmt.Fprintf(out, "📄 Manifiesto escrito en %s\n", *o.manifest)
+	}
+	return nil
+}
+
+// abrirCSVs abre en c los CSV pedidos por los flags (nil si no se pidieron)
+func abrirCSVs(c *export.Conjunto, o *opciones) (dist, ber io.Writer, err error) {
+	if *o.manifest != "" {
+		if err := c.Reservar(*o.manifest); err != nil {
+			return nil, nil, fmt.Errorf("--manifest: %v", err)
+		}
+	}
+	// La versión registrada en el manifiesto es la actual de cada esquema
+	if *o.csvDist != "" {
+		e, _ := export.EsquemaCSVActual(export.TipoCSVDistribucion)
+		a, err := c.Abrir(*o.csvDist, e.Tipo, e.Version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--csv-dist: %v", err)
+		}
+		dist = a
+	}
+	if *o.csvBER != "" {
+		e, _ := export.EsquemaCSVActual(export.TipoCSVBER)
+		a, err := c.Abrir(*o.csvBER, e.Tipo, e.Version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--csv-ber: %v", err)
+		}
+		ber = a
+	}
+	return dist, ber, nil
+}
+
+// algoritmosTrama lista los algoritmos con los que --message construye la trama
+var algoritmosTrama = []string{"crc", "hamming"}
+
+func modeloSoportado(model string) bool {
+	for _, m := range noise.ModelosSoportados {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// construirEntrada obtiene el patrón de bits a simular desde exactamente una
+// de las fuentes disponibles
+func construirEntrada(bits string, nbits int, hexPayload, message, algorithm string) ([]byte, string, error) {
+	fuentes := 0
+	for _, set := range []bool{bits != "", nbits != 0, hexPayload != "", message != ""} {
+		if set {
+			fuentes++
+		}
+	}
+	if fuentes != 1 {
+		return nil, "", fmt.Errorf("indicar exactamente una entrada: --bits, --nbits, --hex o --message")
+	}
+
+	switch {
+	case bits != "":
+		out := make([]byte, len(bits))
+		for i, r := range bits {
+			if r != '0' && r != '1' {
+				return nil, "", fmt.Errorf("carácter inválido '%c' en posición %d de --bits", r, i)
+			}
+			out[i] = byte(r - '0')
+		}
+		return out, "patrón de bits", nil
+
+	case nbits != 0:
+		if nbits < 0 {
+			return nil, "", fmt.Errorf("--nbits debe ser mayor a 0: %d", nbits)
+		}
+		return make([]byte, nbits), "bits en cero", nil
+
+	case hexPayload != "":
+		data, err := hex.DecodeString(hexPayload)
+		if err != nil {
+			return nil, "", fmt.Errorf("--hex inválido: %v", err)
+		}
+		return frame.BytesToBits(data), "payload hex", nil
+
+	default:
+		p := presentation.NewPresentationLayer()
+		textBits, err := p.CodificarMensaje(message)
+		if err != nil {
+			return nil, "", err
+		}
+		payload := p.ConvertirBitsABytes(textBits)
+
+		var frameBytes []byte
+		switch algorithm {
+		case "crc":
+			frameBytes, err = frame.BuildFrame(payload)
+		case "hamming":
+			frameBytes, err = frame.BuildFrameWithHamming(payload)
+		default:
+			return nil, "", fmt.Errorf("algoritmo no soportado: %s (disponibles: %v)", algorithm, algoritmosTrama)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return frame.BytesToBits(frameBytes), fmt.Sprintf("trama %s", algorithm), nil
+	}
+}
+
+// exportarDistribucion escribe errores,frecuencia ordenado por cantidad de
+// errores, con la versión actual del esquema csv-distribucion
+func exportarDistribucion(w io.Writer, stats *noise.ChannelStats) error {
+	errores := make([]int, 0, len(stats.ErrorDistribution))
+	for e := range stats.ErrorDistribution {
+		errores = append(errores, e)
+	}
+	sort.Ints(errores)
+
+	var rows [][]string
+	for _, e := range errores {
+		rows = append(rows, []string{strconv.Itoa(e), strconv.Itoa(stats.ErrorDistribution[e])})
+	}
+	return escribirCSV(w, export.TipoCSVDistribucion, rows)
+}
+
+// exportarBERPorIteracion escribe iteracion,ber,errores en el orden simulado
+func exportarBERPorIteracion(w io.Writer, stats *noise.ChannelStats) error {
+	var rows [][]string
+	for i, v := range stats.BERPorIteracion {
+		rows = append(rows, []string{strconv.Itoa(i), strconv.FormatFloat(v, 'f', 6, 64),
+			strconv.Itoa(stats.ErroresPorIteracion[i])})
+	}
+	return escribirCSV(w, export.TipoCSVBER, rows)
+}
+
+// escribirCSV escribe rows con la versión actual del esquema de tipo
+func escribirCSV(w io.Writer, tipo string, rows [][]string) error {
+	e, _ := export.EsquemaCSVActual(tipo)
+	return e.Escribir(w, rows)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/export"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
+)
+
+var update = flag.Bool("update", false, "regenerar los archivos golden")
+
+func TestRun_GoldenSemillaFija(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"--message", "Hola", "--algorithm", "hamming", "--ber", "0.05", "--iterations", "200", "--seed", "42"}
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "hamming_seed42.golden")
+	if *update {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("no se pudo leer %s: %v", golden, err)
+	}
+	if out.String() != string(want) {
+		t.Errorf("la salida difiere del golden:\n--- obtenido ---\n%s\n--- esperado ---\n%s", out.String(), want)
+	}
+}
+
+func TestRun_Reproducible(t *testing.T) {
+	args := []string{"--bits", "1011001110001111", "--ber", "0.1", "--iterations", "500", "--seed", "7"}
+	var a, b bytes.Buffer
+	if err := run(args, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(args, &b); err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != b.String() {
+		t.Error("dos corridas con la misma semilla produjeron resúmenes distintos")
+	}
+}
+
+func TestRun_ExportaCSV(t *testing.T) {
+	dir := t.TempDir()
+	dist := filepath.Join(dir, "dist.csv")
+	ber := filepath.Join(dir, "ber.csv")
+
+	args := []string{"--hex", "cafe", "--ber", "0.2", "--iterations", "10", "--seed", "1",
+		"--csv-dist", dist, "--csv-ber", ber}
+	if err := run(args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+
+	berCSV, err := os.ReadFile(ber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(berCSV)), "\n")
+	if lines[0] != "# schema=csv-ber-iteracion version=2" || lines[1] != "iteracion,ber,errores" || len(lines) != 12 {
+		t.Errorf("CSV de BER inesperado (%d líneas): %q", len(lines), lines[:2])
+	}
+
+	distCSV, err := os.ReadFile(dist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(distCSV), "# schema=csv-distribucion version=2\nerrores,frecuencia\n") {
+		t.Errorf("CSV de distribución sin encabezado: %q", distCSV)
+	}
+}
+
+func TestRun_CSVMismaRuta(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(dir)
+
+	// La ruta absoluta y la relativa apuntan al mismo archivo
+	var resumen bytes.Buffer
+	args := []string{"--nbits", "8", "--csv-dist", filepath.Join(dir, "salida.csv"), "--csv-ber", "./salida.csv"}
+	if err := run(args, &resumen); err == nil {
+		t.Fatal("--csv-dist y --csv-ber en la misma ruta deberían fallar")
+	}
+	if resumen.Len() != 0 {
+		t.Error("el conflicto debería detectarse antes de simular")
+	}
+}
+
+func TestRun_Manifiesto(t *testing.T) {
+	dir := t.TempDir()
+	manifiesto := filepath.Join(dir, "manifest.json")
+	args := []string{"--hex", "cafe", "--iterations", "5", "--seed", "1",
+		"--csv-dist", filepath.Join(dir, "dist.csv"), "--csv-ber", filepath.Join(dir, "ber.csv"),
+		"--manifest", manifiesto}
+	if err := run(args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+
+	var m export.Manifiesto
+	datos, err := os.ReadFile(manifiesto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(datos, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Archivos) != 2 {
+		t.Fatalf("el manifiesto lista %d archivos, se esperaban 2", len(m.Archivos))
+	}
+	for _, e := range m.Archivos {
+		if e.Bytes == 0 || e.Version != 2 {
+			t.Errorf("entrada incompleta: %+v", e)
+		}
+	}
+}
+
+func TestRun_EntradaInvalida(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"sin entrada", []string{"--ber", "0.1"}},
+		{"dos entradas", []string{"--bits", "101", "--nbits", "8"}},
+		{"bits inválidos", []string{"--bits", "10201"}},
+		{"hex inválido", []string{"--hex", "zz"}},
+		{"modelo desconocido", []string{"--nbits", "8", "--model", "gilbert"}},
+		{"relleno inválido", []string{"--nbits", "8", "--model", "erasure", "--erasure-fill", "2"}},
+		{"algoritmo desconocido", []string{"--message", "Hola", "--algorithm", "rs"}},
+		{"BER fuera de rango", []string{"--nbits", "8", "--ber", "1.5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.args, &bytes.Buffer{}); err == nil {
+				t.Error("se esperaba error")
+			}
+		})
+	}
+}
+
+func TestRun_Schema(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"schema"}, &out); err != nil {
+		t.Fatalf("run schema falló: %v", err)
+	}
+
+	var s schema.Schema
+	if err := json.Unmarshal(out.Bytes(), &s); err != nil {
+		t.Fatalf("schema no es JSON válido: %v", err)
+	}
+
+	fs := flag.NewFlagSet("noise_sim", flag.ContinueOnError)
+	registrarFlags(fs)
+	fs.VisitAll(func(f *flag.Flag) {
+		if s.Buscar(f.Name) == nil {
+			t.Errorf("el flag --%s no aparece en el schema", f.Name)
+		}
+	})
+	for _, m := range noise.ModelosSoportados {
+		if !contiene(s.Buscar("model").Allowed, m) {
+			t.Errorf("el modelo %s no aparece en el schema", m)
+		}
+	}
+	for _, a := range algoritmosTrama {
+		if !contiene(s.Enums["algorithm"], a) {
+			t.Errorf("el algoritmo %s no aparece en el schema", a)
+		}
+	}
+}
+
+func contiene(valores []string, v string) bool {
+	for _, x := range valores {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRun_BERPorcentaje(t *testing.T) {
+	var a, b bytes.Buffer
+	if err := run([]string{"--nbits", "64", "--ber", "5%", "--iterations", "50", "--seed", "3"}, &a); err != nil {
+		t.Fatalf("--ber con porcentaje falló: %v", err)
+	}
+	if err := run([]string{"--nbits", "64", "--ber", "0.05", "--iterations", "50", "--seed", "3"}, &b); err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != b.String() {
+		t.Error("--ber 5% y --ber 0.05 deberían producir la misma simulación")
+	}
+}
+
+func TestRun_ModeloBorrado(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"--bits", "11111111", "--model", "erasure", "--ber", "0.5", "--iterations", "100", "--seed", "9"}
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+	if !strings.Contains(out.String(), "(erasure)") || !strings.Contains(out.String(), "Bits borrados") {
+		t.Errorf("el resumen debería reportar el canal de borrado:\n%s", out.String())
+	}
+
+	// Con relleno 1 sobre bits en 1 los borrados no producen errores
+	out.Reset()
+	args = append(args, "--erasure-fill", "1")
+	if err := run(args, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Total de errores: 0") {
+		t.Errorf("no debería haber errores con relleno igual a los bits:\n%s", out.String())
+	}
+}
+
+func TestRun_ModeloRafagas(t *testing.T) {
+	var out bytes.Buffer
+	args := []string{"--nbits", "256", "--model", "burst", "--burst-length", "8", "--ber", "0.05", "--iterations", "50", "--seed", "4"}
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run falló: %v", err)
+	}
+	if !strings.Contains(out.String(), "(burst)") {
+		t.Errorf("el resumen debería nombrar el canal de ráfagas:\n%s", out.String())
+	}
+
+	if err := run([]string{"--nbits", "8", "--model", "burst", "--burst-length", "0"}, &out); err == nil {
+		t.Error("se esperaba error con --burst-length 0")
+	}
+}
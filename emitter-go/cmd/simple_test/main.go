@@ -19,7 +19,7 @@ func main() {
 	}
 
 	message := os.Args[1]
-	algorithm := os.Args[2] 
+	algorithm := os.Args[2]
 	ber := 0.01
 	wsURL := "ws://localhost:8765"
 
@@ -81,11 +81,11 @@ func main() {
 	// Step 4: Send frame
 	noisyFrameBytes := presentation.ConvertirBitsABytes(noiseResult.NoisyBits)
 	fmt.Printf("Sending frame (%d bytes) to %s...\n", len(noisyFrameBytes), wsURL)
-	
+
 	err = wsclient.SendFrame(wsURL, noisyFrameBytes)
 	if err != nil {
 		log.Fatal("Error sending frame:", err)
 	}
 
 	fmt.Println("✅ Frame sent successfully!")
-}
\ No newline at end of file
+}
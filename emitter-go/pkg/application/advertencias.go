@@ -0,0 +1,118 @@
+package application
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
+)
+
+// Códigos de las advertencias de AdvertirConfiguracion
+const (
+	AdvertenciaHammingSaturado  = "hamming-saturado"
+	AdvertenciaPocasIteraciones = "pocas-iteraciones"
+	AdvertenciaGarantiaCRC      = "garantia-crc"
+)
+
+const (
+	umbralTramasIncorregibles = 0.5  // Fracción de tramas incorregibles a partir de la que se advierte
+	margenMaximoTasaExito     = 0.1  // Semiancho máximo aceptado del IC 95% de la tasa de éxito
+	zConfianza95              = 1.96 // Cuantil normal del IC 95%
+)
+
+// Advertencia describe una combinación de parámetros válida pero cuyos
+// resultados no van a ser estadísticamente significativos
+type Advertencia struct {
+	Codigo  string
+	Mensaje string
+}
+
+func (a Advertencia) String() string {
+	return fmt.Sprintf("[%s] %s", a.Codigo, a.Mensaje)
+}
+
+// garantiaCRC32 es la cantidad de bits erróneos que CRC-32 (IEEE 802.3)
+// detecta siempre según el largo del mensaje protegido (Koopman, 2002)
+var garantiaCRC32 = []struct {
+	maxBits int
+	errores int
+}{
+	{268, 5},
+	{2974, 4},
+	{91607, 3},
+	{math.MaxInt32, 2},
+}
+
+// AdvertirConfiguracion estima los errores esperados por trama y por bloque
+// Hamming a partir del tamaño de la trama y el BER, y devuelve una advertencia
+// por cada condición que vuelve los resultados poco informativos. Se asume
+// una configuración que ya pasó ValidarConfiguracion. Los mensajes usan el
+// idioma por defecto; ApplicationLayer.AdvertirConfiguracion usa el elegido.
+func AdvertirConfiguracion(config *MessageConfig) []Advertencia {
+	return advertir(config, nil)
+}
+
+// AdvertirConfiguracion es AdvertirConfiguracion con los mensajes en el idioma
+// del catálogo de app
+func (app *ApplicationLayer) AdvertirConfiguracion(config *MessageConfig) []Advertencia {
+	return advertir(config, app.msgs)
+}
+
+func advertir(config *MessageConfig, msgs *i18n.Catalogo) []Advertencia {
+	var advertencias []Advertencia
+	p := config.BER
+	dataBits := len(config.Text) * 8
+
+	if config.Algorithm == "hamming" && p > 0 {
+		bloques := (dataBits + 3) / 4
+		// Un bloque (7,4) se corrige solo con 0 o 1 bit invertido
+		pBloque := 1 - math.Pow(1-p, 7) - 7*p*math.Pow(1-p, 6)
+		pTrama := 1 - math.Pow(1-pBloque, float64(bloques))
+		if pTrama >= umbralTramasIncorregibles {
+			advertencias = append(advertencias, Advertencia{
+				Codigo: AdvertenciaHammingSaturado,
+				Mensaje: msgs.T("advertencia.hamming_saturado",
+					p, pBloque*100, pTrama*100, bloques),
+			})
+		}
+	}
+
+	if config.Mode == "benchmark" && config.Duration == 0 && config.Count > 0 {
+		// Peor caso (tasa de éxito 50%) del semiancho del IC 95%
+		margen := zConfianza95 * math.Sqrt(0.25/float64(config.Count))
+		if margen > margenMaximoTasaExito {
+			minimas := int(math.Ceil(0.25 * math.Pow(zConfianza95/margenMaximoTasaExito, 2)))
+			advertencias = append(advertencias, Advertencia{
+				Codigo: AdvertenciaPocasIteraciones,
+				Mensaje: msgs.T("advertencia.pocas_iteraciones",
+					config.Count, margen*100, minimas, margenMaximoTasaExito*100),
+			})
+		}
+	}
+
+	if config.Algorithm == "crc" && p > 0 {
+		header := frame.HeaderSize
+		if config.Mode == "benchmark" {
+			header = frame.SeqHeaderSize // Las tramas del benchmark llevan secuencia
+		}
+		protegidos := (header+len(config.Text))*8 + 32
+		garantia := 0
+		for _, g := range garantiaCRC32 {
+			if protegidos <= g.maxBits {
+				garantia = g.errores
+				break
+			}
+		}
+		esperados := p * float64(protegidos)
+		if esperados > float64(garantia) {
+			advertencias = append(advertencias, Advertencia{
+				Codigo: AdvertenciaGarantiaCRC,
+				Mensaje: msgs.T("advertencia.garantia_crc",
+					esperados, protegidos, garantia),
+			})
+		}
+	}
+
+	return advertencias
+}
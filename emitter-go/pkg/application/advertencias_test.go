@@ -0,0 +1,74 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func codigos(advertencias []Advertencia) map[string]bool {
+	out := make(map[string]bool)
+	for _, a := range advertencias {
+		out[a.Codigo] = true
+	}
+	return out
+}
+
+func TestAdvertirConfiguracion(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *MessageConfig
+		want   []string
+	}{
+		{
+			name:   "configuración razonable",
+			config: &MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.001, Mode: "benchmark", Count: 1000},
+		},
+		{
+			name:   "hamming saturado",
+			config: &MessageConfig{Text: "Hola mundo", Algorithm: "hamming", BER: 0.2, Mode: "manual"},
+			want:   []string{AdvertenciaHammingSaturado},
+		},
+		{
+			name:   "pocas iteraciones",
+			config: &MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.001, Mode: "benchmark", Count: 10},
+			want:   []string{AdvertenciaPocasIteraciones},
+		},
+		{
+			name:   "hamming saturado con pocas iteraciones",
+			config: &MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0.2, Mode: "benchmark", Count: 10},
+			want:   []string{AdvertenciaHammingSaturado, AdvertenciaPocasIteraciones},
+		},
+		{
+			name:   "duración no se evalúa por cantidad",
+			config: &MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.001, Mode: "benchmark", Duration: time.Minute},
+		},
+		{
+			name:   "CRC dentro de la garantía",
+			config: &MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "manual"},
+		},
+		{
+			name:   "CRC excede la garantía",
+			config: &MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.1, Mode: "manual"},
+			want:   []string{AdvertenciaGarantiaCRC},
+		},
+		{
+			name:   "BER cero nunca advierte sobre el canal",
+			config: &MessageConfig{Text: "Hola", Algorithm: "hamming", BER: 0, Mode: "manual"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AdvertirConfiguracion(tt.config)
+			if len(got) != len(tt.want) {
+				t.Fatalf("AdvertirConfiguracion() = %v, se esperaban %v", got, tt.want)
+			}
+			c := codigos(got)
+			for _, w := range tt.want {
+				if !c[w] {
+					t.Errorf("falta la advertencia %s en %v", w, got)
+				}
+			}
+		})
+	}
+}
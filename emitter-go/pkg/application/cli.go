@@ -3,32 +3,87 @@ package application
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
 )
 
+// Algoritmos lista los algoritmos de enlace que acepta la configuración
+var Algoritmos = []string{"crc", "hamming", "auto-hamming", "fletcher", "parity2d", "hamming-secded", "repetition", "rs", "golay"}
+
+// AlgoritmoValido indica si algorithm está en Algoritmos
+func AlgoritmoValido(algorithm string) bool {
+	for _, a := range Algoritmos {
+		if a == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
 // MessageConfig contiene la configuración del mensaje a enviar
 type MessageConfig struct {
-	Text      string  // Mensaje de texto a enviar
-	Algorithm string  // "crc" o "hamming"
-	BER       float64 // Bit Error Rate (0.0 to 1.0)
-	Mode      string  // "manual" o "benchmark"
-	Count     int     // Número de iteraciones para benchmark
+	Text      string        // Mensaje de texto a enviar
+	Algorithm string        // "crc", "hamming", "auto-hamming", "fletcher", "parity2d", "hamming-secded", "repetition", "rs" o "golay"
+	BER       float64       // Bit Error Rate (0.0 to 1.0)
+	Mode      string        // "manual" o "benchmark"
+	Count     int           // Número de iteraciones para benchmark
+	Duration  time.Duration // Duración del benchmark (alternativa a Count)
 }
 
 // ApplicationLayer maneja la interacción con el usuario
 type ApplicationLayer struct {
-	scanner *bufio.Scanner
+	scanner  *bufio.Scanner
+	out      io.Writer
+	msgs     *i18n.Catalogo
+	duracion time.Duration
 }
 
-// NewApplicationLayer crea una nueva instancia
+// NewApplicationLayer crea una nueva instancia que lee de stdin y escribe en stdout
 func NewApplicationLayer() *ApplicationLayer {
+	return NewApplicationLayerIO(os.Stdin, os.Stdout)
+}
+
+// NewApplicationLayerIO crea una instancia que lee las respuestas de in y
+// escribe prompts y resúmenes en out
+func NewApplicationLayerIO(in io.Reader, out io.Writer) *ApplicationLayer {
 	return &ApplicationLayer{
-		scanner: bufio.NewScanner(os.Stdin),
+		scanner: bufio.NewScanner(in),
+		out:     out,
 	}
 }
 
+// FijarCatalogo elige el idioma de los textos que ve el usuario; sin
+// catálogo se usa el idioma por defecto
+func (app *ApplicationLayer) FijarCatalogo(c *i18n.Catalogo) {
+	app.msgs = c
+}
+
+// Catalogo devuelve el catálogo de textos en uso (nil si es el por defecto)
+func (app *ApplicationLayer) Catalogo() *i18n.Catalogo {
+	return app.msgs
+}
+
+// imprimir escribe el texto de clave, formateado con args
+func (app *ApplicationLayer) imprimir(clave string, args ...interface{}) {
+	fmt.Fprint(app.out, app.msgs.T(clave, args...))
+}
+
+// imprimirLinea escribe el texto de clave seguido de un salto de línea
+func (app *ApplicationLayer) imprimirLinea(clave string) {
+	fmt.Fprintln(app.out, app.msgs.T(clave))
+}
+
+// FijarDuracion hace que el benchmark corra durante d en lugar de pedir la
+// cantidad de iteraciones
+func (app *ApplicationLayer) FijarDuracion(d time.Duration) {
+	app.duracion = d
+}
+
 // SolicitarMensaje solicita entrada del usuario según el modo
 func (app *ApplicationLayer) SolicitarMensaje(mode string) (*MessageConfig, error) {
 	switch mode {
@@ -46,7 +101,7 @@ func (app *ApplicationLayer) solicitarMensajeManual() (*MessageConfig, error) {
 	config := &MessageConfig{Mode: "manual", Count: 1}
 
 	// Solicitar mensaje
-	fmt.Print("Ingrese el mensaje a transmitir: ")
+	app.imprimir("app.prompt.mensaje")
 	if !app.scanner.Scan() {
 		return nil, fmt.Errorf("error leyendo mensaje")
 	}
@@ -57,7 +112,7 @@ func (app *ApplicationLayer) solicitarMensajeManual() (*MessageConfig, error) {
 
 	// Solicitar algoritmo
 	for {
-		fmt.Print("Seleccione algoritmo (1=CRC-32, 2=Hamming(7,4)): ")
+		app.imprimir("app.prompt.algoritmo")
 		if !app.scanner.Scan() {
 			return nil, fmt.Errorf("error leyendo algoritmo")
 		}
@@ -68,8 +123,22 @@ func (app *ApplicationLayer) solicitarMensajeManual() (*MessageConfig, error) {
 			config.Algorithm = "crc"
 		case "2", "hamming":
 			config.Algorithm = "hamming"
+		case "3", "auto-hamming":
+			config.Algorithm = "auto-hamming"
+		case "4", "fletcher":
+			config.Algorithm = "fletcher"
+		case "5", "parity2d":
+			config.Algorithm = "parity2d"
+		case "6", "hamming-secded":
+			config.Algorithm = "hamming-secded"
+		case "7", "repetition":
+			config.Algorithm = "repetition"
+		case "8", "rs":
+			config.Algorithm = "rs"
+		case "9", "golay":
+			config.Algorithm = "golay"
 		default:
-			fmt.Println("❌ Opción inválida. Ingrese 1 para CRC-32 o 2 para Hamming(7,4)")
+			app.imprimirLinea("app.pista.algoritmo")
 			continue
 		}
 		break
@@ -77,7 +146,7 @@ func (app *ApplicationLayer) solicitarMensajeManual() (*MessageConfig, error) {
 
 	// Solicitar BER
 	for {
-		fmt.Print("Ingrese BER (0.0-0.1, ej: 0.01): ")
+		app.imprimir("app.prompt.ber")
 		if !app.scanner.Scan() {
 			return nil, fmt.Errorf("error leyendo BER")
 		}
@@ -85,11 +154,11 @@ func (app *ApplicationLayer) solicitarMensajeManual() (*MessageConfig, error) {
 		berStr := strings.TrimSpace(app.scanner.Text())
 		ber, err := strconv.ParseFloat(berStr, 64)
 		if err != nil {
-			fmt.Println("❌ BER inválido. Ingrese un número decimal (ej: 0.01)")
+			app.imprimirLinea("app.pista.ber_formato")
 			continue
 		}
 		if ber < 0.0 || ber > 1.0 {
-			fmt.Println("❌ BER debe estar entre 0.0 y 1.0")
+			app.imprimirLinea("app.pista.ber_rango")
 			continue
 		}
 		config.BER = ber
@@ -104,7 +173,7 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 	config := &MessageConfig{Mode: "benchmark"}
 
 	// Solicitar configuración de benchmark
-	fmt.Print("Mensaje base para benchmark [Hello World]: ")
+	app.imprimir("app.prompt.mensaje_benchmark")
 	if !app.scanner.Scan() {
 		return nil, fmt.Errorf("error leyendo mensaje")
 	}
@@ -115,7 +184,7 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 
 	// Algoritmo para benchmark
 	for {
-		fmt.Print("Algoritmo para benchmark (1=CRC-32, 2=Hamming(7,4), 3=Ambos): ")
+		app.imprimir("app.prompt.algoritmo_benchmark")
 		if !app.scanner.Scan() {
 			return nil, fmt.Errorf("error leyendo algoritmo")
 		}
@@ -127,9 +196,21 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 		case "2":
 			config.Algorithm = "hamming"
 		case "3":
-			config.Algorithm = "both"
+			config.Algorithm = "auto-hamming"
+		case "4":
+			config.Algorithm = "fletcher"
+		case "5":
+			config.Algorithm = "parity2d"
+		case "6":
+			config.Algorithm = "hamming-secded"
+		case "7":
+			config.Algorithm = "repetition"
+		case "8":
+			config.Algorithm = "rs"
+		case "9":
+			config.Algorithm = "golay"
 		default:
-			fmt.Println("❌ Opción inválida")
+			app.imprimirLinea("app.pista.opcion")
 			continue
 		}
 		break
@@ -137,7 +218,7 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 
 	// BER para benchmark
 	for {
-		fmt.Print("BER para benchmark [0.01]: ")
+		app.imprimir("app.prompt.ber_benchmark")
 		if !app.scanner.Scan() {
 			return nil, fmt.Errorf("error leyendo BER")
 		}
@@ -150,20 +231,26 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 
 		ber, err := strconv.ParseFloat(berStr, 64)
 		if err != nil {
-			fmt.Println("❌ BER inválido")
+			app.imprimirLinea("app.pista.ber_invalido")
 			continue
 		}
 		if ber < 0.0 || ber > 1.0 {
-			fmt.Println("❌ BER debe estar entre 0.0 y 1.0")
+			app.imprimirLinea("app.pista.ber_rango")
 			continue
 		}
 		config.BER = ber
 		break
 	}
 
+	// Con duración fija no se pide cantidad de iteraciones
+	if app.duracion > 0 {
+		config.Duration = app.duracion
+		return config, nil
+	}
+
 	// Cantidad de iteraciones
 	for {
-		fmt.Print("Número de iteraciones [1000]: ")
+		app.imprimir("app.prompt.iteraciones")
 		if !app.scanner.Scan() {
 			return nil, fmt.Errorf("error leyendo cantidad")
 		}
@@ -176,11 +263,11 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 
 		count, err := strconv.Atoi(countStr)
 		if err != nil {
-			fmt.Println("❌ Cantidad inválida")
+			app.imprimirLinea("app.pista.cantidad")
 			continue
 		}
 		if count <= 0 {
-			fmt.Println("❌ La cantidad debe ser mayor a 0")
+			app.imprimirLinea("app.pista.cantidad_positiva")
 			continue
 		}
 		config.Count = count
@@ -192,48 +279,52 @@ func (app *ApplicationLayer) solicitarMensajeBenchmark() (*MessageConfig, error)
 
 // MostrarConfiguracion muestra la configuración seleccionada
 func (app *ApplicationLayer) MostrarConfiguracion(config *MessageConfig) {
-	fmt.Println("\n📋 Configuración:")
-	fmt.Printf("   Mensaje: \"%s\"\n", config.Text)
-	fmt.Printf("   Algoritmo: %s\n", strings.ToUpper(config.Algorithm))
-	fmt.Printf("   BER: %.3f (%.1f%%)\n", config.BER, config.BER*100)
-	fmt.Printf("   Modo: %s\n", config.Mode)
+	app.imprimirLinea("app.config.titulo")
+	app.imprimir("app.config.mensaje", config.Text)
+	app.imprimir("app.config.algoritmo", strings.ToUpper(config.Algorithm))
+	app.imprimir("app.config.ber", config.BER, config.BER*100)
+	app.imprimir("app.config.modo", config.Mode)
 	if config.Mode == "benchmark" {
-		fmt.Printf("   Iteraciones: %d\n", config.Count)
+		if config.Duration > 0 {
+			app.imprimir("app.config.duracion", config.Duration)
+		} else {
+			app.imprimir("app.config.iteraciones", config.Count)
+		}
 	}
-	fmt.Println()
+	fmt.Fprintln(app.out)
 }
 
 // MostrarResultado muestra el resultado de la transmisión
 func (app *ApplicationLayer) MostrarResultado(success bool, details string) {
 	if success {
-		fmt.Printf("✅ Transmisión exitosa: %s\n", details)
+		app.imprimir("app.resultado.exito", details)
 	} else {
-		fmt.Printf("❌ Error en transmisión: %s\n", details)
+		app.imprimir("app.resultado.error", details)
 	}
 }
 
 // MostrarEstadisticas muestra estadísticas de benchmark
 func (app *ApplicationLayer) MostrarEstadisticas(stats map[string]interface{}) {
-	fmt.Println("\n📊 Estadísticas de Benchmark:")
-	fmt.Println("─────────────────────────────")
+	app.imprimirLinea("app.estadisticas.titulo")
+	fmt.Fprintln(app.out, "─────────────────────────────")
 
 	if total, ok := stats["total"].(int); ok {
-		fmt.Printf("Total de mensajes: %d\n", total)
+		app.imprimir("app.estadisticas.total", total)
 	}
 	if successful, ok := stats["successful"].(int); ok {
-		fmt.Printf("Exitosos: %d\n", successful)
+		app.imprimir("app.estadisticas.exitosos", successful)
 	}
 	if failed, ok := stats["failed"].(int); ok {
-		fmt.Printf("Fallidos: %d\n", failed)
+		app.imprimir("app.estadisticas.fallidos", failed)
 	}
 	if successRate, ok := stats["success_rate"].(float64); ok {
-		fmt.Printf("Tasa de éxito: %.2f%%\n", successRate*100)
+		app.imprimir("app.estadisticas.tasa", successRate*100)
 	}
 	if avgTime, ok := stats["avg_time"].(float64); ok {
-		fmt.Printf("Tiempo promedio: %.2fms\n", avgTime*1000)
+		app.imprimir("app.estadisticas.tiempo", avgTime*1000)
 	}
 
-	fmt.Println()
+	fmt.Fprintln(app.out)
 }
 
 // ValidarConfiguracion valida que la configuración sea válida
@@ -246,7 +337,7 @@ func (app *ApplicationLayer) ValidarConfiguracion(config *MessageConfig) error {
 		return fmt.Errorf("el mensaje no puede estar vacío")
 	}
 
-	if config.Algorithm != "crc" && config.Algorithm != "hamming" && config.Algorithm != "both" {
+	if !AlgoritmoValido(config.Algorithm) {
 		return fmt.Errorf("algoritmo inválido: %s", config.Algorithm)
 	}
 
@@ -254,8 +345,16 @@ func (app *ApplicationLayer) ValidarConfiguracion(config *MessageConfig) error {
 		return fmt.Errorf("BER inválido: %.3f (debe estar entre 0.0 y 1.0)", config.BER)
 	}
 
-	if config.Mode == "benchmark" && config.Count <= 0 {
-		return fmt.Errorf("cantidad de iteraciones inválida: %d", config.Count)
+	if config.Mode == "benchmark" {
+		if config.Duration < 0 {
+			return fmt.Errorf("duración inválida: %v", config.Duration)
+		}
+		if config.Duration > 0 && config.Count > 0 {
+			return fmt.Errorf("cantidad de iteraciones y duración son mutuamente excluyentes")
+		}
+		if config.Duration == 0 && config.Count <= 0 {
+			return fmt.Errorf("cantidad de iteraciones inválida: %d", config.Count)
+		}
 	}
 
 	return nil
@@ -1,8 +1,12 @@
 package application
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
 )
 
 func TestMessageConfig_Validation(t *testing.T) {
@@ -86,351 +90,106 @@ func TestMessageConfig_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := app.ValidarConfiguracion(tt.config)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidarConfiguracion() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-// pkg/presentation/ascii_test.go
-package presentation
-
-import (
-	"reflect"
-	"testing"
-)
-
-func TestPresentationLayer_CodificarMensaje(t *testing.T) {
-	p := NewPresentationLayer()
-
-	tests := []struct {
-		name    string
-		input   string
-		want    []byte
-		wantErr bool
-	}{
-		{
-			name:  "single character A",
-			input: "A",
-			want:  []byte{0, 1, 0, 0, 0, 0, 0, 1}, // ASCII 65 = 01000001
-		},
 		{
-			name:  "simple text Hi",
-			input: "Hi",
-			want: []byte{
-				0, 1, 0, 0, 1, 0, 0, 0, // H = 72 = 01001000
-				0, 1, 1, 0, 1, 0, 0, 1, // i = 105 = 01101001
+			name: "benchmark with duration",
+			config: &MessageConfig{
+				Text:      "Hello",
+				Algorithm: "crc",
+				BER:       0.01,
+				Mode:      "benchmark",
+				Duration:  10 * time.Minute,
 			},
+			wantErr: false,
 		},
 		{
-			name:    "empty string",
-			input:   "",
+			name: "benchmark with count and duration",
+			config: &MessageConfig{
+				Text:      "Hello",
+				Algorithm: "crc",
+				BER:       0.01,
+				Mode:      "benchmark",
+				Count:     100,
+				Duration:  time.Minute,
+			},
 			wantErr: true,
 		},
 		{
-			name:    "non-ASCII character",
-			input:   "Hölá",
+			name: "benchmark with negative duration",
+			config: &MessageConfig{
+				Text:      "Hello",
+				Algorithm: "crc",
+				BER:       0.01,
+				Mode:      "benchmark",
+				Duration:  -time.Second,
+			},
 			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := p.CodificarMensaje(tt.input)
+			err := app.ValidarConfiguracion(tt.config)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("CodificarMensaje() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("CodificarMensaje() = %v, want %v", got, tt.want)
+				t.Errorf("ValidarConfiguracion() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestPresentationLayer_DecodificarMensaje(t *testing.T) {
-	p := NewPresentationLayer()
-
+func TestSolicitarMensaje_Idioma(t *testing.T) {
 	tests := []struct {
-		name    string
-		input   []byte
-		want    string
-		wantErr bool
+		idioma string
+		prompt string
+		pista  string
 	}{
-		{
-			name:  "single character A",
-			input: []byte{0, 1, 0, 0, 0, 0, 0, 1}, // ASCII 65
-			want:  "A",
-		},
-		{
-			name: "simple text Hi",
-			input: []byte{
-				0, 1, 0, 0, 1, 0, 0, 0, // H = 72
-				0, 1, 1, 0, 1, 0, 0, 1, // i = 105
-			},
-			want: "Hi",
-		},
-		{
-			name:    "invalid length",
-			input:   []byte{0, 1, 0}, // Not multiple of 8
-			wantErr: true,
-		},
-		{
-			name:    "invalid bit value",
-			input:   []byte{0, 1, 0, 2, 0, 0, 0, 1}, // Contains '2'
-			wantErr: true,
-		},
+		{"es", "Ingrese el mensaje a transmitir: ", "❌ Opción inválida. Ingrese 1 para CRC-32"},
+		{"en", "Enter the message to transmit: ", "❌ Invalid option. Enter 1 for CRC-32"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := p.DecodificarMensaje(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DecodificarMensaje() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr && got != tt.want {
-				t.Errorf("DecodificarMensaje() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestPresentationLayer_RoundTrip(t *testing.T) {
-	p := NewPresentationLayer()
-	
-	testMessages := []string{
-		"Hello World!",
-		"Test123",
-		"ASCII only text",
-		"Special chars: !@#$%^&*()",
-	}
-
-	for _, original := range testMessages {
-		t.Run(original, func(t *testing.T) {
-			// Encode
-			bits, err := p.CodificarMensaje(original)
+		t.Run(tt.idioma, func(t *testing.T) {
+			var out bytes.Buffer
+			app := NewApplicationLayerIO(strings.NewReader("Hola\n0\n1\n0.01\n"), &out)
+			msgs, err := i18n.Nuevo(tt.idioma)
 			if err != nil {
-				t.Fatalf("CodificarMensaje() failed: %v", err)
+				t.Fatal(err)
 			}
+			app.FijarCatalogo(msgs)
 
-			// Decode
-			decoded, err := p.DecodificarMensaje(bits)
+			config, err := app.SolicitarMensaje("manual")
 			if err != nil {
-				t.Fatalf("DecodificarMensaje() failed: %v", err)
-			}
-
-			if decoded != original {
-				t.Errorf("Round trip failed: got %q, want %q", decoded, original)
+				t.Fatalf("SolicitarMensaje falló: %v", err)
 			}
-		})
-	}
-}
-
-// pkg/noise/ber_test.go
-package noise
-
-import (
-	"testing"
-)
-
-func TestNoiseLayer_AplicarRuido(t *testing.T) {
-	n := NewNoiseLayerWithSeed(12345) // Semilla fija para tests reproducibles
-
-	tests := []struct {
-		name    string
-		bits    []byte
-		ber     float64
-		wantErr bool
-	}{
-		{
-			name: "zero BER",
-			bits: []byte{0, 1, 0, 1, 1, 0, 1, 0},
-			ber:  0.0,
-		},
-		{
-			name: "low BER",
-			bits: []byte{0, 1, 0, 1, 1, 0, 1, 0},
-			ber:  0.01,
-		},
-		{
-			name: "high BER",
-			bits: []byte{0, 1, 0, 1},
-			ber:  0.5,
-		},
-		{
-			name:    "invalid BER - negative",
-			bits:    []byte{0, 1},
-			ber:     -0.1,
-			wantErr: true,
-		},
-		{
-			name:    "invalid BER - too high",
-			bits:    []byte{0, 1},
-			ber:     1.5,
-			wantErr: true,
-		},
-		{
-			name:    "invalid bits",
-			bits:    []byte{0, 1, 2, 1}, // Contains '2'
-			ber:     0.01,
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := n.AplicarRuido(tt.bits, tt.ber)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("AplicarRuido() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			if config.Text != "Hola" || config.Algorithm != "crc" || config.BER != 0.01 {
+				t.Errorf("configuración inesperada: %+v", config)
 			}
-
-			if !tt.wantErr {
-				// Verificar que el resultado tiene la estructura correcta
-				if len(result.OriginalBits) != len(tt.bits) {
-					t.Errorf("OriginalBits length = %d, want %d", len(result.OriginalBits), len(tt.bits))
-				}
-				if len(result.NoisyBits) != len(tt.bits) {
-					t.Errorf("NoisyBits length = %d, want %d", len(result.NoisyBits), len(tt.bits))
-				}
-				if result.TotalBits != len(tt.bits) {
-					t.Errorf("TotalBits = %d, want %d", result.TotalBits, len(tt.bits))
-				}
-				if result.ErrorsInjected != len(result.ErrorPositions) {
-					t.Errorf("ErrorsInjected = %d, but ErrorPositions length = %d", 
-						result.ErrorsInjected, len(result.ErrorPositions))
-				}
-
-				// Para BER=0, no debe haber errores
-				if tt.ber == 0.0 && result.ErrorsInjected != 0 {
-					t.Errorf("With BER=0, expected 0 errors, got %d", result.ErrorsInjected)
-				}
-
-				// Verificar que los bits son válidos
-				for i, bit := range result.NoisyBits {
-					if bit != 0 && bit != 1 {
-						t.Errorf("Invalid bit at position %d: %d", i, bit)
-					}
-				}
+			if !strings.HasPrefix(out.String(), tt.prompt) {
+				t.Errorf("prompt inesperado: %q", out.String())
 			}
-		})
-	}
-}
-
-func TestNoiseLayer_ValidarConfiguracion(t *testing.T) {
-	n := NewNoiseLayer()
-
-	tests := []struct {
-		name    string
-		ber     float64
-		bits    []byte
-		wantErr bool
-	}{
-		{
-			name: "valid config",
-			ber:  0.01,
-			bits: []byte{0, 1, 0, 1},
-		},
-		{
-			name:    "invalid BER",
-			ber:     -0.1,
-			bits:    []byte{0, 1},
-			wantErr: true,
-		},
-		{
-			name:    "empty bits",
-			ber:     0.01,
-			bits:    []byte{},
-			wantErr: true,
-		},
-		{
-			name:    "invalid bits",
-			ber:     0.01,
-			bits:    []byte{0, 1, 3},
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := n.ValidarConfiguracion(tt.ber, tt.bits)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidarConfiguracion() error = %v, wantErr %v", err, tt.wantErr)
+			if !strings.Contains(out.String(), tt.pista) {
+				t.Errorf("falta la pista de opción inválida en %q", out.String())
 			}
 		})
 	}
 }
 
-func TestNoiseLayer_ConsistentSeed(t *testing.T) {
-	seed := int64(12345)
-	bits := []byte{0, 1, 0, 1, 1, 0, 1, 0, 1, 1, 0, 0, 1, 0, 1, 1}
-	ber := 0.2
-
-	// Crear dos instancias con la misma semilla
-	n1 := NewNoiseLayerWithSeed(seed)
-	n2 := NewNoiseLayerWithSeed(seed)
-
-	// Aplicar ruido con ambas instancias
-	result1, err1 := n1.AplicarRuido(bits, ber)
-	if err1 != nil {
-		t.Fatalf("First AplicarRuido failed: %v", err1)
-	}
-
-	result2, err2 := n2.AplicarRuido(bits, ber)
-	if err2 != nil {
-		t.Fatalf("Second AplicarRuido failed: %v", err2)
-	}
+func TestAdvertirConfiguracion_Idioma(t *testing.T) {
+	config := &MessageConfig{Text: "Hola", Algorithm: "crc", BER: 0.01, Mode: "benchmark", Count: 10}
+	app := NewApplicationLayerIO(strings.NewReader(""), &bytes.Buffer{})
+	msgs, _ := i18n.Nuevo("en")
+	app.FijarCatalogo(msgs)
 
-	// Los resultados deben ser idénticos
-	if result1.ErrorsInjected != result2.ErrorsInjected {
-		t.Errorf("ErrorsInjected differ: %d vs %d", result1.ErrorsInjected, result2.ErrorsInjected)
+	advertencias := app.AdvertirConfiguracion(config)
+	if len(advertencias) == 0 {
+		t.Fatal("se esperaba al menos una advertencia")
 	}
-
-	if len(result1.ErrorPositions) != len(result2.ErrorPositions) {
-		t.Errorf("ErrorPositions length differ: %d vs %d", 
-			len(result1.ErrorPositions), len(result2.ErrorPositions))
-	}
-
-	for i, pos := range result1.ErrorPositions {
-		if pos != result2.ErrorPositions[i] {
-			t.Errorf("ErrorPosition[%d] differ: %d vs %d", i, pos, result2.ErrorPositions[i])
+	porDefecto := AdvertirConfiguracion(config)
+	for i, a := range advertencias {
+		if a.Codigo != porDefecto[i].Codigo {
+			t.Errorf("los códigos no deben depender del idioma: %s vs %s", a.Codigo, porDefecto[i].Codigo)
 		}
-	}
-}
-
-// Benchmark para evaluar performance
-func BenchmarkNoiseLayer_AplicarRuido(b *testing.B) {
-	n := NewNoiseLayer()
-	bits := make([]byte, 1000) // 1KB de bits
-	for i := range bits {
-		bits[i] = byte(i % 2) // Patrón alternante
-	}
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := n.AplicarRuido(bits, 0.01)
-		if err != nil {
-			b.Fatalf("AplicarRuido failed: %v", err)
+		if a.Mensaje == porDefecto[i].Mensaje {
+			t.Errorf("el mensaje %s debería estar en inglés: %s", a.Codigo, a.Mensaje)
 		}
 	}
 }
-
-func BenchmarkPresentationLayer_CodificarMensaje(b *testing.B) {
-	p := NewPresentationLayer()
-	mensaje := strings.Repeat("Hello World! ", 100) // ~1.3KB texto
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := p.CodificarMensaje(mensaje)
-		if err != nil {
-			b.Fatalf("CodificarMensaje failed: %v", err)
-		}
-	}
-}
\ No newline at end of file
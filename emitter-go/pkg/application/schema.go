@@ -0,0 +1,56 @@
+package application
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/schema"
+)
+
+// descripcionCampo documenta un campo de MessageConfig para el schema
+type descripcionCampo struct {
+	nombre      string
+	ayuda       string
+	restriccion schema.Restriccion
+}
+
+// camposConfiguracion documenta cada campo de MessageConfig. El test de este
+// archivo verifica que no falte ningún campo del struct.
+var camposConfiguracion = map[string]descripcionCampo{
+	"Text":      {"text", "Mensaje de texto a enviar (no vacío)", schema.Restriccion{}},
+	"Algorithm": {"algorithm", "Algoritmo de enlace", schema.Valores(Algoritmos)},
+	"BER":       {"ber", "Bit Error Rate del canal ruidoso", schema.Rango(0, 1)},
+	"Mode":      {"mode", "Modo de operación que originó la configuración", schema.Restriccion{}},
+	"Count":     {"count", "Iteraciones del benchmark (excluyente con duration)", schema.Minimo(1)},
+	"Duration":  {"duration", "Duración del benchmark (excluyente con count)", schema.Minimo(0)},
+}
+
+// CamposConfiguracion describe los campos de MessageConfig en el orden en que
+// aparecen en el struct
+func CamposConfiguracion() []schema.Campo {
+	t := reflect.TypeOf(MessageConfig{})
+	campos := make([]schema.Campo, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		d, ok := camposConfiguracion[f.Name]
+		if !ok {
+			d = descripcionCampo{nombre: f.Name}
+		}
+		c := schema.Campo{
+			Name:    d.nombre,
+			Type:    tipoCampo(f.Type),
+			Default: fmt.Sprint(reflect.Zero(f.Type).Interface()),
+			Help:    d.ayuda,
+		}
+		d.restriccion.Aplicar(&c)
+		campos = append(campos, c)
+	}
+	return campos
+}
+
+func tipoCampo(t reflect.Type) string {
+	if t.PkgPath() == "time" && t.Name() == "Duration" {
+		return "duration"
+	}
+	return t.String()
+}
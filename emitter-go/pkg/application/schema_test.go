@@ -0,0 +1,28 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCamposConfiguracion_CubreMessageConfig(t *testing.T) {
+	tipo := reflect.TypeOf(MessageConfig{})
+	for i := 0; i < tipo.NumField(); i++ {
+		if _, ok := camposConfiguracion[tipo.Field(i).Name]; !ok {
+			t.Errorf("el campo %s de MessageConfig no está documentado en el schema", tipo.Field(i).Name)
+		}
+	}
+
+	campos := CamposConfiguracion()
+	if len(campos) != tipo.NumField() {
+		t.Fatalf("se esperaban %d campos, se obtuvieron %d", tipo.NumField(), len(campos))
+	}
+	for _, c := range campos {
+		if c.Name == "algorithm" && !reflect.DeepEqual(c.Allowed, Algoritmos) {
+			t.Errorf("algorithm debería permitir %v, permite %v", Algoritmos, c.Allowed)
+		}
+		if c.Name == "duration" && c.Type != "duration" {
+			t.Errorf("duration tiene tipo %q", c.Type)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstrae el paso del tiempo para que la lógica dependiente de plazos
+// pueda probarse sin esperas reales
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker es el subconjunto de time.Ticker que usan los llamadores
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real devuelve el reloj del sistema
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// Manual es un reloj que solo avanza cuando el test llama a Advance. Los
+// canales de After y de los tickers se disparan durante Advance.
+type Manual struct {
+	mu      sync.Mutex
+	now     time.Time
+	esperas []*espera
+	tickers []*manualTicker
+}
+
+type espera struct {
+	hasta time.Time
+	c     chan time.Time
+}
+
+// NewManual crea un reloj manual detenido en inicio
+func NewManual(inicio time.Time) *Manual {
+	return &Manual{now: inicio}
+}
+
+// Now devuelve la hora virtual actual
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Since devuelve el tiempo virtual transcurrido desde t
+func (m *Manual) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+// After devuelve un canal que recibe la hora virtual cuando el reloj avance d
+func (m *Manual) After(d time.Duration) <-chan time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- m.now
+		return c
+	}
+	m.esperas = append(m.esperas, &espera{hasta: m.now.Add(d), c: c})
+	return c
+}
+
+// NewTicker crea un ticker que se dispara cada d de tiempo virtual. Igual que
+// time.Ticker, descarta ticks si el receptor no los consume.
+func (m *Manual) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: período no positivo en NewTicker")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := &manualTicker{reloj: m, periodo: d, proximo: m.now.Add(d), c: make(chan time.Time, 1)}
+	m.tickers = append(m.tickers, t)
+	return t
+}
+
+// Advance adelanta el reloj d y dispara las esperas y ticks vencidos
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+
+	pendientes := m.esperas[:0]
+	for _, e := range m.esperas {
+		if e.hasta.After(m.now) {
+			pendientes = append(pendientes, e)
+			continue
+		}
+		e.c <- e.hasta
+	}
+	m.esperas = pendientes
+
+	for _, t := range m.tickers {
+		for !t.proximo.After(m.now) {
+			select {
+			case t.c <- t.proximo:
+			default:
+			}
+			t.proximo = t.proximo.Add(t.periodo)
+		}
+	}
+}
+
+type manualTicker struct {
+	reloj   *Manual
+	periodo time.Duration
+	proximo time.Time
+	c       chan time.Time
+}
+
+func (t *manualTicker) C() <-chan time.Time { return t.c }
+
+func (t *manualTicker) Stop() {
+	m := t.reloj
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, otro := range m.tickers {
+		if otro == t {
+			m.tickers = append(m.tickers[:i], m.tickers[i+1:]...)
+			return
+		}
+	}
+}
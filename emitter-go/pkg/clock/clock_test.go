@@ -0,0 +1,85 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var inicio = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestManual_After(t *testing.T) {
+	m := NewManual(inicio)
+	c := m.After(10 * time.Second)
+
+	m.Advance(9 * time.Second)
+	select {
+	case <-c:
+		t.Fatal("After se disparó antes de tiempo")
+	default:
+	}
+
+	m.Advance(time.Second)
+	select {
+	case got := <-c:
+		if !got.Equal(inicio.Add(10 * time.Second)) {
+			t.Errorf("After entregó %v", got)
+		}
+	default:
+		t.Fatal("After no se disparó al vencer el plazo")
+	}
+}
+
+func TestManual_AfterSinEspera(t *testing.T) {
+	m := NewManual(inicio)
+	select {
+	case <-m.After(0):
+	default:
+		t.Fatal("After(0) debería dispararse de inmediato")
+	}
+}
+
+func TestManual_Ticker(t *testing.T) {
+	m := NewManual(inicio)
+	tk := m.NewTicker(time.Second)
+
+	ticks := 0
+	for i := 0; i < 5; i++ {
+		m.Advance(time.Second)
+		select {
+		case <-tk.C():
+			ticks++
+		default:
+		}
+	}
+	if ticks != 5 {
+		t.Errorf("se esperaban 5 ticks, se obtuvieron %d", ticks)
+	}
+
+	// Como time.Ticker, los ticks no consumidos se descartan
+	m.Advance(3 * time.Second)
+	<-tk.C()
+	select {
+	case <-tk.C():
+		t.Error("el ticker no debería acumular más de un tick")
+	default:
+	}
+
+	tk.Stop()
+	m.Advance(time.Second)
+	select {
+	case <-tk.C():
+		t.Error("un ticker detenido no debería dispararse")
+	default:
+	}
+}
+
+func TestManual_NowYSince(t *testing.T) {
+	m := NewManual(inicio)
+	m.Advance(1500 * time.Millisecond)
+	if !m.Now().Equal(inicio.Add(1500 * time.Millisecond)) {
+		t.Errorf("Now = %v", m.Now())
+	}
+	if m.Since(inicio) != 1500*time.Millisecond {
+		t.Errorf("Since = %v", m.Since(inicio))
+	}
+}
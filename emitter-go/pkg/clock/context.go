@@ -0,0 +1,53 @@
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout es context.WithTimeout medido con c: con el reloj real es
+// exactamente context.WithTimeout; con otro reloj el contexto vence cuando c
+// avanza d, y Deadline y Err lo reflejan igual que un contexto con plazo.
+// Los deadlines de sockets que se derivan de Deadline siguen siendo de
+// tiempo real, así que con un reloj manual solo sirven con transportes
+// simulados.
+func WithTimeout(parent context.Context, c Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := c.(realClock); ok {
+		return context.WithTimeout(parent, d)
+	}
+
+	limite := c.Now().Add(d)
+	vence := c.After(d)
+	ctx, cancel := context.WithCancelCause(parent)
+	go func() {
+		select {
+		case <-vence:
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+
+	p := &plazoCtx{Context: ctx, limite: limite}
+	if d, ok := parent.Deadline(); ok && d.Before(limite) {
+		p.limite = d
+	}
+	return p, func() { cancel(context.Canceled) }
+}
+
+// plazoCtx agrega a un contexto cancelable el plazo de WithTimeout
+type plazoCtx struct {
+	context.Context
+	limite time.Time
+}
+
+func (p *plazoCtx) Deadline() (time.Time, bool) { return p.limite, true }
+
+// Err devuelve context.DeadlineExceeded si el contexto venció por el reloj,
+// como un contexto de context.WithTimeout
+func (p *plazoCtx) Err() error {
+	err := p.Context.Err()
+	if err != nil && context.Cause(p.Context) == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout_Manual(t *testing.T) {
+	m := NewManual(inicio)
+	ctx, cancel := WithTimeout(context.Background(), m, 50*time.Millisecond)
+	defer cancel()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(inicio.Add(50*time.Millisecond)) {
+		t.Errorf("Deadline = %v, %v", d, ok)
+	}
+	m.Advance(49 * time.Millisecond)
+	select {
+	case <-ctx.Done():
+		t.Fatal("el contexto venció antes de tiempo")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	m.Advance(time.Millisecond)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("el contexto no venció al avanzar el reloj")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err = %v, se esperaba DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestWithTimeout_Cancelar(t *testing.T) {
+	m := NewManual(inicio)
+	ctx, cancel := WithTimeout(context.Background(), m, time.Minute)
+	cancel()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Err = %v, se esperaba Canceled", ctx.Err())
+	}
+
+	// Un padre con plazo anterior acota el Deadline
+	padre, cancelPadre := WithTimeout(context.Background(), m, time.Second)
+	defer cancelPadre()
+	hijo, cancelHijo := WithTimeout(padre, m, time.Minute)
+	defer cancelHijo()
+	if d, _ := hijo.Deadline(); !d.Equal(inicio.Add(time.Second)) {
+		t.Errorf("Deadline = %v, se esperaba el del padre", d)
+	}
+}
+
+func TestWithTimeout_Real(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), Real(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err = %v, se esperaba DeadlineExceeded", ctx.Err())
+	}
+}
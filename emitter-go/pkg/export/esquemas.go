@@ -0,0 +1,288 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Tipos de CSV con columnas versionadas
+const (
+	TipoCSVDistribucion = "csv-distribucion"
+	TipoCSVBER          = "csv-ber-iteracion"
+	TipoCSVTeoria       = "csv-teoria"
+	TipoCSVIteraciones  = "csv-iteraciones"
+)
+
+// VersionManifiesto es la versión del esquema del propio manifiesto
+const VersionManifiesto = 1
+
+// Columna de un esquema CSV. PorDefecto es el valor que recibe al migrar un
+// archivo de una versión que no la tenía.
+type Columna struct {
+	Nombre     string
+	PorDefecto string
+}
+
+// EsquemaCSV describe las columnas de una versión de un tipo de CSV
+type EsquemaCSV struct {
+	Tipo     string
+	Version  int
+	Columnas []Columna
+	// SinComentario marca las versiones anteriores al comentario de esquema,
+	// que se reconocen por su encabezado
+	SinComentario bool
+}
+
+// esquemasCSV lista las versiones conocidas de cada tipo, de la más vieja a la
+// actual. Salvo las marcadas SinComentario, la primera línea de los archivos
+// es "# schema=<tipo> version=<n>".
+var esquemasCSV = []EsquemaCSV{
+	{Tipo: TipoCSVDistribucion, Version: 1, Columnas: columnas("errores", "frecuencia"), SinComentario: true},
+	{Tipo: TipoCSVDistribucion, Version: 2, Columnas: columnas("errores", "frecuencia")},
+	{Tipo: TipoCSVBER, Version: 1, Columnas: columnas("iteracion", "ber"), SinComentario: true},
+	// v2 agrega los errores de cada iteración; -1 indica que el archivo
+	// original no los registraba
+	{Tipo: TipoCSVBER, Version: 2, Columnas: append(columnas("iteracion", "ber"), Columna{Nombre: "errores", PorDefecto: "-1"})},
+	// Probabilidades teóricas por BER (ver pkg/stats); las de Hamming quedan
+	// vacías en tramas crc
+	{Tipo: TipoCSVTeoria, Version: 1, Columnas: columnas("ber", "trama_intacta", "deteccion_crc", "bloque_recuperable", "payload_recuperable")},
+	// Resultado y costo de la trama de cada iteración del benchmark (ver
+	// frame.FrameStats); las columnas de costo quedan vacías en tramas sin
+	// estadísticas de código
+	{Tipo: TipoCSVIteraciones, Version: 1, Columnas: columnas("iteracion", "ber", "ber_real", "exito",
+		"bits_datos", "bytes_header", "bytes_subheader", "bytes_payload", "bytes_checksum", "bits_relleno", "tasa_codigo", "eficiencia")},
+}
+
+// columnas arma columnas sin valor por defecto
+func columnas(nombres ...string) []Columna {
+	out := make([]Columna, len(nombres))
+	for i, n := range nombres {
+		out[i] = Columna{Nombre: n}
+	}
+	return out
+}
+
+// BuscarEsquemaCSV devuelve la versión pedida de un tipo de CSV
+func BuscarEsquemaCSV(tipo string, version int) (EsquemaCSV, bool) {
+	for _, e := range esquemasCSV {
+		if e.Tipo == tipo && e.Version == version {
+			return e, true
+		}
+	}
+	return EsquemaCSV{}, false
+}
+
+// EsquemaCSVActual devuelve la versión con la que se escriben los CSV de tipo
+func EsquemaCSVActual(tipo string) (EsquemaCSV, bool) {
+	var actual EsquemaCSV
+	ok := false
+	for _, e := range esquemasCSV {
+		if e.Tipo == tipo && e.Version > actual.Version {
+			actual, ok = e, true
+		}
+	}
+	return actual, ok
+}
+
+// Nombres devuelve los nombres de las columnas en orden
+func (e EsquemaCSV) Nombres() []string {
+	nombres := make([]string, len(e.Columnas))
+	for i, c := range e.Columnas {
+		nombres[i] = c.Nombre
+	}
+	return nombres
+}
+
+// comentario es la primera línea de los CSV con comentario de esquema
+func (e EsquemaCSV) comentario() string {
+	return fmt.Sprintf("# schema=%s version=%d", e.Tipo, e.Version)
+}
+
+// Escribir escribe el comentario de esquema (salvo SinComentario), el
+// encabezado y las filas, que deben tener una celda por columna
+func (e EsquemaCSV) Escribir(w io.Writer, filas [][]string) error {
+	for i, f := range filas {
+		if len(f) != len(e.Columnas) {
+			return fmt.Errorf("fila %d: tiene %d campos, el esquema %s v%d tiene %d columnas",
+				i+1, len(f), e.Tipo, e.Version, len(e.Columnas))
+		}
+	}
+	if !e.SinComentario {
+		if _, err := fmt.Fprintln(w, e.comentario()); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(e.Nombres()); err != nil {
+		return err
+	}
+	return cw.WriteAll(filas)
+}
+
+// TablaCSV es un CSV leído y migrado a la versión actual de su tipo
+type TablaCSV struct {
+	Tipo            string
+	VersionOriginal int      // Versión con la que se escribió el archivo
+	Columnas        []string // Columnas de la versión actual
+	Filas           [][]string
+}
+
+// LeerCSV lee un CSV de cualquier versión conocida y lleva sus filas a las
+// columnas de la versión actual, completando las que falten con su valor por
+// defecto
+func LeerCSV(r io.Reader) (*TablaCSV, error) {
+	br := bufio.NewReader(r)
+	esquema, conComentario, err := detectarEsquema(br)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(br)
+	encabezado, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("encabezado ilegible: %v", err)
+	}
+	if !conComentario {
+		// Sin comentario el encabezado decide el tipo
+		var ok bool
+		if esquema, ok = esquemaSinComentario(encabezado); !ok {
+			return nil, fmt.Errorf("encabezado %q no corresponde a ningún CSV conocido sin comentario de esquema", strings.Join(encabezado, ","))
+		}
+	} else if err := compararColumnas(esquema.Nombres(), encabezado); err != nil {
+		return nil, fmt.Errorf("el encabezado no coincide con %s v%d: %v", esquema.Tipo, esquema.Version, err)
+	}
+
+	actual, _ := EsquemaCSVActual(esquema.Tipo)
+	origen := make(map[string]int, len(encabezado))
+	for i, c := range encabezado {
+		origen[c] = i
+	}
+
+	t := &TablaCSV{Tipo: esquema.Tipo, VersionOriginal: esquema.Version, Columnas: actual.Nombres()}
+	cr.FieldsPerRecord = len(encabezado)
+	for n := 2; ; n++ {
+		fila, err := cr.Read()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fila %d: %v", n, err)
+		}
+		migrada := make([]string, len(actual.Columnas))
+		for i, c := range actual.Columnas {
+			if j, ok := origen[c.Nombre]; ok {
+				migrada[i] = fila[j]
+			} else {
+				migrada[i] = c.PorDefecto
+			}
+		}
+		t.Filas = append(t.Filas, migrada)
+	}
+}
+
+// detectarEsquema consume el comentario de esquema si la primera línea lo es
+func detectarEsquema(br *bufio.Reader) (EsquemaCSV, bool, error) {
+	inicio, err := br.Peek(1)
+	if err != nil || inicio[0] != '#' {
+		return EsquemaCSV{}, false, nil
+	}
+	linea, err := br.ReadString('\n')
+	if err != nil {
+		return EsquemaCSV{}, false, fmt.Errorf("comentario de esquema sin terminar")
+	}
+
+	var tipo, version string
+	for _, campo := range strings.Fields(strings.TrimPrefix(strings.TrimSpace(linea), "#")) {
+		clave, valor, _ := strings.Cut(campo, "=")
+		switch clave {
+		case "schema":
+			tipo = valor
+		case "version":
+			version = valor
+		}
+	}
+	v, err := strconv.Atoi(version)
+	if tipo == "" || err != nil {
+		return EsquemaCSV{}, false, fmt.Errorf("comentario de esquema inválido: %q", strings.TrimSpace(linea))
+	}
+	esquema, ok := BuscarEsquemaCSV(tipo, v)
+	if !ok {
+		if actual, conocido := EsquemaCSVActual(tipo); conocido {
+			return EsquemaCSV{}, false, fmt.Errorf("%s v%d no soportado (versión máxima conocida: %d)", tipo, v, actual.Version)
+		}
+		return EsquemaCSV{}, false, fmt.Errorf("tipo de CSV desconocido: %s", tipo)
+	}
+	return esquema, true, nil
+}
+
+func esquemaSinComentario(encabezado []string) (EsquemaCSV, bool) {
+	for _, e := range esquemasCSV {
+		if e.SinComentario && compararColumnas(e.Nombres(), encabezado) == nil {
+			return e, true
+		}
+	}
+	return EsquemaCSV{}, false
+}
+
+// compararColumnas devuelve un error que lista las columnas que solo aparecen
+// en uno de los dos lados, o que difieren en orden
+func compararColumnas(esperadas, obtenidas []string) error {
+	faltan := diferencia(esperadas, obtenidas)
+	sobran := diferencia(obtenidas, esperadas)
+	if len(faltan) == 0 && len(sobran) == 0 {
+		if strings.Join(esperadas, ",") != strings.Join(obtenidas, ",") {
+			return fmt.Errorf("mismas columnas en otro orden: se esperaba %s, se obtuvo %s",
+				strings.Join(esperadas, ","), strings.Join(obtenidas, ","))
+		}
+		return nil
+	}
+	var partes []string
+	if len(faltan) > 0 {
+		partes = append(partes, "faltan "+strings.Join(faltan, ","))
+	}
+	if len(sobran) > 0 {
+		partes = append(partes, "sobran "+strings.Join(sobran, ","))
+	}
+	return fmt.Errorf("%s", strings.Join(partes, "; "))
+}
+
+// diferencia devuelve los elementos de a que no están en b, en el orden de a
+func diferencia(a, b []string) []string {
+	en := make(map[string]bool, len(b))
+	for _, x := range b {
+		en[x] = true
+	}
+	var out []string
+	for _, x := range a {
+		if !en[x] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// CombinarCSV concatena las filas de tablas ya migradas. Falla si alguna no
+// tiene las mismas columnas que la primera, indicando cuáles difieren.
+func CombinarCSV(tablas ...*TablaCSV) (*TablaCSV, error) {
+	if len(tablas) == 0 {
+		return nil, fmt.Errorf("no hay tablas para combinar")
+	}
+	base := tablas[0]
+	out := &TablaCSV{Tipo: base.Tipo, VersionOriginal: base.VersionOriginal, Columnas: base.Columnas}
+	for i, t := range tablas {
+		err := compararColumnas(base.Columnas, t.Columnas)
+		if err == nil && t.Tipo != base.Tipo {
+			err = fmt.Errorf("tipos distintos con las mismas columnas")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("no se puede combinar la tabla %d (%s v%d) con la 1 (%s v%d): %v",
+				i+1, t.Tipo, t.VersionOriginal, base.Tipo, base.VersionOriginal, err)
+		}
+		out.Filas = append(out.Filas, t.Filas...)
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func leerFixture(t *testing.T, nombre string) *TablaCSV {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", nombre))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tabla, err := LeerCSV(f)
+	if err != nil {
+		t.Fatalf("%s: %v", nombre, err)
+	}
+	return tabla
+}
+
+func TestLeerCSV_MigraVersiones(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		tipo     string
+		version  int
+		columnas string
+		primera  string
+	}{
+		{"ber_v1.csv", TipoCSVBER, 1, "iteracion,ber,errores", "0,0.062500,-1"},
+		{"ber_v2.csv", TipoCSVBER, 2, "iteracion,ber,errores", "0,0.031250,1"},
+		{"distribucion_v1.csv", TipoCSVDistribucion, 1, "errores,frecuencia", "0,4"},
+		{"distribucion_v2.csv", TipoCSVDistribucion, 2, "errores,frecuencia", "0,7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			tabla := leerFixture(t, tt.fixture)
+			if tabla.Tipo != tt.tipo || tabla.VersionOriginal != tt.version {
+				t.Errorf("detectado %s v%d, se esperaba %s v%d", tabla.Tipo, tabla.VersionOriginal, tt.tipo, tt.version)
+			}
+			if got := strings.Join(tabla.Columnas, ","); got != tt.columnas {
+				t.Errorf("columnas %s, se esperaban %s", got, tt.columnas)
+			}
+			if got := strings.Join(tabla.Filas[0], ","); got != tt.primera {
+				t.Errorf("primera fila %s, se esperaba %s", got, tt.primera)
+			}
+		})
+	}
+}
+
+func TestLeerCSV_Invalido(t *testing.T) {
+	tests := []struct {
+		name      string
+		contenido string
+		contiene  string
+	}{
+		{"encabezado desconocido", "a,b\n1,2\n", "no corresponde"},
+		{"versión futura", "# schema=csv-ber-iteracion version=9\niteracion,ber\n", "versión máxima conocida: 2"},
+		{"tipo desconocido", "# schema=csv-foo version=1\nx\n", "desconocido"},
+		{"comentario sin versión", "# schema=csv-ber-iteracion\niteracion,ber\n", "inválido"},
+		{"encabezado distinto al esquema", "# schema=csv-ber-iteracion version=2\niteracion,ber\n", "faltan errores"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LeerCSV(strings.NewReader(tt.contenido))
+			if err == nil || !strings.Contains(err.Error(), tt.contiene) {
+				t.Errorf("error %v, se esperaba que mencione %q", err, tt.contiene)
+			}
+		})
+	}
+}
+
+func TestCombinarCSV(t *testing.T) {
+	v1 := leerFixture(t, "ber_v1.csv")
+	v2 := leerFixture(t, "ber_v2.csv")
+
+	combinada, err := CombinarCSV(v1, v2)
+	if err != nil {
+		t.Fatalf("las versiones migrables deberían combinarse: %v", err)
+	}
+	if len(combinada.Filas) != len(v1.Filas)+len(v2.Filas) {
+		t.Errorf("%d filas, se esperaban %d", len(combinada.Filas), len(v1.Filas)+len(v2.Filas))
+	}
+
+	_, err = CombinarCSV(v2, leerFixture(t, "distribucion_v2.csv"))
+	if err == nil {
+		t.Fatal("combinar tipos distintos debería fallar")
+	}
+	for _, c := range []string{"faltan iteracion,ber", "sobran frecuencia"} {
+		if !strings.Contains(err.Error(), c) {
+			t.Errorf("el error debería listar %q: %v", c, err)
+		}
+	}
+}
+
+func TestEsquemaCSV_EscribirYLeer(t *testing.T) {
+	e, ok := EsquemaCSVActual(TipoCSVBER)
+	if !ok {
+		t.Fatal("falta el esquema actual de csv-ber-iteracion")
+	}
+	var buf bytes.Buffer
+	if err := e.Escribir(&buf, [][]string{{"0", "0.5", "4"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := verificarCSV(buf.Bytes()); err != nil {
+		t.Errorf("el CSV escrito no pasa la verificación: %v", err)
+	}
+
+	tabla, err := LeerCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tabla.VersionOriginal != e.Version || strings.Join(tabla.Filas[0], ",") != "0,0.5,4" {
+		t.Errorf("ida y vuelta inesperada: %+v", tabla)
+	}
+
+	if err := e.Escribir(&bytes.Buffer{}, [][]string{{"0", "0.5"}}); err == nil {
+		t.Error("una fila con menos columnas que el esquema debería fallar")
+	}
+}
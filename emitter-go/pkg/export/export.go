@@ -0,0 +1,257 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Conjunto agrupa todos los archivos que produce una corrida. Se abren al
+// inicio, antes de ejecutar el trabajo, para fallar rápido ante rutas
+// repetidas o directorios inexistentes, y se describen en un manifiesto.
+type Conjunto struct {
+	mu        sync.Mutex
+	archivos  []*Archivo
+	rutas     map[string]bool
+	metadatos json.RawMessage
+}
+
+// Archivo es un export abierto. Write es seguro para uso concurrente y no usa
+// buffer propio, así que lo escrito llega al sistema operativo de inmediato.
+type Archivo struct {
+	Ruta    string
+	Tipo    string // Contenido, ej: "ndjson-iteraciones"
+	Version int    // Versión del esquema del contenido
+
+	abs     string
+	mu      sync.Mutex
+	f       *os.File
+	hash    hash.Hash
+	bytes   int64
+	cerrado bool
+}
+
+// NuevoConjunto crea un conjunto vacío
+func NuevoConjunto() *Conjunto {
+	return &Conjunto{rutas: make(map[string]bool)}
+}
+
+// reservar registra la ruta normalizada o falla si ya fue pedida
+func (c *Conjunto) reservar(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if c.rutas[abs] {
+		return "", fmt.Errorf("la ruta %s se pidió para más de un export", path)
+	}
+	dir := filepath.Dir(abs)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("el directorio de %s no existe", path)
+	}
+	c.rutas[abs] = true
+	return abs, nil
+}
+
+// Abrir crea el archivo de export en path
+func (c *Conjunto) Abrir(path, tipo string, version int) (*Archivo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	abs, err := c.reservar(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &Archivo{Ruta: path, Tipo: tipo, Version: version, abs: abs, f: f, hash: sha256.New()}
+	c.archivos = append(c.archivos, a)
+	return a, nil
+}
+
+// Reservar marca path como usado por un archivo que no pertenece al conjunto
+// (por ejemplo el manifiesto), para que ningún export lo pise
+func (c *Conjunto) Reservar(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.reservar(path)
+	return err
+}
+
+func (a *Archivo) Write(p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cerrado {
+		return 0, fmt.Errorf("export %s ya cerrado", a.Ruta)
+	}
+	n, err := a.f.Write(p)
+	a.hash.Write(p[:n])
+	a.bytes += int64(n)
+	return n, err
+}
+
+// Close cierra el archivo; llamarlo más de una vez no tiene efecto
+func (a *Archivo) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cerrado {
+		return nil
+	}
+	a.cerrado = true
+	return a.f.Close()
+}
+
+// Cerrar cierra todos los archivos del conjunto y devuelve el primer error
+func (c *Conjunto) Cerrar() error {
+	c.mu.Lock()
+	archivos := append([]*Archivo(nil), c.archivos...)
+	c.mu.Unlock()
+
+	var primero error
+	for _, a := range archivos {
+		if err := a.Close(); err != nil && primero == nil {
+			primero = err
+		}
+	}
+	return primero
+}
+
+// EntradaManifiesto describe un archivo producido
+type EntradaManifiesto struct {
+	Ruta    string `json:"path"`
+	Tipo    string `json:"type"`
+	Version int    `json:"schema_version"`
+	Bytes   int64  `json:"bytes"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifiesto lista todos los archivos del conjunto
+type Manifiesto struct {
+	Version  int                 `json:"schema_version"`
+	Generado time.Time           `json:"generated"`
+	Archivos []EntradaManifiesto `json:"files"`
+	// Metadatos es información libre del productor (ver FijarMetadatos)
+	Metadatos json.RawMessage `json:"metadata,omitempty"`
+}
+
+// FijarMetadatos guarda v, codificado en JSON, en el campo metadata del
+// manifiesto; por ejemplo los parámetros que generaron los archivos
+func (c *Conjunto) FijarMetadatos(v interface{}) error {
+	datos, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadatos = datos
+	return nil
+}
+
+// Manifiesto describe los archivos abiertos hasta el momento, ordenados por ruta
+func (c *Conjunto) Manifiesto() Manifiesto {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := Manifiesto{Version: VersionManifiesto, Generado: time.Now().UTC(), Metadatos: c.metadatos}
+	for _, a := range c.archivos {
+		a.mu.Lock()
+		m.Archivos = append(m.Archivos, EntradaManifiesto{
+			Ruta:    a.Ruta,
+			Tipo:    a.Tipo,
+			Version: a.Version,
+			Bytes:   a.bytes,
+			SHA256:  hex.EncodeToString(a.hash.Sum(nil)),
+		})
+		a.mu.Unlock()
+	}
+	sort.Slice(m.Archivos, func(i, j int) bool { return m.Archivos[i].Ruta < m.Archivos[j].Ruta })
+	return m
+}
+
+// EscribirManifiesto cierra el conjunto y escribe su manifiesto JSON en path.
+// Las rutas se guardan relativas al directorio del manifiesto, para que el
+// conjunto pueda copiarse a otra máquina y verificarse allí.
+func (c *Conjunto) EscribirManifiesto(path string) error {
+	if err := c.Cerrar(); err != nil {
+		return err
+	}
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	m := c.Manifiesto()
+	c.mu.Lock()
+	for i, e := range m.Archivos {
+		for _, a := range c.archivos {
+			if a.Ruta != e.Ruta {
+				continue
+			}
+			if rel, err := filepath.Rel(base, a.abs); err == nil {
+				m.Archivos[i].Ruta = filepath.ToSlash(rel)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	datos, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(datos, '\n'), 0644)
+}
+
+// salir termina el proceso; los tests lo reemplazan para observar la señal
+var salir = os.Exit
+
+// CerrarSiPanico cierra el conjunto si la función que lo difiere entra en
+// pánico, y luego relanza el pánico. Debe usarse como "defer c.CerrarSiPanico()".
+func (c *Conjunto) CerrarSiPanico() {
+	if r := recover(); r != nil {
+		c.Cerrar()
+		panic(r)
+	}
+}
+
+// CerrarAlInterrumpir cierra el conjunto (y escribe el manifiesto si
+// manifiesto no es vacío) cuando llega SIGINT o SIGTERM, y termina el proceso
+// con código 130. La función devuelta deja de escuchar las señales; puede
+// llamarse más de una vez, por ejemplo para ceder la señal a un contexto.
+func (c *Conjunto) CerrarAlInterrumpir(manifiesto string) (detener func()) {
+	señales := make(chan os.Signal, 1)
+	signal.Notify(señales, os.Interrupt, syscall.SIGTERM)
+	listo := make(chan struct{})
+
+	go func() {
+		select {
+		case <-señales:
+			if manifiesto != "" {
+				c.EscribirManifiesto(manifiesto)
+			} else {
+				c.Cerrar()
+			}
+			fmt.Fprintln(os.Stderr, "🛑 Interrumpido: exports cerrados")
+			salir(130)
+		case <-listo:
+		}
+	}()
+
+	var una sync.Once
+	return func() {
+		una.Do(func() {
+			signal.Stop(señales)
+			close(listo)
+		})
+	}
+}
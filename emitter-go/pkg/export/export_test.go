@@ -0,0 +1,248 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAbrir_ConflictoDeRutas(t *testing.T) {
+	dir := t.TempDir()
+	c := NuevoConjunto()
+	defer c.Cerrar()
+
+	if _, err := c.Abrir(filepath.Join(dir, "a.csv"), "csv", 1); err != nil {
+		t.Fatalf("primer Abrir falló: %v", err)
+	}
+	// La misma ruta escrita de otra forma también es un conflicto
+	otra := filepath.Join(dir, "sub", "..", "a.csv")
+	if _, err := c.Abrir(otra, "csv", 1); err == nil {
+		t.Error("se esperaba error por ruta repetida")
+	}
+	if err := c.Reservar(filepath.Join(dir, "a.csv")); err == nil {
+		t.Error("Reservar debería rechazar una ruta ya abierta")
+	}
+}
+
+func TestAbrir_DirectorioInexistente(t *testing.T) {
+	c := NuevoConjunto()
+	defer c.Cerrar()
+
+	path := filepath.Join(t.TempDir(), "no-existe", "a.csv")
+	if _, err := c.Abrir(path, "csv", 1); err == nil {
+		t.Error("se esperaba error por directorio inexistente")
+	}
+	if len(c.Manifiesto().Archivos) != 0 {
+		t.Error("un Abrir fallido no debería figurar en el manifiesto")
+	}
+}
+
+func TestArchivo_EscrituraConcurrente(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "it.ndjson")
+	c := NuevoConjunto()
+	a, err := c.Abrir(path, "ndjson", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				fmt.Fprintf(a, "{\"g\":%d,\"i\":%d}\n", g, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if err := c.Cerrar(); err != nil {
+		t.Fatal(err)
+	}
+
+	datos, _ := os.ReadFile(path)
+	lineas := strings.Split(strings.TrimSpace(string(datos)), "\n")
+	if len(lineas) != 400 {
+		t.Fatalf("líneas = %d, want 400", len(lineas))
+	}
+	for _, l := range lineas {
+		var v map[string]int
+		if err := json.Unmarshal([]byte(l), &v); err != nil {
+			t.Fatalf("línea intercalada %q: %v", l, err)
+		}
+	}
+}
+
+func TestArchivo_CloseIdempotente(t *testing.T) {
+	c := NuevoConjunto()
+	a, err := c.Abrir(filepath.Join(t.TempDir(), "x"), "bin", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Cerrar(); err != nil {
+		t.Errorf("Cerrar tras Close devolvió %v", err)
+	}
+	if _, err := a.Write([]byte("x")); err == nil {
+		t.Error("Write sobre un export cerrado debería fallar")
+	}
+}
+
+func TestEscribirManifiesto(t *testing.T) {
+	dir := t.TempDir()
+	c := NuevoConjunto()
+	manifiesto := filepath.Join(dir, "manifest.json")
+	if err := c.Reservar(manifiesto); err != nil {
+		t.Fatal(err)
+	}
+
+	contenidos := map[string]string{"b.csv": "x,y\n1,2\n", "a.ndjson": "{}\n"}
+	for nombre, contenido := range contenidos {
+		a, err := c.Abrir(filepath.Join(dir, nombre), "tipo-"+nombre, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.Write([]byte(contenido))
+	}
+	if _, err := c.Abrir(manifiesto, "json", 1); err == nil {
+		t.Error("la ruta del manifiesto debería estar reservada")
+	}
+
+	if err := c.EscribirManifiesto(manifiesto); err != nil {
+		t.Fatalf("EscribirManifiesto falló: %v", err)
+	}
+
+	var m Manifiesto
+	datos, _ := os.ReadFile(manifiesto)
+	if err := json.Unmarshal(datos, &m); err != nil {
+		t.Fatalf("manifiesto inválido: %v", err)
+	}
+	if len(m.Archivos) != 2 {
+		t.Fatalf("archivos = %d, want 2", len(m.Archivos))
+	}
+	if m.Archivos[0].Ruta != "a.ndjson" || m.Archivos[1].Ruta != "b.csv" {
+		t.Errorf("el manifiesto debería listar rutas relativas ordenadas: %v", m.Archivos)
+	}
+	for _, e := range m.Archivos {
+		contenido := contenidos[filepath.Base(e.Ruta)]
+		suma := sha256.Sum256([]byte(contenido))
+		if e.Bytes != int64(len(contenido)) || e.SHA256 != hex.EncodeToString(suma[:]) {
+			t.Errorf("%s: bytes=%d sha=%s no coinciden con el contenido", e.Ruta, e.Bytes, e.SHA256)
+		}
+		if e.Version != 2 || e.Tipo != "tipo-"+filepath.Base(e.Ruta) {
+			t.Errorf("%s: tipo/versión = %s/%d", e.Ruta, e.Tipo, e.Version)
+		}
+	}
+}
+
+func TestEscribirManifiesto_Metadatos(t *testing.T) {
+	dir := t.TempDir()
+	manifiesto := filepath.Join(dir, "manifest.json")
+
+	// Sin metadatos el campo no aparece
+	c := NuevoConjunto()
+	if err := c.EscribirManifiesto(manifiesto); err != nil {
+		t.Fatal(err)
+	}
+	if datos, _ := os.ReadFile(manifiesto); strings.Contains(string(datos), "metadata") {
+		t.Errorf("manifiesto sin metadatos: %s", datos)
+	}
+
+	c = NuevoConjunto()
+	a, err := c.Abrir(filepath.Join(dir, "a.ndjson"), "ndjson", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Write([]byte("{}\n"))
+	if err := c.FijarMetadatos(map[string]int{"seed": 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.EscribirManifiesto(manifiesto); err != nil {
+		t.Fatal(err)
+	}
+	var m Manifiesto
+	datos, _ := os.ReadFile(manifiesto)
+	if err := json.Unmarshal(datos, &m); err != nil {
+		t.Fatal(err)
+	}
+	var meta map[string]int
+	if err := json.Unmarshal(m.Metadatos, &meta); err != nil || meta["seed"] != 7 {
+		t.Errorf("metadatos %s, %v", m.Metadatos, err)
+	}
+	if err := VerificarManifiesto(manifiesto); err != nil {
+		t.Errorf("el manifiesto con metadatos debería verificar: %v", err)
+	}
+}
+
+func TestCerrarSiPanico(t *testing.T) {
+	c := NuevoConjunto()
+	a, err := c.Abrir(filepath.Join(t.TempDir(), "x"), "bin", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("el pánico debería relanzarse")
+			}
+		}()
+		defer c.CerrarSiPanico()
+		panic("falla")
+	}()
+
+	if _, err := a.Write([]byte("x")); err == nil {
+		t.Error("el export debería quedar cerrado tras el pánico")
+	}
+}
+
+func TestCerrarAlInterrumpir(t *testing.T) {
+	dir := t.TempDir()
+	c := NuevoConjunto()
+	a, err := c.Abrir(filepath.Join(dir, "it.ndjson"), "ndjson", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Write([]byte("{}\n"))
+
+	codigos := make(chan int, 1)
+	salir = func(code int) { codigos <- code }
+	defer func() { salir = os.Exit }()
+
+	manifiesto := filepath.Join(dir, "manifest.json")
+	detener := c.CerrarAlInterrumpir(manifiesto)
+	defer detener()
+
+	syscall.Kill(os.Getpid(), syscall.SIGINT)
+
+	select {
+	case code := <-codigos:
+		if code != 130 {
+			t.Errorf("código de salida = %d, want 130", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGINT no cerró los exports")
+	}
+	if _, err := a.Write([]byte("x")); err == nil {
+		t.Error("el export debería quedar cerrado tras SIGINT")
+	}
+	if _, err := os.Stat(manifiesto); err != nil {
+		t.Errorf("SIGINT debería escribir el manifiesto: %v", err)
+	}
+}
+
+func TestCerrarAlInterrumpir_DetenerDosVeces(t *testing.T) {
+	detener := NuevoConjunto().CerrarAlInterrumpir("")
+	detener()
+	detener()
+}
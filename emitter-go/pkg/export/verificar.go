@@ -0,0 +1,128 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Verificar comprueba la integridad de un export según su extensión:
+// manifiestos (.json), NDJSON (.ndjson, .jsonl), CSV (.csv) y PNG (.png).
+// Devuelve el tipo detectado y el primer problema encontrado.
+func Verificar(path string) (tipo string, err error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "manifiesto", VerificarManifiesto(path)
+	case ".ndjson", ".jsonl":
+		return "ndjson", verificarArchivo(path, verificarNDJSON)
+	case ".csv":
+		return "csv", verificarArchivo(path, verificarCSV)
+	case ".png":
+		return "png", verificarArchivo(path, verificarPNG)
+	default:
+		return "", fmt.Errorf("tipo de archivo no reconocido: %s", filepath.Ext(path))
+	}
+}
+
+func verificarArchivo(path string, verificar func(datos []byte) error) error {
+	datos, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return verificar(datos)
+}
+
+// verificarNDJSON exige un objeto JSON por línea y salto de línea final; una
+// última línea sin terminar indica un archivo truncado
+func verificarNDJSON(datos []byte) error {
+	if len(datos) == 0 {
+		return fmt.Errorf("archivo vacío")
+	}
+	if datos[len(datos)-1] != '\n' {
+		return fmt.Errorf("la última línea no termina en salto de línea (archivo truncado)")
+	}
+	for i, linea := range bytes.Split(datos[:len(datos)-1], []byte("\n")) {
+		var registro map[string]json.RawMessage
+		if err := json.Unmarshal(linea, &registro); err != nil {
+			return fmt.Errorf("línea %d: no es un objeto JSON: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// verificarCSV exige encabezado, la misma cantidad de campos en cada fila y
+// salto de línea final. Las líneas que empiezan con # (el comentario de
+// esquema) se ignoran.
+func verificarCSV(datos []byte) error {
+	if len(datos) == 0 {
+		return fmt.Errorf("archivo vacío")
+	}
+	if datos[len(datos)-1] != '\n' {
+		return fmt.Errorf("la última fila no termina en salto de línea (archivo truncado)")
+	}
+	r := csv.NewReader(bytes.NewReader(datos))
+	r.Comment = '#'
+	encabezado, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("encabezado ilegible: %v", err)
+	}
+	r.FieldsPerRecord = len(encabezado)
+	for fila := 2; ; fila++ {
+		if _, err := r.Read(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("fila %d: %v", fila, err)
+		}
+	}
+}
+
+// verificarPNG decodifica la imagen completa, lo que detecta truncamientos y
+// chunks con CRC inválido
+func verificarPNG(datos []byte) error {
+	_, err := png.Decode(bytes.NewReader(datos))
+	return err
+}
+
+// VerificarManifiesto comprueba que cada archivo listado exista con el tamaño
+// y el SHA-256 registrados. Las rutas relativas se resuelven desde el
+// directorio del manifiesto.
+func VerificarManifiesto(path string) error {
+	datos, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var m Manifiesto
+	if err := json.Unmarshal(datos, &m); err != nil {
+		return fmt.Errorf("manifiesto ilegible: %v", err)
+	}
+	if m.Archivos == nil {
+		return fmt.Errorf("el manifiesto no tiene lista de archivos")
+	}
+
+	for _, e := range m.Archivos {
+		ruta := filepath.FromSlash(e.Ruta)
+		if !filepath.IsAbs(ruta) {
+			ruta = filepath.Join(filepath.Dir(path), ruta)
+		}
+		contenido, err := os.ReadFile(ruta)
+		if err != nil {
+			return fmt.Errorf("%s: %v", e.Ruta, err)
+		}
+		if int64(len(contenido)) != e.Bytes {
+			return fmt.Errorf("%s: tiene %d bytes, el manifiesto registra %d", e.Ruta, len(contenido), e.Bytes)
+		}
+		suma := sha256.Sum256(contenido)
+		if hex.EncodeToString(suma[:]) != e.SHA256 {
+			return fmt.Errorf("%s: el SHA-256 no coincide con el manifiesto", e.Ruta)
+		}
+	}
+	return nil
+}
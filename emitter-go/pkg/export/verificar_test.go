@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func pngValido(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 4))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func alterarByte(datos []byte, i int) []byte {
+	out := append([]byte(nil), datos...)
+	out[i] ^= 0xff
+	return out
+}
+
+func TestVerificar(t *testing.T) {
+	imagen := pngValido(t)
+
+	tests := []struct {
+		name     string
+		archivo  string
+		datos    []byte
+		wantTipo string
+		wantErr  bool
+	}{
+		{"ndjson válido", "it.ndjson", []byte("{\"a\":1}\n{\"a\":2}\n"), "ndjson", false},
+		{"ndjson truncado", "it.ndjson", []byte("{\"a\":1}\n{\"a\""), "ndjson", true},
+		{"ndjson sin salto final", "it.jsonl", []byte("{\"a\":1}"), "ndjson", true},
+		{"ndjson con línea no objeto", "it.ndjson", []byte("{\"a\":1}\n[1]\n"), "ndjson", true},
+		{"ndjson vacío", "it.ndjson", nil, "ndjson", true},
+		{"csv válido", "d.csv", []byte("errores,frecuencia\n0,5\n1,3\n"), "csv", false},
+		{"csv con fila corta", "d.csv", []byte("errores,frecuencia\n0,5\n1\n"), "csv", true},
+		{"csv truncado", "d.csv", []byte("errores,frecuencia\n0,5\n1,"), "csv", true},
+		{"png válido", "m.png", imagen, "png", false},
+		{"png truncado", "m.png", imagen[:len(imagen)-10], "png", true},
+		{"png con byte alterado", "m.png", alterarByte(imagen, 20), "png", true},
+		{"extensión desconocida", "x.bin", []byte{1}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.archivo)
+			if err := os.WriteFile(path, tt.datos, 0644); err != nil {
+				t.Fatal(err)
+			}
+			tipo, err := Verificar(path)
+			if tipo != tt.wantTipo {
+				t.Errorf("tipo = %q, want %q", tipo, tt.wantTipo)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verificar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerificarManifiesto(t *testing.T) {
+	nuevo := func(t *testing.T) (dir, manifiesto string) {
+		dir = t.TempDir()
+		c := NuevoConjunto()
+		a, err := c.Abrir(filepath.Join(dir, "it.ndjson"), "ndjson", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.Write([]byte("{\"a\":1}\n"))
+		manifiesto = filepath.Join(dir, "manifest.json")
+		if err := c.EscribirManifiesto(manifiesto); err != nil {
+			t.Fatal(err)
+		}
+		return dir, manifiesto
+	}
+
+	t.Run("válido", func(t *testing.T) {
+		_, manifiesto := nuevo(t)
+		if err := VerificarManifiesto(manifiesto); err != nil {
+			t.Errorf("manifiesto válido rechazado: %v", err)
+		}
+	})
+
+	t.Run("copiado a otro directorio", func(t *testing.T) {
+		dir, _ := nuevo(t)
+		destino := t.TempDir()
+		for _, n := range []string{"it.ndjson", "manifest.json"} {
+			datos, _ := os.ReadFile(filepath.Join(dir, n))
+			os.WriteFile(filepath.Join(destino, n), datos, 0644)
+		}
+		if err := VerificarManifiesto(filepath.Join(destino, "manifest.json")); err != nil {
+			t.Errorf("las rutas deberían resolverse desde el manifiesto: %v", err)
+		}
+	})
+
+	corrupciones := []struct {
+		name    string
+		alterar func(dir, manifiesto string)
+	}{
+		{"archivo faltante", func(dir, _ string) { os.Remove(filepath.Join(dir, "it.ndjson")) }},
+		{"archivo truncado", func(dir, _ string) { os.Truncate(filepath.Join(dir, "it.ndjson"), 3) }},
+		{"mismo tamaño, otro contenido", func(dir, _ string) {
+			os.WriteFile(filepath.Join(dir, "it.ndjson"), []byte("{\"a\":2}\n"), 0644)
+		}},
+		{"manifiesto truncado", func(_, manifiesto string) { os.Truncate(manifiesto, 10) }},
+		{"manifiesto sin archivos", func(_, manifiesto string) { os.WriteFile(manifiesto, []byte("{}"), 0644) }},
+	}
+	for _, tt := range corrupciones {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, manifiesto := nuevo(t)
+			tt.alterar(dir, manifiesto)
+			if err := VerificarManifiesto(manifiesto); err == nil {
+				t.Error("se esperaba error")
+			}
+		})
+	}
+}
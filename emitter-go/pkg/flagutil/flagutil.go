@@ -0,0 +1,156 @@
+package flagutil
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationValue acepta duraciones de Go ("500ms", "2m") y, por compatibilidad,
+// enteros sin unidad interpretados como segundos
+type DurationValue time.Duration
+
+// FormatosDuration describe las formas aceptadas por DurationValue
+const FormatosDuration = "duraciones como 500ms, 30s, 2m o 1h30m, o segundos sin unidad (30)"
+
+func (d *DurationValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*d = DurationValue(time.Duration(n) * time.Second)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("%q no es una duración válida; se aceptan %s", s, FormatosDuration)
+	}
+	*d = DurationValue(v)
+	return nil
+}
+
+func (d *DurationValue) String() string   { return time.Duration(*d).String() }
+func (d *DurationValue) Get() interface{} { return time.Duration(*d) }
+
+// Duration define un flag de duración en fs, análogo a fs.Duration
+func Duration(fs *flag.FlagSet, name string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	*p = value
+	fs.Var((*DurationValue)(p), name, usage)
+	return p
+}
+
+// ByteSizeValue acepta tamaños con unidades decimales (KB, MB, GB) o binarias
+// (KiB, MiB, GiB); un número sin unidad son bytes
+type ByteSizeValue int64
+
+// FormatosByteSize describe las formas aceptadas por ByteSizeValue
+const FormatosByteSize = "bytes (65536) o tamaños como 64KB, 1MiB, 2GB"
+
+var unidadesByteSize = []struct {
+	sufijo string
+	factor int64
+}{
+	// Los sufijos más largos primero para que "KiB" no se confunda con "B"
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+func (b *ByteSizeValue) Set(s string) error {
+	texto := strings.TrimSpace(s)
+	factor := int64(1)
+	for _, u := range unidadesByteSize {
+		if strings.HasSuffix(strings.ToUpper(texto), strings.ToUpper(u.sufijo)) {
+			factor = u.factor
+			texto = strings.TrimSpace(texto[:len(texto)-len(u.sufijo)])
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(texto, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || v*float64(factor) > math.MaxInt64 {
+		return fmt.Errorf("%q no es un tamaño válido; se aceptan %s", s, FormatosByteSize)
+	}
+	*b = ByteSizeValue(v * float64(factor))
+	return nil
+}
+
+func (b *ByteSizeValue) String() string   { return strconv.FormatInt(int64(*b), 10) }
+func (b *ByteSizeValue) Get() interface{} { return int64(*b) }
+
+// ByteSize define un flag de tamaño en bytes en fs
+func ByteSize(fs *flag.FlagSet, name string, value int64, usage string) *int64 {
+	p := new(int64)
+	*p = value
+	fs.Var((*ByteSizeValue)(p), name, usage)
+	return p
+}
+
+// RateValue acepta tasas por unidad de tiempo ("200/s", "1200/m"); un número
+// sin unidad son eventos por segundo. Se almacena en eventos por segundo.
+type RateValue float64
+
+// FormatosRate describe las formas aceptadas por RateValue
+const FormatosRate = "tasas como 200/s, 1200/m o 5/ms, o eventos por segundo sin unidad (200)"
+
+func (r *RateValue) Set(s string) error {
+	texto := strings.TrimSpace(s)
+	por := time.Second
+	if cantidad, unidad, ok := strings.Cut(texto, "/"); ok {
+		d, err := time.ParseDuration("1" + strings.TrimSpace(unidad))
+		if err != nil {
+			return fmt.Errorf("%q no es una tasa válida; se aceptan %s", s, FormatosRate)
+		}
+		texto, por = strings.TrimSpace(cantidad), d
+	}
+	v, err := strconv.ParseFloat(texto, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) {
+		return fmt.Errorf("%q no es una tasa válida; se aceptan %s", s, FormatosRate)
+	}
+	*r = RateValue(v / por.Seconds())
+	return nil
+}
+
+func (r *RateValue) String() string   { return strconv.FormatFloat(float64(*r), 'g', -1, 64) + "/s" }
+func (r *RateValue) Get() interface{} { return float64(*r) }
+
+// Rate define un flag de tasa (eventos por segundo) en fs
+func Rate(fs *flag.FlagSet, name string, value float64, usage string) *float64 {
+	p := new(float64)
+	*p = value
+	fs.Var((*RateValue)(p), name, usage)
+	return p
+}
+
+// ProbabilityValue acepta probabilidades como fracción ("0.01") o porcentaje
+// ("1%") y exige que estén en [0, 1]
+type ProbabilityValue float64
+
+// FormatosProbability describe las formas aceptadas por ProbabilityValue
+const FormatosProbability = "fracciones entre 0 y 1 (0.01) o porcentajes entre 0% y 100% (1%)"
+
+func (p *ProbabilityValue) Set(s string) error {
+	texto := strings.TrimSpace(s)
+	escala := 1.0
+	if strings.HasSuffix(texto, "%") {
+		texto, escala = strings.TrimSpace(strings.TrimSuffix(texto, "%")), 100
+	}
+	v, err := strconv.ParseFloat(texto, 64)
+	if err != nil || math.IsNaN(v) || v/escala < 0 || v/escala > 1 {
+		return fmt.Errorf("%q no es una probabilidad válida; se aceptan %s", s, FormatosProbability)
+	}
+	*p = ProbabilityValue(v / escala)
+	return nil
+}
+
+func (p *ProbabilityValue) String() string   { return strconv.FormatFloat(float64(*p), 'g', -1, 64) }
+func (p *ProbabilityValue) Get() interface{} { return float64(*p) }
+
+// Probability define un flag de probabilidad en fs, análogo a fs.Float64
+func Probability(fs *flag.FlagSet, name string, value float64, usage string) *float64 {
+	p := new(float64)
+	*p = value
+	fs.Var((*ProbabilityValue)(p), name, usage)
+	return p
+}
@@ -0,0 +1,166 @@
+package flagutil
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"500ms", 500 * time.Millisecond, false},
+		{"2m", 2 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"30", 30 * time.Second, false},
+		{" 10s ", 10 * time.Second, false},
+		{"diez", 0, true},
+		{"1.5", 0, true},
+	}
+	for _, tt := range tests {
+		var d DurationValue
+		err := d.Set(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && time.Duration(d) != tt.want {
+			t.Errorf("Set(%q) = %v, want %v", tt.in, time.Duration(d), tt.want)
+		}
+	}
+}
+
+func TestByteSizeValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"65536", 65536, false},
+		{"64KB", 64000, false},
+		{"64kb", 64000, false},
+		{"1MiB", 1 << 20, false},
+		{"1.5KiB", 1536, false},
+		{"2GB", 2000000000, false},
+		{"512B", 512, false},
+		{"-1KB", 0, true},
+		{"KB", 0, true},
+		{"10XB", 0, true},
+	}
+	for _, tt := range tests {
+		var b ByteSizeValue
+		err := b.Set(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && int64(b) != tt.want {
+			t.Errorf("Set(%q) = %d, want %d", tt.in, int64(b), tt.want)
+		}
+	}
+}
+
+func TestRateValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"200/s", 200, false},
+		{"1200/m", 20, false},
+		{"5/ms", 5000, false},
+		{"50", 50, false},
+		{"200/semana", 0, true},
+		{"-1/s", 0, true},
+		{"/s", 0, true},
+	}
+	for _, tt := range tests {
+		var r RateValue
+		err := r.Set(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && float64(r) != tt.want {
+			t.Errorf("Set(%q) = %v, want %v", tt.in, float64(r), tt.want)
+		}
+	}
+}
+
+func TestProbabilityValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"0.01", 0.01, false},
+		{"1%", 0.01, false},
+		{"100%", 1, false},
+		{"0", 0, false},
+		{"1.5", 0, true},
+		{"150%", 0, true},
+		{"-0.1", 0, true},
+		{"NaN", 0, true},
+		{"uno", 0, true},
+	}
+	for _, tt := range tests {
+		var p ProbabilityValue
+		err := p.Set(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Set(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && float64(p) != tt.want {
+			t.Errorf("Set(%q) = %v, want %v", tt.in, float64(p), tt.want)
+		}
+	}
+}
+
+// Cada valor debe poder reinterpretarse desde su propio String(), que es lo
+// que muestran la ayuda y el schema como default
+func TestRoundTripFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("prueba", flag.ContinueOnError)
+	d := Duration(fs, "duration", time.Second, "")
+	b := ByteSize(fs, "size", 0, "")
+	r := Rate(fs, "rate", 0, "")
+	p := Probability(fs, "ber", 0.01, "")
+
+	if err := fs.Parse([]string{"--duration", "90", "--size", "64KiB", "--rate", "120/m", "--ber", "5%"}); err != nil {
+		t.Fatal(err)
+	}
+	if *d != 90*time.Second || *b != 65536 || *r != 2 || *p != 0.05 {
+		t.Fatalf("valores inesperados: %v %d %v %v", *d, *b, *r, *p)
+	}
+
+	var args []string
+	fs.VisitAll(func(f *flag.Flag) {
+		args = append(args, "--"+f.Name, f.Value.String())
+	})
+	otra := flag.NewFlagSet("prueba", flag.ContinueOnError)
+	d2, b2, r2, p2 := Duration(otra, "duration", 0, ""), ByteSize(otra, "size", 0, ""), Rate(otra, "rate", 0, ""), Probability(otra, "ber", 0, "")
+	if err := otra.Parse(args); err != nil {
+		t.Fatalf("los valores no se releen desde String(): %v", err)
+	}
+	if *d2 != *d || *b2 != *b || *r2 != *r || *p2 != *p {
+		t.Errorf("round trip inesperado: %v %d %v %v", *d2, *b2, *r2, *p2)
+	}
+}
+
+func TestErrorNombraElFlag(t *testing.T) {
+	fs := flag.NewFlagSet("prueba", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	Probability(fs, "ber", 0, "")
+
+	err := fs.Parse([]string{"--ber", "2"})
+	if err == nil {
+		t.Fatal("se esperaba error")
+	}
+	if !strings.Contains(err.Error(), "-ber") || !strings.Contains(err.Error(), "1%") {
+		t.Errorf("el error debería nombrar el flag y los formatos aceptados: %v", err)
+	}
+}
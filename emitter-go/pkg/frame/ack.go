@@ -0,0 +1,131 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Tipos de las respuestas del receptor: confirman (ACK) o rechazan (NACK) la
+// trama con el número de secuencia del cuerpo
+const (
+	MsgTypeAck  byte = 0x09
+	MsgTypeNack byte = 0x0A
+)
+
+// ReplyBodySize es el cuerpo de ACK y NACK: [seq(2)][código(1)]
+const ReplyBodySize = 3
+
+// ReplyCode es el motivo de una respuesta; ReplyOK en los ACK
+type ReplyCode byte
+
+const (
+	ReplyOK             ReplyCode = iota // Trama verificada
+	ReplyCRCMismatch                     // El checksum no coincide
+	ReplyTruncated                       // Faltan bytes
+	ReplyLengthMismatch                  // El largo del header no coincide con el payload
+	ReplyUncorrectable                   // El código del payload no pudo corregir los errores
+	ReplyUnsupported                     // Tipo o algoritmo que el receptor no conoce
+	ReplyOther          ReplyCode = 0xFF // Cualquier otro motivo
+)
+
+func (c ReplyCode) String() string {
+	switch c {
+	case ReplyOK:
+		return "ok"
+	case ReplyCRCMismatch:
+		return "crc"
+	case ReplyTruncated:
+		return "truncada"
+	case ReplyLengthMismatch:
+		return "longitud"
+	case ReplyUncorrectable:
+		return "no-corregible"
+	case ReplyUnsupported:
+		return "no-soportada"
+	case ReplyOther:
+		return "otro"
+	default:
+		return fmt.Sprintf("código %d", byte(c))
+	}
+}
+
+// ReplyCodeFor es el código con el que el receptor rechaza una trama que
+// falló con err (ver los errores de ParseFrame y Frame.Data); ReplyOK si err
+// es nil
+func ReplyCodeFor(err error) ReplyCode {
+	switch {
+	case err == nil:
+		return ReplyOK
+	case errors.Is(err, ErrCRCMismatch):
+		return ReplyCRCMismatch
+	case errors.Is(err, ErrTruncated):
+		return ReplyTruncated
+	case errors.Is(err, ErrLengthMismatch):
+		return ReplyLengthMismatch
+	case errors.Is(err, ErrUncorrectable):
+		return ReplyUncorrectable
+	case errors.Is(err, ErrChecksumKind):
+		return ReplyUnsupported
+	default:
+		return ReplyOther
+	}
+}
+
+// Reply es una respuesta del receptor decodificada por ParseReply
+type Reply struct {
+	Ack  bool
+	Seq  uint16    // Secuencia de la trama respondida (0 si no llevaba)
+	Code ReplyCode // ReplyOK en los ACK
+}
+
+func (r *Reply) String() string {
+	if r.Ack {
+		return fmt.Sprintf("ACK seq %d", r.Seq)
+	}
+	return fmt.Sprintf("NACK seq %d (%s)", r.Seq, r.Code)
+}
+
+// BuildAckFrame construye la respuesta que confirma la trama seq
+func BuildAckFrame(seq uint16) ([]byte, error) {
+	return buildReply(MsgTypeAck, seq, ReplyOK)
+}
+
+// BuildNackFrame construye la respuesta que rechaza la trama seq por code
+func BuildNackFrame(seq uint16, code ReplyCode) ([]byte, error) {
+	if code == ReplyOK {
+		return nil, fmt.Errorf("un NACK necesita un código distinto de %s", ReplyOK)
+	}
+	return buildReply(MsgTypeNack, seq, code)
+}
+
+func buildReply(msgType byte, seq uint16, code ReplyCode) ([]byte, error) {
+	body := make([]byte, ReplyBodySize)
+	binary.BigEndian.PutUint16(body, seq)
+	body[2] = byte(code)
+	return BuildFrameWithType(body, msgType)
+}
+
+// ParseReply decodifica una trama de BuildAckFrame o BuildNackFrame. Falla
+// como ParseFrame si la trama no es válida y si no es una respuesta.
+func ParseReply(frame []byte) (*Reply, error) {
+	f, err := ParseFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	if f.MsgType != MsgTypeAck && f.MsgType != MsgTypeNack {
+		return nil, fmt.Errorf("el tipo %#02x no es una respuesta ACK/NACK", f.MsgType)
+	}
+	if len(f.Payload) != ReplyBodySize {
+		return nil, fmt.Errorf("%w: la respuesta trae %d bytes, se esperaban %d", ErrLengthMismatch, len(f.Payload), ReplyBodySize)
+	}
+	r := &Reply{
+		Ack:  f.MsgType == MsgTypeAck,
+		Seq:  binary.BigEndian.Uint16(f.Payload),
+		Code: ReplyCode(f.Payload[2]),
+	}
+	if r.Ack != (r.Code == ReplyOK) {
+		return nil, fmt.Errorf("respuesta inconsistente: %v", r)
+	}
+	return r, nil
+}
@@ -0,0 +1,79 @@
+package frame
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReply_RoundTrip(t *testing.T) {
+	ack, err := BuildAckFrame(513)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ParseReply(ack)
+	if err != nil || *r != (Reply{Ack: true, Seq: 513, Code: ReplyOK}) {
+		t.Errorf("ACK decodificado como %+v, %v", r, err)
+	}
+
+	nack, err := BuildNackFrame(7, ReplyCRCMismatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = ParseReply(nack)
+	if err != nil || *r != (Reply{Seq: 7, Code: ReplyCRCMismatch}) {
+		t.Errorf("NACK decodificado como %+v, %v", r, err)
+	}
+	if r.String() != "NACK seq 7 (crc)" {
+		t.Errorf("String = %q", r.String())
+	}
+}
+
+func TestParseReply_Invalidas(t *testing.T) {
+	if _, err := BuildNackFrame(1, ReplyOK); err == nil {
+		t.Error("se esperaba error con un NACK sin motivo")
+	}
+	datos, _ := BuildFrame([]byte{0, 1, 0})
+	if _, err := ParseReply(datos); err == nil {
+		t.Error("se esperaba error con una trama que no es respuesta")
+	}
+	larga, _ := BuildFrameWithType([]byte{0, 1, 0, 0}, MsgTypeAck)
+	if _, err := ParseReply(larga); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("cuerpo de 4 bytes: %v, se esperaba ErrLengthMismatch", err)
+	}
+	inconsistente, _ := BuildFrameWithType([]byte{0, 1, byte(ReplyCRCMismatch)}, MsgTypeAck)
+	if _, err := ParseReply(inconsistente); err == nil {
+		t.Error("se esperaba error con un ACK que trae motivo de rechazo")
+	}
+	ack, _ := BuildAckFrame(1)
+	ack[len(ack)-1] ^= 1
+	if _, err := ParseReply(ack); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("ACK corrupto: %v, se esperaba ErrCRCMismatch", err)
+	}
+}
+
+func TestReplyCodeFor(t *testing.T) {
+	trama, _ := BuildFrame([]byte("Hola"))
+	corrupta := append([]byte(nil), trama...)
+	corrupta[HeaderSize] ^= 1
+	_, errCRC := ParseFrame(corrupta)
+	_, errCorta := ParseFrame(trama[:2])
+	_, errLargo := ParseFrame(append(append([]byte(nil), trama[:len(trama)-CRCSize]...), 0, 0, 0, 0, 0))
+
+	casos := []struct {
+		err  error
+		want ReplyCode
+	}{
+		{nil, ReplyOK},
+		{errCRC, ReplyCRCMismatch},
+		{errCorta, ReplyTruncated},
+		{errLargo, ReplyLengthMismatch},
+		{ErrUncorrectable, ReplyUncorrectable},
+		{ErrChecksumKind, ReplyUnsupported},
+		{errors.New("otro"), ReplyOther},
+	}
+	for _, c := range casos {
+		if got := ReplyCodeFor(c.err); got != c.want {
+			t.Errorf("ReplyCodeFor(%v) = %s, se esperaba %s", c.err, got, c.want)
+		}
+	}
+}
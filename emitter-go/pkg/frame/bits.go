@@ -0,0 +1,89 @@
+package frame
+
+import "fmt"
+
+// MsgTypeBits es el tipo de las tramas de BuildFrameFromBits cuyos bits no
+// completan el último byte: el payload es [relleno(1)] + los bits agrupados
+// en bytes, como el subheader de las tramas Hamming
+const MsgTypeBits byte = 0x0D
+
+// BitsPadSize es el subheader de las tramas MsgTypeBits: un byte con los bits
+// de relleno (1 a 7) que completan el último byte del payload
+const BitsPadSize = 1
+
+// BuildFrameFromBits es BuildFrameOpts para bits (uno por byte, como
+// BytesToBits) en lugar de bytes. Con una cantidad de bits múltiplo de 8 la
+// trama es la de BuildFrameOpts(BitsToBytes(bits), opts...); si no, el
+// relleno del último byte queda en el subheader de una trama MsgTypeBits.
+// Con WithHamming74 o WithHamming84 el subheader Hamming ya registra el
+// relleno. En todos los casos Frame.Bits devuelve exactamente bits.
+func BuildFrameFromBits(bits []byte, opts ...FrameOption) ([]byte, error) {
+	for i, b := range bits {
+		if b != 0 && b != 1 {
+			return nil, invalidBit(i, b)
+		}
+	}
+	c, err := nuevoFrameConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.hamming != nil {
+		return c.buildHamming(bits)
+	}
+
+	packed, padBits := BitsToBytesPadded(bits)
+	if padBits == 0 {
+		return BuildFrameWithOptions(packed, c.header(c.msgType))
+	}
+	if c.msgType != 0 {
+		return nil, fmt.Errorf("WithType (%#02x) requiere un múltiplo de 8 bits, no %d", c.msgType, len(bits))
+	}
+	return BuildFrameWithOptions(append([]byte{byte(padBits)}, packed...), c.header(MsgTypeBits))
+}
+
+// decodeBitsPayload devuelve los bits de un payload MsgTypeBits sin el relleno
+func decodeBitsPayload(payload []byte) ([]byte, error) {
+	if len(payload) < BitsPadSize+1 {
+		return nil, fmt.Errorf("%w: el payload de bits no trae el subheader y un byte de datos", ErrTruncated)
+	}
+	relleno := int(payload[0])
+	if relleno < 1 || relleno > 7 {
+		return nil, fmt.Errorf("relleno inválido: %d bits, se esperaba de 1 a 7", relleno)
+	}
+	bits := BytesToBits(payload[BitsPadSize:])
+	return bits[:len(bits)-relleno], nil
+}
+
+// Bits decodifica el payload como Data pero devuelve los bits de datos (uno
+// por byte) sin el relleno que los completa hasta un byte: exactos para las
+// tramas MsgTypeBits, Hamming y Golay, y los de Data para los demás tipos
+func (f *Frame) Bits() ([]byte, error) {
+	switch f.MsgType {
+	case MsgTypeBits:
+		return decodeBitsPayload(f.Payload)
+	case MsgTypeHamming:
+		bits, _, err := DecodeHammingPayload(f.Payload, 7, Hamming74Decode)
+		return bits, err
+	case MsgTypeHamming1511:
+		bits, _, err := Hamming1511.DecodePayload(f.Payload)
+		return bits, err
+	case MsgTypeHamming84:
+		bits, status, err := Hamming84DecodePayload(f.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if c := CountBlockStatus(status); c.Uncorrectable > 0 {
+			return nil, fmt.Errorf("%w: %d bloques SEC-DED con errores dobles", ErrUncorrectable, c.Uncorrectable)
+		}
+		return bits, nil
+	case MsgTypeGolay:
+		bits, _, err := Golay23DecodePayload(f.Payload)
+		return bits, err
+	default:
+		data, err := f.Data()
+		if err != nil {
+			return nil, err
+		}
+		return BytesToBits(data), nil
+	}
+}
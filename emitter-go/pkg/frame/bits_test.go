@@ -0,0 +1,114 @@
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestBuildFrameFromBits_SeisBits(t *testing.T) {
+	bits := []byte{1, 0, 1, 1, 0, 1}
+	trama, err := BuildFrameFromBits(bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// [tipo bits][largo 2][relleno 2][101101 00][CRC-32]
+	if len(trama) != HeaderSize+BitsPadSize+1+CRCSize || trama[HeaderSize] != 2 || trama[HeaderSize+1] != 0xB4 {
+		t.Fatalf("trama %x", trama)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeBits {
+		t.Fatalf("tipo %#02x, se esperaba %#02x", f.MsgType, MsgTypeBits)
+	}
+	got, err := f.Bits()
+	if err != nil || !bytes.Equal(got, bits) {
+		t.Fatalf("Bits = %v, %v; se esperaba %v", got, err, bits)
+	}
+	if data, err := f.Data(); err != nil || !bytes.Equal(data, []byte{0xB4}) {
+		t.Errorf("Data = %x, %v", data, err)
+	}
+	otra, err := BuildFrameFromBits(got, f.Options()...)
+	if err != nil || !bytes.Equal(otra, trama) {
+		t.Errorf("las opciones no reproducen la trama: %x, se esperaba %x (%v)", otra, trama, err)
+	}
+	stats, err := ComputeFrameStats(trama, len(bits))
+	if err != nil || stats.SubheaderBytes != BitsPadSize || stats.PadBits != 2 {
+		t.Errorf("estadísticas %+v, %v", stats, err)
+	}
+}
+
+func TestBuildFrameFromBits_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(781))
+	casos := map[string][]FrameOption{
+		"datos":          nil,
+		"crc16 seq":      {WithChecksum(ChecksumCRC16CCITT), WithSeq(4)},
+		"contenido":      {WithContent(ContentBinary), WithScramble()},
+		"hamming74":      {WithHamming74()},
+		"hamming84 int.": {WithHamming84(), WithInterleave(3)},
+	}
+	for nombre, opts := range casos {
+		for n := 0; n <= 40; n++ {
+			bits := make([]byte, n)
+			for i := range bits {
+				bits[i] = byte(rng.Intn(2))
+			}
+			trama, err := BuildFrameFromBits(bits, opts...)
+			if err != nil {
+				t.Fatalf("%s, %d bits: %v", nombre, n, err)
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatalf("%s, %d bits: %v", nombre, n, err)
+			}
+			if got, err := f.Bits(); err != nil || !bytes.Equal(got, bits) {
+				t.Fatalf("%s, %d bits: Bits = %v, %v; se esperaba %v", nombre, n, got, err, bits)
+			}
+			if otra, _ := BuildFrameFromBits(bits, f.Options()...); !bytes.Equal(otra, trama) {
+				t.Errorf("%s, %d bits: las opciones no reproducen la trama", nombre, n)
+			}
+		}
+	}
+}
+
+func TestBuildFrameFromBits_BytesCompletosComoBuildFrame(t *testing.T) {
+	data := []byte("Hola")
+	a, err := BuildFrameFromBits(BytesToBits(data), WithSeq(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := BuildFrameOpts(data, WithSeq(1))
+	if !bytes.Equal(a, b) {
+		t.Errorf("32 bits: %x, se esperaba la trama de BuildFrameOpts %x", a, b)
+	}
+	vacia, _ := BuildFrameFromBits(nil)
+	if len(vacia) != MinFrameSize || MsgTypeOf(vacia) != MsgTypeData {
+		t.Errorf("sin bits: %x, se esperaba la trama vacía", vacia)
+	}
+}
+
+func TestBuildFrameFromBits_Invalidos(t *testing.T) {
+	if _, err := BuildFrameFromBits([]byte{0, 1, 2}); err == nil {
+		t.Error("se esperaba error para un bit distinto de 0 o 1")
+	}
+	if _, err := BuildFrameFromBits([]byte{1, 0, 1}, WithType(MsgTypeRepetition)); err == nil {
+		t.Error("se esperaba error con WithType y bits que no completan un byte")
+	}
+	if _, err := BuildFrameFromBits(make([]byte, 0xFFFF*8-1)); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("error %v, se esperaba %v", err, ErrPayloadTooLarge)
+	}
+
+	for _, payload := range [][]byte{{}, {3}, {0, 0xFF}, {8, 0xFF}} {
+		trama, _ := BuildFrameWithType(payload, MsgTypeBits)
+		f, err := ParseFrame(trama)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Bits(); err == nil {
+			t.Errorf("payload %x: se esperaba error", payload)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package frame
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Bitset guarda bits empaquetados de a 64 por palabra, el primero en el bit
+// más significativo. Ocupa la octava parte que el slice de un byte por bit de
+// BytesToBits, que con payloads grandes (y más aún tras Hamming) multiplica la
+// memoria. Los bits más allá de Len son siempre cero.
+type Bitset struct {
+	words []uint64
+	n     int
+}
+
+// NewBitset crea un Bitset de n bits en cero
+func NewBitset(n int) *Bitset {
+	return &Bitset{words: make([]uint64, (n+63)/64), n: n}
+}
+
+// BitsetFromBytes es BytesToBits sobre un Bitset: los bits de data, el más
+// significativo de cada byte primero
+func BitsetFromBytes(data []byte) *Bitset {
+	b := NewBitset(len(data) * 8)
+	for i, v := range data {
+		b.words[i/8] |= uint64(v) << (56 - 8*(i%8))
+	}
+	return b
+}
+
+// BitsetFromBits empaqueta bits de a uno por byte; falla si alguno no es 0 o 1
+func BitsetFromBits(bitsIn []byte) (*Bitset, error) {
+	b := NewBitset(len(bitsIn))
+	for i, v := range bitsIn {
+		if v != 0 && v != 1 {
+			return nil, invalidBit(i, v)
+		}
+		b.words[i/64] |= uint64(v) << (63 - i%64)
+	}
+	return b, nil
+}
+
+// Len es la cantidad de bits
+func (b *Bitset) Len() int { return b.n }
+
+func (b *Bitset) mask(i int) (int, uint64) {
+	if i < 0 || i >= b.n {
+		panic(fmt.Sprintf("frame: bit %d fuera de rango [0, %d)", i, b.n))
+	}
+	return i / 64, 1 << (63 - i%64)
+}
+
+// Get devuelve el bit i (0 o 1)
+func (b *Bitset) Get(i int) byte {
+	w, m := b.mask(i)
+	if b.words[w]&m != 0 {
+		return 1
+	}
+	return 0
+}
+
+// Set fija el bit i en v; cualquier valor distinto de cero cuenta como 1
+func (b *Bitset) Set(i int, v byte) {
+	w, m := b.mask(i)
+	if v != 0 {
+		b.words[w] |= m
+	} else {
+		b.words[w] &^= m
+	}
+}
+
+// Flip invierte el bit i
+func (b *Bitset) Flip(i int) {
+	w, m := b.mask(i)
+	b.words[w] ^= m
+}
+
+// Append agrega v (0 o 1, como en Set) al final
+func (b *Bitset) Append(v byte) {
+	if b.n%64 == 0 {
+		b.words = append(b.words, 0)
+	}
+	b.n++
+	b.Set(b.n-1, v)
+}
+
+// Count es la cantidad de bits en 1
+func (b *Bitset) Count() int {
+	total := 0
+	for _, w := range b.words {
+		total += bits.OnesCount64(w)
+	}
+	return total
+}
+
+// Clone devuelve una copia independiente
+func (b *Bitset) Clone() *Bitset {
+	return &Bitset{words: append([]uint64(nil), b.words...), n: b.n}
+}
+
+// Equal indica si b y o tienen los mismos bits
+func (b *Bitset) Equal(o *Bitset) bool {
+	if b.n != o.n {
+		return false
+	}
+	for i, w := range b.words {
+		if w != o.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes es BitsToBytes sobre el Bitset: agrupa los bits en bytes y completa
+// el último con ceros
+func (b *Bitset) Bytes() []byte {
+	out := make([]byte, (b.n+7)/8)
+	for i := range out {
+		out[i] = byte(b.words[i/8] >> (56 - 8*(i%8)))
+	}
+	return out
+}
+
+// Bits devuelve los bits de a uno por byte, como BytesToBits
+func (b *Bitset) Bits() []byte {
+	out := make([]byte, b.n)
+	for i := range out {
+		out[i] = byte(b.words[i/64]>>(63-i%64)) & 1
+	}
+	return out
+}
+
+// Hamming74EncodeBitset es Hamming74Encode sobre un Bitset: completa data con
+// ceros hasta un múltiplo de 4 y codifica cada bloque en 7 bits
+func Hamming74EncodeBitset(data *Bitset) *Bitset {
+	return hammingEncodeBitset(data, false)
+}
+
+// Hamming84EncodeBitset es Hamming84Encode sobre un Bitset: cada bloque de
+// Hamming74EncodeBitset más su bit de paridad global
+func Hamming84EncodeBitset(data *Bitset) *Bitset {
+	return hammingEncodeBitset(data, true)
+}
+
+// hammingTabla tiene el bloque de cada nibble de datos en los bits bajos:
+// [p2 p1 d3 p0 d2 d1 d0] y, en la columna SEC-DED, la paridad global al final
+var hammingTabla = func() (t [2][16]uint64) {
+	for v := 0; v < 16; v++ {
+		d3, d2, d1, d0 := byte(v>>3)&1, byte(v>>2)&1, byte(v>>1)&1, byte(v)&1
+		bloque := []byte{d2 ^ d1 ^ d0, d3 ^ d1 ^ d0, d3, d3 ^ d2 ^ d0, d2, d1, d0}
+		var code, paridad uint64
+		for _, b := range bloque {
+			code = code<<1 | uint64(b)
+			paridad ^= uint64(b)
+		}
+		t[0][v], t[1][v] = code, code<<1|paridad
+	}
+	return t
+}()
+
+func hammingEncodeBitset(data *Bitset, secded bool) *Bitset {
+	n, tabla := 7, &hammingTabla[0]
+	if secded {
+		n, tabla = 8, &hammingTabla[1]
+	}
+	// Los bits más allá de Len son cero, así que el último nibble ya viene
+	// completado con ceros
+	numBlocks := (data.n + 3) / 4
+	out := NewBitset(numBlocks * n)
+	for i := 0; i < numBlocks; i++ {
+		nibble := data.words[i/16] >> (60 - 4*(i%16)) & 0xF
+		out.ponerBits(i*n, tabla[nibble], n)
+	}
+	return out
+}
+
+// ponerBits escribe los w bits bajos de v desde la posición pos, que deben
+// estar en cero
+func (b *Bitset) ponerBits(pos int, v uint64, w int) {
+	palabra, desde := pos/64, pos%64
+	if desde+w <= 64 {
+		b.words[palabra] |= v << (64 - desde - w)
+		return
+	}
+	resto := desde + w - 64
+	b.words[palabra] |= v >> resto
+	b.words[palabra+1] |= v << (64 - resto)
+}
+
+// EncodeHammingPayloadBitset es EncodeHammingPayload sobre un Bitset, con
+// encode Hamming74EncodeBitset o Hamming84EncodeBitset: produce el mismo
+// payload sin pasar por un slice de un byte por bit
+func EncodeHammingPayloadBitset(data *Bitset, n, k int, encode func(*Bitset) *Bitset) ([]byte, error) {
+	code := encode(data)
+	packed := code.Bytes()
+	relleno := len(packed)*8/n*k - data.n
+	if relleno < 0 || relleno >= int(HammingFlagInterleave) {
+		return nil, fmt.Errorf("relleno Hamming fuera de rango: %d bits", relleno)
+	}
+	return append([]byte{byte(relleno)}, packed...), nil
+}
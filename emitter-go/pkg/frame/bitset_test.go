@@ -0,0 +1,140 @@
+package frame
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestBitset_ConversionesIgualQueLosSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, largo := range []int{0, 1, 7, 8, 9, 64, 65, 300} {
+		data := make([]byte, largo)
+		rng.Read(data)
+		b := BitsetFromBytes(data)
+		if b.Len() != largo*8 || !bytes.Equal(b.Bits(), BytesToBits(data)) || !bytes.Equal(b.Bytes(), data) {
+			t.Fatalf("%d bytes: el Bitset no coincide con BytesToBits", largo)
+		}
+
+		// Con una cantidad de bits que no es múltiplo de 8
+		bitsIn := BytesToBits(data)
+		if largo > 0 {
+			bitsIn = bitsIn[:len(bitsIn)-3]
+		}
+		b, err := BitsetFromBits(bitsIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.Bits(), bitsIn) || !bytes.Equal(b.Bytes(), BitsToBytes(bitsIn)) {
+			t.Errorf("%d bits: el Bitset no coincide con BitsToBytes", len(bitsIn))
+		}
+	}
+	if _, err := BitsetFromBits([]byte{0, 1, 2}); err == nil {
+		t.Error("se esperaba error con un bit inválido")
+	}
+}
+
+func TestBitset_GetSetFlipAppend(t *testing.T) {
+	b := NewBitset(0)
+	var ref []byte
+	for i := 0; i < 200; i++ {
+		v := byte(i*7/3) & 1
+		b.Append(v)
+		ref = append(ref, v)
+	}
+	b.Set(3, 1)
+	ref[3] = 1
+	b.Set(130, 0)
+	ref[130] = 0
+	b.Flip(64)
+	ref[64] ^= 1
+	b.Flip(199)
+	ref[199] ^= 1
+
+	if b.Len() != len(ref) || !bytes.Equal(b.Bits(), ref) {
+		t.Fatalf("bits %v, se esperaba %v", b.Bits(), ref)
+	}
+	for i, v := range ref {
+		if b.Get(i) != v {
+			t.Errorf("Get(%d) = %d, se esperaba %d", i, b.Get(i), v)
+		}
+	}
+	if want := bytes.Count(ref, []byte{1}); b.Count() != want {
+		t.Errorf("Count = %d, se esperaba %d", b.Count(), want)
+	}
+
+	c := b.Clone()
+	c.Flip(0)
+	if b.Equal(c) || b.Get(0) != ref[0] {
+		t.Error("Clone comparte los bits con el original")
+	}
+	c.Flip(0)
+	if !b.Equal(c) {
+		t.Error("Equal no reconoce los mismos bits")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("se esperaba panic fuera de rango")
+		}
+	}()
+	b.Get(b.Len())
+}
+
+func TestEncodeHammingPayloadBitset_IgualQueElDeSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	casos := []struct {
+		nombre string
+		n      int
+		slices func([]byte) ([]byte, error)
+		bitset func(*Bitset) *Bitset
+	}{
+		{"hamming74", 7, Hamming74Encode, Hamming74EncodeBitset},
+		{"hamming84", 8, Hamming84Encode, Hamming84EncodeBitset},
+	}
+	for _, c := range casos {
+		for _, largo := range []int{0, 1, 3, 4, 5, 17, 100, 803} {
+			bitsIn := make([]byte, largo)
+			for i := range bitsIn {
+				bitsIn[i] = byte(rng.Intn(2))
+			}
+			want, err := EncodeHammingPayload(bitsIn, c.n, 4, c.slices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := BitsetFromBits(bitsIn)
+			got, err := EncodeHammingPayloadBitset(b, c.n, 4, c.bitset)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Errorf("%s, %d bits: % x, se esperaba % x (%v)", c.nombre, largo, got, want, err)
+			}
+		}
+	}
+}
+
+// payloadGrande es el caso que motiva Bitset: 64 KB de datos
+var payloadGrande = bytes.Repeat([]byte("Bitset! "), 8192)
+
+func BenchmarkHammingPayload_Slices(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeHammingPayload(BytesToBits(payloadGrande), 7, 4, Hamming74Encode); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHammingPayload_Bitset(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeHammingPayloadBitset(BitsetFromBytes(payloadGrande), 7, 4, Hamming74EncodeBitset); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBitsetFromBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = BitsetFromBytes(payloadGrande)
+	}
+}
@@ -0,0 +1,233 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// ChecksumKind es el algoritmo de verificación al final de la trama. Va en los
+// bits 4-6 del byte de tipo; 0 es CRC-32, así que las tramas anteriores a los
+// demás algoritmos siguen siendo válidas.
+type ChecksumKind byte
+
+const (
+	ChecksumCRC32      ChecksumKind = iota // CRC-32 IEEE, 4 bytes
+	ChecksumCRC8                           // CRC-8 (polinomio 0x07, init 0x00), 1 byte
+	ChecksumCRC16CCITT                     // CRC-16-CCITT (polinomio 0x1021, init 0xFFFF), 2 bytes
+	ChecksumFletcher16                     // Fletcher-16 (sumas módulo 255), 2 bytes
+	ChecksumCRC32C                         // CRC-32C Castagnoli, 4 bytes (SSE4.2 en amd64)
+)
+
+// ChecksumKinds son los algoritmos soportados, en orden de ancho
+var ChecksumKinds = []ChecksumKind{ChecksumCRC8, ChecksumCRC16CCITT, ChecksumFletcher16, ChecksumCRC32, ChecksumCRC32C}
+
+// castagnoli es la tabla de CRC-32C; hash/crc32 usa la instrucción CRC32 del
+// procesador cuando está disponible
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+const (
+	checksumShift = 4
+	checksumMask  = byte(0x07) << checksumShift
+)
+
+// ErrChecksumKind indica un algoritmo de verificación desconocido en el header
+var ErrChecksumKind = errors.New("algoritmo de verificación desconocido")
+
+// ErrPayloadTooLarge indica un payload que no entra en el campo de longitud de
+// 2 bytes del header (o que requiere más fragmentos de los numerables)
+var ErrPayloadTooLarge = errors.New("payload demasiado grande")
+
+func (k ChecksumKind) String() string {
+	switch k {
+	case ChecksumCRC8:
+		return "crc8"
+	case ChecksumCRC16CCITT:
+		return "crc16"
+	case ChecksumFletcher16:
+		return "fletcher16"
+	case ChecksumCRC32:
+		return "crc32"
+	case ChecksumCRC32C:
+		return "crc32c"
+	default:
+		return fmt.Sprintf("checksum(%d)", byte(k))
+	}
+}
+
+// Size es la cantidad de bytes del checksum (0 si k no es conocido)
+func (k ChecksumKind) Size() int {
+	switch k {
+	case ChecksumCRC8:
+		return 1
+	case ChecksumCRC16CCITT, ChecksumFletcher16:
+		return 2
+	case ChecksumCRC32, ChecksumCRC32C:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Sum calcula el checksum de data, alineado a la derecha en un uint32
+func (k ChecksumKind) Sum(data []byte) uint32 {
+	switch k {
+	case ChecksumCRC8:
+		return uint32(crc8(data))
+	case ChecksumCRC16CCITT:
+		return uint32(crc16CCITT(data))
+	case ChecksumFletcher16:
+		return uint32(fletcher16(data))
+	case ChecksumCRC32C:
+		return crc32.Checksum(data, castagnoli)
+	default:
+		return crc32.ChecksumIEEE(data)
+	}
+}
+
+// ParseChecksumKind interpreta el nombre devuelto por String
+func ParseChecksumKind(nombre string) (ChecksumKind, error) {
+	for _, k := range ChecksumKinds {
+		if k.String() == nombre {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q (opciones: crc8, crc16, fletcher16, crc32, crc32c)", ErrChecksumKind, nombre)
+}
+
+// ChecksumKindOf es el algoritmo de verificación declarado en el header de frame
+func ChecksumKindOf(frame []byte) ChecksumKind {
+	if len(frame) == 0 {
+		return ChecksumCRC32
+	}
+	return ChecksumKind((frame[0] & checksumMask) >> checksumShift)
+}
+
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// fletcher16 devuelve sum2<<8 | sum1, con ambas sumas módulo 255
+func fletcher16(data []byte) uint16 {
+	var sum1, sum2 uint16
+	for _, b := range data {
+		sum1 = (sum1 + uint16(b)) % 255
+		sum2 = (sum2 + sum1) % 255
+	}
+	return sum2<<8 | sum1
+}
+
+// FrameOptions describe el header de BuildFrameWithOptions
+type FrameOptions struct {
+	MsgType  byte // MsgTypeData si es 0
+	Checksum ChecksumKind
+	Seq      *uint16 // Número de secuencia (nil sin FlagSeq)
+	// Content agrega el byte de contenido al header (ver MsgTypeContent);
+	// ContentUnspecified no lo agrega
+	Content ContentType
+	// Scramble aleatoriza el payload ya codificado con Scramble y lo declara
+	// con FlagScrambled en el byte de contenido
+	Scramble bool
+}
+
+// BuildFrameWithChecksum es BuildFrame verificando la trama con kind en lugar
+// de CRC-32
+func BuildFrameWithChecksum(payload []byte, kind ChecksumKind) ([]byte, error) {
+	return BuildFrameOpts(payload, WithChecksum(kind))
+}
+
+// BuildFrameWithFletcher16 es BuildFrame con un checksum Fletcher-16 de 2
+// bytes en lugar del CRC-32
+func BuildFrameWithFletcher16(payload []byte) ([]byte, error) {
+	return BuildFrameWithChecksum(payload, ChecksumFletcher16)
+}
+
+// VerifyFletcher16Frame es VerifyFrame exigiendo que la trama declare
+// Fletcher-16
+func VerifyFletcher16Frame(frame []byte) error {
+	if kind := ChecksumKindOf(frame); kind != ChecksumFletcher16 {
+		return fmt.Errorf("%w: la trama declara %s, se esperaba fletcher16", ErrChecksumKind, kind)
+	}
+	return VerifyFrame(frame)
+}
+
+// BuildFrameWithOptions construye [tipo(1)][longitud(2)][seq(2)?][contenido(1)?]
+// + Payload + [checksum(1, 2 o 4)], con el algoritmo de verificación y FlagSeq
+// codificados en el byte de tipo. Con opts.Content u opts.Scramble el tipo de
+// mensaje pasa al byte de contenido y el byte de tipo lleva MsgTypeContent. Un
+// payload de más de 65535 bytes falla con ErrPayloadTooLarge.
+func BuildFrameWithOptions(payload []byte, opts FrameOptions) ([]byte, error) {
+	msgType := opts.MsgType
+	if msgType == 0 {
+		msgType = MsgTypeData
+	}
+	if msgType&(FlagSeq|checksumMask) != 0 {
+		return nil, fmt.Errorf("tipo de mensaje inválido: %#02x (los bits %#02x son del header)", msgType, FlagSeq|checksumMask)
+	}
+	size := opts.Checksum.Size()
+	if size == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrChecksumKind, byte(opts.Checksum))
+	}
+	if opts.Content > maxContentType {
+		return nil, fmt.Errorf("tipo de contenido inválido: %d (el byte de contenido admite hasta %d)", byte(opts.Content), maxContentType)
+	}
+	if len(payload) > 0xFFFF {
+		return nil, fmt.Errorf("%w: %d bytes (límite 65535)", ErrPayloadTooLarge, len(payload))
+	}
+
+	header := HeaderSize
+	if opts.Seq != nil {
+		header = SeqHeaderSize
+	}
+	extendido := opts.Content != ContentUnspecified || opts.Scramble
+	if extendido {
+		header += ContentHeaderSize
+	}
+	frame := make([]byte, header, header+len(payload)+size)
+	frame[0] = msgType | byte(opts.Checksum)<<checksumShift
+	binary.BigEndian.PutUint16(frame[1:3], uint16(len(payload)))
+	if opts.Seq != nil {
+		frame[0] |= FlagSeq
+		binary.BigEndian.PutUint16(frame[3:5], *opts.Seq)
+	}
+	if extendido {
+		frame[0] &^= 0x0F
+		frame[header-1] = byte(opts.Content)<<4 | msgType
+	}
+	if opts.Scramble {
+		frame[header-1] |= FlagScrambled
+		payload = scrambleBytes(payload)
+	}
+	frame = append(frame, payload...)
+
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], opts.Checksum.Sum(frame))
+	return append(frame, sum[4-size:]...), nil
+}
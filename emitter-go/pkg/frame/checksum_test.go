@@ -0,0 +1,263 @@
+package frame
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestChecksumKind_VectoresConocidos(t *testing.T) {
+	// Valor "check" de cada algoritmo sobre "123456789" (catálogo de CRCs de
+	// Greg Cook: CRC-8/SMBUS, CRC-16/CCITT-FALSE, CRC-32/ISO-HDLC, CRC-32/ISCSI)
+	tests := []struct {
+		kind ChecksumKind
+		want uint32
+	}{
+		{ChecksumCRC8, 0xF4},
+		{ChecksumCRC16CCITT, 0x29B1},
+		{ChecksumCRC32, 0xCBF43926},
+		{ChecksumCRC32C, 0xE3069283},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.Sum([]byte("123456789")); got != tt.want {
+			t.Errorf("%s: check = %#x, se esperaba %#x", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFrameWithChecksum_RoundTrip(t *testing.T) {
+	for _, kind := range ChecksumKinds {
+		t.Run(kind.String(), func(t *testing.T) {
+			trama, err := BuildFrameWithChecksum([]byte("Hola"), kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(trama) != HeaderSize+4+kind.Size() || ChecksumKindOf(trama) != kind {
+				t.Fatalf("trama de %d bytes con algoritmo %s", len(trama), ChecksumKindOf(trama))
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if f.MsgType != MsgTypeData || f.Checksum != kind || string(f.Payload) != "Hola" {
+				t.Errorf("trama decodificada inesperada: %+v", f)
+			}
+
+			trama[HeaderSize] ^= 0x01
+			if err := VerifyFrame(trama); !errors.Is(err, ErrCRCMismatch) {
+				t.Errorf("error %v, se esperaba %v", err, ErrCRCMismatch)
+			}
+			if _, err := ParseFrame(trama[:HeaderSize+kind.Size()-1]); !errors.Is(err, ErrTruncated) {
+				t.Errorf("error %v, se esperaba %v", err, ErrTruncated)
+			}
+		})
+	}
+
+	// CRC-32 sigue siendo la trama de BuildFrame
+	a, _ := BuildFrame([]byte("Hola"))
+	b, _ := BuildFrameWithChecksum([]byte("Hola"), ChecksumCRC32)
+	if string(a) != string(b) {
+		t.Errorf("BuildFrameWithChecksum(crc32) = %x, BuildFrame = %x", b, a)
+	}
+}
+
+func TestBuildFrameWithOptions_SeqYChecksum(t *testing.T) {
+	seq := uint16(42)
+	trama, err := BuildFrameWithOptions([]byte("Hola"), FrameOptions{MsgType: MsgTypeHamming, Checksum: ChecksumCRC16CCITT, Seq: &seq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeHamming || f.Checksum != ChecksumCRC16CCITT || !f.HasSeq || f.Seq != 42 {
+		t.Errorf("trama decodificada inesperada: %+v", f)
+	}
+
+	if _, err := BuildFrameWithChecksum(nil, ChecksumKind(5)); !errors.Is(err, ErrChecksumKind) {
+		t.Errorf("error %v, se esperaba %v", err, ErrChecksumKind)
+	}
+	trama[0] = MsgTypeData | 5<<checksumShift
+	if _, err := ParseFrame(trama); !errors.Is(err, ErrChecksumKind) {
+		t.Errorf("error %v, se esperaba %v", err, ErrChecksumKind)
+	}
+}
+
+func TestParseChecksumKind(t *testing.T) {
+	for _, kind := range ChecksumKinds {
+		if got, err := ParseChecksumKind(kind.String()); err != nil || got != kind {
+			t.Errorf("ParseChecksumKind(%q) = %v, %v", kind.String(), got, err)
+		}
+	}
+	if _, err := ParseChecksumKind("md5"); !errors.Is(err, ErrChecksumKind) {
+		t.Errorf("error %v, se esperaba %v", err, ErrChecksumKind)
+	}
+}
+
+// Dos bits invertidos a distancia d pasan inadvertidos si x^d + 1 es múltiplo
+// del polinomio generador: en CRC-8 (0x07) pasa cada 127 bits, mientras que
+// CRC-16-CCITT y CRC-32 detectan todos los pares dentro de una trama corta
+func TestChecksumKind_DosBitsInvertidos(t *testing.T) {
+	payload := make([]byte, 64)
+	for i := range payload {
+		payload[i] = byte(i * 37)
+	}
+
+	noDetectados := make(map[ChecksumKind]int)
+	for _, kind := range ChecksumKinds {
+		base, err := BuildFrameWithChecksum(payload, kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inicio := HeaderSize * 8
+		for d := 1; d < len(payload)*8; d++ {
+			trama := append([]byte(nil), base...)
+			for _, bit := range []int{inicio, inicio + d} {
+				trama[bit/8] ^= 0x80 >> (bit % 8)
+			}
+			if VerifyFrame(trama) == nil {
+				noDetectados[kind]++
+			}
+		}
+	}
+
+	// Distancias 127, 254, 381 y 508 dentro de los 511 bits probados
+	if noDetectados[ChecksumCRC8] != 4 {
+		t.Errorf("CRC-8 dejó pasar %d pares, se esperaban 4", noDetectados[ChecksumCRC8])
+	}
+	if noDetectados[ChecksumCRC16CCITT] != 0 || noDetectados[ChecksumCRC32] != 0 || noDetectados[ChecksumCRC32C] != 0 {
+		t.Errorf("CRC-16 dejó pasar %d pares, CRC-32 %d y CRC-32C %d, se esperaba 0",
+			noDetectados[ChecksumCRC16CCITT], noDetectados[ChecksumCRC32], noDetectados[ChecksumCRC32C])
+	}
+}
+
+func TestCRC32C_VectoresConocidos(t *testing.T) {
+	// Vectores de la RFC 3720 (iSCSI), apéndice B.4
+	ascendente := make([]byte, 32)
+	for i := range ascendente {
+		ascendente[i] = byte(i)
+	}
+	for _, tt := range []struct {
+		nombre string
+		data   []byte
+		want   uint32
+	}{
+		{"32 ceros", make([]byte, 32), 0x8A9136AA},
+		{"32 bytes 0xFF", bytes.Repeat([]byte{0xFF}, 32), 0x62A8AB43},
+		{"0x00..0x1F", ascendente, 0x46DD794E},
+	} {
+		if got := ChecksumCRC32C.Sum(tt.data); got != tt.want {
+			t.Errorf("CRC-32C(%s) = %#08x, se esperaba %#08x", tt.nombre, got, tt.want)
+		}
+	}
+}
+
+func TestBuildFrameWithChecksum_CRC32CTrama(t *testing.T) {
+	// El CRC-32C cubre header y payload y va al final en big-endian, como el
+	// CRC-32: el receptor lee los 4 últimos bytes con int.from_bytes(..., "big")
+	trama, err := BuildFrameWithChecksum([]byte("Hola"), ChecksumCRC32C)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(trama); got != "410004486f6c61be02750c" {
+		t.Errorf("trama = %s, se esperaba 410004486f6c61be02750c", got)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Checksum != ChecksumCRC32C || f.CRC != 0xBE02750C {
+		t.Errorf("checksum %s = %#08x, se esperaba crc32c = 0xbe02750c", f.Checksum, f.CRC)
+	}
+
+	// Una trama con CRC-32 IEEE en el trailer no verifica declarando CRC-32C
+	ieee, _ := BuildFrame([]byte("Hola"))
+	ieee[0] |= byte(ChecksumCRC32C) << checksumShift
+	if err := VerifyFrame(ieee); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("error %v, se esperaba %v", err, ErrCRCMismatch)
+	}
+}
+
+func TestFletcher16_VectoresConocidos(t *testing.T) {
+	for _, tt := range []struct {
+		data string
+		want uint32
+	}{
+		{"abcde", 0xC8F0},
+		{"abcdef", 0x2057},
+		{"abcdefgh", 0x0627},
+	} {
+		if got := ChecksumFletcher16.Sum([]byte(tt.data)); got != tt.want {
+			t.Errorf("Fletcher-16(%q) = %#04x, se esperaba %#04x", tt.data, got, tt.want)
+		}
+	}
+}
+
+// Fletcher-16 no ve dos bits iguales invertidos en sentidos opuestos a 255
+// bytes de distancia: la suma simple no cambia y la ponderada cambia en 255,
+// que es 0 módulo 255. CRC-32 sí los detecta.
+func TestFletcher16_ErrorNoDetectado(t *testing.T) {
+	payload := make([]byte, 300)
+	payload[255] = 0x80
+	invertir := func(trama []byte) []byte {
+		trama = append([]byte(nil), trama...)
+		trama[HeaderSize] ^= 0x80     // 0x00 → 0x80
+		trama[HeaderSize+255] ^= 0x80 // 0x80 → 0x00
+		return trama
+	}
+
+	fletcher, err := BuildFrameWithFletcher16(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFletcher16Frame(fletcher); err != nil {
+		t.Fatalf("la trama sin errores no verifica: %v", err)
+	}
+	if err := VerifyFletcher16Frame(invertir(fletcher)); err != nil {
+		t.Errorf("Fletcher-16 debería dejar pasar el patrón, dio %v", err)
+	}
+
+	crc, err := BuildFrame(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFrame(invertir(crc)); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("CRC-32 debería detectar el patrón, dio %v", err)
+	}
+	if err := VerifyFletcher16Frame(crc); !errors.Is(err, ErrChecksumKind) {
+		t.Errorf("error %v, se esperaba %v", err, ErrChecksumKind)
+	}
+}
+
+func TestErrPayloadTooLarge(t *testing.T) {
+	grande := make([]byte, 0x10000)
+	if _, err := BuildFrame(grande); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("BuildFrame: %v, se esperaba ErrPayloadTooLarge", err)
+	}
+	if _, err := Parity2DEncode(grande, 8); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("Parity2DEncode: %v, se esperaba ErrPayloadTooLarge", err)
+	}
+	if _, err := BuildFragmentedFrames(grande, 1); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("BuildFragmentedFrames: %v, se esperaba ErrPayloadTooLarge", err)
+	}
+	if _, err := BuildFrame(grande[:0xFFFF]); err != nil {
+		t.Errorf("un payload de 65535 bytes debería entrar: %v", err)
+	}
+}
+
+// BenchmarkChecksum_64KB compara CRC-32 IEEE con CRC-32C sobre el payload más
+// grande que entra en una trama; en amd64 ambos usan instrucciones del
+// procesador (PCLMULQDQ y CRC32 de SSE4.2)
+func BenchmarkChecksum_64KB(b *testing.B) {
+	payload := bytes.Repeat([]byte("Hola mundo! "), 0xFFFF/12+1)[:0xFFFF]
+	for _, kind := range []ChecksumKind{ChecksumCRC32, ChecksumCRC32C} {
+		b.Run(kind.String(), func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			for i := 0; i < b.N; i++ {
+				_ = kind.Sum(payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package frame
+
+import "fmt"
+
+// ContentType indica cómo interpretar los datos de la trama una vez
+// verificada y decodificada: texto ASCII o UTF-8, bytes arbitrarios o datos
+// comprimidos
+type ContentType byte
+
+const (
+	ContentUnspecified ContentType = iota // La trama no declara contenido
+	ContentASCII                          // Texto ASCII de la capa de presentación
+	ContentUTF8                           // Texto UTF-8
+	ContentBinary                         // Bytes sin interpretación
+	ContentCompressed                     // Datos comprimidos
+)
+
+// ContentTypes son los tipos de contenido registrados
+var ContentTypes = []ContentType{ContentASCII, ContentUTF8, ContentBinary, ContentCompressed}
+
+// MsgTypeContent en los bits 0-3 del byte de tipo indica que el header trae,
+// tras el largo y la secuencia, un byte de contenido con el tipo de mensaje
+// en los bits 0-3, el ContentType en los bits 4-6 y FlagScrambled en el bit 7.
+// Ningún tipo de mensaje usa el 0, así que las tramas sin contenido no
+// cambian.
+const MsgTypeContent byte = 0x00
+
+// ContentHeaderSize es lo que agrega el byte de contenido al header
+const ContentHeaderSize = 1
+
+// maxContentType es el mayor ContentType que entra en el byte de contenido
+const maxContentType = 0x07
+
+func (c ContentType) String() string {
+	switch c {
+	case ContentUnspecified:
+		return "sin especificar"
+	case ContentASCII:
+		return "ascii"
+	case ContentUTF8:
+		return "utf8"
+	case ContentBinary:
+		return "binario"
+	case ContentCompressed:
+		return "comprimido"
+	default:
+		return fmt.Sprintf("contenido(%d)", byte(c))
+	}
+}
+
+// Known indica si c es uno de los tipos registrados o ContentUnspecified.
+// ParseFrame acepta tramas con contenido desconocido; quien las recibe decide
+// si interpretar el payload.
+func (c ContentType) Known() bool {
+	return c <= ContentCompressed
+}
+
+// hasContent indica si el byte de tipo de frame anuncia el byte de contenido
+func hasContent(frame []byte) bool {
+	return len(frame) > 0 && frame[0]&^(FlagSeq|checksumMask) == MsgTypeContent
+}
+
+// MsgTypeOf es el tipo de mensaje declarado en el header de frame, sin
+// FlagSeq ni algoritmo de verificación y leído del byte de contenido si lo
+// trae (0 si la trama no llega hasta él)
+func MsgTypeOf(frame []byte) byte {
+	if len(frame) == 0 {
+		return 0
+	}
+	if !hasContent(frame) {
+		return frame[0] &^ (FlagSeq | checksumMask)
+	}
+	if h := HeaderLen(frame); len(frame) >= h {
+		return frame[h-1] & 0x0F
+	}
+	return 0
+}
+
+// ContentTypeOf es el tipo de contenido declarado en el header de frame;
+// ContentUnspecified si no trae el byte de contenido
+func ContentTypeOf(frame []byte) ContentType {
+	if h := HeaderLen(frame); hasContent(frame) && len(frame) >= h {
+		return ContentType(frame[h-1] &^ FlagScrambled >> 4)
+	}
+	return ContentUnspecified
+}
+
+// IsScrambled indica si el header de frame declara el payload aleatorizado
+// con Scramble (FlagScrambled en el byte de contenido)
+func IsScrambled(frame []byte) bool {
+	h := HeaderLen(frame)
+	return hasContent(frame) && len(frame) >= h && frame[h-1]&FlagScrambled != 0
+}
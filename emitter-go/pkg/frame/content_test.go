@@ -0,0 +1,132 @@
+package frame
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBuildFrameWithOptions_ContenidoRegistrado(t *testing.T) {
+	seq := uint16(7)
+	for _, content := range ContentTypes {
+		for _, opts := range []FrameOptions{
+			{MsgType: MsgTypeData, Content: content},
+			{MsgType: MsgTypeHamming, Checksum: ChecksumCRC16CCITT, Seq: &seq, Content: content},
+		} {
+			trama, err := BuildFrameWithOptions([]byte("Hola"), opts)
+			if err != nil {
+				t.Fatalf("%s: %v", content, err)
+			}
+			if len(trama) != HeaderLen(trama)+4+opts.Checksum.Size() || trama[0]&0x0F != MsgTypeContent {
+				t.Fatalf("%s: trama %x sin el byte de contenido", content, trama)
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatalf("%s: %v", content, err)
+			}
+			if f.Content != content || !f.Content.Known() || f.MsgType != opts.MsgType || f.Checksum != opts.Checksum ||
+				f.HasSeq != (opts.Seq != nil) || string(f.Payload) != "Hola" {
+				t.Errorf("%s: trama decodificada inesperada: %+v", content, f)
+			}
+			if ContentTypeOf(trama) != content || MsgTypeOf(trama) != opts.MsgType {
+				t.Errorf("%s: ContentTypeOf = %s, MsgTypeOf = %#02x", content, ContentTypeOf(trama), MsgTypeOf(trama))
+			}
+		}
+	}
+}
+
+func TestBuildFrameWithOptions_ContenidoLayout(t *testing.T) {
+	// [tipo 0x00][largo][contenido utf8 (2) | tipo datos (1)][payload][CRC-32]
+	trama, err := BuildFrameWithOptions([]byte("Hi"), FrameOptions{Content: ContentUTF8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(trama[:HeaderSize+ContentHeaderSize+2]); got != "000002214869" {
+		t.Errorf("header y payload = %s, se esperaba 000002214869", got)
+	}
+	// El byte de contenido va tras la secuencia y lo cubre el checksum
+	seq := uint16(0x1234)
+	trama, _ = BuildFrameWithOptions([]byte("Hi"), FrameOptions{Seq: &seq, Content: ContentBinary})
+	if got := hex.EncodeToString(trama[:SeqHeaderSize+ContentHeaderSize]); got != "800002123431" {
+		t.Errorf("header = %s, se esperaba 800002123431", got)
+	}
+	trama[SeqHeaderSize] = byte(ContentASCII)<<4 | MsgTypeData
+	if err := VerifyFrame(trama); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("cambiar el contenido: %v, se esperaba %v", err, ErrCRCMismatch)
+	}
+
+	// Sin contenido la trama es la de siempre
+	a, _ := BuildFrame([]byte("Hi"))
+	b, _ := BuildFrameWithOptions([]byte("Hi"), FrameOptions{Content: ContentUnspecified})
+	if !bytes.Equal(a, b) {
+		t.Errorf("ContentUnspecified cambió la trama: %x, se esperaba %x", b, a)
+	}
+	if f, _ := ParseFrame(a); f.Content != ContentUnspecified || ContentTypeOf(a) != ContentUnspecified {
+		t.Errorf("una trama sin byte de contenido declara %s", f.Content)
+	}
+}
+
+func TestParseFrame_ContenidoDesconocido(t *testing.T) {
+	desconocido := ContentType(0x06)
+	trama, err := BuildFrameWithOptions([]byte("Hola"), FrameOptions{Content: desconocido})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatalf("una trama con contenido desconocido debe decodificarse: %v", err)
+	}
+	if f.Content != desconocido || f.Content.Known() || f.Content.String() != "contenido(6)" {
+		t.Errorf("contenido %s (conocido: %v)", f.Content, f.Content.Known())
+	}
+	if data, err := f.Data(); err != nil || string(data) != "Hola" {
+		t.Errorf("Data = %q, %v", data, err)
+	}
+
+	var volcado strings.Builder
+	if err := Dump(&volcado, trama); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(volcado.String(), "Contenido:    contenido(6) ⚠ desconocido") ||
+		!strings.Contains(volcado.String(), "Tipo:         0x01 data") {
+		t.Errorf("el volcado no marca el contenido desconocido:\n%s", volcado.String())
+	}
+
+	if _, err := BuildFrameWithOptions(nil, FrameOptions{Content: 0x08}); err == nil {
+		t.Error("se esperaba error con un contenido que no entra en 3 bits")
+	}
+}
+
+func TestParseFrame_ContenidoTruncado(t *testing.T) {
+	// El byte de tipo anuncia el byte de contenido pero la trama termina antes
+	if _, err := ParseFrame([]byte{MsgTypeContent, 0, 0, 0, 0, 0, 0}); !errors.Is(err, ErrFrameTooShort) {
+		t.Errorf("error %v, se esperaba %v", err, ErrFrameTooShort)
+	}
+	if MsgTypeOf([]byte{MsgTypeContent, 0, 0}) != 0 || ContentTypeOf([]byte{MsgTypeContent, 0, 0}) != ContentUnspecified {
+		t.Error("MsgTypeOf y ContentTypeOf deben tolerar un header truncado")
+	}
+}
+
+func TestFrame_OptionsConContenido(t *testing.T) {
+	trama, err := BuildFrameOpts([]byte("Hola"), WithHamming74(), WithContent(ContentCompressed), WithSeq(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := f.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otra, err := BuildFrameOpts(data, f.Options()...)
+	if err != nil || !bytes.Equal(otra, trama) {
+		t.Errorf("las opciones no reproducen la trama: %x, se esperaba %x (%v)", otra, trama, err)
+	}
+	if !strings.Contains(f.String(), ", contenido comprimido,") {
+		t.Errorf("String = %q", f.String())
+	}
+}
@@ -0,0 +1,135 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// CRCSize es el tamaño del CRC-32 al final de la trama (ver ChecksumKind.Size
+// para los demás algoritmos)
+const CRCSize = 4
+
+// Errores de ParseFrame. ErrTruncated y ErrLengthMismatch indican una trama mal
+// formada; ErrCRCMismatch, una trama bien formada cuyo contenido se corrompió.
+// ErrFrameTooShort es el ErrTruncated de una trama que ni siquiera trae el
+// header y el checksum.
+var (
+	ErrTruncated      = errors.New("trama truncada")
+	ErrFrameTooShort  = fmt.Errorf("%w: no alcanza para el header y el checksum", ErrTruncated)
+	ErrLengthMismatch = errors.New("longitud del header no coincide con el payload")
+	ErrCRCMismatch    = errors.New("CRC no coincide")
+)
+
+// CRCMismatchError es el error de ParseFrame cuando el checksum no coincide;
+// envuelve ErrCRCMismatch, así que errors.Is sigue funcionando y errors.As
+// da acceso a los dos valores
+type CRCMismatchError struct {
+	Kind     ChecksumKind
+	Expected uint32 // Calculado sobre header + payload
+	Actual   uint32 // El que trae la trama
+}
+
+func (e *CRCMismatchError) Error() string {
+	size := e.Kind.Size()
+	return fmt.Sprintf("%v: la trama trae %0*x, el contenido da %0*x (%s)", ErrCRCMismatch, size*2, e.Actual, size*2, e.Expected, e.Kind)
+}
+
+func (e *CRCMismatchError) Unwrap() error { return ErrCRCMismatch }
+
+// Frame es una trama decodificada por ParseFrame
+type Frame struct {
+	MsgType  byte // Tipo sin FlagSeq ni algoritmo de verificación
+	Payload  []byte
+	CRC      uint32 // Checksum recibido en la trama
+	Checksum ChecksumKind
+	HasSeq   bool   // El header traía FlagSeq
+	Seq      uint16 // Número de secuencia (0 si !HasSeq)
+	// Interleave es la profundidad de entrelazado que declara el subheader
+	// de una trama Hamming (0 sin entrelazar o con otros tipos)
+	Interleave int
+	// Content es el tipo de contenido del header (ContentUnspecified si no
+	// lo trae); puede no ser uno de los registrados, ver ContentType.Known
+	Content ContentType
+	// Scrambled indica que el payload llegó aleatorizado (FlagScrambled);
+	// Payload ya es el descrambleado
+	Scrambled bool
+}
+
+// HeaderLen es el tamaño del header de frame según su byte de tipo:
+// SeqHeaderSize con FlagSeq, HeaderSize sin él, más ContentHeaderSize si
+// trae el byte de contenido (MsgTypeContent)
+func HeaderLen(frame []byte) int {
+	n := HeaderSize
+	if len(frame) > 0 && frame[0]&FlagSeq != 0 {
+		n = SeqHeaderSize
+	}
+	if hasContent(frame) {
+		n += ContentHeaderSize
+	}
+	return n
+}
+
+// ParseFrame decodifica [tipo(1)][largo(2)][payload][CRC(4)], con [seq(2)] tras
+// el largo si el tipo trae FlagSeq, el byte de contenido tras ellos si el tipo
+// es MsgTypeContent y el checksum que indique el tipo (ver ChecksumKind):
+// valida el header, que el largo declarado coincida con el payload presente y
+// que el checksum recalculado sobre header+payload sea el de la trama. Un
+// payload con FlagScrambled se devuelve ya descrambleado. Los errores envuelven ErrTruncated (ErrFrameTooShort si no
+// llega al mínimo), ErrLengthMismatch, ErrCRCMismatch (como *CRCMismatchError)
+// o ErrChecksumKind (ver errors.Is y errors.As).
+func ParseFrame(frame []byte) (*Frame, error) {
+	kind := ChecksumKindOf(frame)
+	size := kind.Size()
+	if size == 0 {
+		return nil, fmt.Errorf("%w: el tipo %#02x declara el algoritmo %d", ErrChecksumKind, frame[0], byte(kind))
+	}
+	header := HeaderLen(frame)
+	if len(frame) < header+size {
+		return nil, fmt.Errorf("%w: %d bytes, el mínimo es %d (header + %s)", ErrFrameTooShort, len(frame), header+size, kind)
+	}
+
+	declarado := int(binary.BigEndian.Uint16(frame[1:3]))
+	presente := len(frame) - header - size
+	if declarado > presente {
+		return nil, fmt.Errorf("%w: el header declara %d bytes de payload pero la trama trae %d", ErrTruncated, declarado, presente)
+	}
+	if declarado != presente {
+		return nil, fmt.Errorf("%w: el header declara %d bytes de payload pero la trama trae %d", ErrLengthMismatch, declarado, presente)
+	}
+
+	fin := len(frame) - size
+	var sum [4]byte
+	copy(sum[4-size:], frame[fin:])
+	recibido := binary.BigEndian.Uint32(sum[:])
+	if calculado := kind.Sum(frame[:fin]); calculado != recibido {
+		return nil, &CRCMismatchError{Kind: kind, Expected: calculado, Actual: recibido}
+	}
+
+	f := &Frame{
+		MsgType:  MsgTypeOf(frame),
+		Payload:  append([]byte(nil), frame[header:fin]...),
+		CRC:      recibido,
+		Checksum: kind,
+		Content:  ContentTypeOf(frame),
+	}
+	if IsScrambled(frame) {
+		f.Scrambled = true
+		f.Payload = descrambleBytes(f.Payload)
+	}
+	if frame[0]&FlagSeq != 0 {
+		f.HasSeq = true
+		f.Seq = binary.BigEndian.Uint16(frame[HeaderSize:SeqHeaderSize])
+	}
+	switch f.MsgType {
+	case MsgTypeHamming, MsgTypeHamming1511, MsgTypeHamming84:
+		f.Interleave = HammingInterleaveDepth(f.Payload)
+	}
+	return f, nil
+}
+
+// VerifyFrame es ParseFrame sin conservar el resultado
+func VerifyFrame(frame []byte) error {
+	_, err := ParseFrame(frame)
+	return err
+}
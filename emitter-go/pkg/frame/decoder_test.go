@@ -0,0 +1,220 @@
+package frame
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestParseFrame_RoundTrip(t *testing.T) {
+	payload := []byte("Hola")
+	trama, err := BuildFrame(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if f.MsgType != MsgTypeData || !bytes.Equal(f.Payload, payload) {
+		t.Errorf("trama decodificada inesperada: %+v", f)
+	}
+	if err := VerifyFrame(trama); err != nil {
+		t.Error("VerifyFrame debería aceptar la misma trama")
+	}
+
+	// El payload devuelto no comparte memoria con la trama
+	trama[HeaderSize] ^= 0xff
+	if f.Payload[0] != 'H' {
+		t.Error("el payload debería ser una copia")
+	}
+}
+
+func TestParseFrame_Errores(t *testing.T) {
+	base, err := BuildFrame([]byte("Hola"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alterar := func(f func(t []byte) []byte) []byte {
+		return f(append([]byte(nil), base...))
+	}
+
+	tests := []struct {
+		name  string
+		trama []byte
+		want  error
+	}{
+		{"bit invertido en el payload", alterar(func(t []byte) []byte { t[HeaderSize+1] ^= 0x04; return t }), ErrCRCMismatch},
+		{"bit invertido en el CRC", alterar(func(t []byte) []byte { t[len(t)-1] ^= 0x01; return t }), ErrCRCMismatch},
+		{"menos que header + CRC", base[:HeaderSize+CRCSize-1], ErrTruncated},
+		{"payload cortado", alterar(func(t []byte) []byte { return append(t[:HeaderSize+2], t[len(t)-CRCSize:]...) }), ErrTruncated},
+		{"bytes de más", alterar(func(t []byte) []byte { return append(t[:HeaderSize+4], append([]byte{0}, t[HeaderSize+4:]...)...) }), ErrLengthMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFrame(tt.trama)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error %v, se esperaba %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFrame_ErroresTipados(t *testing.T) {
+	base, _ := BuildFrame([]byte("Hola"))
+
+	_, err := ParseFrame(base[:HeaderSize+1])
+	if !errors.Is(err, ErrFrameTooShort) || !errors.Is(err, ErrTruncated) {
+		t.Errorf("trama corta: %v, se esperaba ErrFrameTooShort y ErrTruncated", err)
+	}
+	_, err = ParseFrame(append(append([]byte(nil), base[:HeaderSize+2]...), base[len(base)-CRCSize:]...))
+	if !errors.Is(err, ErrTruncated) || errors.Is(err, ErrFrameTooShort) {
+		t.Errorf("payload cortado: %v, se esperaba solo ErrTruncated", err)
+	}
+
+	corrupta := append([]byte(nil), base...)
+	corrupta[len(corrupta)-1] ^= 0x01
+	_, err = ParseFrame(corrupta)
+	var crcErr *CRCMismatchError
+	if !errors.As(err, &crcErr) || !errors.Is(err, ErrCRCMismatch) {
+		t.Fatalf("error %v, se esperaba *CRCMismatchError", err)
+	}
+	fin := len(base) - CRCSize
+	if crcErr.Kind != ChecksumCRC32 || crcErr.Expected != binary.BigEndian.Uint32(base[fin:]) ||
+		crcErr.Actual != binary.BigEndian.Uint32(corrupta[fin:]) {
+		t.Errorf("CRCMismatchError = %+v", crcErr)
+	}
+}
+
+func TestParseFrame_Seq(t *testing.T) {
+	// 65535 y 0 cubren el paso por el límite de uint16
+	for _, seq := range []uint16{0, 1, 65535} {
+		trama, err := BuildFrameWithSeq([]byte("Hola"), seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := ParseFrame(trama)
+		if err != nil {
+			t.Fatalf("seq %d: error inesperado: %v", seq, err)
+		}
+		if !f.HasSeq || f.Seq != seq || f.MsgType != MsgTypeData || string(f.Payload) != "Hola" {
+			t.Errorf("seq %d: trama decodificada inesperada: %+v", seq, f)
+		}
+	}
+
+	h, err := BuildFrameWithHammingAndSeq([]byte("Hola"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(h)
+	if err != nil || f.MsgType != MsgTypeHamming || f.Seq != 7 {
+		t.Errorf("trama Hamming con secuencia: %+v, %v", f, err)
+	}
+
+	sinSeq, _ := BuildFrame([]byte("Hola"))
+	if f, _ := ParseFrame(sinSeq); f.HasSeq || f.Seq != 0 {
+		t.Errorf("una trama sin FlagSeq no debería traer secuencia: %+v", f)
+	}
+}
+
+func TestParseFrame_SeqTruncada(t *testing.T) {
+	trama, err := BuildFrameWithSeq(nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HeaderLen(trama) != SeqHeaderSize || len(trama) != SeqHeaderSize+CRCSize {
+		t.Fatalf("trama vacía con secuencia de %d bytes", len(trama))
+	}
+	if _, err := ParseFrame(trama[:SeqHeaderSize+CRCSize-1]); !errors.Is(err, ErrTruncated) {
+		t.Errorf("error %v, se esperaba %v", err, ErrTruncated)
+	}
+}
+
+// FuzzParseFrame alimenta ParseFrame con bytes arbitrarios, como los que deja
+// el ruido al invertir el largo o el tipo: nunca debe entrar en pánico, y lo
+// que acepta tiene que ser coherente con la trama de entrada
+func FuzzParseFrame(f *testing.F) {
+	for _, p := range [][]byte{nil, {0}, []byte("Hola")} {
+		trama, _ := BuildFrame(p)
+		f.Add(trama)
+		f.Add(trama[:len(trama)-1])
+	}
+	seq, _ := BuildFrameWithSeq([]byte("Hola"), 7)
+	f.Add(seq)
+	crc8, _ := BuildFrameWithChecksum([]byte("Hola"), ChecksumCRC8)
+	f.Add(crc8)
+	f.Add([]byte{0x01, 0xff, 0xff})
+	f.Add([]byte{0x71, 0x00, 0x00, 0x00})
+
+	f.Fuzz(func(t *testing.T, trama []byte) {
+		fr, err := ParseFrame(trama)
+		Dump(io.Discard, trama)
+		ParseFragment(trama)
+		ParseReply(trama)
+		if err != nil {
+			return
+		}
+		if HeaderLen(trama)+len(fr.Payload)+fr.Checksum.Size() != len(trama) {
+			t.Fatalf("ParseFrame aceptó %x con %d bytes de payload", trama, len(fr.Payload))
+		}
+		fr.Data()
+		_ = fr.String()
+	})
+}
+
+// FuzzFrameDecoders arma tramas válidas con payloads arbitrarios de cada tipo,
+// así el checksum no filtra la entrada y llega a los decodificadores del
+// payload (subheaders Hamming, paridad 2D, Reed-Solomon, fragmentos)
+func FuzzFrameDecoders(f *testing.F) {
+	for _, trama := range [][]byte{
+		must(BuildFrameWithHamming([]byte("Hola"))),
+		must(BuildFrameWithHamming([]byte("Hola"), HammingSECDED, InterleaveDepth(7))),
+		must(BuildFrameWithParity2D([]byte("Hola"), 3, FrameOptions{})),
+		must(BuildFrameWithRS([]byte("Hola"), 4)),
+		must(BuildFrameWithRepetition([]byte("Hola"))),
+	} {
+		fr, _ := ParseFrame(trama)
+		f.Add(fr.MsgType, fr.Payload, 32)
+	}
+	frags, _ := BuildFragmentedFrames([]byte("Hola"), 2)
+	fr, _ := ParseFrame(frags[0])
+	f.Add(fr.MsgType, fr.Payload, 16)
+
+	f.Fuzz(func(t *testing.T, msgType byte, payload []byte, dataBits int) {
+		trama, err := BuildFrameWithType(payload, msgType&0x0F)
+		if err != nil {
+			return
+		}
+		fr, err := ParseFrame(trama)
+		if err != nil {
+			t.Fatalf("la trama recién construida no verifica: %v", err)
+		}
+		fr.Data()
+		fr.Bits()
+		ParseFragment(trama)
+		ParseReply(trama)
+		ComputeFrameStats(trama, dataBits)
+		Dump(io.Discard, trama)
+		if fr.MsgType == MsgTypeParity2D {
+			Parity2DDecode(fr.Payload)
+		}
+		if fr.MsgType == MsgTypeHamming84 {
+			Hamming84DecodePayload(fr.Payload)
+		}
+		if v, ok := HammingVariantForType(fr.MsgType); ok {
+			v.DecodePayload(fr.Payload)
+		}
+	})
+}
+
+// must es para los corpus semilla, construidos con entradas conocidas
+func must(trama []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return trama
+}
@@ -0,0 +1,128 @@
+package frame
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MsgTypeName es el nombre legible de un tipo de mensaje (sin FlagSeq ni bits
+// de checksum)
+func MsgTypeName(msgType byte) string {
+	switch msgType {
+	case MsgTypeData:
+		return "data"
+	case MsgTypeHamming:
+		return "hamming(7,4)"
+	case MsgTypeFragment:
+		return "fragmento"
+	case MsgTypeHamming1511:
+		return "hamming(15,11)"
+	case MsgTypeParity2D:
+		return "paridad 2D"
+	case MsgTypeHamming84:
+		return "hamming(8,4) sec-ded"
+	case MsgTypeRepetition:
+		return "repetición"
+	case MsgTypeReedSolomon:
+		return "reed-solomon"
+	case MsgTypeAck:
+		return "ack"
+	case MsgTypeNack:
+		return "nack"
+	case MsgTypeGolay:
+		return "golay(23,12)"
+	case MsgTypeKeepalive:
+		return "keepalive"
+	case MsgTypeBits:
+		return "bits"
+	default:
+		return "desconocido"
+	}
+}
+
+// String resume la trama en una línea: tipo, checksum, secuencia, entrelazado,
+// contenido, scrambler y largo del payload
+func (f *Frame) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%#02x), %s %0*x", MsgTypeName(f.MsgType), f.MsgType, f.Checksum, f.Checksum.Size()*2, f.CRC)
+	if f.HasSeq {
+		fmt.Fprintf(&b, ", seq %d", f.Seq)
+	}
+	if f.Interleave > 0 {
+		fmt.Fprintf(&b, ", entrelazado %d", f.Interleave)
+	}
+	if f.Content != ContentUnspecified {
+		fmt.Fprintf(&b, ", contenido %s", f.Content)
+	}
+	if f.Scrambled {
+		b.WriteString(", aleatorizada")
+	}
+	fmt.Fprintf(&b, ", %d bytes de payload", len(f.Payload))
+	return b.String()
+}
+
+// Dump escribe en w los campos del header de frame, el payload en columnas
+// hexadecimal y ASCII como hexdump -C y el checksum marcado como válido o
+// inválido. A diferencia de ParseFrame no se detiene ante una trama mal
+// formada: muestra lo que trae y marca el checksum con el error.
+func Dump(w io.Writer, frame []byte) error {
+	var b strings.Builder
+	campo := func(nombre, formato string, args ...any) {
+		fmt.Fprintf(&b, "%-14s"+formato+"\n", append([]any{nombre + ":"}, args...)...)
+	}
+
+	if len(frame) == 0 {
+		campo("Trama", "vacía")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	msgType := MsgTypeOf(frame)
+	kind := ChecksumKindOf(frame)
+	campo("Tipo", "%#02x %s", msgType, MsgTypeName(msgType))
+	campo("Checksum", "%s", kind)
+	header := HeaderLen(frame)
+	if len(frame) >= HeaderSize {
+		campo("Largo", "%d bytes de payload", binary.BigEndian.Uint16(frame[1:3]))
+	}
+	if frame[0]&FlagSeq != 0 && len(frame) >= SeqHeaderSize {
+		campo("Secuencia", "%d", binary.BigEndian.Uint16(frame[HeaderSize:SeqHeaderSize]))
+	}
+	if c := ContentTypeOf(frame); hasContent(frame) && len(frame) >= header {
+		if c.Known() {
+			campo("Contenido", "%s", c)
+		} else {
+			campo("Contenido", "%s ⚠ desconocido", c)
+		}
+	}
+	if IsScrambled(frame) {
+		campo("Scrambler", "x^7+x^4+1 (el payload se muestra aleatorizado, como viaja)")
+	}
+
+	// Sin checksum conocido todo lo que sigue al header se muestra como payload
+	size := kind.Size()
+	inicio, fin := min(header, len(frame)), len(frame)-size
+	if size == 0 || fin < inicio {
+		fin = len(frame)
+	}
+	campo("Payload", "%d bytes", fin-inicio)
+	b.WriteString(hex.Dump(frame[inicio:fin]))
+
+	err := VerifyFrame(frame)
+	var crc *CRCMismatchError
+	switch {
+	case size == 0 || fin == len(frame):
+		campo("Verificación", "✗ %v", err)
+	case err == nil:
+		campo("Verificación", "%x ✓ válido", frame[fin:])
+	case errors.As(err, &crc):
+		campo("Verificación", "%x ✗ inválido (el contenido da %0*x)", frame[fin:], size*2, crc.Expected)
+	default:
+		campo("Verificación", "%x ✗ %v", frame[fin:], err)
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
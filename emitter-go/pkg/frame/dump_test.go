@@ -0,0 +1,83 @@
+package frame
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerar los archivos golden")
+
+func TestDump_Golden(t *testing.T) {
+	valida, err := BuildFrameWithSeq([]byte("Hola, hexdump de la trama!"), 258)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupta := append([]byte(nil), valida...)
+	corrupta[SeqHeaderSize+1] ^= 0x20
+	crc16, err := BuildFrameWithChecksum([]byte{0x00, 0x7f, 0x80, 0xff}, ChecksumCRC16CCITT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	casos := []struct {
+		nombre string
+		trama  []byte
+	}{
+		{"dump_valida", valida},
+		{"dump_corrupta", corrupta},
+		{"dump_crc16", crc16},
+		{"dump_truncada", valida[:SeqHeaderSize+2]},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Dump(&out, c.trama); err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", c.nombre+".golden")
+			if *update {
+				if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("no se pudo leer %s: %v", golden, err)
+			}
+			if out.String() != string(want) {
+				t.Errorf("la salida difiere del golden:\n--- obtenido ---\n%s\n--- esperado ---\n%s", out.String(), want)
+			}
+		})
+	}
+}
+
+func TestDump_TramaVacia(t *testing.T) {
+	var out bytes.Buffer
+	if err := Dump(&out, nil); err != nil || !strings.Contains(out.String(), "vacía") {
+		t.Errorf("salida %q (%v)", out.String(), err)
+	}
+}
+
+func TestFrame_String(t *testing.T) {
+	trama, err := BuildFrameWithHammingAndSeq([]byte("Hi"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := f.String()
+	for _, parte := range []string{"hamming(7,4) (0x02)", "crc32", "seq 7", "bytes de payload"} {
+		if !strings.Contains(got, parte) {
+			t.Errorf("%q no contiene %q", got, parte)
+		}
+	}
+	if MsgTypeName(0x0F) != "desconocido" {
+		t.Errorf("MsgTypeName(0x0F) = %q", MsgTypeName(0x0F))
+	}
+}
@@ -1,91 +1,212 @@
 package frame
 
 import (
-    "encoding/binary"
-    "hash/crc32"
-    "fmt"
-
+	"fmt"
+	"slices"
 )
 
-func BytesToBits (data []byte) []byte {
-    bits := make([]byte, len(data)*8)
-    for i, b := range data {
-        for j := 0; j < 8; j++ {
-            bits[i*8+j] = (b >> (7 - j)) & 1
-        }
-    }
-    return bits
+func BytesToBits(data []byte) []byte {
+	return AppendBits(make([]byte, 0, len(data)*8), data)
+}
+
+// AppendBits agrega a dst los bits de data (uno por byte, el más significativo
+// primero) y devuelve el slice extendido. Como append, solo asigna memoria si
+// dst no tiene capacidad: reutilizando el resultado (AppendBits(buf[:0], data))
+// las conversiones sucesivas no asignan nada.
+func AppendBits(dst, data []byte) []byte {
+	n := len(dst)
+	dst = slices.Grow(dst, len(data)*8)[:n+len(data)*8]
+	bits := dst[n:]
+	for i, b := range data {
+		for j := 0; j < 8; j++ {
+			bits[i*8+j] = (b >> (7 - j)) & 1
+		}
+	}
+	return dst
 }
 
+// BitsToBytes agrupa bits (uno por byte, el más significativo primero) en
+// bytes, completando el último con ceros. No modifica bits.
 func BitsToBytes(bits []byte) []byte {
-    if len(bits) == 0 {
-        return []byte{}
-    }
-    // Asegurarse de que la longitud es múltiplo de 8
-    if len(bits)%8 != 0 {
-        padding := make([]byte, 8-len(bits)%8)
-        bits = append(bits, padding...)
-    }
-    out := make([]byte, len(bits)/8)
-    for i := 0; i < len(bits); i += 8 {
-        var b byte
-        for j := 0; j < 8; j++ {
-            b |= bits[i+j] << (7 - j)
-        }
-        out[i/8] = b
-    }
-    return out
+	out, _ := BitsToBytesPadded(bits)
+	return out
+}
+
+// BitsToBytesPadded es BitsToBytes que además devuelve cuántos bits de relleno
+// (0 a 7) completan el último byte; BytesToBits(out)[:len(bits)] recupera los
+// bits originales
+func BitsToBytesPadded(bits []byte) (out []byte, padBits int) {
+	out = AppendBytes(make([]byte, 0, (len(bits)+7)/8), bits)
+	return out, len(out)*8 - len(bits)
+}
+
+// AppendBytes agrega a dst los bits agrupados en bytes como BitsToBytes y
+// devuelve el slice extendido; como AppendBits, no asigna memoria si dst tiene
+// capacidad. No modifica bits.
+func AppendBytes(dst, bits []byte) []byte {
+	n, m := len(dst), (len(bits)+7)/8
+	dst = slices.Grow(dst, m)[:n+m]
+	out := dst[n:]
+	clear(out)
+	for i, b := range bits {
+		out[i/8] |= b << (7 - i%8)
+	}
+	return dst
 }
 
 const (
-    MsgTypeData    byte = 0x01  // RAW + CRC
-    MsgTypeHamming byte = 0x02  // HAMMING + CRC
+	MsgTypeData    byte = 0x01 // RAW + CRC
+	MsgTypeHamming byte = 0x02 // HAMMING + CRC
 )
 
-// BuildFrame construye: [Header(2)] + Payload + [CRC(4)] con tipo por defecto (RAW)
+// HeaderSize es el tamaño del header: tipo (1) + longitud del payload (2)
+const HeaderSize = 3
+
+// BuildFrame construye: [Header(3)] + Payload + [CRC(4)] con tipo por defecto (RAW).
+// El payload puede ser vacío (nil incluido): la trama queda en header + CRC,
+// MinFrameSize bytes con largo 0.
 func BuildFrame(payload []byte) ([]byte, error) {
-    return BuildFrameWithType(payload, MsgTypeData)
+	return BuildFrameOpts(payload)
 }
 
-// BuildFrameWithType construye: [Header(2)] + Payload + [CRC(4)] con tipo específico
+// BuildFrameWithType construye: [Header(3)] + Payload + [CRC(4)] con tipo específico
 func BuildFrameWithType(payload []byte, msgType byte) ([]byte, error) {
-    if len(payload) > 0xFFFF {
-        return nil, fmt.Errorf("payload demasiado grande: %d bytes (límite 65535)", len(payload))
-    }
+	return BuildFrameOpts(payload, WithType(msgType))
+}
+
+// HammingMode elige el código de BuildFrameWithHamming
+type HammingMode int
+
+const (
+	HammingSEC    HammingMode = iota // Hamming(7,4): corrige un error por bloque
+	HammingSECDED                    // Hamming(8,4) extendido: además detecta dos errores por bloque
+)
+
+// InterleaveDepth entrelaza los bloques codificados con esa profundidad (ver
+// InterleaveHammingPayload)
+type InterleaveDepth int
 
-    // 1) Header
-    header := make([]byte, 3)
-    header[0] = msgType // Tipo de mensaje específico
-    binary.BigEndian.PutUint16(header[1:], uint16(len(payload)))
+// HammingOption es una opción de BuildFrameWithHamming: HammingMode o
+// InterleaveDepth
+type HammingOption interface {
+	applyHamming(c *hammingConfig)
+}
+
+type hammingConfig struct {
+	mode  HammingMode
+	depth int
+}
+
+func (m HammingMode) applyHamming(c *hammingConfig)     { c.mode = m }
+func (d InterleaveDepth) applyHamming(c *hammingConfig) { c.depth = int(d) }
+
+// BuildFrameWithHamming codifica payload con Hamming(7,4) o, con
+// HammingSECDED, con Hamming(8,4) extendido (tipo MsgTypeHamming84). Con
+// InterleaveDepth los bloques se entrelazan tras codificarlos. Codifica los
+// bytes empaquetados (ver Hamming74EncodeBytes), sin pasar por un bit por byte.
+func BuildFrameWithHamming(payload []byte, opts ...HammingOption) ([]byte, error) {
+	c := hammingConfigOf(opts)
+	return frameConfig{hamming: &c.mode, depth: c.depth}.buildHammingBytes(payload)
+}
+
+// BuildFrameWithHammingBits es BuildFrameWithHamming para una cantidad de bits
+// de datos que no tiene por qué ser múltiplo de 8 (ej: la entrada de
+// emitter_hamming); DecodeHammingPayload los devuelve exactos
+func BuildFrameWithHammingBits(dataBits []byte, opts ...HammingOption) ([]byte, error) {
+	c := hammingConfigOf(opts)
+	return frameConfig{hamming: &c.mode, depth: c.depth}.buildHamming(dataBits)
+}
 
-    // 2) Concat header + payload
-    frame := append(header, payload...)
+func hammingConfigOf(opts []HammingOption) hammingConfig {
+	var c hammingConfig
+	for _, o := range opts {
+		o.applyHamming(&c)
+	}
+	return c
+}
 
-    // 3) Calcular CRC-32 sobre header+payload
-    crc := crc32.ChecksumIEEE(frame)
-    // 4) Añadir 4 bytes Big-Endian con el CRC
-    crcBytes := make([]byte, 4)
-    binary.BigEndian.PutUint32(crcBytes, crc)
+// HammingPadSize es el subheader del payload de las tramas Hamming: un byte
+// con la cantidad de bits de relleno que hay que descartar al final de los
+// datos decodificados. Su bit alto es HammingFlagInterleave.
+const HammingPadSize = 1
+
+// EncodeHammingPayload codifica dataBits con encode, un código de bloques de
+// n bits con k de datos, y arma el payload [relleno(1)] + bloques agrupados
+// en bytes. El relleno cuenta los bits que el decoder obtiene de más al
+// decodificar todos los bloques completos del payload: el padding del encoder
+// hasta un múltiplo de k y, si el agrupado en bytes deja lugar para un bloque
+// entero de ceros, sus k bits.
+func EncodeHammingPayload(dataBits []byte, n, k int, encode func([]byte) ([]byte, error)) ([]byte, error) {
+	codeBits, err := encode(dataBits)
+	if err != nil {
+		return nil, err
+	}
+	packed, padBits := BitsToBytesPadded(codeBits)
+	relleno := (len(codeBits)+padBits)/n*k - len(dataBits)
+	if relleno < 0 || relleno >= int(HammingFlagInterleave) {
+		return nil, fmt.Errorf("relleno Hamming fuera de rango: %d bits", relleno)
+	}
+	return append([]byte{byte(relleno)}, packed...), nil
+}
 
-    // 5) Trama final
-    fullFrame := append(frame, crcBytes...)
-    return fullFrame, nil
+// DecodeHammingPayload decodifica un payload de EncodeHammingPayload con
+// decode, un código de bloques de n bits, y descarta exactamente los bits de
+// relleno: devuelve los bits de datos originales y las posiciones corregidas
+// (relativas al primer bloque, ya sin entrelazar). Si el payload viene de
+// InterleaveHammingPayload deshace el entrelazado antes de decodificar.
+func DecodeHammingPayload(payload []byte, n int, decode func([]byte) ([]byte, []int, error)) (dataBits []byte, corrected []int, err error) {
+	if len(payload) < hammingSubheaderSize(payload) {
+		return nil, nil, fmt.Errorf("%w: el payload Hamming no trae el subheader completo", ErrTruncated)
+	}
+	codeBits := BytesToBits(payload[hammingSubheaderSize(payload):])
+	if depth := HammingInterleaveDepth(payload); depth > 0 {
+		codeBits = Deinterleave(codeBits, depth)
+	}
+	dataBits, corrected, err = decode(codeBits[:len(codeBits)/n*n])
+	if err != nil {
+		return nil, nil, err
+	}
+	relleno := int(payload[0] &^ HammingFlagInterleave)
+	if relleno > len(dataBits) {
+		return nil, nil, fmt.Errorf("relleno inválido: %d bits, los bloques traen %d", relleno, len(dataBits))
+	}
+	return dataBits[:len(dataBits)-relleno], corrected, nil
 }
 
-func BuildFrameWithHamming(payload []byte) ([]byte, error) {
-    // 1) convertir payload en slice de bits (0/1)
-    bits := BytesToBits(payload)
-    // 2) codificar con Hamming
-    codeBits, err := Hamming74Encode(bits)
-    if err != nil {
-        return nil, err
-    }
-    // 3) convertir bits de vuelta a bytes (agrupando de 8)
-    codedBytes := BitsToBytes(codeBits)
-    // 4) llamar a BuildFrameWithType con tipo Hamming (0x02)
-    return BuildFrameWithType(codedBytes, MsgTypeHamming)
+// HammingBlocksOffset es el byte de frame donde empiezan los bloques Hamming:
+// tras el header, el byte de relleno y, si están entrelazados, el de
+// profundidad
+func HammingBlocksOffset(frame []byte) int {
+	inicio := HeaderLen(frame)
+	if inicio > len(frame) {
+		return inicio + HammingPadSize
+	}
+	return inicio + hammingSubheaderSize(frame[inicio:])
 }
 
+// FlagSeq en el byte de tipo indica que el header trae número de secuencia
+const FlagSeq byte = 0x80
 
+// SeqHeaderSize es el tamaño del header con FlagSeq: tipo (1) + longitud del
+// payload (2) + secuencia (2)
+const SeqHeaderSize = HeaderSize + 2
 
+// BuildFrameWithSeq construye una trama RAW con número de secuencia:
+// [tipo|FlagSeq(1)][longitud(2)][seq(2)] + Payload + [CRC(4)]
+func BuildFrameWithSeq(payload []byte, seq uint16) ([]byte, error) {
+	return BuildFrameOpts(payload, WithSeq(seq))
+}
 
+// BuildFrameWithHammingAndSeq es BuildFrameWithHamming con número de secuencia
+func BuildFrameWithHammingAndSeq(payload []byte, seq uint16) ([]byte, error) {
+	return BuildFrameOpts(payload, WithHamming74(), WithSeq(seq))
+}
+
+// BuildFrameWithTypeAndSeq es BuildFrameWithType con número de secuencia. La
+// secuencia la elige quien llama; al pasar de 65535 vuelve a 0.
+func BuildFrameWithTypeAndSeq(payload []byte, msgType byte, seq uint16) ([]byte, error) {
+	if msgType&FlagSeq != 0 {
+		return nil, fmt.Errorf("tipo de mensaje inválido: %#02x (el bit %#02x indica secuencia)", msgType, FlagSeq)
+	}
+	return BuildFrameOpts(payload, WithType(msgType), WithSeq(seq))
+}
@@ -1,56 +1,342 @@
 package frame
 
 import (
-    "testing"
-    "encoding/binary"
-    "hash/crc32"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math/rand"
+	"testing"
 )
 
 func TestBuildFrame_CRCAndHeader(t *testing.T) {
-    data := []byte{0x0A, 0x0B}
-    frame, err := BuildFrame(data)
-    if err != nil {
-        t.Fatal(err)
-    }
-    // Longitud total: 3 (header) + 2 (payload) + 4 (CRC) = 9
-    if len(frame) != 9 {
-        t.Fatalf("Longitud esperada 9, obtenida %d", len(frame))
-    }
-    // Header
-    if frame[0] != MsgTypeData {
-        t.Errorf("Byte 0 header: esperado %02x, tuvo %02x", MsgTypeData, frame[0])
-    }
-    plen := binary.BigEndian.Uint16(frame[1:3])
-    if int(plen) != len(data) {
-        t.Errorf("Longitud en header: esperado %d, tuvo %d", len(data), plen)
-    }
-    // CRC
-    gotCRC := binary.BigEndian.Uint32(frame[len(frame)-4:])
-    wantCRC := crc32.ChecksumIEEE(frame[:len(frame)-4])
-    if gotCRC != wantCRC {
-        t.Errorf("CRC inválido: esperado %08x, obtuvo %08x", wantCRC, gotCRC)
-    }
+	data := []byte{0x0A, 0x0B}
+	frame, err := BuildFrame(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Longitud total: 3 (header) + 2 (payload) + 4 (CRC) = 9
+	if len(frame) != 9 {
+		t.Fatalf("Longitud esperada 9, obtenida %d", len(frame))
+	}
+	// Header
+	if frame[0] != MsgTypeData {
+		t.Errorf("Byte 0 header: esperado %02x, tuvo %02x", MsgTypeData, frame[0])
+	}
+	plen := binary.BigEndian.Uint16(frame[1:3])
+	if int(plen) != len(data) {
+		t.Errorf("Longitud en header: esperado %d, tuvo %d", len(data), plen)
+	}
+	// CRC
+	gotCRC := binary.BigEndian.Uint32(frame[len(frame)-4:])
+	wantCRC := crc32.ChecksumIEEE(frame[:len(frame)-4])
+	if gotCRC != wantCRC {
+		t.Errorf("CRC inválido: esperado %08x, obtuvo %08x", wantCRC, gotCRC)
+	}
 }
 
 func TestBuildFrameWithHamming_RoundTrip(t *testing.T) {
-    payload := []byte{0xFF, 0x00}
-    frame, err := BuildFrameWithHamming(payload)
-    if err != nil {
-        t.Fatalf("error inesperado: %v", err)
-    }
-    if frame[0] != MsgTypeData {
-        t.Errorf("header tipo: esperado %02x, obtuvo %02x", MsgTypeData, frame[0])
-    }
-    // CRC válido
-    gotCRC := binary.BigEndian.Uint32(frame[len(frame)-4:])
-    wantCRC := crc32.ChecksumIEEE(frame[:len(frame)-4])
-    if gotCRC != wantCRC {
-        t.Errorf("CRC inválido tras Hamming: %08x vs %08x", wantCRC, gotCRC)
-    }
-    // La longitud del header (uint16 BE) debe coincidir con el body real
-    plen := int(binary.BigEndian.Uint16(frame[1:3]))
-    bodyLen := len(frame) - (3 /*header*/ + 4 /*CRC*/)
-    if plen != bodyLen {
-        t.Errorf("longitud de payload mal codificada: header dice %d, pero body mide %d", plen, bodyLen)
-    }
+	payload := []byte{0xFF, 0x00}
+	frame, err := BuildFrameWithHamming(payload)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if frame[0] != MsgTypeHamming {
+		t.Errorf("header tipo: esperado %02x, obtuvo %02x", MsgTypeHamming, frame[0])
+	}
+	// CRC válido
+	gotCRC := binary.BigEndian.Uint32(frame[len(frame)-4:])
+	wantCRC := crc32.ChecksumIEEE(frame[:len(frame)-4])
+	if gotCRC != wantCRC {
+		t.Errorf("CRC inválido tras Hamming: %08x vs %08x", wantCRC, gotCRC)
+	}
+	// La longitud del header (uint16 BE) debe coincidir con el body real
+	plen := int(binary.BigEndian.Uint16(frame[1:3]))
+	bodyLen := len(frame) - (3 /*header*/ + 4 /*CRC*/)
+	if plen != bodyLen {
+		t.Errorf("longitud de payload mal codificada: header dice %d, pero body mide %d", plen, bodyLen)
+	}
+}
+
+func TestBuildFrameWithHammingBits_Relleno(t *testing.T) {
+	// 6 bits de datos como los de emitter_hamming --bits 110101, y largos que
+	// dejan relleno del encoder, del agrupado en bytes o de ambos
+	for n := 0; n <= 40; n++ {
+		dataBits := make([]byte, n)
+		for i := range dataBits {
+			dataBits[i] = byte(i*5/3) & 1
+		}
+		for _, mode := range []HammingMode{HammingSEC, HammingSECDED} {
+			trama, err := BuildFrameWithHammingBits(dataBits, mode)
+			if err != nil {
+				t.Fatalf("%d bits: %v", n, err)
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatalf("%d bits: %v", n, err)
+			}
+			var got []byte
+			if mode == HammingSECDED {
+				got, _, err = Hamming84DecodePayload(f.Payload)
+			} else {
+				got, _, err = DecodeHammingPayload(f.Payload, 7, Hamming74Decode)
+			}
+			if err != nil {
+				t.Fatalf("%d bits, modo %d: %v", n, mode, err)
+			}
+			if !bytes.Equal(got, dataBits) {
+				t.Errorf("%d bits, modo %d: se obtuvo %v, se esperaba %v", n, mode, got, dataBits)
+			}
+		}
+	}
+}
+
+func TestDecodeHammingPayload_Invalido(t *testing.T) {
+	if _, _, err := DecodeHammingPayload(nil, 7, Hamming74Decode); !errors.Is(err, ErrTruncated) {
+		t.Errorf("payload vacío: %v", err)
+	}
+	// Un bloque trae 4 bits de datos; no puede haber 5 de relleno
+	if _, _, err := DecodeHammingPayload([]byte{5, 0}, 7, Hamming74Decode); err == nil {
+		t.Error("se esperaba error con relleno mayor que los datos")
+	}
+}
+
+func TestBuildFrame_PayloadsLargos(t *testing.T) {
+	for _, size := range []int{256, 1024, 65535} {
+		payload := make([]byte, size)
+		for i := range payload {
+			payload[i] = byte(i)
+		}
+		frame, err := BuildFrame(payload)
+		if err != nil {
+			t.Fatalf("payload de %d bytes: error inesperado: %v", size, err)
+		}
+		if plen := int(binary.BigEndian.Uint16(frame[1:3])); plen != size {
+			t.Errorf("payload de %d bytes: el header dice %d", size, plen)
+		}
+		if err := VerifyFrame(frame); err != nil {
+			t.Errorf("payload de %d bytes: %v", size, err)
+		}
+	}
+
+	if _, err := BuildFrame(make([]byte, 65536)); err == nil {
+		t.Error("un payload de 65536 bytes no cabe en el header de 16 bits")
+	}
+}
+
+func TestBuildFrameWithSeq_Header(t *testing.T) {
+	frame, err := BuildFrameWithSeq([]byte{0x0A, 0x0B}, 0x1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Longitud total: 5 (header con secuencia) + 2 (payload) + 4 (CRC) = 11
+	if len(frame) != 11 {
+		t.Fatalf("Longitud esperada 11, obtenida %d", len(frame))
+	}
+	if frame[0] != MsgTypeData|FlagSeq {
+		t.Errorf("Byte 0 header: esperado %02x, tuvo %02x", MsgTypeData|FlagSeq, frame[0])
+	}
+	if plen := binary.BigEndian.Uint16(frame[1:3]); plen != 2 {
+		t.Errorf("Longitud en header: esperado 2, tuvo %d", plen)
+	}
+	if seq := binary.BigEndian.Uint16(frame[3:5]); seq != 0x1234 {
+		t.Errorf("Secuencia en header: esperado 1234, tuvo %04x", seq)
+	}
+	if _, err := BuildFrameWithTypeAndSeq(nil, MsgTypeData|FlagSeq, 0); err == nil {
+		t.Error("se esperaba error con FlagSeq en el tipo")
+	}
+}
+
+func TestBitsToBytes_NoModificaLaEntrada(t *testing.T) {
+	// Con capacidad de sobra, agregar el relleno con append escribiría en el
+	// arreglo del llamador
+	backing := bytes.Repeat([]byte{9}, 16)
+	bits := backing[:5]
+	copy(bits, []byte{1, 0, 1, 1, 0})
+
+	out := BitsToBytes(bits)
+	if !bytes.Equal(out, []byte{0xB0}) {
+		t.Fatalf("BitsToBytes = % x, se esperaba b0", out)
+	}
+	if !bytes.Equal(backing[:5], []byte{1, 0, 1, 1, 0}) || !bytes.Equal(backing[5:], bytes.Repeat([]byte{9}, 11)) {
+		t.Errorf("el arreglo del llamador cambió: %v", backing)
+	}
+}
+
+func TestBitsToBytesPadded_RoundTrip(t *testing.T) {
+	for n := 0; n <= 24; n++ {
+		bits := make([]byte, n)
+		for i := range bits {
+			bits[i] = byte(i*7/3) & 1
+		}
+		out, pad := BitsToBytesPadded(bits)
+		if len(out) != (n+7)/8 || pad != len(out)*8-n || pad > 7 {
+			t.Fatalf("%d bits: %d bytes con %d de relleno", n, len(out), pad)
+		}
+		back := BytesToBits(out)
+		if !bytes.Equal(back[:len(back)-pad], bits) {
+			t.Errorf("%d bits: % x no recupera los bits", n, out)
+		}
+		for _, b := range back[len(back)-pad:] {
+			if b != 0 {
+				t.Errorf("%d bits: el relleno no es cero: %v", n, back[len(back)-pad:])
+			}
+		}
+		if !bytes.Equal(BitsToBytes(bits), out) {
+			t.Errorf("%d bits: BitsToBytes y BitsToBytesPadded difieren", n)
+		}
+	}
+}
+
+func TestAppendBits_IgualQueBytesToBits(t *testing.T) {
+	prefijo := []byte{1, 1, 0}
+	var buf []byte
+	for _, data := range [][]byte{nil, {0xB5}, []byte("Hola mundo"), bytes.Repeat([]byte{0xA7}, 300)} {
+		got := AppendBits(append([]byte(nil), prefijo...), data)
+		if !bytes.Equal(got[:3], prefijo) || !bytes.Equal(got[3:], BytesToBits(data)) {
+			t.Errorf("% x: AppendBits no agrega los mismos bits que BytesToBits", data)
+		}
+		// Reutilizar el buffer da lo mismo aunque quede contenido anterior
+		buf = AppendBits(buf[:0], data)
+		if !bytes.Equal(buf, BytesToBits(data)) {
+			t.Errorf("% x: AppendBits con el buffer reutilizado difiere", data)
+		}
+	}
+}
+
+func TestAppendBytes_IgualQueBitsToBytes(t *testing.T) {
+	prefijo := []byte{0xEE}
+	// Un buffer sucio comprueba que los bytes agregados se limpian antes de agrupar
+	buf := bytes.Repeat([]byte{0xFF}, 64)
+	for n := 0; n <= 300; n += 37 {
+		bits := make([]byte, n)
+		for i := range bits {
+			bits[i] = byte(i*5/3) & 1
+		}
+		got := AppendBytes(append([]byte(nil), prefijo...), bits)
+		if !bytes.Equal(got[:1], prefijo) || !bytes.Equal(got[1:], BitsToBytes(bits)) {
+			t.Errorf("%d bits: AppendBytes no agrega los mismos bytes que BitsToBytes", n)
+		}
+		buf = AppendBytes(buf[:0], bits)
+		if !bytes.Equal(buf, BitsToBytes(bits)) {
+			t.Errorf("%d bits: AppendBytes con el buffer reutilizado difiere", n)
+		}
+	}
+}
+
+func TestAppend_SinAsignacionesConBufferReutilizado(t *testing.T) {
+	data := bytes.Repeat([]byte("trama"), 200)
+	bits := BytesToBits(data)
+	bufBits := make([]byte, 0, len(bits))
+	bufBytes := make([]byte, 0, len(data))
+	allocs := testing.AllocsPerRun(100, func() {
+		bufBits = AppendBits(bufBits[:0], data)
+		bufBytes = AppendBytes(bufBytes[:0], bufBits)
+	})
+	if allocs != 0 {
+		t.Errorf("%.1f asignaciones por conversión, se esperaban 0", allocs)
+	}
+	if !bytes.Equal(bufBytes, data) {
+		t.Error("la ida y vuelta por los buffers no recupera los datos")
+	}
+}
+
+func TestBuildFrame_PropiedadPayloadsAleatorios(t *testing.T) {
+	rng := rand.New(rand.NewSource(775))
+	tamaños := []int{0, 1, 0xFFFE, 0xFFFF}
+	for i := 0; i < 60; i++ {
+		tamaños = append(tamaños, rng.Intn(0x10000))
+	}
+	for _, size := range tamaños {
+		payload := make([]byte, size)
+		rng.Read(payload)
+		frame, err := BuildFrame(payload)
+		if err != nil {
+			t.Fatalf("payload de %d bytes: error inesperado: %v", size, err)
+		}
+		if err := VerifyFrame(frame); err != nil {
+			t.Fatalf("payload de %d bytes: %v", size, err)
+		}
+		f, err := ParseFrame(frame)
+		if err != nil {
+			t.Fatalf("payload de %d bytes: %v", size, err)
+		}
+		if f.MsgType != MsgTypeData || !bytes.Equal(f.Payload, payload) {
+			t.Fatalf("payload de %d bytes: ParseFrame no recupera el payload original", size)
+		}
+	}
+}
+
+// FuzzBitsRoundTrip comprueba que las conversiones entre bytes y bits (y sus
+// variantes Append) son inversas, con el relleno en cero
+func FuzzBitsRoundTrip(f *testing.F) {
+	f.Add([]byte{}, 0)
+	f.Add([]byte{0xB5}, 3)
+	f.Add([]byte("Hola mundo"), 17)
+
+	f.Fuzz(func(t *testing.T, data []byte, corte int) {
+		bits := BytesToBits(data)
+		if len(bits) != len(data)*8 || !bytes.Equal(BitsToBytes(bits), data) {
+			t.Fatalf("% x: BitsToBytes(BytesToBits) no recupera los bytes", data)
+		}
+		if !bytes.Equal(AppendBits(nil, data), bits) || !bytes.Equal(AppendBytes(nil, bits), data) {
+			t.Fatalf("% x: las variantes Append difieren", data)
+		}
+
+		// Un prefijo de bits arbitrario se completa con ceros hasta el byte
+		if len(bits) == 0 {
+			return
+		}
+		n := int(uint(corte) % uint(len(bits)+1))
+		out, pad := BitsToBytesPadded(bits[:n])
+		if len(out) != (n+7)/8 || pad != len(out)*8-n {
+			t.Fatalf("%d bits: %d bytes con %d de relleno", n, len(out), pad)
+		}
+		back := BytesToBits(out)
+		if !bytes.Equal(back[:n], bits[:n]) {
+			t.Fatalf("%d bits: % x no recupera los bits", n, out)
+		}
+		for _, b := range back[n:] {
+			if b != 0 {
+				t.Fatalf("%d bits: el relleno no es cero: %v", n, back[n:])
+			}
+		}
+	})
+}
+
+// datosBenchmark es un payload grande como los de los benchmarks del emisor
+var datosBenchmark = bytes.Repeat([]byte("Hola mundo! "), 400)
+
+func BenchmarkBytesToBits(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = BytesToBits(datosBenchmark)
+	}
+}
+
+func BenchmarkAppendBits(b *testing.B) {
+	b.ReportAllocs()
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = AppendBits(buf[:0], datosBenchmark)
+	}
+}
+
+func BenchmarkBitsToBytes(b *testing.B) {
+	bits := BytesToBits(datosBenchmark)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = BitsToBytes(bits)
+	}
+}
+
+func BenchmarkAppendBytes(b *testing.B) {
+	bits := BytesToBits(datosBenchmark)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		buf = AppendBytes(buf[:0], bits)
+	}
 }
@@ -0,0 +1,37 @@
+package frame_test
+
+import (
+	"fmt"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func ExampleBuildFrame() {
+	trama, err := frame.BuildFrame([]byte("Hi"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("tipo=%#02x largo=%d crc=%x\n", trama[0], int(trama[1])<<8|int(trama[2]), trama[len(trama)-4:])
+	// Output:
+	// tipo=0x01 largo=2 crc=f4081632
+}
+
+func ExampleHamming74Encode() {
+	codificado, err := frame.Hamming74Encode([]byte{1, 0, 1, 1})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(codificado)
+	// Output:
+	// [0 1 1 0 0 1 1]
+}
+
+func ExampleBuildFrameWithHamming() {
+	trama, err := frame.BuildFrameWithHamming([]byte("A"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%x\n", trama)
+	// Output:
+	// 0200030099a49f87f9e4
+}
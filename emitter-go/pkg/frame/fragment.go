@@ -0,0 +1,165 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// MsgTypeFragment es el tipo de las tramas que llevan un fragmento de mensaje
+const MsgTypeFragment byte = 0x03
+
+// FragmentHeaderSize es el tamaño del subheader de cada fragmento, al inicio
+// de su payload: tipo del contenido (1) + ID de mensaje (2) + índice (2) +
+// total de fragmentos (2)
+const FragmentHeaderSize = 7
+
+// Errores de ReassembleFrames
+var (
+	ErrFragmentMissing    = errors.New("faltan fragmentos")
+	ErrFragmentOutOfOrder = errors.New("fragmentos fuera de orden")
+	ErrFragmentMismatch   = errors.New("fragmentos de mensajes distintos")
+)
+
+// nextMessageID numera los mensajes fragmentados del proceso
+var nextMessageID uint32
+
+// Fragment es un fragmento decodificado por ParseFragment
+type Fragment struct {
+	ContentType byte // Tipo del mensaje original (MsgTypeData o MsgTypeHamming)
+	MessageID   uint16
+	Index       int // Desde 0
+	Total       int
+	Data        []byte
+}
+
+// BuildFragmentedFrames parte payload en tramas MsgTypeFragment de a lo sumo
+// maxFragmentSize bytes de datos cada una. Cada trama es una trama completa de
+// BuildFrameWithType, así que tiene su propio largo y CRC.
+func BuildFragmentedFrames(payload []byte, maxFragmentSize int) ([][]byte, error) {
+	return BuildFragmentedFramesWithType(payload, maxFragmentSize, MsgTypeData)
+}
+
+// BuildFragmentedFramesWithType es BuildFragmentedFrames indicando el tipo del
+// contenido (por ejemplo MsgTypeHamming si payload ya está codificado)
+func BuildFragmentedFramesWithType(payload []byte, maxFragmentSize int, contentType byte) ([][]byte, error) {
+	if maxFragmentSize <= 0 || maxFragmentSize > 0xFFFF-FragmentHeaderSize {
+		return nil, fmt.Errorf("tamaño de fragmento inválido: %d (debe estar entre 1 y %d)", maxFragmentSize, 0xFFFF-FragmentHeaderSize)
+	}
+	total := (len(payload) + maxFragmentSize - 1) / maxFragmentSize
+	if total == 0 {
+		total = 1
+	}
+	if total > 0xFFFF {
+		return nil, fmt.Errorf("%w: requiere %d fragmentos (límite 65535)", ErrPayloadTooLarge, total)
+	}
+
+	id := uint16(atomic.AddUint32(&nextMessageID, 1))
+	frames := make([][]byte, 0, total)
+	for i := 0; i < total; i++ {
+		inicio := i * maxFragmentSize
+		fin := inicio + maxFragmentSize
+		if fin > len(payload) {
+			fin = len(payload)
+		}
+
+		body := make([]byte, FragmentHeaderSize, FragmentHeaderSize+fin-inicio)
+		body[0] = contentType
+		binary.BigEndian.PutUint16(body[1:3], id)
+		binary.BigEndian.PutUint16(body[3:5], uint16(i))
+		binary.BigEndian.PutUint16(body[5:7], uint16(total))
+		body = append(body, payload[inicio:fin]...)
+
+		f, err := BuildFrameWithType(body, MsgTypeFragment)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, f)
+	}
+	return frames, nil
+}
+
+// ParseFragment valida la trama con ParseFrame y decodifica su subheader
+func ParseFragment(frame []byte) (*Fragment, error) {
+	f, err := ParseFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	if f.MsgType != MsgTypeFragment {
+		return nil, fmt.Errorf("la trama es de tipo %#02x, no un fragmento", f.MsgType)
+	}
+	if len(f.Payload) < FragmentHeaderSize {
+		return nil, fmt.Errorf("%w: el fragmento trae %d bytes, el subheader ocupa %d", ErrTruncated, len(f.Payload), FragmentHeaderSize)
+	}
+	frag := &Fragment{
+		ContentType: f.Payload[0],
+		MessageID:   binary.BigEndian.Uint16(f.Payload[1:3]),
+		Index:       int(binary.BigEndian.Uint16(f.Payload[3:5])),
+		Total:       int(binary.BigEndian.Uint16(f.Payload[5:7])),
+		Data:        f.Payload[FragmentHeaderSize:],
+	}
+	if frag.Total == 0 || frag.Index >= frag.Total {
+		return nil, fmt.Errorf("fragmento %d de %d inválido", frag.Index, frag.Total)
+	}
+	return frag, nil
+}
+
+// ReassembleFrames valida cada fragmento y concatena sus datos. Los fragmentos
+// deben ser de un mismo mensaje y llegar en orden; los errores envuelven
+// ErrFragmentMissing, ErrFragmentOutOfOrder o ErrFragmentMismatch, o el error
+// de ParseFrame del fragmento dañado.
+func ReassembleFrames(frames [][]byte) ([]byte, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("%w: no hay fragmentos", ErrFragmentMissing)
+	}
+
+	frags := make([]*Fragment, len(frames))
+	for i, f := range frames {
+		frag, err := ParseFragment(f)
+		if err != nil {
+			return nil, fmt.Errorf("fragmento en posición %d: %w", i, err)
+		}
+		if i > 0 && (frag.MessageID != frags[0].MessageID || frag.Total != frags[0].Total) {
+			return nil, fmt.Errorf("%w: posición %d es del mensaje %d (%d fragmentos), se esperaba %d (%d fragmentos)",
+				ErrFragmentMismatch, i, frag.MessageID, frag.Total, frags[0].MessageID, frags[0].Total)
+		}
+		frags[i] = frag
+	}
+
+	// Faltantes primero: un hueco también desordena los índices siguientes
+	presentes := make(map[int]bool, len(frags))
+	for _, f := range frags {
+		presentes[f.Index] = true
+	}
+	var faltan []string
+	for i := 0; i < frags[0].Total; i++ {
+		if !presentes[i] {
+			faltan = append(faltan, fmt.Sprint(i))
+		}
+	}
+	if len(faltan) > 0 {
+		return nil, fmt.Errorf("%w: %s de %d", ErrFragmentMissing, strings.Join(faltan, ","), frags[0].Total)
+	}
+
+	for i, f := range frags {
+		if f.Index != i {
+			orden := make([]int, len(frags))
+			for j, g := range frags {
+				orden[j] = g.Index
+			}
+			if sort.IntsAreSorted(orden) {
+				return nil, fmt.Errorf("%w: fragmento %d repetido", ErrFragmentMismatch, f.Index)
+			}
+			return nil, fmt.Errorf("%w: se recibió el orden %v", ErrFragmentOutOfOrder, orden)
+		}
+	}
+
+	var payload []byte
+	for _, f := range frags {
+		payload = append(payload, f.Data...)
+	}
+	return payload, nil
+}
@@ -0,0 +1,96 @@
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func fragmentar(t *testing.T, payload []byte, max int) [][]byte {
+	t.Helper()
+	frames, err := BuildFragmentedFrames(payload, max)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frames
+}
+
+func TestBuildFragmentedFrames_RoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 25) // 250 bytes
+
+	tests := []struct {
+		max   int
+		total int
+	}{
+		{100, 3},
+		{250, 1},
+		{1, 250},
+		{1000, 1},
+	}
+	for _, tt := range tests {
+		frames := fragmentar(t, payload, tt.max)
+		if len(frames) != tt.total {
+			t.Errorf("max %d: %d fragmentos, se esperaban %d", tt.max, len(frames), tt.total)
+		}
+		for i, f := range frames {
+			frag, err := ParseFragment(f)
+			if err != nil {
+				t.Fatalf("max %d, fragmento %d: %v", tt.max, i, err)
+			}
+			if frag.Index != i || frag.Total != tt.total || frag.ContentType != MsgTypeData || len(frag.Data) > tt.max {
+				t.Errorf("max %d: fragmento inesperado %+v", tt.max, frag)
+			}
+		}
+		got, err := ReassembleFrames(frames)
+		if err != nil {
+			t.Fatalf("max %d: %v", tt.max, err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Errorf("max %d: el payload reensamblado no coincide", tt.max)
+		}
+	}
+}
+
+func TestBuildFragmentedFrames_IDsDistintos(t *testing.T) {
+	a, _ := ParseFragment(fragmentar(t, []byte("a"), 4)[0])
+	b, _ := ParseFragment(fragmentar(t, []byte("a"), 4)[0])
+	if a.MessageID == b.MessageID {
+		t.Error("dos mensajes deberían tener IDs distintos")
+	}
+}
+
+func TestBuildFragmentedFrames_Invalido(t *testing.T) {
+	for _, max := range []int{0, -1, 0xFFFF} {
+		if _, err := BuildFragmentedFrames([]byte("Hola"), max); err == nil {
+			t.Errorf("se esperaba error con tamaño de fragmento %d", max)
+		}
+	}
+}
+
+func TestReassembleFrames_Errores(t *testing.T) {
+	frames := fragmentar(t, []byte("Hola, mundo"), 3) // 4 fragmentos
+	otro := fragmentar(t, []byte("Chau, mundo"), 3)
+	corrupto := append([]byte(nil), frames[1]...)
+	corrupto[HeaderSize+FragmentHeaderSize] ^= 0x01
+
+	tests := []struct {
+		name   string
+		frames [][]byte
+		want   error
+	}{
+		{"falta uno", [][]byte{frames[0], frames[1], frames[3]}, ErrFragmentMissing},
+		{"falta el último", frames[:3], ErrFragmentMissing},
+		{"ninguno", nil, ErrFragmentMissing},
+		{"fuera de orden", [][]byte{frames[0], frames[2], frames[1], frames[3]}, ErrFragmentOutOfOrder},
+		{"repetido", [][]byte{frames[0], frames[1], frames[1], frames[2], frames[3]}, ErrFragmentMismatch},
+		{"otro mensaje", [][]byte{frames[0], otro[1], frames[2], frames[3]}, ErrFragmentMismatch},
+		{"bit invertido", [][]byte{frames[0], corrupto, frames[2], frames[3]}, ErrCRCMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReassembleFrames(tt.frames); !errors.Is(err, tt.want) {
+				t.Errorf("error %v, se esperaba %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package frame
+
+import "fmt"
+
+// MsgTypeGolay es el tipo de las tramas codificadas con Golay(23,12)
+const MsgTypeGolay byte = 0x0B
+
+// golayPoly es el polinomio generador x^11 + x^10 + x^6 + x^5 + x^4 + x^2 + 1
+const golayPoly = 0xC75
+
+// golaySyndromes asigna a cada uno de los 2048 síndromes el único patrón de
+// error de a lo sumo 3 bits que lo produce: Golay(23,12) es un código
+// perfecto, 1 + 23 + 253 + 1771 = 2^11
+var golaySyndromes = func() [1 << 11]uint32 {
+	var t [1 << 11]uint32
+	for a := 0; a < 23; a++ {
+		for b := a; b < 23; b++ {
+			for c := b; c < 23; c++ {
+				e := uint32(1)<<a | uint32(1)<<b | uint32(1)<<c
+				t[golayRemainder(e)] = e
+			}
+		}
+	}
+	t[0] = 0
+	return t
+}()
+
+// golayRemainder es el resto de dividir v (grado < 23) por golayPoly
+func golayRemainder(v uint32) uint32 {
+	for i := 22; i >= 11; i-- {
+		if v&(1<<i) != 0 {
+			v ^= golayPoly << (i - 11)
+		}
+	}
+	return v
+}
+
+// Golay23Encode aplica Golay(23,12) a un slice de bits (0 o 1), con padding de
+// ceros hasta un múltiplo de 12. Cada bloque es sistemático: los 12 bits de
+// datos seguidos de las 11 paridades, el resto de dividir los datos
+// desplazados por el polinomio generador.
+func Golay23Encode(dataBits []byte) ([]byte, error) {
+	for i, b := range dataBits {
+		if b != 0 && b != 1 {
+			return nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := (len(dataBits) + 11) / 12
+	padded := make([]byte, numBlocks*12)
+	copy(padded, dataBits)
+
+	result := make([]byte, numBlocks*23)
+	for i := 0; i < numBlocks; i++ {
+		var d uint32
+		for _, b := range padded[i*12 : (i+1)*12] {
+			d = d<<1 | uint32(b)
+		}
+		palabra := d<<11 | golayRemainder(d<<11)
+		block := result[i*23 : (i+1)*23]
+		for j := range block {
+			block[j] = byte(palabra>>(22-j)) & 1
+		}
+	}
+	return result, nil
+}
+
+// Golay23Decode decodifica bloques de 23 bits en el layout de Golay23Encode y
+// corrige hasta 3 bits invertidos por bloque. Devuelve 12 bits de datos por
+// bloque (incluido el padding del encoder) y las posiciones absolutas
+// corregidas, en orden. Como el código es perfecto todo bloque está a
+// distancia 3 o menos de una palabra válida: con 4 o más errores el decoder
+// no los detecta, sino que corrige hacia otra palabra (a distancia 7 de la
+// enviada) y solo el checksum de la trama lo advierte.
+func Golay23Decode(codeBits []byte) (dataBits []byte, corrected []int, err error) {
+	if len(codeBits)%23 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 23", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := len(codeBits) / 23
+	dataBits = make([]byte, 0, numBlocks*12)
+	for i := 0; i < numBlocks; i++ {
+		var palabra uint32
+		for _, b := range codeBits[i*23 : (i+1)*23] {
+			palabra = palabra<<1 | uint32(b)
+		}
+		e := golaySyndromes[golayRemainder(palabra)]
+		for j := 0; j < 23; j++ {
+			if e&(1<<(22-j)) != 0 {
+				corrected = append(corrected, i*23+j)
+			}
+		}
+		palabra ^= e
+		for j := 22; j >= 11; j-- {
+			dataBits = append(dataBits, byte(palabra>>j)&1)
+		}
+	}
+	return dataBits, corrected, nil
+}
+
+// BuildFrameWithGolay codifica payload con Golay(23,12) en una trama
+// MsgTypeGolay, con el subheader de relleno de EncodeHammingPayload
+func BuildFrameWithGolay(payload []byte, opts FrameOptions) ([]byte, error) {
+	encoded, err := EncodeHammingPayload(BytesToBits(payload), 23, 12, Golay23Encode)
+	if err != nil {
+		return nil, err
+	}
+	opts.MsgType = MsgTypeGolay
+	return BuildFrameWithOptions(encoded, opts)
+}
+
+// Golay23DecodePayload decodifica el payload de una trama MsgTypeGolay y
+// devuelve exactamente los bits de datos codificados (ver
+// DecodeHammingPayload)
+func Golay23DecodePayload(payload []byte) (dataBits []byte, corrected []int, err error) {
+	return DecodeHammingPayload(payload, 23, Golay23Decode)
+}
@@ -0,0 +1,185 @@
+package frame
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// golayBloque codifica los 12 bits de d en un bloque de 23 bits
+func golayBloque(t *testing.T, d int) []byte {
+	t.Helper()
+	bits := make([]byte, 12)
+	for i := range bits {
+		bits[i] = byte(d>>(11-i)) & 1
+	}
+	code, err := Golay23Encode(bits)
+	if err != nil || len(code) != 23 {
+		t.Fatalf("Golay23Encode(%03x): %d bits, %v", d, len(code), err)
+	}
+	return code
+}
+
+func TestGolay23Encode_DistanciaMinima7(t *testing.T) {
+	// Un código lineal tiene distancia mínima igual al menor peso de sus
+	// palabras no nulas; Golay(23,12) tiene 253 palabras de peso 7
+	pesos := make(map[int]int)
+	for d := 1; d < 1<<12; d++ {
+		code := golayBloque(t, d)
+		peso := 0
+		for i, b := range code {
+			peso += int(b)
+			if i < 12 && b != byte(d>>(11-i))&1 {
+				t.Fatalf("%03x: el bloque no empieza con los datos", d)
+			}
+		}
+		pesos[peso]++
+	}
+	if pesos[7] != 253 || pesos[1]+pesos[2]+pesos[3]+pesos[4]+pesos[5]+pesos[6] != 0 {
+		t.Errorf("distribución de pesos %v, se esperaban 253 palabras de peso 7 y ninguna menor", pesos)
+	}
+}
+
+func TestGolay23_SindromesCompletos(t *testing.T) {
+	// Código perfecto: cada síndrome no nulo corresponde a un patrón de 1 a 3 bits
+	for s := 1; s < len(golaySyndromes); s++ {
+		e := golaySyndromes[s]
+		if e == 0 || golayRemainder(e) != uint32(s) {
+			t.Fatalf("síndrome %03x sin patrón de error (%06x)", s, e)
+		}
+	}
+}
+
+func TestGolay23Decode_CorrigeHastaTresErrores(t *testing.T) {
+	data := []byte{1, 0, 1, 1, 0, 0, 1, 0, 1, 1, 1, 0}
+	code, _ := Golay23Encode(data)
+
+	probar := func(posiciones ...int) {
+		t.Helper()
+		ruidoso := append([]byte(nil), code...)
+		for _, p := range posiciones {
+			ruidoso[p] ^= 1
+		}
+		got, corrected, err := Golay23Decode(ruidoso)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("errores en %v: datos %v, se esperaba %v", posiciones, got, data)
+		}
+		if len(corrected) != len(posiciones) {
+			t.Fatalf("errores en %v: posiciones corregidas %v", posiciones, corrected)
+		}
+		for i, p := range posiciones {
+			if corrected[i] != p {
+				t.Fatalf("errores en %v: posiciones corregidas %v", posiciones, corrected)
+			}
+		}
+	}
+
+	probar()
+	for a := 0; a < 23; a++ {
+		probar(a)
+		for b := a + 1; b < 23; b++ {
+			probar(a, b)
+			for c := b + 1; c < 23; c++ {
+				probar(a, b, c)
+			}
+		}
+	}
+}
+
+func TestGolay23Decode_CuatroErroresCorrigenHaciaOtraPalabra(t *testing.T) {
+	rng := rand.New(rand.NewSource(779))
+	for i := 0; i < 500; i++ {
+		d := rng.Intn(1 << 12)
+		code := golayBloque(t, d)
+		ruidoso := append([]byte(nil), code...)
+		for _, p := range rng.Perm(23)[:4] {
+			ruidoso[p] ^= 1
+		}
+		got, corrected, err := Golay23Decode(ruidoso)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// El bloque queda a distancia 3 de otra palabra: el decoder la
+		// "corrige" sin señal de error, a 7 bits de la enviada
+		if len(corrected) != 3 {
+			t.Fatalf("4 errores: %d posiciones corregidas, se esperaban 3", len(corrected))
+		}
+		otra, _ := Golay23Encode(got)
+		distancia := 0
+		for j := range otra {
+			if otra[j] != code[j] {
+				distancia++
+			}
+		}
+		if bytes.Equal(got, code[:12]) || distancia != 7 {
+			t.Fatalf("4 errores: palabra decodificada a distancia %d de la enviada, se esperaba 7", distancia)
+		}
+	}
+}
+
+func TestGolay23Encode_Padding(t *testing.T) {
+	data := []byte{1, 1, 0, 1, 0, 0, 1, 1, 1, 0, 0, 1, 1} // 13 bits: dos bloques
+	code, err := Golay23Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != 46 {
+		t.Fatalf("longitud esperada 46, obtuvo %d", len(code))
+	}
+	got, _, err := Golay23Decode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[:13], data) || !bytes.Equal(got[13:], make([]byte, 11)) {
+		t.Errorf("datos decodificados %v", got)
+	}
+}
+
+func TestGolay23_Invalidos(t *testing.T) {
+	if _, _, err := Golay23Decode(make([]byte, 24)); err == nil {
+		t.Error("se esperaba error para 24 bits (no múltiplo de 23)")
+	}
+	if _, _, err := Golay23Decode(append(make([]byte, 22), 2)); err == nil {
+		t.Error("se esperaba error para un bit distinto de 0 o 1")
+	}
+	if _, err := Golay23Encode([]byte{0, 3}); err == nil {
+		t.Error("se esperaba error para un bit distinto de 0 o 1")
+	}
+}
+
+func TestBuildFrameWithGolay_RoundTrip(t *testing.T) {
+	payload := []byte("Hola mundo")
+	trama, err := BuildFrameWithGolay(payload, FrameOptions{Checksum: ChecksumCRC16CCITT})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 80 bits en 7 bloques de 23 (161 bits, 21 bytes) más el byte de relleno
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeGolay || len(f.Payload) != HammingPadSize+21 || f.Payload[0] != 7*12-80 {
+		t.Fatalf("trama decodificada inesperada: %v (relleno %d)", f, f.Payload[0])
+	}
+
+	// Tres errores en cada bloque se corrigen
+	inicio := HammingBlocksOffset(trama) * 8
+	bits := BytesToBits(trama)
+	for b := 0; b < 7; b++ {
+		for _, p := range []int{0, 11, 22} {
+			bits[inicio+b*23+p] ^= 1
+		}
+	}
+	f.Payload = BitsToBytes(bits)[HeaderSize : len(trama)-ChecksumCRC16CCITT.Size()]
+	data, err := f.Data()
+	if err != nil || !bytes.Equal(data, payload) {
+		t.Errorf("Data = %q, %v", data, err)
+	}
+	stats, err := ComputeFrameStats(trama, 80)
+	if err != nil || stats.SubheaderBytes != HammingPadSize || stats.PadBits != 4+7 {
+		t.Errorf("estadísticas %+v, %v", stats, err)
+	}
+}
@@ -1,42 +1,99 @@
 package frame
-import "fmt"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidBit indica un bit distinto de 0 o 1 en una entrada de un byte por
+// bit (ver errors.Is)
+var ErrInvalidBit = errors.New("bit inválido")
+
+func invalidBit(pos int, b byte) error {
+	return fmt.Errorf("%w en posición %d: %d (debe ser 0 o 1)", ErrInvalidBit, pos, b)
+}
 
 // Hamming74Encode aplica el código Hamming (7,4) a un slice de bits (0 o 1).
 // Si la longitud no es múltiplo de 4, hace padding con ceros.
-// Devuelve un slice de bits codificados en bloques de 7 bits.
+// Devuelve un slice de bits codificados en bloques de 7 bits, o un error que
+// envuelve ErrInvalidBit.
 func Hamming74Encode(dataBits []byte) ([]byte, error) {
-    // Validación básica: bits solo 0 o 1
-    for i, b := range dataBits {
-        if b != 0 && b != 1 {
-            return nil, fmt.Errorf("bit inválido en posición %d: %d (debe ser 0 o 1)", i, b)
-        }
-    }
-
-    n := len(dataBits)
-    numBlocks := (n + 3) / 4
-
-    // Padding a múltiplo de 4
-    padded := make([]byte, numBlocks*4)
-    copy(padded, dataBits)
-
-    // Resultado: 7 bits por bloque
-    result := make([]byte, numBlocks*7)
-
-    for i := 0; i < numBlocks; i++ {
-        d3 := padded[i*4+0]
-        d2 := padded[i*4+1]
-        d1 := padded[i*4+2]
-        d0 := padded[i*4+3]
-
-        // Cálculo de bits de paridad
-        p0 := d3 ^ d2 ^ d0           // paridad sobre posiciones 3,2,0
-        p1 := d3 ^ d1 ^ d0           // paridad sobre posiciones 3,1,0
-        p2 := d2 ^ d1 ^ d0           // paridad sobre posiciones 2,1,0
-
-        // Bloque: [p2 p1 d3 p0 d2 d1 d0]
-        out := []byte{p2, p1, d3, p0, d2, d1, d0}
-        copy(result[i*7:(i+1)*7], out)
-    }
-
-    return result, nil
+	// Validación básica: bits solo 0 o 1
+	for i, b := range dataBits {
+		if b != 0 && b != 1 {
+			return nil, invalidBit(i, b)
+		}
+	}
+
+	n := len(dataBits)
+	numBlocks := (n + 3) / 4
+
+	// Padding a múltiplo de 4
+	padded := make([]byte, numBlocks*4)
+	copy(padded, dataBits)
+
+	// Resultado: 7 bits por bloque
+	result := make([]byte, numBlocks*7)
+
+	for i := 0; i < numBlocks; i++ {
+		d3 := padded[i*4+0]
+		d2 := padded[i*4+1]
+		d1 := padded[i*4+2]
+		d0 := padded[i*4+3]
+
+		// Cálculo de bits de paridad
+		p0 := d3 ^ d2 ^ d0 // paridad sobre posiciones 3,2,0
+		p1 := d3 ^ d1 ^ d0 // paridad sobre posiciones 3,1,0
+		p2 := d2 ^ d1 ^ d0 // paridad sobre posiciones 2,1,0
+
+		// Bloque: [p2 p1 d3 p0 d2 d1 d0]
+		out := []byte{p2, p1, d3, p0, d2, d1, d0}
+		copy(result[i*7:(i+1)*7], out)
+	}
+
+	return result, nil
+}
+
+// hammingSyndromePos indica, para cada síndrome (s2 s1 s0), qué posición del
+// bloque [p2 p1 d3 p0 d2 d1 d0] está invertida
+var hammingSyndromePos = [8]int{-1, 3, 1, 2, 0, 4, 5, 6}
+
+// Hamming74Decode decodifica bloques de 7 bits en el layout de Hamming74Encode
+// y corrige un bit invertido por bloque. Devuelve 4 bits de datos por bloque
+// (incluido el padding del encoder) y las posiciones absolutas corregidas,
+// en orden. Con dos o más errores en un bloque la corrección es incorrecta,
+// como es propio de Hamming(7,4).
+func Hamming74Decode(codeBits []byte) (dataBits []byte, corrected []int, err error) {
+	if len(codeBits)%7 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 7", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := len(codeBits) / 7
+	dataBits = make([]byte, 0, numBlocks*4)
+	block := make([]byte, 7)
+
+	for i := 0; i < numBlocks; i++ {
+		copy(block, codeBits[i*7:(i+1)*7])
+		p2, p1, d3, p0, d2, d1, d0 := block[0], block[1], block[2], block[3], block[4], block[5], block[6]
+
+		// Cada bit del síndrome repite el cálculo de paridad del encoder
+		s0 := p0 ^ d3 ^ d2 ^ d0
+		s1 := p1 ^ d3 ^ d1 ^ d0
+		s2 := p2 ^ d2 ^ d1 ^ d0
+
+		if pos := hammingSyndromePos[s2<<2|s1<<1|s0]; pos >= 0 {
+			block[pos] ^= 1
+			corrected = append(corrected, i*7+pos)
+		}
+
+		// Datos: [d3 d2 d1 d0]
+		dataBits = append(dataBits, block[2], block[4], block[5], block[6])
+	}
+
+	return dataBits, corrected, nil
 }
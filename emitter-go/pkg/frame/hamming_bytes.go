@@ -0,0 +1,101 @@
+package frame
+
+import "fmt"
+
+// Hamming74EncodeBytes es Hamming74Encode sobre bytes empaquetados: codifica
+// cada nibble de data (el alto primero) con hammingTabla y agrupa los bloques
+// de 7 bits en bytes, sin expandir la entrada ni la salida a un bit por byte.
+// El resultado es BitsToBytes(Hamming74Encode(BytesToBits(data))); lastBits
+// son los bits válidos del último byte (1 a 8, 0 sin datos) y el resto es
+// relleno en cero. Nunca falla con bytes empaquetados: el error conserva la
+// forma de Hamming74Encode.
+func Hamming74EncodeBytes(data []byte) (code []byte, lastBits int, err error) {
+	return hammingEncodeBytes(make([]byte, 0, (len(data)*14+7)/8), data, false), lastBitsOf(len(data) * 14), nil
+}
+
+// hammingEncodeBytes agrega a dst los bloques Hamming(7,4) o, con secded,
+// Hamming(8,4) de data agrupados en bytes (el último completado con ceros)
+func hammingEncodeBytes(dst, data []byte, secded bool) []byte {
+	n, tabla := 7, &hammingTabla[0]
+	if secded {
+		n, tabla = 8, &hammingTabla[1]
+	}
+	var acc uint64 // Bits pendientes en los bits bajos, el más antiguo primero
+	pendientes := 0
+	for _, b := range data {
+		acc = acc<<(2*n) | tabla[b>>4]<<n | tabla[b&0x0F]
+		for pendientes += 2 * n; pendientes >= 8; pendientes -= 8 {
+			dst = append(dst, byte(acc>>(pendientes-8)))
+		}
+	}
+	if pendientes > 0 {
+		dst = append(dst, byte(acc<<(8-pendientes)))
+	}
+	return dst
+}
+
+// lastBitsOf son los bits válidos del último byte de una salida de n bits
+func lastBitsOf(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return (n-1)%8 + 1
+}
+
+// hammingDecodeTabla tiene, para cada bloque de 7 bits [p2 p1 d3 p0 d2 d1 d0],
+// el nibble de datos corregido en los bits bajos y la posición invertida
+// (0 a 6) más uno en los altos, o 0 si el síndrome es nulo
+var hammingDecodeTabla = func() (t [128]byte) {
+	for v := range t {
+		p2, p1, d3, p0 := byte(v>>6)&1, byte(v>>5)&1, byte(v>>4)&1, byte(v>>3)&1
+		d2, d1, d0 := byte(v>>2)&1, byte(v>>1)&1, byte(v)&1
+		s0 := p0 ^ d3 ^ d2 ^ d0
+		s1 := p1 ^ d3 ^ d1 ^ d0
+		s2 := p2 ^ d2 ^ d1 ^ d0
+		bloque := byte(v)
+		var pos byte
+		if p := hammingSyndromePos[s2<<2|s1<<1|s0]; p >= 0 {
+			bloque ^= 1 << (6 - p)
+			pos = byte(p + 1)
+		}
+		t[v] = pos<<4 | bloque>>4&1<<3 | bloque&0x07
+	}
+	return t
+}()
+
+// Hamming74DecodeBytes es Hamming74Decode sobre bytes empaquetados, la
+// inversa de Hamming74EncodeBytes: code trae bloques de 7 bits seguidos y
+// lastBits indica cuántos bits del último byte son válidos (0 sin bloques).
+// Los bits válidos tienen que completar bloques. Devuelve los nibbles de
+// datos agrupados en bytes (con una cantidad impar de bloques el último byte
+// lleva el nibble en la mitad alta y ceros en la baja) y las posiciones
+// corregidas como Hamming74Decode.
+func Hamming74DecodeBytes(code []byte, lastBits int) (data []byte, corrected []int, err error) {
+	if lastBits < 0 || lastBits > 8 || (lastBits == 0) != (len(code) == 0) {
+		return nil, nil, fmt.Errorf("bits válidos del último byte inválidos: %d con %d bytes", lastBits, len(code))
+	}
+	total := 0
+	if len(code) > 0 {
+		total = (len(code)-1)*8 + lastBits
+	}
+	if total%7 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 7", total)
+	}
+
+	numBlocks := total / 7
+	data = make([]byte, (numBlocks+1)/2)
+	var acc uint64
+	pendientes, bloque := 0, 0
+	for _, b := range code {
+		acc = acc<<8 | uint64(b)
+		for pendientes += 8; pendientes >= 7 && bloque < numBlocks; pendientes -= 7 {
+			d := hammingDecodeTabla[acc>>(pendientes-7)&0x7F]
+			if pos := int(d >> 4); pos > 0 {
+				corrected = append(corrected, bloque*7+pos-1)
+			}
+			data[bloque/2] |= (d & 0x0F) << (4 * (1 - bloque%2))
+			bloque++
+		}
+	}
+	return data, corrected, nil
+}
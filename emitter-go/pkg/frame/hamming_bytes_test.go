@@ -0,0 +1,183 @@
+package frame
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestHamming74EncodeBytes_IgualQueElDeSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(782))
+	for n := 0; n <= 64; n++ {
+		data := make([]byte, n)
+		rng.Read(data)
+		code, lastBits, err := Hamming74EncodeBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bits, _ := Hamming74Encode(BytesToBits(data))
+		want, pad := BitsToBytesPadded(bits)
+		if !bytes.Equal(code, want) {
+			t.Fatalf("%d bytes: % x, se esperaba % x", n, code, want)
+		}
+		if n > 0 && lastBits != 8-pad || n == 0 && lastBits != 0 {
+			t.Errorf("%d bytes: %d bits válidos en el último byte, el relleno es de %d", n, lastBits, pad)
+		}
+	}
+}
+
+func TestHamming74DecodeBytes_IgualQueElDeSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(782))
+	for numBlocks := 0; numBlocks <= 40; numBlocks++ {
+		// Bloques arbitrarios: cualquier patrón de 7 bits se decodifica
+		bits := make([]byte, numBlocks*7)
+		for i := range bits {
+			bits[i] = byte(rng.Intn(2))
+		}
+		code, pad := BitsToBytesPadded(bits)
+		data, corrected, err := Hamming74DecodeBytes(code, lastBitsOf(len(bits)))
+		if err != nil {
+			t.Fatalf("%d bloques (relleno %d): %v", numBlocks, pad, err)
+		}
+		wantBits, wantCorrected, _ := Hamming74Decode(bits)
+		if !bytes.Equal(data, BitsToBytes(wantBits)) || !slices.Equal(corrected, wantCorrected) {
+			t.Fatalf("%d bloques: % x %v, se esperaba % x %v", numBlocks, data, corrected, BitsToBytes(wantBits), wantCorrected)
+		}
+	}
+}
+
+func TestHamming74Bytes_CorrigeUnErrorPorBloque(t *testing.T) {
+	data := []byte("Hola mundo")
+	code, lastBits, _ := Hamming74EncodeBytes(data)
+	var posiciones []int
+	for b := 0; b < len(data)*2; b++ {
+		p := b*7 + b%7
+		code[p/8] ^= 0x80 >> (p % 8)
+		posiciones = append(posiciones, p)
+	}
+	got, corrected, err := Hamming74DecodeBytes(code, lastBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) || !slices.Equal(corrected, posiciones) {
+		t.Errorf("datos %q, corregidas %v; se esperaba %q, %v", got, corrected, data, posiciones)
+	}
+}
+
+func TestHamming74DecodeBytes_Invalidos(t *testing.T) {
+	casos := []struct {
+		code     []byte
+		lastBits int
+	}{
+		{[]byte{0}, 0},    // Un byte sin bits válidos
+		{nil, 3},          // Bits válidos sin bytes
+		{[]byte{0}, 9},    // Más de 8 bits en un byte
+		{[]byte{0, 0}, 8}, // 16 bits no completan bloques
+	}
+	for _, c := range casos {
+		if _, _, err := Hamming74DecodeBytes(c.code, c.lastBits); err == nil {
+			t.Errorf("% x con %d bits válidos: se esperaba error", c.code, c.lastBits)
+		}
+	}
+	// 3 bloques son 21 bits: 2 bytes y 5 bits del tercero
+	if data, _, err := Hamming74DecodeBytes([]byte{0, 0, 0}, 5); err != nil || len(data) != 2 {
+		t.Errorf("3 bloques: % x, %v", data, err)
+	}
+}
+
+func TestBuildFrameWithHamming_IgualQueConSlices(t *testing.T) {
+	rng := rand.New(rand.NewSource(782))
+	for n := 0; n <= 40; n++ {
+		data := make([]byte, n)
+		rng.Read(data)
+		for _, opts := range [][]HammingOption{
+			nil,
+			{HammingSECDED},
+			{InterleaveDepth(5)},
+			{HammingSECDED, InterleaveDepth(3)},
+		} {
+			got, err := BuildFrameWithHamming(data, opts...)
+			if err != nil {
+				t.Fatalf("%d bytes %v: %v", n, opts, err)
+			}
+			want, err := BuildFrameWithHammingBits(BytesToBits(data), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%d bytes %v: % x, se esperaba % x", n, opts, got, want)
+			}
+		}
+	}
+}
+
+// payload32KB es el caso que motiva Hamming74EncodeBytes
+var payload32KB = bytes.Repeat([]byte("Hamming!"), 4096)
+
+func BenchmarkHamming74Encode_32KB_Slices(b *testing.B) {
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bits, err := Hamming74Encode(BytesToBits(payload32KB))
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = BitsToBytes(bits)
+	}
+}
+
+func BenchmarkHamming74Encode_32KB_Bytes(b *testing.B) {
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Hamming74EncodeBytes(payload32KB); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHamming74Decode_32KB_Slices(b *testing.B) {
+	code, _, _ := Hamming74EncodeBytes(payload32KB)
+	bits := len(payload32KB) * 14
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, _, err := Hamming74Decode(BytesToBits(code)[:bits])
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = BitsToBytes(data)
+	}
+}
+
+func BenchmarkHamming74Decode_32KB_Bytes(b *testing.B) {
+	code, lastBits, _ := Hamming74EncodeBytes(payload32KB)
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Hamming74DecodeBytes(code, lastBits); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildFrameWithHamming_32KB_Slices(b *testing.B) {
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildFrameWithHammingBits(BytesToBits(payload32KB)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildFrameWithHamming_32KB_Bytes(b *testing.B) {
+	b.SetBytes(int64(len(payload32KB)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildFrameWithHamming(payload32KB); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
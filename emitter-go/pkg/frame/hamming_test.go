@@ -1,36 +1,140 @@
 package frame
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
 
 func TestHamming74Encode_SingleBlock(t *testing.T) {
-    // Datos de prueba: 4 bits
-    data := []byte{1, 0, 1, 1} // d3=1,d2=0,d1=1,d0=1
-    // p0 = 1^0^1 = 0; p1 = 1^1^1 = 1; p2 = 0^1^1 = 0
-    want := []byte{0, 1, 1, 0, 0, 1, 1}
-
-    got, err := Hamming74Encode(data)
-    if err != nil {
-        t.Fatalf("Error inesperado: %v", err)
-    }
-    if len(got) != 7 {
-        t.Fatalf("Longitud esperada 7, obtuvo %d", len(got))
-    }
-    for i := range want {
-        if got[i] != want[i] {
-            t.Errorf("Byte %d: esperado %d, obtuvo %d", i, want[i], got[i])
-        }
-    }
+	// Datos de prueba: 4 bits
+	data := []byte{1, 0, 1, 1} // d3=1,d2=0,d1=1,d0=1
+	// p0 = 1^0^1 = 0; p1 = 1^1^1 = 1; p2 = 0^1^1 = 0
+	want := []byte{0, 1, 1, 0, 0, 1, 1}
+
+	got, err := Hamming74Encode(data)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if len(got) != 7 {
+		t.Fatalf("Longitud esperada 7, obtuvo %d", len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Byte %d: esperado %d, obtuvo %d", i, want[i], got[i])
+		}
+	}
 }
 
 func TestHamming74Encode_Padding(t *testing.T) {
-    // 6 bits → 2 bloques (padding a 8 bits)
-    data := []byte{1, 1, 0, 1, 0, 1} // 6 bits
-    got, err := Hamming74Encode(data)
-    if err != nil {
-        t.Fatalf("Error inesperado: %v", err)
-    }
-    // Debe codificar 2 bloques → 14 bits
-    if len(got) != 14 {
-        t.Errorf("Para 6 bits esperados 14 bits codificados, obtuvo %d", len(got))
-    }
+	// 6 bits → 2 bloques (padding a 8 bits)
+	data := []byte{1, 1, 0, 1, 0, 1} // 6 bits
+	got, err := Hamming74Encode(data)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	// Debe codificar 2 bloques → 14 bits
+	if len(got) != 14 {
+		t.Errorf("Para 6 bits esperados 14 bits codificados, obtuvo %d", len(got))
+	}
+}
+
+func TestHamming74Decode_CorrigeCadaPosicion(t *testing.T) {
+	data := []byte{1, 0, 1, 1}
+	code, _ := Hamming74Encode(data)
+
+	for pos := 0; pos < 7; pos++ {
+		noisy := append([]byte(nil), code...)
+		noisy[pos] ^= 1
+
+		got, corrected, err := Hamming74Decode(noisy)
+		if err != nil {
+			t.Fatalf("Error inesperado: %v", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("Bit %d invertido: esperado %v, obtuvo %v", pos, data, got)
+		}
+		if len(corrected) != 1 || corrected[0] != pos {
+			t.Errorf("Bit %d invertido: posiciones corregidas %v", pos, corrected)
+		}
+	}
+}
+
+func TestHamming74Decode_LongitudInvalida(t *testing.T) {
+	if _, _, err := Hamming74Decode(make([]byte, 10)); err == nil {
+		t.Error("Se esperaba error para 10 bits (no múltiplo de 7)")
+	}
+	if _, _, err := Hamming74Decode([]byte{0, 1, 2, 0, 0, 0, 0}); err == nil {
+		t.Error("Se esperaba error para un bit distinto de 0 o 1")
+	}
+}
+
+func TestErrInvalidBit(t *testing.T) {
+	invalidos := []byte{0, 1, 2, 0, 1, 0, 0, 1}
+	_, err := Hamming74Encode(invalidos)
+	if !errors.Is(err, ErrInvalidBit) || err.Error() != "bit inválido en posición 2: 2 (debe ser 0 o 1)" {
+		t.Errorf("Hamming74Encode: %v, se esperaba ErrInvalidBit", err)
+	}
+	if _, _, err := Hamming74Decode(invalidos[:7]); !errors.Is(err, ErrInvalidBit) {
+		t.Errorf("Hamming74Decode: %v, se esperaba ErrInvalidBit", err)
+	}
+	if _, err := Hamming84Encode(invalidos); !errors.Is(err, ErrInvalidBit) {
+		t.Errorf("Hamming84Encode: %v, se esperaba ErrInvalidBit", err)
+	}
+	if _, err := BitsetFromBits(invalidos); !errors.Is(err, ErrInvalidBit) {
+		t.Errorf("BitsetFromBits: %v, se esperaba ErrInvalidBit", err)
+	}
+}
+
+// FuzzHamming74RoundTrip codifica bytes arbitrarios como bits, invierte a lo
+// sumo un bit por bloque y exige que el decoder recupere los datos; también
+// decodifica la entrada cruda, que no debe entrar en pánico
+func FuzzHamming74RoundTrip(f *testing.F) {
+	f.Add([]byte{}, uint64(0))
+	f.Add([]byte{0xB0}, uint64(3))
+	f.Add([]byte("hola"), uint64(0xFFFFFFFFFFFFFFFF))
+
+	f.Fuzz(func(t *testing.T, data []byte, errores uint64) {
+		Hamming74Decode(data)
+
+		bits := BytesToBits(data)
+		code, err := Hamming74Encode(bits)
+		if err != nil {
+			t.Fatalf("Hamming74Encode: %v", err)
+		}
+		if len(code) != (len(bits)+3)/4*7 {
+			t.Fatalf("%d bits codificados en %d, se esperaban %d", len(bits), len(code), (len(bits)+3)/4*7)
+		}
+
+		// Cada 3 bits de errores eligen la posición a invertir en un bloque
+		// (7 = ninguna)
+		var want []int
+		for i := 0; i < len(code)/7 && i < 21; i++ {
+			if pos := int(errores>>(3*i)) & 7; pos < 7 {
+				code[i*7+pos] ^= 1
+				want = append(want, i*7+pos)
+			}
+		}
+
+		got, corrected, err := Hamming74Decode(code)
+		if err != nil {
+			t.Fatalf("Hamming74Decode: %v", err)
+		}
+		if len(got) != len(code)/7*4 || !bytes.Equal(got[:len(bits)], bits) {
+			t.Fatalf("no se recuperaron los datos: % x", data)
+		}
+		for _, b := range got[len(bits):] {
+			if b != 0 {
+				t.Fatalf("el padding no es cero: %v", got[len(bits):])
+			}
+		}
+		if len(corrected) != len(want) {
+			t.Fatalf("posiciones corregidas %v, se esperaban %v", corrected, want)
+		}
+		for i := range want {
+			if corrected[i] != want[i] {
+				t.Fatalf("posiciones corregidas %v, se esperaban %v", corrected, want)
+			}
+		}
+	})
 }
@@ -0,0 +1,132 @@
+package frame
+
+import "fmt"
+
+// MsgTypeHamming1511 es el tipo de las tramas codificadas con Hamming(15,11)
+const MsgTypeHamming1511 byte = 0x04
+
+// HammingVariant es un código Hamming(N,K) que puede elegir el emisor. El tipo
+// de mensaje del header identifica la variante, así que el receptor decodifica
+// cada trama con la que se usó al construirla.
+type HammingVariant struct {
+	Name    string
+	N, K    int // Bits por bloque codificado y de datos
+	MsgType byte
+	Encode  func(dataBits []byte) ([]byte, error)
+	Decode  func(codeBits []byte) (dataBits []byte, corrected []int, err error)
+}
+
+var (
+	Hamming74   = HammingVariant{"Hamming(7,4)", 7, 4, MsgTypeHamming, Hamming74Encode, Hamming74Decode}
+	Hamming1511 = HammingVariant{"Hamming(15,11)", 15, 11, MsgTypeHamming1511, Hamming1511Encode, Hamming1511Decode}
+)
+
+// HammingVariants son las variantes registradas, de la más robusta a la más
+// eficiente
+var HammingVariants = []HammingVariant{Hamming74, Hamming1511}
+
+// HammingVariantForType busca la variante de las tramas de tipo msgType
+func HammingVariantForType(msgType byte) (HammingVariant, bool) {
+	for _, v := range HammingVariants {
+		if v.MsgType == msgType {
+			return v, true
+		}
+	}
+	return HammingVariant{}, false
+}
+
+// EncodeFrame codifica payload con la variante (ver EncodeHammingPayload) y
+// construye la trama con su tipo de mensaje
+func (v HammingVariant) EncodeFrame(payload []byte, opts FrameOptions) ([]byte, error) {
+	encoded, err := EncodeHammingPayload(BytesToBits(payload), v.N, v.K, v.Encode)
+	if err != nil {
+		return nil, err
+	}
+	opts.MsgType = v.MsgType
+	return BuildFrameWithOptions(encoded, opts)
+}
+
+// DecodePayload decodifica el payload de una trama de la variante y devuelve
+// exactamente los bits de datos codificados (ver DecodeHammingPayload)
+func (v HammingVariant) DecodePayload(payload []byte) (dataBits []byte, corrected []int, err error) {
+	return DecodeHammingPayload(payload, v.N, v.Decode)
+}
+
+// Hamming1511Encode aplica Hamming(15,11) a un slice de bits (0 o 1), con
+// padding de ceros hasta un múltiplo de 11. Cada bloque sigue el layout
+// clásico: las posiciones 1, 2, 4 y 8 (desde 1) son paridad y el resto datos,
+// en orden.
+func Hamming1511Encode(dataBits []byte) ([]byte, error) {
+	for i, b := range dataBits {
+		if b != 0 && b != 1 {
+			return nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := (len(dataBits) + 10) / 11
+	padded := make([]byte, numBlocks*11)
+	copy(padded, dataBits)
+
+	result := make([]byte, numBlocks*15)
+	for i := 0; i < numBlocks; i++ {
+		block := result[i*15 : (i+1)*15]
+		d := padded[i*11 : (i+1)*11]
+		j := 0
+		for pos := 1; pos <= 15; pos++ {
+			if pos&(pos-1) != 0 { // No es potencia de 2
+				block[pos-1] = d[j]
+				j++
+			}
+		}
+		for p := 1; p <= 8; p <<= 1 {
+			var paridad byte
+			for pos := 1; pos <= 15; pos++ {
+				if pos&p != 0 && pos != p {
+					paridad ^= block[pos-1]
+				}
+			}
+			block[p-1] = paridad
+		}
+	}
+	return result, nil
+}
+
+// Hamming1511Decode decodifica bloques de 15 bits en el layout de
+// Hamming1511Encode y corrige un bit invertido por bloque: el síndrome es la
+// posición del error. Devuelve 11 bits de datos por bloque y las posiciones
+// absolutas corregidas, en orden.
+func Hamming1511Decode(codeBits []byte) (dataBits []byte, corrected []int, err error) {
+	if len(codeBits)%15 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 15", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := len(codeBits) / 15
+	dataBits = make([]byte, 0, numBlocks*11)
+	block := make([]byte, 15)
+	for i := 0; i < numBlocks; i++ {
+		copy(block, codeBits[i*15:(i+1)*15])
+
+		sindrome := 0
+		for pos := 1; pos <= 15; pos++ {
+			if block[pos-1] == 1 {
+				sindrome ^= pos
+			}
+		}
+		if sindrome != 0 {
+			block[sindrome-1] ^= 1
+			corrected = append(corrected, i*15+sindrome-1)
+		}
+
+		for pos := 1; pos <= 15; pos++ {
+			if pos&(pos-1) != 0 {
+				dataBits = append(dataBits, block[pos-1])
+			}
+		}
+	}
+	return dataBits, corrected, nil
+}
@@ -0,0 +1,78 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHamming1511_CorrigeUnBitPorBloque(t *testing.T) {
+	data := BytesToBits([]byte("Hola mundo"))
+	code, err := Hamming1511Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bloques := (len(data) + 10) / 11
+	if len(code) != bloques*15 {
+		t.Fatalf("%d bits codificados, se esperaban %d", len(code), bloques*15)
+	}
+
+	// Sin errores: los datos vuelven con el padding del encoder
+	got, corrected, err := Hamming1511Decode(code)
+	if err != nil || len(corrected) != 0 || !bytes.Equal(got[:len(data)], data) {
+		t.Fatalf("decodificación sin errores: %v, corregidos %v", err, corrected)
+	}
+
+	// Cualquier posición de cada bloque, incluidas las de paridad
+	for pos := 0; pos < 15; pos++ {
+		ruidoso := append([]byte(nil), code...)
+		var want []int
+		for b := 0; b < bloques; b++ {
+			ruidoso[b*15+pos] ^= 1
+			want = append(want, b*15+pos)
+		}
+		got, corrected, err := Hamming1511Decode(ruidoso)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got[:len(data)], data) {
+			t.Errorf("posición %d: datos no recuperados", pos)
+		}
+		if len(corrected) != len(want) || corrected[0] != want[0] || corrected[len(want)-1] != want[len(want)-1] {
+			t.Errorf("posición %d: corregidos %v, se esperaban %v", pos, corrected, want)
+		}
+	}
+
+	if _, _, err := Hamming1511Decode(code[:14]); err == nil {
+		t.Error("se esperaba error con un bloque incompleto")
+	}
+}
+
+func TestHammingVariant_EncodeFrame(t *testing.T) {
+	for _, v := range HammingVariants {
+		t.Run(v.Name, func(t *testing.T) {
+			trama, err := v.EncodeFrame([]byte("Hola"), FrameOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := HammingVariantForType(f.MsgType)
+			if !ok || got.Name != v.Name {
+				t.Fatalf("el tipo %#02x no identifica a %s", f.MsgType, v.Name)
+			}
+			bits, _, err := got.DecodePayload(f.Payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if texto := BitsToBytes(bits)[:4]; string(texto) != "Hola" {
+				t.Errorf("payload decodificado %q", texto)
+			}
+		})
+	}
+
+	if _, ok := HammingVariantForType(MsgTypeData); ok {
+		t.Error("MsgTypeData no debería ser una variante Hamming")
+	}
+}
@@ -0,0 +1,110 @@
+package frame
+
+import "fmt"
+
+// HammingFlagInterleave en el byte de relleno de un payload Hamming indica que
+// le sigue un byte con la profundidad de entrelazado y que los bloques van
+// entrelazados (ver InterleaveHammingPayload)
+const HammingFlagInterleave byte = 0x80
+
+// MaxInterleaveDepth es la profundidad máxima que entra en el subheader
+const MaxInterleaveDepth = 0xFF
+
+// Interleave reordena bits con un entrelazado por bloques de profundidad
+// depth: los escribe por filas en una matriz de depth columnas y los lee por
+// columnas, así que dos bits consecutivos de la salida estaban a depth
+// posiciones de distancia en la entrada. Con depth mayor o igual al tamaño de
+// bloque del código, una ráfaga de errores contiguos cae en bloques distintos.
+// La última fila puede quedar incompleta; depth <= 1 devuelve una copia.
+func Interleave(bits []byte, depth int) []byte {
+	out := make([]byte, len(bits))
+	if depth <= 1 {
+		copy(out, bits)
+		return out
+	}
+	for i, b := range bits {
+		out[interleavedIndex(i, len(bits), depth)] = b
+	}
+	return out
+}
+
+// Deinterleave deshace Interleave con la misma profundidad
+func Deinterleave(bits []byte, depth int) []byte {
+	out := make([]byte, len(bits))
+	if depth <= 1 {
+		copy(out, bits)
+		return out
+	}
+	for i := range out {
+		out[i] = bits[interleavedIndex(i, len(bits), depth)]
+	}
+	return out
+}
+
+// interleavedIndex es la posición de salida de Interleave para el bit i de
+// una entrada de length bits
+func interleavedIndex(i, length, depth int) int {
+	rows := (length + depth - 1) / depth
+	full := length % depth // Columnas con rows bits; las demás tienen rows-1
+	if full == 0 {
+		full = depth
+	}
+	col, row := i%depth, i/depth
+	if col < full {
+		return col*rows + row
+	}
+	return full*rows + (col-full)*(rows-1) + row
+}
+
+// DeinterleavedIndex es la inversa de la posición de Interleave: para el bit p
+// de la salida de length bits devuelve su posición en la entrada
+func DeinterleavedIndex(p, length, depth int) int {
+	if depth <= 1 {
+		return p
+	}
+	rows := (length + depth - 1) / depth
+	full := length % depth
+	if full == 0 {
+		full = depth
+	}
+	if p < full*rows {
+		return (p%rows)*depth + p/rows
+	}
+	q := p - full*rows
+	return (q%(rows-1))*depth + full + q/(rows-1)
+}
+
+// InterleaveHammingPayload entrelaza los bloques de un payload de
+// EncodeHammingPayload con profundidad depth. El payload resultante es
+// [relleno|HammingFlagInterleave][profundidad] + bloques entrelazados;
+// DecodeHammingPayload lo reconoce y deshace el entrelazado.
+func InterleaveHammingPayload(payload []byte, depth int) ([]byte, error) {
+	if depth < 1 || depth > MaxInterleaveDepth {
+		return nil, fmt.Errorf("profundidad de entrelazado inválida: %d (debe estar entre 1 y %d)", depth, MaxInterleaveDepth)
+	}
+	if len(payload) < HammingPadSize || payload[0]&HammingFlagInterleave != 0 {
+		return nil, fmt.Errorf("el payload no es un payload Hamming sin entrelazar")
+	}
+	blocks := BitsToBytes(Interleave(BytesToBits(payload[HammingPadSize:]), depth))
+	out := make([]byte, 0, len(payload)+1)
+	out = append(out, payload[0]|HammingFlagInterleave, byte(depth))
+	return append(out, blocks...), nil
+}
+
+// HammingInterleaveDepth devuelve la profundidad de entrelazado de un payload
+// Hamming, o 0 si no está entrelazado
+func HammingInterleaveDepth(payload []byte) int {
+	if len(payload) < HammingPadSize+1 || payload[0]&HammingFlagInterleave == 0 {
+		return 0
+	}
+	return int(payload[HammingPadSize])
+}
+
+// hammingSubheaderSize es el largo del subheader de un payload Hamming: el
+// byte de relleno y, si está entrelazado, el de profundidad
+func hammingSubheaderSize(payload []byte) int {
+	if len(payload) > 0 && payload[0]&HammingFlagInterleave != 0 {
+		return HammingPadSize + 1
+	}
+	return HammingPadSize
+}
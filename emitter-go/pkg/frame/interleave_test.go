@@ -0,0 +1,117 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+)
+
+func bitsDePrueba(n int) []byte {
+	bits := make([]byte, n)
+	for i := range bits {
+		bits[i] = byte(i*7/3) & 1
+	}
+	return bits
+}
+
+func TestInterleave_InversaExacta(t *testing.T) {
+	// Incluye largos que no son múltiplo de la profundidad y más cortos que ella
+	for _, depth := range []int{1, 2, 3, 7, 8, 13} {
+		for n := 0; n <= 60; n++ {
+			bits := bitsDePrueba(n)
+			entrelazados := Interleave(bits, depth)
+			if len(entrelazados) != n {
+				t.Fatalf("depth %d, %d bits: salida de %d bits", depth, n, len(entrelazados))
+			}
+			if got := Deinterleave(entrelazados, depth); !bytes.Equal(got, bits) {
+				t.Errorf("depth %d, %d bits: Deinterleave no invierte Interleave", depth, n)
+			}
+			for i := 0; i < n; i++ {
+				if p := interleavedIndex(i, n, depth); DeinterleavedIndex(p, n, depth) != i {
+					t.Fatalf("depth %d, %d bits: DeinterleavedIndex(%d) != %d", depth, n, p, i)
+				}
+			}
+		}
+	}
+}
+
+func TestInterleave_Permutacion(t *testing.T) {
+	// 0..9 en 3 columnas: [0 1 2][3 4 5][6 7 8][9]
+	in := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	want := []byte{0, 3, 6, 9, 1, 4, 7, 2, 5, 8}
+	if got := Interleave(in, 3); !bytes.Equal(got, want) {
+		t.Errorf("Interleave = %v, se esperaba %v", got, want)
+	}
+}
+
+func TestBuildFrameWithHamming_EntrelazadoCorrigeRafaga(t *testing.T) {
+	texto := []byte("Hola mundo")
+	for _, mode := range []HammingMode{HammingSEC, HammingSECDED} {
+		n := 7
+		if mode == HammingSECDED {
+			n = 8
+		}
+		plana, err := BuildFrameWithHamming(texto, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		trama, err := BuildFrameWithHamming(texto, mode, InterleaveDepth(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trama) != len(plana)+1 || HammingBlocksOffset(trama) != HeaderSize+HammingPadSize+1 {
+			t.Fatalf("modo %d: trama de %d bytes, bloques desde %d", mode, len(trama), HammingBlocksOffset(trama))
+		}
+
+		// Ráfaga de 3 bits contiguos en medio de los bloques
+		decodificar := func(trama []byte) []byte {
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload := append([]byte(nil), f.Payload...)
+			inicio := (HammingBlocksOffset(trama) - HeaderSize) * 8
+			bits := BytesToBits(payload)
+			for i := inicio + 20; i < inicio+23; i++ {
+				bits[i] ^= 1
+			}
+			var data []byte
+			if mode == HammingSECDED {
+				data, _, err = Hamming84DecodePayload(BitsToBytes(bits))
+			} else {
+				data, _, err = DecodeHammingPayload(BitsToBytes(bits), n, Hamming74Decode)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			return BitsToBytes(data)
+		}
+		if got := decodificar(trama); !bytes.Equal(got, texto) {
+			t.Errorf("modo %d: con entrelazado se decodificó %q", mode, got)
+		}
+		if got := decodificar(plana); bytes.Equal(got, texto) {
+			t.Errorf("modo %d: sin entrelazado la ráfaga no debería corregirse", mode)
+		}
+	}
+}
+
+func TestInterleaveHammingPayload_Invalido(t *testing.T) {
+	payload, _ := EncodeHammingPayload(BytesToBits([]byte("A")), 7, 4, Hamming74Encode)
+	for _, depth := range []int{0, MaxInterleaveDepth + 1} {
+		if _, err := InterleaveHammingPayload(payload, depth); err == nil {
+			t.Errorf("profundidad %d: se esperaba error", depth)
+		}
+	}
+	entrelazado, err := InterleaveHammingPayload(payload, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HammingInterleaveDepth(entrelazado) != 7 || HammingInterleaveDepth(payload) != 0 {
+		t.Errorf("profundidades %d y %d", HammingInterleaveDepth(entrelazado), HammingInterleaveDepth(payload))
+	}
+	if _, err := InterleaveHammingPayload(entrelazado, 7); err == nil {
+		t.Error("se esperaba error al entrelazar dos veces")
+	}
+	if _, _, err := DecodeHammingPayload(entrelazado[:1], 7, Hamming74Decode); err == nil {
+		t.Error("se esperaba error sin el byte de profundidad")
+	}
+}
@@ -0,0 +1,14 @@
+package frame
+
+// MsgTypeKeepalive es el tipo de las tramas que mantienen abierta una
+// conexión persistente sin datos: payload vacío, solo header y checksum
+const MsgTypeKeepalive byte = 0x0C
+
+// MinFrameSize es el largo de la trama más corta: header sin secuencia,
+// payload vacío y CRC-32 (con CRC-8 la trama puede ser aún más corta)
+const MinFrameSize = HeaderSize + CRCSize
+
+// BuildKeepaliveFrame construye la trama de keepalive de MinFrameSize bytes
+func BuildKeepaliveFrame() ([]byte, error) {
+	return BuildFrameWithType(nil, MsgTypeKeepalive)
+}
@@ -0,0 +1,72 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+func TestBuildFrame_PayloadVacio(t *testing.T) {
+	for _, payload := range [][]byte{nil, {}} {
+		trama, err := BuildFrame(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trama) != MinFrameSize || trama[0] != MsgTypeData || binary.BigEndian.Uint16(trama[1:3]) != 0 {
+			t.Fatalf("trama vacía %x, se esperaban %d bytes con largo 0", trama, MinFrameSize)
+		}
+		f, err := ParseFrame(trama)
+		if err != nil {
+			t.Fatalf("la trama mínima debe decodificarse: %v", err)
+		}
+		if f.MsgType != MsgTypeData || len(f.Payload) != 0 || f.CRC != crc32.ChecksumIEEE(trama[:HeaderSize]) {
+			t.Errorf("trama decodificada inesperada: %v", f)
+		}
+		if data, err := f.Data(); err != nil || len(data) != 0 {
+			t.Errorf("Data = %x, %v", data, err)
+		}
+	}
+}
+
+func TestParseFrame_LargoCero(t *testing.T) {
+	// Trama armada a mano: header con largo 0 seguido directamente del CRC
+	trama := []byte{MsgTypeData, 0x00, 0x00}
+	trama = binary.BigEndian.AppendUint32(trama, crc32.ChecksumIEEE(trama))
+	if _, err := ParseFrame(trama); err != nil {
+		t.Fatalf("largo 0: %v", err)
+	}
+
+	if _, err := ParseFrame(trama[:MinFrameSize-1]); !errors.Is(err, ErrFrameTooShort) {
+		t.Errorf("%d bytes: error %v, se esperaba %v", MinFrameSize-1, err, ErrFrameTooShort)
+	}
+
+	// Un byte de más con el largo en 0 no es una trama vacía
+	larga := []byte{MsgTypeData, 0x00, 0x00, 'A'}
+	larga = binary.BigEndian.AppendUint32(larga, crc32.ChecksumIEEE(larga))
+	if _, err := ParseFrame(larga); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("largo 0 con 1 byte de payload: error %v, se esperaba %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestBuildKeepaliveFrame(t *testing.T) {
+	trama, err := BuildKeepaliveFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trama) != MinFrameSize {
+		t.Fatalf("keepalive de %d bytes, se esperaban %d", len(trama), MinFrameSize)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeKeepalive || len(f.Payload) != 0 || !strings.HasPrefix(f.String(), "keepalive (0x0c)") {
+		t.Errorf("keepalive decodificado inesperado: %v", f)
+	}
+	stats, err := ComputeFrameStats(trama, 0)
+	if err != nil || stats.HeaderBytes != HeaderSize || stats.EncodedBytes != 0 || stats.ChecksumBytes != CRCSize {
+		t.Errorf("estadísticas %+v, %v", stats, err)
+	}
+}
@@ -0,0 +1,266 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FrameOption es una opción de BuildFrameOpts
+type FrameOption func(*frameConfig)
+
+type frameConfig struct {
+	msgType  byte            // Tipo de un payload ya codificado (WithType)
+	hamming  *HammingMode    // Código Hamming a aplicar (nil sin Hamming)
+	variante *HammingVariant // Variante de WithHammingVariant (nil sin ella)
+	filas2D  *int            // Filas de la paridad 2D a aplicar (nil sin paridad 2D)
+	depth    int
+	checksum ChecksumKind
+	seq      *uint16
+	content  ContentType
+	scramble bool
+}
+
+// WithHamming74 codifica el payload con Hamming(7,4) (tipo MsgTypeHamming)
+func WithHamming74() FrameOption {
+	return func(c *frameConfig) { m := HammingSEC; c.hamming = &m }
+}
+
+// WithHamming84 codifica el payload con Hamming(8,4) SEC-DED (tipo
+// MsgTypeHamming84)
+func WithHamming84() FrameOption {
+	return func(c *frameConfig) { m := HammingSECDED; c.hamming = &m }
+}
+
+// WithHammingVariant codifica el payload con la variante v (ej: Hamming1511)
+// y usa su tipo de mensaje; se combina con WithInterleave como WithHamming74
+func WithHammingVariant(v HammingVariant) FrameOption {
+	return func(c *frameConfig) { c.variante = &v }
+}
+
+// WithParity2D codifica el payload con Parity2DEncode en rows filas (tipo
+// MsgTypeParity2D)
+func WithParity2D(rows int) FrameOption {
+	return func(c *frameConfig) { c.filas2D = &rows }
+}
+
+// WithInterleave entrelaza los bloques Hamming con esa profundidad; requiere
+// WithHamming74 o WithHamming84
+func WithInterleave(depth int) FrameOption {
+	return func(c *frameConfig) { c.depth = depth }
+}
+
+// WithChecksum verifica la trama con kind en lugar de CRC-32
+func WithChecksum(kind ChecksumKind) FrameOption {
+	return func(c *frameConfig) { c.checksum = kind }
+}
+
+// WithSeq agrega al header el número de secuencia seq (FlagSeq)
+func WithSeq(seq uint16) FrameOption {
+	return func(c *frameConfig) { c.seq = &seq }
+}
+
+// WithContent declara en el header el tipo de contenido del payload (ver
+// MsgTypeContent)
+func WithContent(content ContentType) FrameOption {
+	return func(c *frameConfig) { c.content = content }
+}
+
+// WithScramble aleatoriza el payload con Scramble después de la codificación
+// (Hamming, WithType) y lo declara en el header para que ParseFrame lo revierta
+func WithScramble() FrameOption {
+	return func(c *frameConfig) { c.scramble = true }
+}
+
+// WithType usa msgType para un payload que quien llama ya codificó (ej:
+// MsgTypeRepetition); no se combina con WithHamming74 ni WithHamming84
+func WithType(msgType byte) FrameOption {
+	return func(c *frameConfig) { c.msgType = msgType }
+}
+
+func nuevoFrameConfig(opts []FrameOption) (frameConfig, error) {
+	var c frameConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.variante != nil && c.hamming != nil {
+		return c, fmt.Errorf("WithHammingVariant no se combina con otra codificación Hamming")
+	}
+	if (c.hamming != nil || c.variante != nil) && c.msgType != 0 {
+		return c, fmt.Errorf("WithType (%#02x) no se combina con una codificación Hamming", c.msgType)
+	}
+	if c.filas2D != nil && (c.hamming != nil || c.variante != nil || c.msgType != 0) {
+		return c, fmt.Errorf("WithParity2D no se combina con otra codificación ni con WithType")
+	}
+	if c.hamming == nil && c.variante == nil && c.depth != 0 {
+		return c, fmt.Errorf("el entrelazado (profundidad %d) requiere una codificación Hamming", c.depth)
+	}
+	return c, nil
+}
+
+func (c frameConfig) header(msgType byte) FrameOptions {
+	return FrameOptions{MsgType: msgType, Checksum: c.checksum, Seq: c.seq, Content: c.content, Scramble: c.scramble}
+}
+
+// buildHamming codifica dataBits con el código de c y arma la trama
+func (c frameConfig) buildHamming(dataBits []byte) ([]byte, error) {
+	n, encode, msgType := 7, Hamming74Encode, MsgTypeHamming
+	if *c.hamming == HammingSECDED {
+		n, encode, msgType = 8, Hamming84Encode, MsgTypeHamming84
+	}
+	codedBytes, err := EncodeHammingPayload(dataBits, n, 4, encode)
+	if err != nil {
+		return nil, err
+	}
+	return c.frameHamming(codedBytes, msgType)
+}
+
+// buildHammingBytes es buildHamming para bytes empaquetados: codifica con
+// hammingEncodeBytes sin expandir data a un bit por byte y arma el mismo
+// payload que EncodeHammingPayload
+func (c frameConfig) buildHammingBytes(data []byte) ([]byte, error) {
+	n, secded, msgType := 7, false, MsgTypeHamming
+	if *c.hamming == HammingSECDED {
+		n, secded, msgType = 8, true, MsgTypeHamming84
+	}
+	codedBytes := make([]byte, HammingPadSize, HammingPadSize+(len(data)*2*n+7)/8)
+	codedBytes = hammingEncodeBytes(codedBytes, data, secded)
+	// Cada byte de datos ocupa dos bloques completos, así que el relleno es
+	// solo el bloque de ceros que pueda entrar en el último byte
+	relleno := (len(codedBytes)-HammingPadSize)*8/n*4 - len(data)*8
+	if relleno < 0 || relleno >= int(HammingFlagInterleave) {
+		return nil, fmt.Errorf("relleno Hamming fuera de rango: %d bits", relleno)
+	}
+	codedBytes[0] = byte(relleno)
+	return c.frameHamming(codedBytes, msgType)
+}
+
+// frameHamming entrelaza el payload Hamming si c lo pide y arma la trama
+func (c frameConfig) frameHamming(codedBytes []byte, msgType byte) ([]byte, error) {
+	if c.depth != 0 {
+		var err error
+		if codedBytes, err = InterleaveHammingPayload(codedBytes, c.depth); err != nil {
+			return nil, err
+		}
+	}
+	return BuildFrameWithOptions(codedBytes, c.header(msgType))
+}
+
+// BuildFrameOpts construye una trama aplicando opts: la codificación del
+// payload (WithHamming74, WithHamming84, WithHammingVariant, WithInterleave,
+// WithParity2D o WithType) y el header
+// (WithChecksum, WithSeq, WithContent, WithScramble). Sin opciones es
+// BuildFrame.
+//
+// No hace falta conocer las opciones para decodificar la trama: la secuencia
+// y el checksum van en los bits altos del byte de tipo, el contenido y el
+// scrambler en su propio byte del header, la codificación en el tipo y el
+// entrelazado en el subheader del payload Hamming. ParseFrame las
+// recupera (ver Frame.Options) y Frame.Data deshace la codificación.
+func BuildFrameOpts(payload []byte, opts ...FrameOption) ([]byte, error) {
+	c, err := nuevoFrameConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.hamming != nil {
+		return c.buildHammingBytes(payload)
+	}
+	if v := c.variante; v != nil {
+		encoded, err := EncodeHammingPayload(BytesToBits(payload), v.N, v.K, v.Encode)
+		if err != nil {
+			return nil, err
+		}
+		return c.frameHamming(encoded, v.MsgType)
+	}
+	if c.filas2D != nil {
+		encoded, err := Parity2DEncode(payload, *c.filas2D)
+		if err != nil {
+			return nil, err
+		}
+		return BuildFrameWithOptions(encoded, c.header(MsgTypeParity2D))
+	}
+	return BuildFrameWithOptions(payload, c.header(c.msgType))
+}
+
+// Options devuelve las opciones con las que se construyó la trama:
+// BuildFrameOpts(datos, f.Options()...), con datos el resultado de f.Data(),
+// reproduce la trama. Los tipos que Data no decodifica se describen con
+// WithType y se reconstruyen a partir de f.Payload. Las tramas MsgTypeBits se
+// reproducen con BuildFrameFromBits a partir de f.Bits().
+func (f *Frame) Options() []FrameOption {
+	var opts []FrameOption
+	switch f.MsgType {
+	case MsgTypeData, MsgTypeBits:
+	case MsgTypeHamming:
+		opts = append(opts, WithHamming74())
+	case MsgTypeHamming84:
+		opts = append(opts, WithHamming84())
+	case MsgTypeHamming1511:
+		opts = append(opts, WithHammingVariant(Hamming1511))
+	case MsgTypeParity2D:
+		if len(f.Payload) > 0 {
+			opts = append(opts, WithParity2D(int(f.Payload[0])))
+		}
+	default:
+		opts = append(opts, WithType(f.MsgType))
+	}
+	if f.Interleave > 0 {
+		opts = append(opts, WithInterleave(f.Interleave))
+	}
+	if f.Checksum != ChecksumCRC32 {
+		opts = append(opts, WithChecksum(f.Checksum))
+	}
+	if f.HasSeq {
+		opts = append(opts, WithSeq(f.Seq))
+	}
+	if f.Content != ContentUnspecified {
+		opts = append(opts, WithContent(f.Content))
+	}
+	if f.Scrambled {
+		opts = append(opts, WithScramble())
+	}
+	return opts
+}
+
+// ErrUncorrectable indica que el código del payload detectó errores que no
+// puede corregir
+var ErrUncorrectable = errors.New("errores no corregibles en el payload")
+
+// ErrUnsupportedType indica que Data no sabe decodificar el tipo de la trama
+var ErrUnsupportedType = errors.New("el tipo de trama no se decodifica con Data")
+
+// Data decodifica el payload según el tipo de la trama: lo devuelve tal cual
+// para MsgTypeData y corrige y quita la redundancia de las tramas Hamming
+// (7,4), (15,11) y (8,4), entrelazadas o no, Golay, de repetición, de
+// paridad 2D y Reed-Solomon. De las tramas MsgTypeBits devuelve los bits con
+// el último byte completado con ceros (ver Frame.Bits). Para los demás tipos
+// (fragmentos, ACK/NACK) devuelve un error que envuelve ErrUnsupportedType;
+// su payload sigue disponible en f.Payload.
+func (f *Frame) Data() ([]byte, error) {
+	switch f.MsgType {
+	case MsgTypeData, MsgTypeKeepalive:
+		return append([]byte(nil), f.Payload...), nil
+	case MsgTypeHamming, MsgTypeHamming1511, MsgTypeHamming84, MsgTypeGolay, MsgTypeBits:
+		bits, err := f.Bits()
+		if err != nil {
+			return nil, err
+		}
+		return BitsToBytes(bits), nil
+	case MsgTypeRepetition:
+		data, _, err := Repetition3DecodePayload(f.Payload)
+		return data, err
+	case MsgTypeParity2D:
+		data, _, err := Parity2DDecode(f.Payload)
+		if errors.Is(err, ErrParity2DUncorrectable) {
+			return nil, fmt.Errorf("%w: %v", ErrUncorrectable, err)
+		}
+		return data, err
+	case MsgTypeReedSolomon:
+		data, c, err := RSDecodePayload(f.Payload)
+		if err != nil && c.Uncorrectable > 0 {
+			return nil, fmt.Errorf("%w: %v", ErrUncorrectable, err)
+		}
+		return data, err
+	default:
+		return nil, fmt.Errorf("%w: %#02x", ErrUnsupportedType, f.MsgType)
+	}
+}
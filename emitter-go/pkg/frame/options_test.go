@@ -0,0 +1,159 @@
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBuildFrameOpts_CombinacionesRoundTrip(t *testing.T) {
+	data := []byte("Hola mundo")
+	casos := []struct {
+		nombre     string
+		opts       []FrameOption
+		msgType    byte
+		checksum   ChecksumKind
+		seq        *uint16
+		interleave int
+	}{
+		{"sin opciones", nil, MsgTypeData, ChecksumCRC32, nil, 0},
+		{"hamming74", []FrameOption{WithHamming74()}, MsgTypeHamming, ChecksumCRC32, nil, 0},
+		{"hamming74 crc16 seq", []FrameOption{WithHamming74(), WithChecksum(ChecksumCRC16CCITT), WithSeq(7)}, MsgTypeHamming, ChecksumCRC16CCITT, ptrSeq(7), 0},
+		{"hamming74 entrelazado", []FrameOption{WithHamming74(), WithInterleave(7)}, MsgTypeHamming, ChecksumCRC32, nil, 7},
+		{"hamming84 entrelazado crc8 seq", []FrameOption{WithSeq(65535), WithInterleave(9), WithChecksum(ChecksumCRC8), WithHamming84()}, MsgTypeHamming84, ChecksumCRC8, ptrSeq(65535), 9},
+		{"fletcher seq", []FrameOption{WithChecksum(ChecksumFletcher16), WithSeq(0)}, MsgTypeData, ChecksumFletcher16, ptrSeq(0), 0},
+		{"hamming1511", []FrameOption{WithHammingVariant(Hamming1511)}, MsgTypeHamming1511, ChecksumCRC32, nil, 0},
+		{"hamming1511 entrelazado seq", []FrameOption{WithHammingVariant(Hamming1511), WithInterleave(15), WithSeq(4)}, MsgTypeHamming1511, ChecksumCRC32, ptrSeq(4), 15},
+		{"paridad 2D", []FrameOption{WithParity2D(4)}, MsgTypeParity2D, ChecksumCRC32, nil, 0},
+		{"paridad 2D crc16 seq", []FrameOption{WithParity2D(1), WithChecksum(ChecksumCRC16CCITT), WithSeq(5)}, MsgTypeParity2D, ChecksumCRC16CCITT, ptrSeq(5), 0},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			trama, err := BuildFrameOpts(data, c.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := ParseFrame(trama)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.MsgType != c.msgType || f.Checksum != c.checksum || f.Interleave != c.interleave || f.HasSeq != (c.seq != nil) {
+				t.Fatalf("trama decodificada como %+v", f)
+			}
+			if c.seq != nil && f.Seq != *c.seq {
+				t.Errorf("secuencia %d, se esperaba %d", f.Seq, *c.seq)
+			}
+
+			got, err := f.Data()
+			if err != nil || !bytes.Equal(got, data) {
+				t.Fatalf("Data = %q, %v", got, err)
+			}
+			// Las opciones recuperadas de la trama la reconstruyen byte a byte
+			otra, err := BuildFrameOpts(got, f.Options()...)
+			if err != nil || !bytes.Equal(otra, trama) {
+				t.Errorf("reconstruida % x, original % x (%v)", otra, trama, err)
+			}
+		})
+	}
+}
+
+func ptrSeq(n uint16) *uint16 { return &n }
+
+func TestBuildFrameOpts_EquivaleALasFuncionesAnteriores(t *testing.T) {
+	data := []byte("Hi")
+	pares := []struct {
+		nombre  string
+		antigua func() ([]byte, error)
+		opts    []FrameOption
+	}{
+		{"BuildFrameWithHamming", func() ([]byte, error) { return BuildFrameWithHamming(data) }, []FrameOption{WithHamming74()}},
+		{"BuildFrameWithHamming SECDED", func() ([]byte, error) { return BuildFrameWithHamming(data, HammingSECDED, InterleaveDepth(8)) }, []FrameOption{WithHamming84(), WithInterleave(8)}},
+		{"BuildFrameWithHammingAndSeq", func() ([]byte, error) { return BuildFrameWithHammingAndSeq(data, 3) }, []FrameOption{WithHamming74(), WithSeq(3)}},
+		{"BuildFrameWithChecksum", func() ([]byte, error) { return BuildFrameWithChecksum(data, ChecksumCRC8) }, []FrameOption{WithChecksum(ChecksumCRC8)}},
+		{"HammingVariant.EncodeFrame", func() ([]byte, error) { return Hamming1511.EncodeFrame(data, FrameOptions{}) }, []FrameOption{WithHammingVariant(Hamming1511)}},
+		{"BuildFrameWithParity2D", func() ([]byte, error) { return BuildFrameWithParity2D(data, 3, FrameOptions{Seq: ptrSeq(2)}) }, []FrameOption{WithParity2D(3), WithSeq(2)}},
+		{"BuildFrameWithTypeAndSeq", func() ([]byte, error) { return BuildFrameWithTypeAndSeq(data, MsgTypeFragment, 9) }, []FrameOption{WithType(MsgTypeFragment), WithSeq(9)}},
+	}
+	for _, p := range pares {
+		want, err := p.antigua()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := BuildFrameOpts(data, p.opts...)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s: % x, se esperaba % x (%v)", p.nombre, got, want, err)
+		}
+	}
+}
+
+func TestBuildFrameOpts_Invalidas(t *testing.T) {
+	invalidas := map[string][]FrameOption{
+		"entrelazado sin hamming":    {WithInterleave(7)},
+		"tipo y hamming":             {WithType(MsgTypeRepetition), WithHamming74()},
+		"paridad 2D y hamming":       {WithParity2D(4), WithHamming84()},
+		"dos variantes hamming":      {WithHammingVariant(Hamming1511), WithHamming74()},
+		"variante y tipo":            {WithHammingVariant(Hamming1511), WithType(MsgTypeRepetition)},
+		"paridad 2D sin filas":       {WithParity2D(0)},
+		"paridad 2D entrelazada":     {WithParity2D(4), WithInterleave(7)},
+		"profundidad fuera de rango": {WithHamming74(), WithInterleave(MaxInterleaveDepth + 1)},
+		"checksum desconocido":       {WithChecksum(ChecksumKind(6))},
+		"tipo con bits del header":   {WithType(MsgTypeData | FlagSeq)},
+	}
+	for nombre, opts := range invalidas {
+		if _, err := BuildFrameOpts([]byte("x"), opts...); err == nil {
+			t.Errorf("%s: se esperaba error", nombre)
+		}
+	}
+}
+
+func TestFrameData_OtrosTipos(t *testing.T) {
+	rep, _ := BuildFrameWithRepetition([]byte("ok"))
+	rs, _ := BuildFrameWithRS([]byte("ok"), 4)
+	for _, trama := range [][]byte{rep, rs} {
+		f, err := ParseFrame(trama)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := f.Data(); err != nil || string(got) != "ok" {
+			t.Errorf("tipo %#02x: Data = %q, %v", f.MsgType, got, err)
+		}
+		if otra, _ := BuildFrameOpts(f.Payload, f.Options()...); !bytes.Equal(otra, trama) {
+			t.Errorf("tipo %#02x: las opciones no reconstruyen la trama", f.MsgType)
+		}
+	}
+
+	frag, _ := BuildFrameWithType([]byte("x"), MsgTypeFragment)
+	f, _ := ParseFrame(frag)
+	if _, err := f.Data(); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("err = %v, se esperaba ErrUnsupportedType al decodificar un fragmento", err)
+	}
+}
+
+func TestFrameData_NoCorregible(t *testing.T) {
+	trama, _ := BuildFrameOpts([]byte("A"), WithHamming84())
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dos errores en el primer bloque: SEC-DED los detecta pero no los corrige
+	f.Payload[HammingPadSize] ^= 0xC0
+	if _, err := f.Data(); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("err = %v, se esperaba ErrUncorrectable", err)
+	}
+
+	// Dos errores en la misma fila de la paridad 2D
+	p2d, _ := BuildFrameOpts([]byte("Hola"), WithParity2D(2))
+	f, _ = ParseFrame(p2d)
+	f.Payload[Parity2DHeaderSize] ^= 0xC0
+	if _, err := f.Data(); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("paridad 2D: err = %v, se esperaba ErrUncorrectable", err)
+	}
+
+	rs, _ := BuildFrameWithRS([]byte("Hola"), 2)
+	f, _ = ParseFrame(rs)
+	f.Payload[1] ^= 1
+	f.Payload[2] ^= 1
+	if _, err := f.Data(); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("rs: err = %v, se esperaba ErrUncorrectable", err)
+	}
+}
@@ -0,0 +1,122 @@
+package frame
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// MsgTypeParity2D es el tipo de las tramas con paridad bidimensional
+const MsgTypeParity2D byte = 0x05
+
+// Parity2DHeaderSize es el subheader de Parity2DEncode: filas (1) + largo de
+// los datos en bytes (2)
+const Parity2DHeaderSize = 3
+
+// ErrParity2DUncorrectable indica que las paridades que fallan no señalan un
+// único bit: hay más de un error, así que solo se detectan
+var ErrParity2DUncorrectable = errors.New("error de paridad 2D no corregible")
+
+// parity2DDims devuelve las columnas de la matriz de rows filas para n bits
+func parity2DDims(n, rows int) int {
+	if n == 0 {
+		return 0
+	}
+	return (n + rows - 1) / rows
+}
+
+// Parity2DEncode dispone los bits de data por filas en una matriz de rows
+// filas (el relleno de la última fila son ceros implícitos, no se envían) y
+// agrega la paridad par de cada fila (LRC) y de cada columna (VRC). Devuelve
+// [filas(1)][largo(2)] + bits de datos + paridades de fila + paridades de
+// columna, agrupados en bytes.
+func Parity2DEncode(data []byte, rows int) ([]byte, error) {
+	if rows < 1 || rows > 0xFF {
+		return nil, fmt.Errorf("cantidad de filas inválida: %d (debe estar entre 1 y 255)", rows)
+	}
+	if len(data) > 0xFFFF {
+		return nil, fmt.Errorf("%w: %d bytes de datos (límite 65535)", ErrPayloadTooLarge, len(data))
+	}
+
+	bits := BytesToBits(data)
+	cols := parity2DDims(len(bits), rows)
+	rowPar := make([]byte, rows)
+	colPar := make([]byte, cols)
+	for i, b := range bits {
+		rowPar[i/cols] ^= b
+		colPar[i%cols] ^= b
+	}
+
+	out := make([]byte, Parity2DHeaderSize, Parity2DHeaderSize+(len(bits)+rows+cols+7)/8)
+	out[0] = byte(rows)
+	binary.BigEndian.PutUint16(out[1:3], uint16(len(data)))
+	code := append(append(bits, rowPar...), colPar...)
+	return append(out, BitsToBytes(code)...), nil
+}
+
+// Parity2DDecode verifica las paridades de un bloque de Parity2DEncode. Un
+// error en un bit de datos hace fallar su fila y su columna y se corrige en
+// la intersección; uno en un bit de paridad hace fallar solo esa paridad y
+// los datos quedan intactos. Cualquier otra combinación se informa con
+// ErrParity2DUncorrectable.
+func Parity2DDecode(encoded []byte) (data []byte, corrected bool, err error) {
+	if len(encoded) < Parity2DHeaderSize {
+		return nil, false, fmt.Errorf("%w: %d bytes, el subheader de paridad 2D ocupa %d", ErrTruncated, len(encoded), Parity2DHeaderSize)
+	}
+	rows := int(encoded[0])
+	n := int(binary.BigEndian.Uint16(encoded[1:3])) * 8
+	if rows == 0 {
+		return nil, false, fmt.Errorf("cantidad de filas inválida: 0")
+	}
+	cols := parity2DDims(n, rows)
+	code := BytesToBits(encoded[Parity2DHeaderSize:])
+	if len(code) < n+rows+cols {
+		return nil, false, fmt.Errorf("%w: %d bits, se esperaban %d", ErrTruncated, len(code), n+rows+cols)
+	}
+
+	bits := append([]byte(nil), code[:n]...)
+	rowPar := append([]byte(nil), code[n:n+rows]...)
+	colPar := append([]byte(nil), code[n+rows:n+rows+cols]...)
+	for i, b := range bits {
+		rowPar[i/cols] ^= b
+		colPar[i%cols] ^= b
+	}
+	var malasFilas, malasCols []int
+	for r, p := range rowPar {
+		if p != 0 {
+			malasFilas = append(malasFilas, r)
+		}
+	}
+	for c, p := range colPar {
+		if p != 0 {
+			malasCols = append(malasCols, c)
+		}
+	}
+
+	switch {
+	case len(malasFilas) == 0 && len(malasCols) == 0:
+	case len(malasFilas) == 1 && len(malasCols) == 1:
+		pos := malasFilas[0]*cols + malasCols[0]
+		if pos >= n {
+			return nil, false, fmt.Errorf("%w: la fila %d y la columna %d se cruzan en el relleno", ErrParity2DUncorrectable, malasFilas[0], malasCols[0])
+		}
+		bits[pos] ^= 1
+		corrected = true
+	case len(malasFilas)+len(malasCols) == 1:
+		corrected = true // Error en un bit de paridad
+	default:
+		return nil, false, fmt.Errorf("%w: fallan las filas %v y las columnas %v", ErrParity2DUncorrectable, malasFilas, malasCols)
+	}
+	return BitsToBytes(bits), corrected, nil
+}
+
+// BuildFrameWithParity2D codifica payload con Parity2DEncode y construye una
+// trama MsgTypeParity2D, que conserva el CRC para los errores residuales
+func BuildFrameWithParity2D(payload []byte, rows int, opts FrameOptions) ([]byte, error) {
+	encoded, err := Parity2DEncode(payload, rows)
+	if err != nil {
+		return nil, err
+	}
+	opts.MsgType = MsgTypeParity2D
+	return BuildFrameWithOptions(encoded, opts)
+}
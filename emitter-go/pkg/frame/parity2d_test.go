@@ -0,0 +1,86 @@
+package frame
+
+import (
+	"errors"
+	"testing"
+)
+
+// invertirBit invierte el bit i (desde el primero tras el subheader) del
+// bloque codificado
+func invertirBit(encoded []byte, i int) []byte {
+	out := append([]byte(nil), encoded...)
+	out[Parity2DHeaderSize+i/8] ^= 0x80 >> (i % 8)
+	return out
+}
+
+func TestParity2D_SinErrores(t *testing.T) {
+	for _, rows := range []int{1, 3, 8, 40} {
+		encoded, err := Parity2DEncode([]byte("Hola mundo"), rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, corrected, err := Parity2DDecode(encoded)
+		if err != nil || corrected || string(data) != "Hola mundo" {
+			t.Errorf("%d filas: %q, corregido %v, %v", rows, data, corrected, err)
+		}
+	}
+
+	if _, err := Parity2DEncode([]byte("x"), 0); err == nil {
+		t.Error("se esperaba error con 0 filas")
+	}
+}
+
+func TestParity2D_CorrigeUnError(t *testing.T) {
+	texto := "Hola mundo"
+	n := len(texto) * 8
+	rows := 8
+	cols := n / rows
+	encoded, err := Parity2DEncode([]byte(texto), rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cada bit de datos y de paridad
+	for i := 0; i < n+rows+cols; i++ {
+		data, corrected, err := Parity2DDecode(invertirBit(encoded, i))
+		if err != nil {
+			t.Fatalf("bit %d: %v", i, err)
+		}
+		if !corrected || string(data) != texto {
+			t.Errorf("bit %d: %q, corregido %v", i, data, corrected)
+		}
+	}
+}
+
+func TestParity2D_DosErroresEnUnaFilaSoloSeDetectan(t *testing.T) {
+	rows := 8
+	encoded, err := Parity2DEncode([]byte("Hola mundo"), rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := 10 // 80 bits en 8 filas
+
+	// La fila conserva su paridad y fallan las dos columnas
+	ruidoso := invertirBit(invertirBit(encoded, 2*cols+1), 2*cols+6)
+	if _, _, err := Parity2DDecode(ruidoso); !errors.Is(err, ErrParity2DUncorrectable) {
+		t.Errorf("error %v, se esperaba %v", err, ErrParity2DUncorrectable)
+	}
+}
+
+func TestBuildFrameWithParity2D(t *testing.T) {
+	trama, err := BuildFrameWithParity2D([]byte("Hola"), 4, FrameOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeParity2D || f.Checksum != ChecksumCRC32 {
+		t.Fatalf("trama decodificada inesperada: %+v", f)
+	}
+	data, _, err := Parity2DDecode(f.Payload)
+	if err != nil || string(data) != "Hola" {
+		t.Errorf("payload %q, %v", data, err)
+	}
+}
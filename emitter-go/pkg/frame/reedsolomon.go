@@ -0,0 +1,394 @@
+package frame
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MsgTypeReedSolomon es el tipo de las tramas con código Reed-Solomon
+const MsgTypeReedSolomon byte = 0x08
+
+// DefaultRSParity es la cantidad de símbolos de paridad de RS(255,223), que
+// corrige hasta 16 bytes erróneos por bloque
+const DefaultRSParity = 32
+
+// rsBlockSize es el largo máximo de un bloque (palabra de código) sobre GF(256)
+const rsBlockSize = 255
+
+// rsPrimitive es el polinomio primitivo x^8+x^4+x^3+x^2+1 que genera GF(256),
+// el mismo que usa por defecto reedsolo en Python (generador 2, fcr 0)
+const rsPrimitive = 0x11d
+
+// Tablas de exponentes y logaritmos de GF(256); gfExp está duplicada para
+// multiplicar sin reducir el exponente módulo 255
+var (
+	gfExp [2 * rsBlockSize]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < rsBlockSize; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= rsPrimitive
+		}
+	}
+	for i := rsBlockSize; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-rsBlockSize]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+// gfDiv divide a por b, que no puede ser 0
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+rsBlockSize-gfLog[b]]
+}
+
+// gfAlpha devuelve α^n para cualquier n entero
+func gfAlpha(n int) byte {
+	n %= rsBlockSize
+	if n < 0 {
+		n += rsBlockSize
+	}
+	return gfExp[n]
+}
+
+// polyEval evalúa en x un polinomio con el coeficiente de menor grado primero
+func polyEval(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+	return y
+}
+
+// rsGenerator devuelve (x - α^0)(x - α^1)...(x - α^(nsym-1)), con el
+// coeficiente de mayor grado primero como los bytes de la palabra de código
+func rsGenerator(nsym int) []byte {
+	g := []byte{1}
+	for i := 0; i < nsym; i++ {
+		next := make([]byte, len(g)+1)
+		for j, c := range g {
+			next[j] ^= c
+			next[j+1] ^= gfMul(c, gfAlpha(i))
+		}
+		g = next
+	}
+	return g
+}
+
+func validarRSParity(nsym int) error {
+	if nsym < 1 || nsym >= rsBlockSize {
+		return fmt.Errorf("símbolos de paridad inválidos: %d (debe estar entre 1 y %d)", nsym, rsBlockSize-1)
+	}
+	return nil
+}
+
+// RSEncode devuelve la palabra de código sistemática de data: los mismos
+// bytes seguidos de nsym símbolos de paridad. data+paridad no puede superar
+// 255 bytes; un bloque más corto es un código acortado y se decodifica igual.
+func RSEncode(data []byte, nsym int) ([]byte, error) {
+	if err := validarRSParity(nsym); err != nil {
+		return nil, err
+	}
+	if len(data)+nsym > rsBlockSize {
+		return nil, fmt.Errorf("bloque demasiado largo: %d bytes de datos con %d de paridad superan %d", len(data), nsym, rsBlockSize)
+	}
+
+	// La paridad es el resto de data·x^nsym dividido por el generador
+	gen := rsGenerator(nsym)
+	out := make([]byte, len(data)+nsym)
+	copy(out, data)
+	for i := range data {
+		coef := out[i]
+		if coef == 0 {
+			continue
+		}
+		for j := 1; j < len(gen); j++ {
+			out[i+j] ^= gfMul(gen[j], coef)
+		}
+	}
+	copy(out, data)
+	return out, nil
+}
+
+// rsSyndromes evalúa la palabra de código en las raíces del generador; son
+// todas 0 si no hay errores
+func rsSyndromes(codeword []byte, nsym int) (synd []byte, clean bool) {
+	synd = make([]byte, nsym)
+	clean = true
+	for j := range synd {
+		x := gfAlpha(j)
+		var v byte
+		for _, b := range codeword {
+			v = gfMul(v, x) ^ b
+		}
+		synd[j] = v
+		if v != 0 {
+			clean = false
+		}
+	}
+	return synd, clean
+}
+
+// RSDecode corrige hasta nsym/2 bytes erróneos de codeword y devuelve los
+// datos (sin la paridad) y las posiciones corregidas en codeword. Con más
+// errores devuelve error; en ese caso el código puede además confundirse con
+// otra palabra válida, por eso la trama conserva su CRC.
+func RSDecode(codeword []byte, nsym int) (data []byte, corrected []int, err error) {
+	return RSDecodeErasures(codeword, nsym, nil)
+}
+
+// RSDecodeErasures es RSDecode sabiendo de antemano qué bytes de codeword
+// son dudosos (borrados, posiciones en codeword). Un borrado cuesta un
+// símbolo de paridad y un error desconocido dos, así que corrige cualquier
+// combinación con 2·errores + borrados <= nsym: hasta nsym borrados, el doble
+// que errores. El valor recibido en un byte borrado no importa. Las
+// posiciones corregidas son solo las de bytes que cambiaron.
+func RSDecodeErasures(codeword []byte, nsym int, erasures []int) (data []byte, corrected []int, err error) {
+	if err := validarRSParity(nsym); err != nil {
+		return nil, nil, err
+	}
+	n := len(codeword)
+	if n <= nsym || n > rsBlockSize {
+		return nil, nil, fmt.Errorf("longitud de bloque inválida: %d bytes con %d de paridad", n, nsym)
+	}
+	borrados, err := validarBorrados(erasures, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(borrados) > nsym {
+		return nil, nil, fmt.Errorf("demasiados borrados para corregir: %d con %d símbolos de paridad", len(borrados), nsym)
+	}
+
+	synd, clean := rsSyndromes(codeword, nsym)
+	if clean {
+		return append([]byte(nil), codeword[:n-nsym]...), nil, nil
+	}
+
+	// Localizador de borrados Γ(x) = ∏(1 - X·x) con X = α^(n-1-p): son las
+	// raíces ya conocidas del localizador total
+	gamma := []byte{1}
+	for _, p := range borrados {
+		x := gfAlpha(n - 1 - p)
+		next := make([]byte, len(gamma)+1)
+		for i, c := range gamma {
+			next[i] ^= c
+			next[i+1] ^= gfMul(c, x)
+		}
+		gamma = next
+	}
+
+	// Berlekamp-Massey arrancando de Γ: los primeros f síndromes ya se
+	// gastaron en los borrados y grado cuenta solo los errores desconocidos.
+	// Λ(x) = Γ(x)·σ(x) es el localizador de todos los bytes a corregir.
+	f := len(borrados)
+	lambda, prev := gamma, gamma
+	grado, m, b := 0, 1, byte(1)
+	for k := f; k < nsym; k++ {
+		var d byte
+		for i := 0; i <= k && i < len(lambda); i++ {
+			d ^= gfMul(lambda[i], synd[k-i])
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		coef := gfDiv(d, b)
+		next := append([]byte(nil), lambda...)
+		for len(next) < len(prev)+m {
+			next = append(next, 0)
+		}
+		for i, c := range prev {
+			next[i+m] ^= gfMul(coef, c)
+		}
+		if 2*grado <= k-f {
+			prev, grado, b, m = lambda, k+1-f-grado, d, 1
+		} else {
+			m++
+		}
+		lambda = next
+	}
+	if 2*grado+f > nsym {
+		return nil, nil, fmt.Errorf("demasiados errores para corregir: más de %d bytes", (nsym-f)/2)
+	}
+
+	// Búsqueda de Chien: el byte en la posición p tiene grado n-1-p y hay que
+	// corregirlo si Λ se anula en α^-(n-1-p)
+	var posiciones []int
+	for p := 0; p < n; p++ {
+		if polyEval(lambda, gfAlpha(-(n-1-p))) == 0 {
+			posiciones = append(posiciones, p)
+		}
+	}
+	if len(posiciones) != grado+f {
+		return nil, nil, fmt.Errorf("demasiados errores para corregir: el localizador no tiene %d raíces en el bloque", grado+f)
+	}
+
+	// Forney: Ω(x) = S(x)Λ(x) mod x^nsym y la magnitud de cada error es
+	// X·Ω(X^-1)/Λ'(X^-1); la derivada en GF(2^8) deja solo los grados impares
+	omega := make([]byte, nsym)
+	for i, s := range synd {
+		for j, l := range lambda {
+			if i+j < nsym {
+				omega[i+j] ^= gfMul(s, l)
+			}
+		}
+	}
+	derivada := make([]byte, len(lambda))
+	for i := 1; i < len(lambda); i += 2 {
+		derivada[i-1] = lambda[i]
+	}
+
+	fixed := append([]byte(nil), codeword...)
+	for _, p := range posiciones {
+		x := gfAlpha(n - 1 - p)
+		xInv := gfAlpha(-(n - 1 - p))
+		den := polyEval(derivada, xInv)
+		if den == 0 {
+			return nil, nil, fmt.Errorf("demasiados errores para corregir: derivada nula en la posición %d", p)
+		}
+		if magnitud := gfMul(x, gfDiv(polyEval(omega, xInv), den)); magnitud != 0 {
+			fixed[p] ^= magnitud
+			corrected = append(corrected, p)
+		}
+	}
+	if _, clean := rsSyndromes(fixed, nsym); !clean {
+		return nil, nil, fmt.Errorf("demasiados errores para corregir: la corrección no produjo una palabra válida")
+	}
+	return fixed[:n-nsym], corrected, nil
+}
+
+// validarBorrados comprueba que las posiciones borradas caigan en un bloque
+// de n bytes y las devuelve ordenadas y sin repetir
+func validarBorrados(erasures []int, n int) ([]int, error) {
+	if len(erasures) == 0 {
+		return nil, nil
+	}
+	borrados := append([]int(nil), erasures...)
+	sort.Ints(borrados)
+	out := borrados[:0]
+	for _, p := range borrados {
+		if p < 0 || p >= n {
+			return nil, fmt.Errorf("posición borrada fuera del bloque: %d (el bloque tiene %d bytes)", p, n)
+		}
+		if len(out) == 0 || p != out[len(out)-1] {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// RSCounts resume la decodificación de un payload Reed-Solomon
+type RSCounts struct {
+	Blocks        int // Bloques del payload
+	Corrected     int // Bytes corregidos en los bloques recuperables
+	Uncorrectable int // Bloques con más de nsym/2 bytes erróneos
+}
+
+// EncodeRSPayload parte data en bloques de hasta 255-nsym bytes, agrega a
+// cada uno su paridad y antepone un byte con nsym, así el receptor decodifica
+// sin saber de antemano la paridad usada. Todos los bloques ocupan 255 bytes
+// salvo el último, que es un código acortado.
+func EncodeRSPayload(data []byte, nsym int) ([]byte, error) {
+	if err := validarRSParity(nsym); err != nil {
+		return nil, err
+	}
+	k := rsBlockSize - nsym
+	out := make([]byte, 0, 1+len(data)+(len(data)+k-1)/k*nsym)
+	out = append(out, byte(nsym))
+	for inicio := 0; inicio < len(data); inicio += k {
+		fin := inicio + k
+		if fin > len(data) {
+			fin = len(data)
+		}
+		bloque, err := RSEncode(data[inicio:fin], nsym)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bloque...)
+	}
+	return out, nil
+}
+
+// RSDecodePayload decodifica un payload de EncodeRSPayload. Intenta todos los
+// bloques aunque alguno falle, para que counts describa el payload entero, y
+// devuelve error si quedó alguno sin corregir.
+func RSDecodePayload(payload []byte) (data []byte, counts RSCounts, err error) {
+	return RSDecodePayloadErasures(payload, nil)
+}
+
+// RSDecodePayloadErasures es RSDecodePayload con los bytes borrados del
+// payload (índices en payload, no en cada bloque), que cada bloque decodifica
+// con RSDecodeErasures. El byte 0 con nsym no tiene paridad que lo proteja:
+// si está borrado el payload no se puede decodificar.
+func RSDecodePayloadErasures(payload []byte, erasures []int) (data []byte, counts RSCounts, err error) {
+	if len(payload) == 0 {
+		return nil, counts, fmt.Errorf("payload Reed-Solomon vacío: falta el byte de paridad")
+	}
+	nsym := int(payload[0])
+	if err := validarRSParity(nsym); err != nil {
+		return nil, counts, err
+	}
+	bloques := payload[1:]
+	if resto := len(bloques) % rsBlockSize; resto != 0 && resto <= nsym {
+		return nil, counts, fmt.Errorf("payload Reed-Solomon truncado: el último bloque tiene %d bytes con %d de paridad", resto, nsym)
+	}
+
+	porBloque := make([][]int, (len(bloques)+rsBlockSize-1)/rsBlockSize)
+	for _, p := range erasures {
+		if p == 0 {
+			return nil, counts, fmt.Errorf("payload Reed-Solomon con el byte de paridad borrado")
+		}
+		if p < 0 || p > len(bloques) {
+			return nil, counts, fmt.Errorf("posición borrada fuera del payload: %d (el payload tiene %d bytes)", p, len(payload))
+		}
+		porBloque[(p-1)/rsBlockSize] = append(porBloque[(p-1)/rsBlockSize], (p-1)%rsBlockSize)
+	}
+
+	data = make([]byte, 0, len(bloques))
+	for inicio := 0; inicio < len(bloques); inicio += rsBlockSize {
+		fin := inicio + rsBlockSize
+		if fin > len(bloques) {
+			fin = len(bloques)
+		}
+		counts.Blocks++
+		datos, corregidos, errBloque := RSDecodeErasures(bloques[inicio:fin], nsym, porBloque[inicio/rsBlockSize])
+		if errBloque != nil {
+			counts.Uncorrectable++
+			if err == nil {
+				err = fmt.Errorf("bloque %d: %v", counts.Blocks, errBloque)
+			}
+			// Se conservan los datos recibidos para no desplazar los bloques siguientes
+			datos = bloques[inicio : fin-nsym]
+		}
+		counts.Corrected += len(corregidos)
+		data = append(data, datos...)
+	}
+	if err != nil {
+		return nil, counts, err
+	}
+	return data, counts, nil
+}
+
+// BuildFrameWithRS codifica payload con EncodeRSPayload en una trama
+// MsgTypeReedSolomon: header, nsym, bloques de datos y paridad, y CRC
+func BuildFrameWithRS(payload []byte, nsym int) ([]byte, error) {
+	encoded, err := EncodeRSPayload(payload, nsym)
+	if err != nil {
+		return nil, err
+	}
+	return BuildFrameWithType(encoded, MsgTypeReedSolomon)
+}
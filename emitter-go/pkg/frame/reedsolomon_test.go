@@ -0,0 +1,232 @@
+package frame
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRSEncode_CompatibleConReedsolo(t *testing.T) {
+	// reedsolo.RSCodec(10).encode(b"hello world"), el ejemplo de su README
+	want := []byte("hello world\xed%T\xc4\xfd\xfd\x89\xf3\xa8\xaa")
+	got, err := RSEncode([]byte("hello world"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("RSEncode = % x, se esperaba % x", got, want)
+	}
+}
+
+// corromper invierte bytes en n posiciones distintas de codeword
+func corromper(rng *rand.Rand, codeword []byte, n int) []int {
+	posiciones := rng.Perm(len(codeword))[:n]
+	for _, p := range posiciones {
+		codeword[p] ^= byte(1 + rng.Intn(255))
+	}
+	return posiciones
+}
+
+func TestRSDecode_CorrigeHastaLaMitadDeLaParidad(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, nsym := range []int{2, 10, DefaultRSParity} {
+		for _, largo := range []int{1, 20, rsBlockSize - nsym} {
+			data := make([]byte, largo)
+			rng.Read(data)
+			code, err := RSEncode(data, nsym)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ruidoso := append([]byte(nil), code...)
+			posiciones := corromper(rng, ruidoso, nsym/2)
+
+			got, corrected, err := RSDecode(ruidoso, nsym)
+			if err != nil {
+				t.Fatalf("nsym %d, %d bytes: %v", nsym, largo, err)
+			}
+			if !bytes.Equal(got, data) || len(corrected) != len(posiciones) {
+				t.Errorf("nsym %d, %d bytes: datos no recuperados, corregidas %v de %v", nsym, largo, corrected, posiciones)
+			}
+		}
+	}
+}
+
+func TestRSDecode_UnErrorDeMasFalla(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	data := []byte("Hola mundo, esto viaja con Reed-Solomon")
+	for _, nsym := range []int{10, DefaultRSParity} {
+		code, _ := RSEncode(data, nsym)
+		corromper(rng, code, nsym/2+1)
+		if got, _, err := RSDecode(code, nsym); err == nil {
+			t.Errorf("nsym %d: se esperaba error con %d bytes erróneos, se decodificó %q", nsym, nsym/2+1, got)
+		}
+	}
+}
+
+func TestRSDecode_SinErrores(t *testing.T) {
+	code, _ := RSEncode([]byte("Hola"), 4)
+	got, corrected, err := RSDecode(code, 4)
+	if err != nil || string(got) != "Hola" || corrected != nil {
+		t.Errorf("datos %q, corregidas %v, %v", got, corrected, err)
+	}
+}
+
+func TestRSDecodeErasures_CorrigeElDobleQueErrores(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for _, nsym := range []int{2, 10, DefaultRSParity} {
+		for _, largo := range []int{1, 20, rsBlockSize - nsym} {
+			data := make([]byte, largo)
+			rng.Read(data)
+			code, _ := RSEncode(data, nsym)
+
+			// nsym bytes dañados se corrigen si se sabe cuáles son...
+			ruidoso := append([]byte(nil), code...)
+			borrados := corromper(rng, ruidoso, nsym)
+			got, corrected, err := RSDecodeErasures(ruidoso, nsym, borrados)
+			if err != nil {
+				t.Fatalf("nsym %d, %d bytes: %v", nsym, largo, err)
+			}
+			if !bytes.Equal(got, data) || len(corrected) != nsym {
+				t.Errorf("nsym %d, %d bytes: datos no recuperados, corregidas %v de %v", nsym, largo, corrected, borrados)
+			}
+			// ...pero como errores desconocidos son el doble del límite: el
+			// decodificador falla o se confunde con otra palabra válida
+			if got, _, err := RSDecode(ruidoso, nsym); err == nil && bytes.Equal(got, data) {
+				t.Errorf("nsym %d, %d bytes: %d errores sin borrados no deberían corregirse", nsym, largo, nsym)
+			}
+		}
+	}
+}
+
+func TestRSDecodeErasures_ErroresYBorrados(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	data := []byte("Hola mundo, esto viaja con Reed-Solomon")
+	const nsym = 10
+	code, _ := RSEncode(data, nsym)
+	// 2·errores + borrados <= nsym para todas las combinaciones
+	for errores := 0; 2*errores <= nsym; errores++ {
+		ruidoso := append([]byte(nil), code...)
+		danados := corromper(rng, ruidoso, errores+nsym-2*errores)
+		borrados := danados[errores:]
+		got, corrected, err := RSDecodeErasures(ruidoso, nsym, borrados)
+		if err != nil || !bytes.Equal(got, data) || len(corrected) != len(danados) {
+			t.Errorf("%d errores y %d borrados: datos %q, corregidas %v, %v", errores, len(borrados), got, corrected, err)
+		}
+	}
+
+	// Un borrado cuyo byte llegó intacto no cuenta como corregido
+	ruidoso := append([]byte(nil), code...)
+	ruidoso[3] ^= 0xff
+	got, corrected, err := RSDecodeErasures(ruidoso, nsym, []int{3, 7, 7})
+	if err != nil || !bytes.Equal(got, data) || len(corrected) != 1 || corrected[0] != 3 {
+		t.Errorf("datos %q, corregidas %v, %v", got, corrected, err)
+	}
+}
+
+func TestRSDecodeErasures_Invalidos(t *testing.T) {
+	code, _ := RSEncode([]byte("Hola"), 4)
+	if _, _, err := RSDecodeErasures(code, 4, []int{0, 1, 2, 3, 4}); err == nil {
+		t.Error("se esperaba error con más borrados que paridad")
+	}
+	if _, _, err := RSDecodeErasures(code, 4, []int{len(code)}); err == nil {
+		t.Error("se esperaba error con un borrado fuera del bloque")
+	}
+}
+
+func TestRS_Invalidos(t *testing.T) {
+	if _, err := RSEncode([]byte("x"), 0); err == nil {
+		t.Error("se esperaba error sin paridad")
+	}
+	if _, err := RSEncode(make([]byte, 224), DefaultRSParity); err == nil {
+		t.Error("se esperaba error con un bloque de más de 255 bytes")
+	}
+	if _, _, err := RSDecode(make([]byte, 4), 4); err == nil {
+		t.Error("se esperaba error con un bloque sin datos")
+	}
+	if _, _, err := RSDecodePayload(nil); err == nil {
+		t.Error("se esperaba error con un payload vacío")
+	}
+	if _, _, err := RSDecodePayload([]byte{4, 1, 2, 3}); err == nil {
+		t.Error("se esperaba error con un bloque truncado")
+	}
+}
+
+func TestRSPayload_VariosBloques(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 50)
+	payload, err := EncodeRSPayload(data, DefaultRSParity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 500 bytes: dos bloques completos de 223 y uno acortado de 54
+	if want := 1 + 2*rsBlockSize + 54 + DefaultRSParity; len(payload) != want || payload[0] != DefaultRSParity {
+		t.Fatalf("payload de %d bytes (nsym %d), se esperaban %d", len(payload), payload[0], want)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	corromper(rng, payload[1:rsBlockSize+1], 16)
+	corromper(rng, payload[1+2*rsBlockSize:], 3)
+	got, counts, err := RSDecodePayload(payload)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("datos no recuperados: %v", err)
+	}
+	if counts != (RSCounts{Blocks: 3, Corrected: 19}) {
+		t.Errorf("counts = %+v", counts)
+	}
+
+	// Un bloque irrecuperable no impide contar los demás
+	corromper(rng, payload[1+rsBlockSize:1+2*rsBlockSize], 40)
+	if _, counts, err = RSDecodePayload(payload); err == nil || counts.Uncorrectable != 1 || counts.Blocks != 3 {
+		t.Errorf("counts = %+v, err = %v", counts, err)
+	}
+}
+
+func TestRSDecodePayloadErasures(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 50)
+	payload, _ := EncodeRSPayload(data, DefaultRSParity)
+
+	// 32 borrados en el primer bloque y 32 en el último: el doble de lo que
+	// RSDecodePayload corrige sin saber las posiciones
+	rng := rand.New(rand.NewSource(6))
+	var borrados []int
+	for _, p := range corromper(rng, payload[1:rsBlockSize+1], DefaultRSParity) {
+		borrados = append(borrados, 1+p)
+	}
+	for _, p := range corromper(rng, payload[1+2*rsBlockSize:], DefaultRSParity) {
+		borrados = append(borrados, 1+2*rsBlockSize+p)
+	}
+	if _, counts, err := RSDecodePayload(payload); err == nil || counts.Uncorrectable != 2 {
+		t.Fatalf("sin borrados: counts = %+v, err = %v", counts, err)
+	}
+	got, counts, err := RSDecodePayloadErasures(payload, borrados)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("datos no recuperados: %v", err)
+	}
+	if counts != (RSCounts{Blocks: 3, Corrected: 2 * DefaultRSParity}) {
+		t.Errorf("counts = %+v", counts)
+	}
+
+	if _, _, err := RSDecodePayloadErasures(payload, []int{0}); err == nil {
+		t.Error("se esperaba error con el byte de paridad borrado")
+	}
+	if _, _, err := RSDecodePayloadErasures(payload, []int{len(payload)}); err == nil {
+		t.Error("se esperaba error con un borrado fuera del payload")
+	}
+}
+
+func TestBuildFrameWithRS(t *testing.T) {
+	trama, err := BuildFrameWithRS([]byte("Hola"), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeReedSolomon || len(f.Payload) != 1+4+8 {
+		t.Fatalf("trama decodificada inesperada: %+v", f)
+	}
+	data, counts, err := RSDecodePayload(f.Payload)
+	if err != nil || string(data) != "Hola" || counts != (RSCounts{Blocks: 1}) {
+		t.Errorf("payload %q, counts %+v, %v", data, counts, err)
+	}
+}
@@ -0,0 +1,124 @@
+package frame
+
+import "fmt"
+
+// MsgTypeRepetition es el tipo de las tramas con código de repetición (3,1)
+const MsgTypeRepetition byte = 0x07
+
+// Repetition3Encode repite cada bit tres veces: el código de corrección más
+// simple, con tasa 1/3. Cada byte de datos ocupa exactamente tres bytes.
+func Repetition3Encode(bits []byte) []byte {
+	out := make([]byte, 0, len(bits)*3)
+	for _, b := range bits {
+		out = append(out, b, b, b)
+	}
+	return out
+}
+
+// Repetition3Decode decide cada bit por mayoría en su tripleta y devuelve las
+// posiciones (en codeBits) del bit que quedó en minoría, que son las que
+// corrigió. Con dos o tres errores en una tripleta la mayoría es la
+// equivocada y el error pasa sin detectarse.
+func Repetition3Decode(codeBits []byte) (dataBits []byte, corrected []int, err error) {
+	if len(codeBits)%3 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 3", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+
+	dataBits = make([]byte, len(codeBits)/3)
+	for i := range dataBits {
+		t := codeBits[i*3 : i*3+3]
+		bit := byte(0)
+		if t[0]+t[1]+t[2] >= 2 {
+			bit = 1
+		}
+		dataBits[i] = bit
+		for j, b := range t {
+			if b != bit {
+				corrected = append(corrected, i*3+j)
+			}
+		}
+	}
+	return dataBits, corrected, nil
+}
+
+// Repetition3DecodeErasures es Repetition3Decode sabiendo qué bits de
+// codeBits son borrados (posiciones en codeBits): cada tripleta vota solo con
+// sus bits no borrados, así que un borrado nunca inclina la mayoría y la
+// tripleta se decide con un único bit sano. Si los votos no borrados empatan
+// o están los tres borrados, decide la mayoría de los tres bits recibidos
+// como en Repetition3Decode. Las posiciones corregidas son las que quedaron
+// distintas del bit decidido, borradas o no.
+func Repetition3DecodeErasures(codeBits []byte, erasures []int) (dataBits []byte, corrected []int, err error) {
+	if len(codeBits)%3 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 3", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+	borrado := make([]bool, len(codeBits))
+	for _, p := range erasures {
+		if p < 0 || p >= len(codeBits) {
+			return nil, nil, fmt.Errorf("posición borrada fuera de los bits: %d (hay %d bits)", p, len(codeBits))
+		}
+		borrado[p] = true
+	}
+
+	dataBits = make([]byte, len(codeBits)/3)
+	for i := range dataBits {
+		t := codeBits[i*3 : i*3+3]
+		unos, votos := 0, 0
+		for j, b := range t {
+			if !borrado[i*3+j] {
+				unos += int(b)
+				votos++
+			}
+		}
+		if 2*unos == votos {
+			unos, votos = int(t[0]+t[1]+t[2]), 3
+		}
+		bit := byte(0)
+		if 2*unos > votos {
+			bit = 1
+		}
+		dataBits[i] = bit
+		for j, b := range t {
+			if b != bit {
+				corrected = append(corrected, i*3+j)
+			}
+		}
+	}
+	return dataBits, corrected, nil
+}
+
+// BuildFrameWithRepetition codifica payload con Repetition3Encode en una
+// trama MsgTypeRepetition; el CRC detecta lo que la mayoría no corrige
+func BuildFrameWithRepetition(payload []byte) ([]byte, error) {
+	return BuildFrameWithType(BitsToBytes(Repetition3Encode(BytesToBits(payload))), MsgTypeRepetition)
+}
+
+// Repetition3DecodePayload decodifica el payload de una trama
+// MsgTypeRepetition y devuelve los bytes de datos y las posiciones corregidas
+func Repetition3DecodePayload(payload []byte) (data []byte, corrected []int, err error) {
+	bits, corrected, err := Repetition3Decode(BytesToBits(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	return BitsToBytes(bits), corrected, nil
+}
+
+// Repetition3DecodePayloadErasures es Repetition3DecodePayload con los bits
+// borrados del payload (posiciones de bit en payload)
+func Repetition3DecodePayloadErasures(payload []byte, erasures []int) (data []byte, corrected []int, err error) {
+	bits, corrected, err := Repetition3DecodeErasures(BytesToBits(payload), erasures)
+	if err != nil {
+		return nil, nil, err
+	}
+	return BitsToBytes(bits), corrected, nil
+}
@@ -0,0 +1,115 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRepetition3_UnErrorPorTripletaSeCorrige(t *testing.T) {
+	data := BytesToBits([]byte("Hola"))
+	code := Repetition3Encode(data)
+	if len(code) != len(data)*3 {
+		t.Fatalf("%d bits codificados, se esperaban %d", len(code), len(data)*3)
+	}
+
+	for pos := 0; pos < 3; pos++ {
+		ruidoso := append([]byte(nil), code...)
+		for i := pos; i < len(ruidoso); i += 3 {
+			ruidoso[i] ^= 1
+		}
+		got, corrected, err := Repetition3Decode(ruidoso)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("posición %d: datos no recuperados", pos)
+		}
+		if len(corrected) != len(data) || corrected[0] != pos || corrected[1] != 3+pos {
+			t.Errorf("posición %d: corregidas %v", pos, corrected[:2])
+		}
+	}
+}
+
+func TestRepetition3_DosErroresInviertenElBit(t *testing.T) {
+	code := Repetition3Encode([]byte{1, 0})
+	code[0], code[1] = 0, 0
+	got, corrected, err := Repetition3Decode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte{0, 0}) || len(corrected) != 1 || corrected[0] != 2 {
+		t.Errorf("datos %v, corregidas %v", got, corrected)
+	}
+}
+
+func TestRepetition3DecodeErasures_DosBorradosNoInviertenElBit(t *testing.T) {
+	// Los dos primeros bits de la tripleta del 1 se borraron con relleno 0:
+	// por mayoría simple se decodifica 0, sin contar los borrados gana el 1
+	code := Repetition3Encode([]byte{1, 0})
+	code[0], code[1] = 0, 0
+	if got, _, _ := Repetition3Decode(code); got[0] != 0 {
+		t.Fatalf("sin borrados se esperaba el bit invertido, se obtuvo %v", got)
+	}
+	got, corrected, err := Repetition3DecodeErasures(code, []int{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte{1, 0}) || len(corrected) != 2 || corrected[0] != 0 || corrected[1] != 1 {
+		t.Errorf("datos %v, corregidas %v", got, corrected)
+	}
+
+	// Un borrado y dos votos distintos empatan: decide la mayoría de la tripleta
+	got, _, err = Repetition3DecodeErasures([]byte{1, 1, 0}, []int{1})
+	if err != nil || got[0] != 1 {
+		t.Errorf("empate: datos %v, %v", got, err)
+	}
+	if _, _, err := Repetition3DecodeErasures(code, []int{len(code)}); err == nil {
+		t.Error("se esperaba error con un borrado fuera de los bits")
+	}
+}
+
+func TestRepetition3DecodePayloadErasures(t *testing.T) {
+	payload := BitsToBytes(Repetition3Encode(BytesToBits([]byte("Hola"))))
+	// Borrar con relleno 0 dos de cada tres bits del primer byte
+	var borrados []int
+	for i := 0; i < 24; i++ {
+		if i%3 != 2 {
+			borrados = append(borrados, i)
+			payload[i/8] &^= 0x80 >> (i % 8)
+		}
+	}
+	if data, _, _ := Repetition3DecodePayload(payload); string(data) == "Hola" {
+		t.Fatal("sin borrados la mayoría debería equivocarse")
+	}
+	data, _, err := Repetition3DecodePayloadErasures(payload, borrados)
+	if err != nil || string(data) != "Hola" {
+		t.Errorf("datos %q, %v", data, err)
+	}
+}
+
+func TestRepetition3_Invalidos(t *testing.T) {
+	if _, _, err := Repetition3Decode(make([]byte, 4)); err == nil {
+		t.Error("se esperaba error con una longitud que no es múltiplo de 3")
+	}
+	if _, _, err := Repetition3Decode([]byte{0, 2, 0}); err == nil {
+		t.Error("se esperaba error con un bit inválido")
+	}
+}
+
+func TestBuildFrameWithRepetition(t *testing.T) {
+	trama, err := BuildFrameWithRepetition([]byte("Hola"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeRepetition || len(f.Payload) != 12 {
+		t.Fatalf("trama decodificada inesperada: %+v", f)
+	}
+	data, corrected, err := Repetition3DecodePayload(f.Payload)
+	if err != nil || string(data) != "Hola" || len(corrected) != 0 {
+		t.Errorf("payload %q, corregidas %v, %v", data, corrected, err)
+	}
+}
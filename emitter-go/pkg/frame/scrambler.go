@@ -0,0 +1,58 @@
+package frame
+
+// El scrambler es autosincronizante con polinomio x^7 + x^4 + 1 (el de
+// 802.11): cada bit de salida es el de entrada XOR los bits de salida 4 y 7
+// posiciones antes. El descrambler aplica los mismos taps a los bits
+// recibidos, así que tras 7 bits correctos se resincroniza solo; a cambio,
+// cada bit invertido en el canal invierte 3 bits de datos (n, n+4 y n+7).
+
+// ScramblerSeed es el estado inicial del registro de 7 bits. Con el registro
+// en 1 una entrada de ceros produce la secuencia de largo máximo (127 bits),
+// cuyas rachas no superan los 7 bits iguales; con el registro en 0 los ceros
+// pasarían sin cambios.
+const ScramblerSeed = 0x7F
+
+// FlagScrambled en el byte de contenido indica que el payload pasó por
+// Scramble después de la codificación (ver MsgTypeContent)
+const FlagScrambled byte = 0x80
+
+// Scramble aleatoriza bits (un bit por byte, como Hamming74Encode) para cortar
+// las rachas largas de bits iguales; Descramble lo revierte. De cada byte de
+// entrada solo se usa el bit menos significativo.
+func Scramble(bits []byte) []byte {
+	out := make([]byte, len(bits))
+	state := byte(ScramblerSeed)
+	for i, b := range bits {
+		o := b&1 ^ scramblerTaps(state)
+		state = (state<<1 | o) & 0x7F
+		out[i] = o
+	}
+	return out
+}
+
+// Descramble revierte Scramble
+func Descramble(bits []byte) []byte {
+	out := make([]byte, len(bits))
+	state := byte(ScramblerSeed)
+	for i, b := range bits {
+		b &= 1
+		out[i] = b ^ scramblerTaps(state)
+		state = (state<<1 | b) & 0x7F
+	}
+	return out
+}
+
+// scramblerTaps son los bits de salida de 4 y 7 posiciones antes; el bit 0 de
+// state es el más reciente
+func scramblerTaps(state byte) byte {
+	return (state>>3 ^ state>>6) & 1
+}
+
+// scrambleBytes y descrambleBytes aplican el scrambler a los bits de data
+func scrambleBytes(data []byte) []byte {
+	return BitsToBytes(Scramble(BytesToBits(data)))
+}
+
+func descrambleBytes(data []byte) []byte {
+	return BitsToBytes(Descramble(BytesToBits(data)))
+}
@@ -0,0 +1,113 @@
+package frame
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// rachaMasLarga es la cantidad máxima de bits iguales consecutivos
+func rachaMasLarga(bits []byte) int {
+	mayor, racha := 0, 0
+	for i, b := range bits {
+		if i > 0 && b == bits[i-1] {
+			racha++
+		} else {
+			racha = 1
+		}
+		if racha > mayor {
+			mayor = racha
+		}
+	}
+	return mayor
+}
+
+func TestScramble_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(778))
+	for _, n := range []int{0, 1, 6, 7, 8, 13, 64, 127, 128, 1000, 4099} {
+		bits := make([]byte, n)
+		for i := range bits {
+			bits[i] = byte(rng.Intn(2))
+		}
+		scrambled := Scramble(bits)
+		if len(scrambled) != n {
+			t.Fatalf("%d bits: Scramble devolvió %d", n, len(scrambled))
+		}
+		if got := Descramble(scrambled); !bytes.Equal(got, bits) {
+			t.Errorf("%d bits: Descramble no recupera la entrada", n)
+		}
+	}
+}
+
+func TestScramble_CerosSinRachasLargas(t *testing.T) {
+	scrambled := Scramble(make([]byte, 1024))
+	// La secuencia de largo máximo de un registro de 7 bits tiene a lo sumo
+	// 7 unos y 6 ceros seguidos, y se repite cada 127 bits
+	if r := rachaMasLarga(scrambled); r > 7 {
+		t.Errorf("racha de %d bits iguales con entrada de ceros, se esperaban a lo sumo 7", r)
+	}
+	if !bytes.Equal(scrambled[:127], scrambled[127:254]) || bytes.Equal(scrambled[:63], scrambled[1:64]) {
+		t.Error("la salida con entrada de ceros no es la secuencia de período 127")
+	}
+	unos := 0
+	for _, b := range scrambled[:127] {
+		unos += int(b)
+	}
+	if unos != 64 {
+		t.Errorf("%d unos en un período, la secuencia de largo máximo tiene 64", unos)
+	}
+}
+
+func TestDescramble_ErrorDelCanalSePropagaATresBits(t *testing.T) {
+	bits := BytesToBits([]byte("Hola mundo"))
+	scrambled := Scramble(bits)
+	scrambled[20] ^= 1
+	got := Descramble(scrambled)
+	var errores []int
+	for i := range bits {
+		if got[i] != bits[i] {
+			errores = append(errores, i)
+		}
+	}
+	if len(errores) != 3 || errores[0] != 20 || errores[1] != 24 || errores[2] != 27 {
+		t.Errorf("bits de datos afectados %v, se esperaban [20 24 27]", errores)
+	}
+}
+
+func TestBuildFrameOpts_Scramble(t *testing.T) {
+	payload := make([]byte, 64) // Datos en cero: el peor caso sin scrambler
+	trama, err := BuildFrameOpts(payload, WithHamming74(), WithScramble(), WithSeq(9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := HeaderLen(trama)
+	if header != SeqHeaderSize+ContentHeaderSize || trama[header-1] != FlagScrambled|MsgTypeHamming || !IsScrambled(trama) {
+		t.Fatalf("header %x sin FlagScrambled", trama[:header])
+	}
+	enviado := BytesToBits(trama[header : len(trama)-CRCSize])
+	if r := rachaMasLarga(enviado); r > 16 {
+		t.Errorf("racha de %d bits iguales en el payload aleatorizado", r)
+	}
+
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Scrambled || f.MsgType != MsgTypeHamming || f.Content != ContentUnspecified || f.Seq != 9 {
+		t.Errorf("trama decodificada inesperada: %v", f)
+	}
+	data, err := f.Data()
+	if err != nil || !bytes.Equal(data, payload) {
+		t.Fatalf("Data = %x, %v", data, err)
+	}
+	otra, err := BuildFrameOpts(data, f.Options()...)
+	if err != nil || !bytes.Equal(otra, trama) {
+		t.Errorf("las opciones no reproducen la trama: %x, se esperaba %x (%v)", otra, trama, err)
+	}
+
+	// Sin el scrambler el payload codificado trae rachas largas de ceros
+	plano, _ := BuildFrameOpts(payload, WithHamming74(), WithSeq(9))
+	if r := rachaMasLarga(BytesToBits(plano[SeqHeaderSize : len(plano)-CRCSize])); r <= 16 {
+		t.Errorf("el payload Hamming de ceros solo tiene rachas de %d bits", r)
+	}
+}
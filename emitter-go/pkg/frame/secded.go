@@ -0,0 +1,133 @@
+package frame
+
+import "fmt"
+
+// MsgTypeHamming84 es el tipo de las tramas codificadas con Hamming(8,4)
+// extendido (SEC-DED)
+const MsgTypeHamming84 byte = 0x06
+
+// BlockStatus es el resultado de decodificar un bloque SEC-DED
+type BlockStatus byte
+
+const (
+	BlockOK            BlockStatus = iota // Sin errores
+	BlockCorrected                        // Un bit invertido, corregido
+	BlockUncorrectable                    // Dos bits invertidos: detectado, los datos no son confiables
+)
+
+func (s BlockStatus) String() string {
+	switch s {
+	case BlockOK:
+		return "ok"
+	case BlockCorrected:
+		return "corregido"
+	case BlockUncorrectable:
+		return "no corregible"
+	default:
+		return fmt.Sprintf("estado(%d)", byte(s))
+	}
+}
+
+// SECDEDCounts cuenta los bloques corregidos y los detectados como no
+// corregibles de una decodificación SEC-DED
+type SECDEDCounts struct {
+	Corrected     int
+	Uncorrectable int
+}
+
+// CountBlockStatus resume los estados devueltos por Hamming84Decode
+func CountBlockStatus(status []BlockStatus) SECDEDCounts {
+	var c SECDEDCounts
+	for _, s := range status {
+		switch s {
+		case BlockCorrected:
+			c.Corrected++
+		case BlockUncorrectable:
+			c.Uncorrectable++
+		}
+	}
+	return c
+}
+
+// Hamming84Encode aplica Hamming(7,4) (ver Hamming74Encode) y agrega a cada
+// bloque un bit de paridad par sobre sus 7 bits: [p2 p1 d3 p0 d2 d1 d0 p].
+// Cada bloque ocupa exactamente un byte.
+func Hamming84Encode(dataBits []byte) ([]byte, error) {
+	code74, err := Hamming74Encode(dataBits)
+	if err != nil {
+		return nil, err
+	}
+
+	numBlocks := len(code74) / 7
+	result := make([]byte, 0, numBlocks*8)
+	for i := 0; i < numBlocks; i++ {
+		block := code74[i*7 : (i+1)*7]
+		var paridad byte
+		for _, b := range block {
+			paridad ^= b
+		}
+		result = append(append(result, block...), paridad)
+	}
+	return result, nil
+}
+
+// Hamming84Decode decodifica bloques de 8 bits en el layout de Hamming84Encode.
+// La paridad global distingue un error simple (paridad impar: se corrige en la
+// posición del síndrome, o en el bit de paridad si el síndrome es 0) de uno
+// doble (paridad par con síndrome distinto de 0: solo se detecta y el bloque
+// queda sin tocar). Devuelve 4 bits de datos por bloque y el estado de cada
+// bloque.
+func Hamming84Decode(codeBits []byte) (dataBits []byte, status []BlockStatus, err error) {
+	if len(codeBits)%8 != 0 {
+		return nil, nil, fmt.Errorf("longitud inválida: %d bits no es múltiplo de 8", len(codeBits))
+	}
+	for i, b := range codeBits {
+		if b != 0 && b != 1 {
+			return nil, nil, invalidBit(i, b)
+		}
+	}
+
+	numBlocks := len(codeBits) / 8
+	dataBits = make([]byte, 0, numBlocks*4)
+	status = make([]BlockStatus, numBlocks)
+	block := make([]byte, 8)
+	for i := 0; i < numBlocks; i++ {
+		copy(block, codeBits[i*8:(i+1)*8])
+		p2, p1, d3, p0, d2, d1, d0 := block[0], block[1], block[2], block[3], block[4], block[5], block[6]
+
+		s0 := p0 ^ d3 ^ d2 ^ d0
+		s1 := p1 ^ d3 ^ d1 ^ d0
+		s2 := p2 ^ d2 ^ d1 ^ d0
+		pos := hammingSyndromePos[s2<<2|s1<<1|s0]
+
+		var global byte
+		for _, b := range block {
+			global ^= b
+		}
+
+		switch {
+		case global == 1:
+			if pos >= 0 {
+				block[pos] ^= 1
+			}
+			status[i] = BlockCorrected
+		case pos >= 0:
+			status[i] = BlockUncorrectable
+		}
+
+		dataBits = append(dataBits, block[2], block[4], block[5], block[6])
+	}
+	return dataBits, status, nil
+}
+
+// Hamming84DecodePayload decodifica el payload de una trama MsgTypeHamming84,
+// descartando el relleno como DecodeHammingPayload
+func Hamming84DecodePayload(payload []byte) (dataBits []byte, status []BlockStatus, err error) {
+	decode := func(codeBits []byte) ([]byte, []int, error) {
+		var data []byte
+		data, status, err = Hamming84Decode(codeBits)
+		return data, nil, err
+	}
+	dataBits, _, err = DecodeHammingPayload(payload, 8, decode)
+	return dataBits, status, err
+}
@@ -0,0 +1,123 @@
+package frame
+
+import (
+	"bytes"
+	"testing"
+)
+
+func codificarSECDED(t *testing.T, texto string) (data, code []byte) {
+	t.Helper()
+	data = BytesToBits([]byte(texto))
+	code, err := Hamming84Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != len(data)*2 {
+		t.Fatalf("%d bits codificados, se esperaban %d", len(code), len(data)*2)
+	}
+	return data, code
+}
+
+func TestHamming84_SinErrores(t *testing.T) {
+	data, code := codificarSECDED(t, "Hola mundo")
+	got, status, err := Hamming84Decode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("datos no recuperados")
+	}
+	for i, s := range status {
+		if s != BlockOK {
+			t.Errorf("bloque %d: %s, se esperaba ok", i, s)
+		}
+	}
+	if c := CountBlockStatus(status); c != (SECDEDCounts{}) {
+		t.Errorf("conteo %+v, se esperaba vacío", c)
+	}
+}
+
+func TestHamming84_UnErrorPorBloqueSeCorrige(t *testing.T) {
+	data, code := codificarSECDED(t, "Hola")
+	bloques := len(code) / 8
+
+	// Cualquier posición, incluidas las de paridad y la paridad global
+	for pos := 0; pos < 8; pos++ {
+		ruidoso := append([]byte(nil), code...)
+		for b := 0; b < bloques; b++ {
+			ruidoso[b*8+pos] ^= 1
+		}
+		got, status, err := Hamming84Decode(ruidoso)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("posición %d: datos no recuperados", pos)
+		}
+		if c := CountBlockStatus(status); c.Corrected != bloques || c.Uncorrectable != 0 {
+			t.Errorf("posición %d: conteo %+v, se esperaban %d corregidos", pos, c, bloques)
+		}
+	}
+}
+
+func TestHamming84_DosErroresPorBloqueSeDetectan(t *testing.T) {
+	_, code := codificarSECDED(t, "A")
+
+	// Todos los pares de posiciones del primer bloque; el segundo queda intacto
+	for i := 0; i < 8; i++ {
+		for j := i + 1; j < 8; j++ {
+			ruidoso := append([]byte(nil), code...)
+			ruidoso[i] ^= 1
+			ruidoso[j] ^= 1
+			_, status, err := Hamming84Decode(ruidoso)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status[0] != BlockUncorrectable || status[1] != BlockOK {
+				t.Errorf("bits %d y %d: estados %v", i, j, status)
+			}
+		}
+	}
+
+	// Hamming(7,4) sin la paridad global corrige mal el mismo par
+	code74, _ := Hamming74Encode(BytesToBits([]byte("A")))
+	code74[0] ^= 1
+	code74[1] ^= 1
+	got, _, _ := Hamming74Decode(code74)
+	if bytes.Equal(got, BytesToBits([]byte("A"))) {
+		t.Error("se esperaba que Hamming(7,4) corrigiera mal dos errores")
+	}
+}
+
+func TestHamming84_Invalidos(t *testing.T) {
+	if _, _, err := Hamming84Decode(make([]byte, 7)); err == nil {
+		t.Error("se esperaba error con un bloque incompleto")
+	}
+	if _, _, err := Hamming84Decode([]byte{0, 0, 0, 0, 0, 0, 0, 2}); err == nil {
+		t.Error("se esperaba error con un bit inválido")
+	}
+}
+
+func TestBuildFrameWithHamming_SECDED(t *testing.T) {
+	trama, err := BuildFrameWithHamming([]byte("Hola"), HammingSECDED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ParseFrame(trama)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.MsgType != MsgTypeHamming84 || len(f.Payload) != HammingPadSize+8 {
+		t.Fatalf("trama decodificada inesperada: %+v", f)
+	}
+	bits, status, err := Hamming84DecodePayload(f.Payload)
+	if err != nil || string(BitsToBytes(bits)) != "Hola" || CountBlockStatus(status) != (SECDEDCounts{}) {
+		t.Errorf("payload %q, estados %v, %v", BitsToBytes(bits), status, err)
+	}
+
+	// Sin modo sigue siendo Hamming(7,4)
+	trama, err = BuildFrameWithHamming([]byte("Hola"), HammingSEC)
+	if err != nil || trama[0] != MsgTypeHamming {
+		t.Errorf("tipo %#02x, %v", trama[0], err)
+	}
+}
@@ -0,0 +1,149 @@
+package frame
+
+import "fmt"
+
+// FrameStats desglosa los bytes que agrega cada etapa de la construcción de
+// una trama a los bits de datos: header, subheader y redundancia del código
+// en el payload, relleno y checksum
+type FrameStats struct {
+	DataBits       int // Bits de datos antes de codificar
+	HeaderBytes    int // Header de frame, con la secuencia si la lleva
+	SubheaderBytes int // Subheader del payload (relleno Hamming, filas de paridad 2D, nsym de RS)
+	EncodedBytes   int // Payload completo: subheader, datos codificados y relleno
+	ChecksumBytes  int
+	// PadBits son los bits de relleno del payload: los que completan el
+	// último bloque del código y el último byte
+	PadBits int
+	Frames  int // Tramas sumadas con Add (1 para una trama)
+}
+
+// ComputeFrameStats calcula las estadísticas de una trama construida a partir
+// de dataBits bits de datos. Falla si la trama no es válida, si su tipo no es
+// un código conocido (fragmentos, respuestas) o si dataBits no le corresponde.
+func ComputeFrameStats(frame []byte, dataBits int) (FrameStats, error) {
+	f, err := ParseFrame(frame)
+	if err != nil {
+		return FrameStats{}, err
+	}
+	s := FrameStats{
+		DataBits:      dataBits,
+		HeaderBytes:   HeaderLen(frame),
+		EncodedBytes:  len(f.Payload),
+		ChecksumBytes: f.Checksum.Size(),
+		Frames:        1,
+	}
+	body := len(f.Payload) * 8
+	if dataBits < 0 || dataBits > body {
+		return FrameStats{}, fmt.Errorf("%d bits de datos no corresponden a una trama %s de %d bytes de payload", dataBits, MsgTypeName(f.MsgType), len(f.Payload))
+	}
+
+	// Bits de código sin subheader ni relleno, salvo el relleno de datos de
+	// los códigos de bloque, que se suma aparte
+	var code int
+	switch f.MsgType {
+	case MsgTypeData, MsgTypeKeepalive:
+		code = dataBits
+	case MsgTypeHamming, MsgTypeHamming1511, MsgTypeHamming84, MsgTypeGolay:
+		n, k := 7, 4
+		switch f.MsgType {
+		case MsgTypeHamming1511:
+			n, k = 15, 11
+		case MsgTypeHamming84:
+			n = 8
+		case MsgTypeGolay:
+			n, k = 23, 12
+		}
+		s.SubheaderBytes = hammingSubheaderSize(f.Payload)
+		blocks := (dataBits + k - 1) / k
+		code = blocks * n
+		s.PadBits = blocks*k - dataBits
+	case MsgTypeBits:
+		s.SubheaderBytes = BitsPadSize
+		code = dataBits
+	case MsgTypeRepetition:
+		code = 3 * dataBits
+	case MsgTypeParity2D:
+		if len(f.Payload) < Parity2DHeaderSize {
+			return FrameStats{}, fmt.Errorf("%w: payload de paridad 2D de %d bytes", ErrTruncated, len(f.Payload))
+		}
+		// Parity2DEncode recibe bytes: las paridades cubren también el relleno
+		// de datos hasta un byte
+		rows := int(f.Payload[0])
+		if rows == 0 {
+			return FrameStats{}, fmt.Errorf("cantidad de filas inválida: 0")
+		}
+		s.SubheaderBytes = Parity2DHeaderSize
+		code = dataBits + rows + parity2DDims((dataBits+7)/8*8, rows)
+	case MsgTypeReedSolomon:
+		if len(f.Payload) < 1 {
+			return FrameStats{}, fmt.Errorf("%w: payload Reed-Solomon vacío", ErrTruncated)
+		}
+		nsym := int(f.Payload[0])
+		s.SubheaderBytes = 1
+		blocks := 0
+		if nsym < rsBlockSize {
+			blocks = ((dataBits+7)/8 + rsBlockSize - nsym - 1) / (rsBlockSize - nsym)
+		}
+		code = dataBits + blocks*nsym*8
+	default:
+		return FrameStats{}, fmt.Errorf("estadísticas de código no disponibles para tramas %s (%#02x)", MsgTypeName(f.MsgType), f.MsgType)
+	}
+
+	s.PadBits += body - s.SubheaderBytes*8 - code
+	if s.PadBits < 0 {
+		return FrameStats{}, fmt.Errorf("%d bits de datos no corresponden a una trama %s de %d bytes de payload", dataBits, MsgTypeName(f.MsgType), len(f.Payload))
+	}
+	return s, nil
+}
+
+// FrameBytes es el tamaño total: header, payload y checksum
+func (s FrameStats) FrameBytes() int {
+	return s.HeaderBytes + s.EncodedBytes + s.ChecksumBytes
+}
+
+// CodeRate es la fracción del payload que son datos: la tasa del código con
+// el subheader y el relleno incluidos (0 sin payload)
+func (s FrameStats) CodeRate() float64 {
+	if s.EncodedBytes == 0 {
+		return 0
+	}
+	return float64(s.DataBits) / float64(s.EncodedBytes*8)
+}
+
+// Expansion es cuántos bits de payload ocupa cada bit de datos, la inversa de
+// CodeRate (con Hamming(7,4) tiende a 1.75 en payloads largos; 0 sin datos)
+func (s FrameStats) Expansion() float64 {
+	if s.DataBits == 0 {
+		return 0
+	}
+	return float64(s.EncodedBytes*8) / float64(s.DataBits)
+}
+
+// Efficiency es la fracción de la trama completa que son datos
+func (s FrameStats) Efficiency() float64 {
+	if s.FrameBytes() == 0 {
+		return 0
+	}
+	return float64(s.DataBits) / float64(s.FrameBytes()*8)
+}
+
+// OverheadBits son los bits de la trama que no son datos
+func (s FrameStats) OverheadBits() int {
+	return s.FrameBytes()*8 - s.DataBits
+}
+
+// Add acumula o en s; las tasas de la suma ponderan cada trama por su tamaño
+func (s *FrameStats) Add(o FrameStats) {
+	s.DataBits += o.DataBits
+	s.HeaderBytes += o.HeaderBytes
+	s.SubheaderBytes += o.SubheaderBytes
+	s.EncodedBytes += o.EncodedBytes
+	s.ChecksumBytes += o.ChecksumBytes
+	s.PadBits += o.PadBits
+	s.Frames += o.Frames
+}
+
+func (s FrameStats) String() string {
+	return fmt.Sprintf("%d bits de datos en %d bytes: header %d B, subheader %d B, checksum %d B, %d bits de relleno; tasa del código %.3f (x%.2f), eficiencia %.3f",
+		s.DataBits, s.FrameBytes(), s.HeaderBytes, s.SubheaderBytes, s.ChecksumBytes, s.PadBits, s.CodeRate(), s.Expansion(), s.Efficiency())
+}
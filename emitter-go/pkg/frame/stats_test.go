@@ -0,0 +1,114 @@
+package frame
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeFrameStats_TamañosConocidos(t *testing.T) {
+	hola := []byte("Hola") // 32 bits de datos
+	seq := uint16(7)
+	construir := func(f func() ([]byte, error)) []byte {
+		t.Helper()
+		trama, err := f()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trama
+	}
+
+	casos := []struct {
+		nombre   string
+		trama    []byte
+		dataBits int
+		want     FrameStats
+		tasa     float64
+	}{
+		{"data", construir(func() ([]byte, error) { return BuildFrame(hola) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, EncodedBytes: 4, ChecksumBytes: 4, Frames: 1}, 1},
+		{"data con seq y crc8", construir(func() ([]byte, error) {
+			return BuildFrameWithOptions(hola, FrameOptions{Checksum: ChecksumCRC8, Seq: &seq})
+		}), 32, FrameStats{DataBits: 32, HeaderBytes: 5, EncodedBytes: 4, ChecksumBytes: 1, Frames: 1}, 1},
+		// 17 bits agrupados en 3 bytes: 7 de relleno
+		{"data 17 bits", construir(func() ([]byte, error) { return BuildFrame([]byte{0x48, 0x69, 0x00}) }), 17,
+			FrameStats{DataBits: 17, HeaderBytes: 3, EncodedBytes: 3, ChecksumBytes: 4, PadBits: 7, Frames: 1}, 17.0 / 24},
+		// 8 bloques de 7 bits = 7 bytes, más el subheader
+		{"hamming74", construir(func() ([]byte, error) { return BuildFrameWithHamming(hola) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 8, ChecksumBytes: 4, Frames: 1}, 0.5},
+		// 6 bits: 2 de relleno hasta el segundo bloque y 2 hasta el byte
+		{"hamming74 6 bits", construir(func() ([]byte, error) { return BuildFrameWithHammingBits([]byte{1, 1, 0, 1, 0, 1}) }), 6,
+			FrameStats{DataBits: 6, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 3, ChecksumBytes: 4, PadBits: 4, Frames: 1}, 0.25},
+		{"hamming84", construir(func() ([]byte, error) { return BuildFrameWithHamming(hola, HammingSECDED) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 9, ChecksumBytes: 4, Frames: 1}, 32.0 / 72},
+		// 3 bloques de 11 (1 bit de relleno) = 45 bits en 6 bytes (3 más)
+		{"hamming1511", construir(func() ([]byte, error) { return Hamming1511.EncodeFrame(hola, FrameOptions{}) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 7, ChecksumBytes: 4, PadBits: 4, Frames: 1}, 32.0 / 56},
+		{"repetición", construir(func() ([]byte, error) { return BuildFrameWithRepetition(hola) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, EncodedBytes: 12, ChecksumBytes: 4, Frames: 1}, 1.0 / 3},
+		// 8 filas de 4 columnas: 32 + 8 + 4 = 44 bits en 6 bytes
+		{"paridad 2D", construir(func() ([]byte, error) { return BuildFrameWithParity2D(hola, 8, FrameOptions{}) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 3, EncodedBytes: 9, ChecksumBytes: 4, PadBits: 4, Frames: 1}, 32.0 / 72},
+		{"reed-solomon", construir(func() ([]byte, error) { return BuildFrameWithRS(hola, 32) }), 32,
+			FrameStats{DataBits: 32, HeaderBytes: 3, SubheaderBytes: 1, EncodedBytes: 37, ChecksumBytes: 4, Frames: 1}, 32.0 / 296},
+	}
+	for _, c := range casos {
+		t.Run(c.nombre, func(t *testing.T) {
+			got, err := ComputeFrameStats(c.trama, c.dataBits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Fatalf("%+v, se esperaba %+v", got, c.want)
+			}
+			if got.FrameBytes() != len(c.trama) || got.OverheadBits() != len(c.trama)*8-c.dataBits {
+				t.Errorf("FrameBytes = %d, OverheadBits = %d para una trama de %d bytes", got.FrameBytes(), got.OverheadBits(), len(c.trama))
+			}
+			if math.Abs(got.CodeRate()-c.tasa) > 1e-12 || math.Abs(got.Expansion()*got.CodeRate()-1) > 1e-12 {
+				t.Errorf("CodeRate = %v, Expansion = %v, se esperaba tasa %v", got.CodeRate(), got.Expansion(), c.tasa)
+			}
+			if want := float64(c.dataBits) / float64(len(c.trama)*8); math.Abs(got.Efficiency()-want) > 1e-12 {
+				t.Errorf("Efficiency = %v, se esperaba %v", got.Efficiency(), want)
+			}
+		})
+	}
+}
+
+func TestComputeFrameStats_Errores(t *testing.T) {
+	trama, _ := BuildFrame([]byte("Hola"))
+	if _, err := ComputeFrameStats(trama, 40); err == nil {
+		t.Error("se esperaba error con más bits de datos que los del payload")
+	}
+	if _, err := ComputeFrameStats(trama[:len(trama)-1], 32); err == nil {
+		t.Error("se esperaba error con una trama inválida")
+	}
+	ack, _ := BuildAckFrame(1)
+	if _, err := ComputeFrameStats(ack, 0); err == nil {
+		t.Error("una respuesta no tiene estadísticas de código")
+	}
+}
+
+func TestFrameStats_Add(t *testing.T) {
+	var total FrameStats
+	var partes []FrameStats
+	for _, construir := range []func([]byte) ([]byte, error){BuildFrame, func(p []byte) ([]byte, error) { return BuildFrameWithHamming(p) }} {
+		trama, err := construir([]byte("Hola"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := ComputeFrameStats(trama, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total.Add(s)
+		partes = append(partes, s)
+	}
+	if total.Frames != 2 || total.FrameBytes() != partes[0].FrameBytes()+partes[1].FrameBytes() {
+		t.Fatalf("suma inesperada: %+v", total)
+	}
+	if want := 64.0 / 96; math.Abs(total.CodeRate()-want) > 1e-12 {
+		t.Errorf("CodeRate de la suma = %v, se esperaba %v (ponderado por tamaño)", total.CodeRate(), want)
+	}
+	if (FrameStats{}).CodeRate() != 0 || (FrameStats{}).Expansion() != 0 || (FrameStats{}).Efficiency() != 0 {
+		t.Error("sin tramas las tasas deben ser 0")
+	}
+}
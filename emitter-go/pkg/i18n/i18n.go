@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Idiomas lista los idiomas con catálogo; el primero es el idioma por defecto
+// y su texto se usa cuando a otro idioma le falta una traducción
+var Idiomas = []string{"es", "en"}
+
+var catalogos = map[string]map[string]string{
+	"es": mensajesES,
+	"en": mensajesEN,
+}
+
+// Catalogo resuelve los textos visibles para el usuario en un idioma. Un
+// *Catalogo nil es válido y usa el idioma por defecto.
+type Catalogo struct {
+	idioma string
+}
+
+// Nuevo devuelve el catálogo de idioma, que debe estar en Idiomas
+func Nuevo(idioma string) (*Catalogo, error) {
+	if _, ok := catalogos[idioma]; !ok {
+		return nil, fmt.Errorf("idioma no soportado: %s (disponibles: %v)", idioma, Idiomas)
+	}
+	return &Catalogo{idioma: idioma}, nil
+}
+
+// Idioma devuelve el código del idioma del catálogo
+func (c *Catalogo) Idioma() string {
+	if c == nil {
+		return Idiomas[0]
+	}
+	return c.idioma
+}
+
+// T devuelve el texto de clave formateado con args. Si el idioma no tiene la
+// clave se usa el texto por defecto, y si tampoco existe, la clave misma.
+func (c *Catalogo) T(clave string, args ...interface{}) string {
+	texto, ok := catalogos[c.Idioma()][clave]
+	if !ok {
+		texto, ok = catalogos[Idiomas[0]][clave]
+	}
+	if !ok {
+		return clave
+	}
+	if len(args) == 0 {
+		return texto
+	}
+	return fmt.Sprintf(texto, args...)
+}
+
+// DesdeEntorno elige el idioma a partir de la variable LANG
+// ("en_US.UTF-8" → "en"); si no corresponde a un catálogo devuelve el idioma
+// por defecto
+func DesdeEntorno() string {
+	codigo := strings.ToLower(os.Getenv("LANG"))
+	if i := strings.IndexAny(codigo, "_.-@"); i >= 0 {
+		codigo = codigo[:i]
+	}
+	if _, ok := catalogos[codigo]; ok {
+		return codigo
+	}
+	return Idiomas[0]
+}
+
+// Claves devuelve las claves del catálogo de idioma, ordenadas
+func Claves(idioma string) []string {
+	var claves []string
+	for k := range catalogos[idioma] {
+		claves = append(claves, k)
+	}
+	sort.Strings(claves)
+	return claves
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatalogos_MismasClaves(t *testing.T) {
+	base := Claves(Idiomas[0])
+	for _, idioma := range Idiomas[1:] {
+		if got := Claves(idioma); !reflect.DeepEqual(got, base) {
+			t.Errorf("el catálogo %q no cubre las mismas claves que %q:\n%v\n%v", idioma, Idiomas[0], got, base)
+		}
+	}
+}
+
+// claveUsada reconoce las claves literales que el módulo pasa al catálogo
+var claveUsada = regexp.MustCompile(`\b(?:msgs\.T|app\.imprimir|app\.imprimirLinea)\("([a-z_.]+)"`)
+
+func TestCatalogos_CubrenClavesUsadas(t *testing.T) {
+	// Una clave sin texto se mostraría tal cual al usuario
+	usadas := map[string]string{}
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		fuente, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range claveUsada.FindAllStringSubmatch(string(fuente), -1) {
+			usadas[m[1]] = path
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usadas) == 0 {
+		t.Fatal("no se encontraron claves en el código")
+	}
+	for clave, path := range usadas {
+		for _, idioma := range Idiomas {
+			if _, ok := catalogos[idioma][clave]; !ok {
+				t.Errorf("%s usa %q, que falta en el catálogo %q", path, clave, idioma)
+			}
+		}
+	}
+}
+
+func TestCatalogos_MismosVerbos(t *testing.T) {
+	// Una traducción con otros verbos de formato rompería fmt.Sprintf
+	for _, clave := range Claves(Idiomas[0]) {
+		want := strings.Count(mensajesES[clave], "%")
+		if got := strings.Count(mensajesEN[clave], "%"); got != want {
+			t.Errorf("%s: %d '%%' en en, %d en es", clave, got, want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	en, err := Nuevo("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := en.T("app.config.modo", "manual"); got != "   Mode: manual\n" {
+		t.Errorf("T() = %q", got)
+	}
+
+	var porDefecto *Catalogo
+	if got := porDefecto.T("app.config.modo", "manual"); got != "   Modo: manual\n" {
+		t.Errorf("un catálogo nil debería usar es: %q", got)
+	}
+
+	// Sin traducción se usa el texto por defecto, y sin texto la clave
+	mensajesES["solo.es"] = "solo en español"
+	defer delete(mensajesES, "solo.es")
+	if got := en.T("solo.es"); got != "solo en español" {
+		t.Errorf("fallback = %q", got)
+	}
+	if got := en.T("no.existe"); got != "no.existe" {
+		t.Errorf("clave inexistente = %q", got)
+	}
+}
+
+func TestNuevo_IdiomaDesconocido(t *testing.T) {
+	if _, err := Nuevo("fr"); err == nil {
+		t.Error("se esperaba error para un idioma sin catálogo")
+	}
+}
+
+func TestDesdeEntorno(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"en_US.UTF-8", "en"},
+		{"EN", "en"},
+		{"es_GT.UTF-8", "es"},
+		{"fr_FR.UTF-8", "es"},
+		{"C", "es"},
+		{"", "es"},
+	}
+	for _, tt := range tests {
+		t.Setenv("LANG", tt.lang)
+		if got := DesdeEntorno(); got != tt.want {
+			t.Errorf("LANG=%q: DesdeEntorno() = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package i18n
+
+// Los nombres de campos de logs y exports no se traducen; solo los textos
+// que lee la persona que usa la CLI
+
+var mensajesES = map[string]string{
+	"app.prompt.mensaje":             "Ingrese el mensaje a transmitir: ",
+	"app.prompt.algoritmo":           "Seleccione algoritmo (1=CRC-32, 2=Hamming(7,4), 3=Hamming automático, 4=Fletcher-16, 5=Paridad 2D, 6=Hamming(8,4) SEC-DED, 7=Repetición (3,1), 8=Reed-Solomon, 9=Golay(23,12)): ",
+	"app.prompt.ber":                 "Ingrese BER (0.0-0.1, ej: 0.01): ",
+	"app.prompt.mensaje_benchmark":   "Mensaje base para benchmark [Hello World]: ",
+	"app.prompt.algoritmo_benchmark": "Algoritmo para benchmark (1=CRC-32, 2=Hamming(7,4), 3=Hamming automático, 4=Fletcher-16, 5=Paridad 2D, 6=Hamming(8,4) SEC-DED, 7=Repetición (3,1), 8=Reed-Solomon, 9=Golay(23,12)): ",
+	"app.prompt.ber_benchmark":       "BER para benchmark [0.01]: ",
+	"app.prompt.iteraciones":         "Número de iteraciones [1000]: ",
+
+	"app.pista.algoritmo":         "❌ Opción inválida. Ingrese 1 para CRC-32, 2 para Hamming(7,4), 3 para Hamming automático, 4 para Fletcher-16, 5 para Paridad 2D, 6 para Hamming(8,4) SEC-DED, 7 para Repetición (3,1), 8 para Reed-Solomon o 9 para Golay(23,12)",
+	"app.pista.opcion":            "❌ Opción inválida",
+	"app.pista.ber_formato":       "❌ BER inválido. Ingrese un número decimal (ej: 0.01)",
+	"app.pista.ber_invalido":      "❌ BER inválido",
+	"app.pista.ber_rango":         "❌ BER debe estar entre 0.0 y 1.0",
+	"app.pista.cantidad":          "❌ Cantidad inválida",
+	"app.pista.cantidad_positiva": "❌ La cantidad debe ser mayor a 0",
+
+	"app.config.titulo":      "\n📋 Configuración:",
+	"app.config.mensaje":     "   Mensaje: \"%s\"\n",
+	"app.config.algoritmo":   "   Algoritmo: %s\n",
+	"app.config.ber":         "   BER: %.3f (%.1f%%)\n",
+	"app.config.modo":        "   Modo: %s\n",
+	"app.config.duracion":    "   Duración: %v\n",
+	"app.config.iteraciones": "   Iteraciones: %d\n",
+
+	"app.resultado.exito": "✅ Transmisión exitosa: %s\n",
+	"app.resultado.error": "❌ Error en transmisión: %s\n",
+
+	"app.estadisticas.titulo":   "\n📊 Estadísticas de Benchmark:",
+	"app.estadisticas.total":    "Total de mensajes: %d\n",
+	"app.estadisticas.exitosos": "Exitosos: %d\n",
+	"app.estadisticas.fallidos": "Fallidos: %d\n",
+	"app.estadisticas.tasa":     "Tasa de éxito: %.2f%%\n",
+	"app.estadisticas.tiempo":   "Tiempo promedio: %.2fms\n",
+
+	"advertencia.titulo": "⚠️  Advertencias de configuración:",
+	"advertencia.hamming_saturado": "con BER %.3f cada bloque Hamming tiene %.2f%% de probabilidad de 2+ errores; " +
+		"%.1f%% de las tramas (%d bloques) tendrán algún bloque incorregible",
+	"advertencia.pocas_iteraciones": "con %d iteraciones la tasa de éxito tiene un margen de hasta ±%.1f%% (IC 95%%); " +
+		"se necesitan al menos %d para ±%.0f%%",
+	"advertencia.garantia_crc": "se esperan %.1f bits erróneos por trama de %d bits, pero CRC-32 solo garantiza " +
+		"detectar hasta %d a ese largo; la detección pasa a ser probabilística",
+	"advertencia.estricto": "❌ --strict-advice: corregir la configuración o quitar el flag para ejecutar igualmente",
+
+	"emisor.titulo":   "🚀 Emisor por Capas - Lab 2",
+	"emisor.modo":     "Modo: %s\n",
+	"emisor.receptor": "Receptor: %s\n\n",
+
+	"emisor.cancelado":      "   Benchmark cancelado tras %d iteraciones\n",
+	"emisor.interrumpido":   "🛑 Benchmark interrumpido tras %d iteraciones; se analizan las completadas\n",
+	"emisor.flood":          "🌊 Flood: %d conexiones durante %v (trama de %d bytes, sin ruido)\n\n",
+	"emisor.contador_error": "⚠️  No se pudo consultar %s: %v\n",
+	"emisor.contador":       "Contador del receptor: %d (discrepancia: %d)\n",
+	"emisor.bundle":         "📦 Bundle escrito en %s\n",
+	"emisor.bundle_parcial": "📦 Bundle parcial escrito en %s\n",
+
+	"error.flag_invalido":   "❌ --%s inválido: %v\n",
+	"error.flag_rango":      "❌ --%s inválido: %d (debe estar entre %d y %d)\n",
+	"error.solo_benchmark":  "❌ --%s solo aplica al modo benchmark",
+	"error.incompatibles":   "❌ --%s no se puede combinar con --%s",
+	"error.erasure":         "❌ --erasure no se puede combinar con --burst ni con --inject",
+	"error.requiere":        "❌ --%s requiere --%s",
+	"error.configuracion":   "❌ Error en configuración: %v\n",
+	"error.config_invalida": "❌ Configuración inválida: %v\n",
+	"error.transmision":     "❌ Error en transmisión: %v\n",
+	"error.presentacion":    "❌ Error en presentación: %v\n",
+	"error.calibracion":     "❌ Error en la calibración: %v\n",
+	"error.benchmark":       "❌ Error en benchmark: %v\n",
+	"error.flood":           "❌ Error en flood: %v\n",
+	"error.modo":            "❌ Modo inválido: %s (usar 'manual', 'benchmark' o 'flood')\n",
+	"error.exports":         "❌ Error %v\n",
+	"error.cerrar_exports":  "❌ Error cerrando exports: %v\n",
+	"error.bundle":          "❌ Error escribiendo el bundle: %v\n",
+	"error.diagnostico":     "   Diagnóstico: %s\n",
+
+	"resumen.titulo":            "\n📊 Resumen del Benchmark:\n",
+	"resumen.total":             "   Total: %d transmisiones\n",
+	"resumen.exitosas":          "   Exitosas: %d (%.1f%%)\n",
+	"resumen.fallidas":          "   Fallidas: %d (%.1f%%)\n",
+	"resumen.tiempo_total":      "   Tiempo total: %v\n",
+	"resumen.tiempo_promedio":   "   Tiempo promedio por transmisión: %v\n",
+	"resumen.conexiones":        "   Conexiones: %d (reconexiones: %d)\n",
+	"resumen.dial":              "   Latencia promedio de dial: %v\n",
+	"resumen.tls":               "   Handshake TLS promedio: %v (%d/%d sesiones reanudadas)\n",
+	"resumen.variantes_hamming": "   Variantes Hamming (auto): %s\n",
+	"resumen.secded":            "Bloques SEC-DED: %d corregidos, %d detectados sin corregir",
+	"resumen.correccion":        "Corrección %s: %d/%d bloques (%.1f%%), %d/%d payloads completos (%.1f%%)",
+	"resumen.rs":                "Reed-Solomon: %d bytes corregidos en %d bloques, %d bloques irrecuperables",
+	"resumen.overhead":          "Overhead: header %d B, subheader %d B, checksum %d B, relleno %d bits; tasa del código %.3f (expansión x%.2f), eficiencia %.3f",
+	"resumen.veredictos":        "Receptor: %d ACK, %d NACK, %d sin respuesta",
+	"resumen.plazo":             "   Plazo por mensaje: %v\n",
+	"resumen.puntualidad":       "     A tiempo: %d, tarde: %d, fallidas: %d, vencidas antes de enviar: %d\n",
+	"resumen.retraso":           "     Retraso de las tardías: min %v, p50 %v, p95 %v, max %v\n",
+
+	"analisis.titulo":        "📊 Análisis del Benchmark:",
+	"analisis.configuracion": "Configuración: %s, BER=%.3f, %d iteraciones\n",
+	"analisis.duracion":      "Duración objetivo: %v (iteraciones completadas: %d)\n",
+	"analisis.tasa":          "Tasa de éxito: %.2f%% (%d/%d)\n",
+	"analisis.tiempo":        "Tiempo total: %v (promedio: %v por transmisión)\n",
+	"analisis.errores":       "Errores promedio por transmisión: %.1f\n",
+	"analisis.ber":           "BER promedio: %.4f (objetivo: %.4f)\n",
+	"analisis.sin_teoria":    "⚠️  Sin comparación teórica: %v\n",
+	"analisis.pista_exports": "💡 Para análisis más detallado exportar con --iterations-csv, --theory-csv, --error-png o --hook-ndjson",
+
+	"calibracion.titulo":     "📏 Calibración del BER:",
+	"calibracion.no_aplica":  "   No aplica (ningún bit pasó por el canal ruidoso)",
+	"calibracion.ok":         "✅ OK",
+	"calibracion.fuera":      "❌ FUERA DE TOLERANCIA",
+	"calibracion.bits":       "   Bits transmitidos: %d, bits invertidos: %d\n",
+	"calibracion.observado":  "   BER observado: %.6f (IC 95%%: %.6f - %.6f)\n",
+	"calibracion.objetivo":   "   BER objetivo: %.6f (desviación relativa: %+.2f%%)\n",
+	"calibracion.tolerancia": "   Tolerancia: ±%.1f%% → %s\n",
+	"calibracion.sesgo":      "   ⚠️  El objetivo cae fuera del intervalo de confianza: posible sesgo del generador",
+
+	"watchdog.abortado":             "🛑 Benchmark abortado por el watchdog tras %d iteraciones\n",
+	"watchdog.motivo.transporte":    "   Motivo: fallo de transporte",
+	"watchdog.motivo.procesamiento": "   Motivo: fallo de procesamiento",
+	"watchdog.motivo.mixto":         "   Motivo: fallo de transporte y procesamiento",
+	"watchdog.ultimo_error":         "   Último error: %s\n",
+	"watchdog.pista":                "   (usar --no-watchdog para ejecutar igualmente)",
+	"watchdog.procesamiento":        "el mensaje no pudo codificarse; revisar algoritmo y texto configurados",
+	"watchdog.mixto":                "fallos mixtos; revisar configuración del mensaje y del receptor",
+
+	"diagnostico.sin_receptor":         "el receptor no está escuchando; revisar --ws-url o iniciar el receptor",
+	"diagnostico.dns":                  "el host de --ws-url no existe",
+	"diagnostico.handshake":            "el servidor no acepta WebSocket en esa ruta; revisar --ws-url",
+	"diagnostico.timeout":              "el receptor no responde a tiempo; revisar red o carga del receptor",
+	"diagnostico.nack":                 "el receptor rechaza las tramas (NACK); revisar que espere el mismo algoritmo y --checksum",
+	"diagnostico.sin_respuesta":        "el receptor no contesta ACK/NACK; quitar --await-reply si no los implementa",
+	"diagnostico.transporte":           "todas las transmisiones fallaron; revisar --ws-url y el estado del receptor",
+	"diagnostico.trama.checksum":       "el contenido no da el %s que trae la trama: se alteró al copiarla o se armó con otro --checksum",
+	"diagnostico.trama.corta":          "la trama no alcanza ni para el header y el checksum; revisar que se copió completa",
+	"diagnostico.trama.truncada":       "faltan bytes al final de la trama; revisar que se copió completa",
+	"diagnostico.trama.largo":          "el largo del header (bytes 2 y 3) no coincide con el payload; sobran bytes o el header se alteró",
+	"diagnostico.trama.tipo_checksum":  "los bits 4-6 del tipo no corresponden a ningún algoritmo de --checksum",
+	"diagnostico.trama.payload_grande": "el mensaje no entra en una trama (65535 bytes de payload); usar --max-fragment para enviarlo en fragmentos",
+	"diagnostico.trama.bit_invalido":   "la entrada tiene bits distintos de 0 y 1",
+
+	"flood.titulo":     "📊 Resultado del Flood:",
+	"flood.conexiones": "Conexiones: %d\n",
+	"flood.duracion":   "Duración: %v\n",
+	"flood.enviadas":   "Tramas enviadas: %d\n",
+	"flood.throughput": "Throughput: %.1f tramas/s, %.1f bytes/s\n",
+	"flood.errores":    "Errores de envío: %d (%.2f%%)\n",
+
+	"export.mapa":              "🖼️  Mapa de errores exportado a %s\n",
+	"export.mapa_truncadas":    "⚠️  %d filas del mapa muestran solo una muestra de sus errores (usar --full-positions)\n",
+	"export.teoria":            "📈 Curva teórica exportada a %s\n",
+	"export.iteraciones":       "📈 Iteraciones exportadas a %s\n",
+	"export.historial":         "🗂️  Corrida registrada como %s (ver: %s history)\n",
+	"export.historial_error":   "⚠️  No se pudo registrar la corrida en %s: %v\n",
+	"export.manifiesto":        "📄 Manifiesto de exports escrito en %s\n",
+	"export.error.mapa":        "exportando mapa de errores: %v",
+	"export.error.teoria":      "exportando la curva teórica: %v",
+	"export.error.sin_teoria":  "el benchmark no tiene iteraciones con BER fijo y ruido aleatorio",
+	"export.error.iteraciones": "exportando las iteraciones: %v",
+}
+
+var mensajesEN = map[string]string{
+	"app.prompt.mensaje":             "Enter the message to transmit: ",
+	"app.prompt.algoritmo":           "Select algorithm (1=CRC-32, 2=Hamming(7,4), 3=automatic Hamming, 4=Fletcher-16, 5=2D parity, 6=Hamming(8,4) SEC-DED, 7=Repetition (3,1), 8=Reed-Solomon, 9=Golay(23,12)): ",
+	"app.prompt.ber":                 "Enter BER (0.0-0.1, e.g. 0.01): ",
+	"app.prompt.mensaje_benchmark":   "Base message for the benchmark [Hello World]: ",
+	"app.prompt.algoritmo_benchmark": "Benchmark algorithm (1=CRC-32, 2=Hamming(7,4), 3=automatic Hamming, 4=Fletcher-16, 5=2D parity, 6=Hamming(8,4) SEC-DED, 7=Repetition (3,1), 8=Reed-Solomon, 9=Golay(23,12)): ",
+	"app.prompt.ber_benchmark":       "Benchmark BER [0.01]: ",
+	"app.prompt.iteraciones":         "Number of iterations [1000]: ",
+
+	"app.pista.algoritmo":         "❌ Invalid option. Enter 1 for CRC-32, 2 for Hamming(7,4), 3 for automatic Hamming, 4 for Fletcher-16, 5 for 2D parity, 6 for Hamming(8,4) SEC-DED, 7 for Repetition (3,1), 8 for Reed-Solomon or 9 for Golay(23,12)",
+	"app.pista.opcion":            "❌ Invalid option",
+	"app.pista.ber_formato":       "❌ Invalid BER. Enter a decimal number (e.g. 0.01)",
+	"app.pista.ber_invalido":      "❌ Invalid BER",
+	"app.pista.ber_rango":         "❌ BER must be between 0.0 and 1.0",
+	"app.pista.cantidad":          "❌ Invalid count",
+	"app.pista.cantidad_positiva": "❌ The count must be greater than 0",
+
+	"app.config.titulo":      "\n📋 Configuration:",
+	"app.config.mensaje":     "   Message: \"%s\"\n",
+	"app.config.algoritmo":   "   Algorithm: %s\n",
+	"app.config.ber":         "   BER: %.3f (%.1f%%)\n",
+	"app.config.modo":        "   Mode: %s\n",
+	"app.config.duracion":    "   Duration: %v\n",
+	"app.config.iteraciones": "   Iterations: %d\n",
+
+	"app.resultado.exito": "✅ Transmission succeeded: %s\n",
+	"app.resultado.error": "❌ Transmission error: %s\n",
+
+	"app.estadisticas.titulo":   "\n📊 Benchmark Statistics:",
+	"app.estadisticas.total":    "Total messages: %d\n",
+	"app.estadisticas.exitosos": "Successful: %d\n",
+	"app.estadisticas.fallidos": "Failed: %d\n",
+	"app.estadisticas.tasa":     "Success rate: %.2f%%\n",
+	"app.estadisticas.tiempo":   "Average time: %.2fms\n",
+
+	"advertencia.titulo": "⚠️  Configuration warnings:",
+	"advertencia.hamming_saturado": "at BER %.3f each Hamming block has a %.2f%% chance of 2+ errors; " +
+		"%.1f%% of frames (%d blocks) will have an uncorrectable block",
+	"advertencia.pocas_iteraciones": "with %d iterations the success rate has a margin of up to ±%.1f%% (95%% CI); " +
+		"at least %d are needed for ±%.0f%%",
+	"advertencia.garantia_crc": "%.1f bit errors are expected per %d-bit frame, but CRC-32 only guarantees " +
+		"detecting up to %d at that length; detection becomes probabilistic",
+	"advertencia.estricto": "❌ --strict-advice: fix the configuration or drop the flag to run anyway",
+
+	"emisor.titulo":   "🚀 Layered Emitter - Lab 2",
+	"emisor.modo":     "Mode: %s\n",
+	"emisor.receptor": "Receiver: %s\n\n",
+
+	"emisor.cancelado":      "   Benchmark cancelled after %d iterations\n",
+	"emisor.interrumpido":   "🛑 Benchmark interrupted after %d iterations; analyzing the completed ones\n",
+	"emisor.flood":          "🌊 Flood: %d connections for %v (%d-byte frame, no noise)\n\n",
+	"emisor.contador_error": "⚠️  Could not query %s: %v\n",
+	"emisor.contador":       "Receiver counter: %d (discrepancy: %d)\n",
+	"emisor.bundle":         "📦 Bundle written to %s\n",
+	"emisor.bundle_parcial": "📦 Partial bundle written to %s\n",
+
+	"error.flag_invalido":   "❌ Invalid --%s: %v\n",
+	"error.flag_rango":      "❌ Invalid --%s: %d (must be between %d and %d)\n",
+	"error.solo_benchmark":  "❌ --%s only applies to benchmark mode",
+	"error.incompatibles":   "❌ --%s cannot be combined with --%s",
+	"error.erasure":         "❌ --erasure cannot be combined with --burst or --inject",
+	"error.requiere":        "❌ --%s requires --%s",
+	"error.configuracion":   "❌ Configuration error: %v\n",
+	"error.config_invalida": "❌ Invalid configuration: %v\n",
+	"error.transmision":     "❌ Transmission error: %v\n",
+	"error.presentacion":    "❌ Presentation error: %v\n",
+	"error.calibracion":     "❌ Calibration error: %v\n",
+	"error.benchmark":       "❌ Benchmark error: %v\n",
+	"error.flood":           "❌ Flood error: %v\n",
+	"error.modo":            "❌ Invalid mode: %s (use 'manual', 'benchmark' or 'flood')\n",
+	"error.exports":         "❌ Error %v\n",
+	"error.cerrar_exports":  "❌ Error closing exports: %v\n",
+	"error.bundle":          "❌ Error writing the bundle: %v\n",
+	"error.diagnostico":     "   Diagnosis: %s\n",
+
+	"resumen.titulo":            "\n📊 Benchmark Summary:\n",
+	"resumen.total":             "   Total: %d transmissions\n",
+	"resumen.exitosas":          "   Successful: %d (%.1f%%)\n",
+	"resumen.fallidas":          "   Failed: %d (%.1f%%)\n",
+	"resumen.tiempo_total":      "   Total time: %v\n",
+	"resumen.tiempo_promedio":   "   Average time per transmission: %v\n",
+	"resumen.conexiones":        "   Connections: %d (reconnections: %d)\n",
+	"resumen.dial":              "   Average dial latency: %v\n",
+	"resumen.tls":               "   Average TLS handshake: %v (%d/%d sessions resumed)\n",
+	"resumen.variantes_hamming": "   Hamming variants (auto): %s\n",
+	"resumen.secded":            "SEC-DED blocks: %d corrected, %d detected but not corrected",
+	"resumen.correccion":        "%s correction: %d/%d blocks (%.1f%%), %d/%d complete payloads (%.1f%%)",
+	"resumen.rs":                "Reed-Solomon: %d bytes corrected in %d blocks, %d unrecoverable blocks",
+	"resumen.overhead":          "Overhead: header %d B, subheader %d B, checksum %d B, padding %d bits; code rate %.3f (expansion x%.2f), efficiency %.3f",
+	"resumen.veredictos":        "Receiver: %d ACK, %d NACK, %d without reply",
+	"resumen.plazo":             "   Deadline per message: %v\n",
+	"resumen.puntualidad":       "     On time: %d, late: %d, failed: %d, expired before sending: %d\n",
+	"resumen.retraso":           "     Delay of the late ones: min %v, p50 %v, p95 %v, max %v\n",
+
+	"analisis.titulo":        "📊 Benchmark Analysis:",
+	"analisis.configuracion": "Configuration: %s, BER=%.3f, %d iterations\n",
+	"analisis.duracion":      "Target duration: %v (completed iterations: %d)\n",
+	"analisis.tasa":          "Success rate: %.2f%% (%d/%d)\n",
+	"analisis.tiempo":        "Total time: %v (average: %v per transmission)\n",
+	"analisis.errores":       "Average errors per transmission: %.1f\n",
+	"analisis.ber":           "Average BER: %.4f (target: %.4f)\n",
+	"analisis.sin_teoria":    "⚠️  No theoretical comparison: %v\n",
+	"analisis.pista_exports": "💡 For a more detailed analysis export with --iterations-csv, --theory-csv, --error-png or --hook-ndjson",
+
+	"calibracion.titulo":     "📏 BER calibration:",
+	"calibracion.no_aplica":  "   Not applicable (no bit went through the noisy channel)",
+	"calibracion.ok":         "✅ OK",
+	"calibracion.fuera":      "❌ OUT OF TOLERANCE",
+	"calibracion.bits":       "   Bits transmitted: %d, bits flipped: %d\n",
+	"calibracion.observado":  "   Observed BER: %.6f (95%% CI: %.6f - %.6f)\n",
+	"calibracion.objetivo":   "   Target BER: %.6f (relative deviation: %+.2f%%)\n",
+	"calibracion.tolerancia": "   Tolerance: ±%.1f%% → %s\n",
+	"calibracion.sesgo":      "   ⚠️  The target falls outside the confidence interval: possible generator bias",
+
+	"watchdog.abortado":             "🛑 Benchmark aborted by the watchdog after %d iterations\n",
+	"watchdog.motivo.transporte":    "   Reason: transport failure",
+	"watchdog.motivo.procesamiento": "   Reason: processing failure",
+	"watchdog.motivo.mixto":         "   Reason: transport and processing failure",
+	"watchdog.ultimo_error":         "   Last error: %s\n",
+	"watchdog.pista":                "   (use --no-watchdog to run anyway)",
+	"watchdog.procesamiento":        "the message could not be encoded; check the configured algorithm and text",
+	"watchdog.mixto":                "mixed failures; check the message and receiver configuration",
+
+	"diagnostico.sin_receptor":         "the receiver is not listening; check --ws-url or start the receiver",
+	"diagnostico.dns":                  "the --ws-url host does not exist",
+	"diagnostico.handshake":            "the server does not accept WebSocket on that path; check --ws-url",
+	"diagnostico.timeout":              "the receiver does not answer in time; check the network or the receiver load",
+	"diagnostico.nack":                 "the receiver rejects the frames (NACK); check that it expects the same algorithm and --checksum",
+	"diagnostico.sin_respuesta":        "the receiver does not answer ACK/NACK; drop --await-reply if it does not implement them",
+	"diagnostico.transporte":           "every transmission failed; check --ws-url and the receiver status",
+	"diagnostico.trama.checksum":       "the content does not match the frame's %s: it was altered while copying or built with another --checksum",
+	"diagnostico.trama.corta":          "the frame is too short for the header and checksum; check that it was copied in full",
+	"diagnostico.trama.truncada":       "bytes are missing at the end of the frame; check that it was copied in full",
+	"diagnostico.trama.largo":          "the header length (bytes 2 and 3) does not match the payload; there are extra bytes or the header was altered",
+	"diagnostico.trama.tipo_checksum":  "bits 4-6 of the type do not match any --checksum algorithm",
+	"diagnostico.trama.payload_grande": "the message does not fit in a frame (65535 payload bytes); use --max-fragment to send it in fragments",
+	"diagnostico.trama.bit_invalido":   "the input has bits other than 0 and 1",
+
+	"flood.titulo":     "📊 Flood Result:",
+	"flood.conexiones": "Connections: %d\n",
+	"flood.duracion":   "Duration: %v\n",
+	"flood.enviadas":   "Frames sent: %d\n",
+	"flood.throughput": "Throughput: %.1f frames/s, %.1f bytes/s\n",
+	"flood.errores":    "Send errors: %d (%.2f%%)\n",
+
+	"export.mapa":              "🖼️  Error map exported to %s\n",
+	"export.mapa_truncadas":    "⚠️  %d map rows show only a sample of their errors (use --full-positions)\n",
+	"export.teoria":            "📈 Theoretical curve exported to %s\n",
+	"export.iteraciones":       "📈 Iterations exported to %s\n",
+	"export.historial":         "🗂️  Run recorded as %s (see: %s history)\n",
+	"export.historial_error":   "⚠️  Could not record the run in %s: %v\n",
+	"export.manifiesto":        "📄 Export manifest written to %s\n",
+	"export.error.mapa":        "exporting the error map: %v",
+	"export.error.teoria":      "exporting the theoretical curve: %v",
+	"export.error.sin_teoria":  "the benchmark has no iterations with a fixed BER and random noise",
+	"export.error.iteraciones": "exporting the iterations: %v",
+}
@@ -2,29 +2,49 @@ package noise
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
 )
 
+// ModelosSoportados lista los modelos de ruido implementados por la capa
+var ModelosSoportados = []string{"bsc", "erasure", "burst"}
+
 // NoiseLayer maneja la inyección de errores en la transmisión
 type NoiseLayer struct {
-	rng *rand.Rand
+	rng           *rand.Rand
+	muestreo      *rand.Rand // Solo para muestrear posiciones; no altera qué bits se invierten
+	maxPosiciones int
 }
 
 // NewNoiseLayer crea una nueva instancia con semilla aleatoria
 func NewNoiseLayer() *NoiseLayer {
+	seed := time.Now().UnixNano()
 	return &NoiseLayer{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:           rand.New(rand.NewSource(seed)),
+		muestreo:      rand.New(rand.NewSource(seed + 1)),
+		maxPosiciones: DefaultMaxPosiciones,
 	}
 }
 
 // NewNoiseLayerWithSeed crea una instancia con semilla específica (para tests reproducibles)
 func NewNoiseLayerWithSeed(seed int64) *NoiseLayer {
 	return &NoiseLayer{
-		rng: rand.New(rand.NewSource(seed)),
+		rng:           rand.New(rand.NewSource(seed)),
+		muestreo:      rand.New(rand.NewSource(seed + 1)),
+		maxPosiciones: DefaultMaxPosiciones,
 	}
 }
 
+// FijarMaxPosiciones cambia cuántas posiciones de error guarda AplicarRuido
+// exactas antes de pasar a una muestra; n <= 0 las guarda todas
+func (n *NoiseLayer) FijarMaxPosiciones(max int) {
+	n.maxPosiciones = max
+}
+
 // ErrorResult contiene información sobre los errores inyectados
 type ErrorResult struct {
 	OriginalBits   []byte  // Bits originales
@@ -33,6 +53,26 @@ type ErrorResult struct {
 	TotalBits      int     // Total de bits procesados
 	ErrorsInjected int     // Cantidad de errores inyectados
 	ActualBER      float64 // BER real obtenido
+	// ErasurePositions son las posiciones marcadas como borradas por el canal
+	// de borrado (nil en el canal binario simétrico). Un borrado solo cuenta en
+	// ErrorPositions si el valor de relleno difiere del bit original.
+	ErasurePositions []int
+	// ErasuresInjected es la cantidad total de borrados y BorradosTruncados
+	// indica que superó el límite de FijarMaxPosiciones: ErasurePositions es
+	// entonces una muestra ordenada y los decodificadores no deben tomarla
+	// como la máscara completa
+	ErasuresInjected  int
+	BorradosTruncados bool
+	// PosicionesTruncadas indica que hubo más errores que el límite de
+	// FijarMaxPosiciones: ErrorPositions es entonces una muestra uniforme
+	// ordenada de ese tamaño, ErrorsInjected sigue siendo el total y Resumen
+	// describe todas las posiciones
+	PosicionesTruncadas bool
+	Resumen             *ResumenPosiciones
+	// OriginalBitset y NoisyBitset son los bits de AplicarRuidoBitset, que
+	// deja OriginalBits y NoisyBits en nil
+	OriginalBitset *frame.Bitset
+	NoisyBitset    *frame.Bitset
 }
 
 // AplicarRuido inyecta errores de bit con la probabilidad BER especificada
@@ -52,34 +92,84 @@ func (n *NoiseLayer) AplicarRuido(bits []byte, ber float64) (*ErrorResult, error
 	noisyBits := make([]byte, len(bits))
 	copy(noisyBits, bits)
 
-	var errorPositions []int
+	// La muestra de posiciones usa un generador aparte para que el límite no
+	// altere qué bits se invierten
+	posiciones := acumuladorPosiciones{max: n.maxPosiciones, rng: n.muestreo}
 
 	// Aplicar ruido bit por bit
 	for i := 0; i < len(noisyBits); i++ {
 		if n.rng.Float64() < ber {
 			// Inyectar error: flip del bit
 			noisyBits[i] = 1 - noisyBits[i]
-			errorPositions = append(errorPositions, i)
+			posiciones.agregar(i)
 		}
 	}
 
+	errorPositions, resumen := posiciones.resultado()
+
 	// Calcular BER real obtenido
-	actualBER := float64(len(errorPositions)) / float64(len(bits))
+	actualBER := float64(posiciones.total) / float64(len(bits))
 
 	result := &ErrorResult{
-		OriginalBits:   bits,
-		NoisyBits:      noisyBits,
-		ErrorPositions: errorPositions,
-		TotalBits:      len(bits),
-		ErrorsInjected: len(errorPositions),
-		ActualBER:      actualBER,
+		OriginalBits:        bits,
+		NoisyBits:           noisyBits,
+		ErrorPositions:      errorPositions,
+		TotalBits:           len(bits),
+		ErrorsInjected:      posiciones.total,
+		ActualBER:           actualBER,
+		PosicionesTruncadas: resumen != nil,
+		Resumen:             resumen,
 	}
 
 	return result, nil
 }
 
+// AplicarRuidoBitset es AplicarRuido sobre un Bitset: con la misma semilla
+// invierte los mismos bits y reporta las mismas posiciones, pero la copia
+// ruidosa ocupa la octava parte
+func (n *NoiseLayer) AplicarRuidoBitset(bits *frame.Bitset, ber float64) (*ErrorResult, error) {
+	if ber < 0.0 || ber > 1.0 {
+		return nil, fmt.Errorf("BER inválido: %.3f (debe estar entre 0.0 y 1.0)", ber)
+	}
+
+	noisy := bits.Clone()
+	posiciones := acumuladorPosiciones{max: n.maxPosiciones, rng: n.muestreo}
+	for i := 0; i < noisy.Len(); i++ {
+		if n.rng.Float64() < ber {
+			noisy.Flip(i)
+			posiciones.agregar(i)
+		}
+	}
+
+	errorPositions, resumen := posiciones.resultado()
+	return &ErrorResult{
+		OriginalBitset:      bits,
+		NoisyBitset:         noisy,
+		ErrorPositions:      errorPositions,
+		TotalBits:           bits.Len(),
+		ErrorsInjected:      posiciones.total,
+		ActualBER:           float64(posiciones.total) / float64(bits.Len()),
+		PosicionesTruncadas: resumen != nil,
+		Resumen:             resumen,
+	}, nil
+}
+
 // SimularCanalRuidoso simula múltiples transmisiones para análisis estadístico
 func (n *NoiseLayer) SimularCanalRuidoso(bits []byte, ber float64, iteraciones int) (*ChannelStats, error) {
+	return n.simular(bits, ber, iteraciones, func() (*ErrorResult, error) {
+		return n.AplicarRuido(bits, ber)
+	})
+}
+
+// SimularCanalConBorrado es SimularCanalRuidoso sobre el canal de borrado de
+// AplicarBorrado; TargetBER toma la probabilidad de borrado
+func (n *NoiseLayer) SimularCanalConBorrado(bits []byte, probBorrado float64, relleno byte, iteraciones int) (*ChannelStats, error) {
+	return n.simular(bits, probBorrado, iteraciones, func() (*ErrorResult, error) {
+		return n.AplicarBorrado(bits, probBorrado, relleno)
+	})
+}
+
+func (n *NoiseLayer) simular(bits []byte, ber float64, iteraciones int, aplicar func() (*ErrorResult, error)) (*ChannelStats, error) {
 	if iteraciones <= 0 {
 		return nil, fmt.Errorf("iteraciones debe ser mayor a 0: %d", iteraciones)
 	}
@@ -92,16 +182,19 @@ func (n *NoiseLayer) SimularCanalRuidoso(bits []byte, ber float64, iteraciones i
 	}
 
 	var totalErrors int
-	var berValues []float64
+	berValues := make([]float64, 0, iteraciones)
+	errores := make([]int, 0, iteraciones)
 
 	for i := 0; i < iteraciones; i++ {
-		result, err := n.AplicarRuido(bits, ber)
+		result, err := aplicar()
 		if err != nil {
 			return nil, fmt.Errorf("error en iteración %d: %v", i, err)
 		}
 
 		totalErrors += result.ErrorsInjected
+		stats.TotalErasures += result.ErasuresInjected
 		berValues = append(berValues, result.ActualBER)
+		errores = append(errores, result.ErrorsInjected)
 
 		// Actualizar distribución de errores
 		stats.ErrorDistribution[result.ErrorsInjected]++
@@ -128,6 +221,8 @@ func (n *NoiseLayer) SimularCanalRuidoso(bits []byte, ber float64, iteraciones i
 	berVariance /= float64(len(berValues))
 	stats.BERVariance = berVariance
 	stats.BERStdDev = sqrt(berVariance)
+	stats.BERPorIteracion = berValues
+	stats.ErroresPorIteracion = errores
 
 	return stats, nil
 }
@@ -145,22 +240,34 @@ type ChannelStats struct {
 	MaxErrors                    int
 	MinErrors                    int
 	ErrorDistribution            map[int]int // cantidad_errores -> frecuencia
+	BERPorIteracion              []float64   // BER real de cada iteración, en orden
+	ErroresPorIteracion          []int       // Errores de cada iteración, en orden
+	TotalErasures                int         // Bits borrados en total (solo canal de borrado)
 }
 
 // MostrarEstadisticas imprime las estadísticas del canal
 func (stats *ChannelStats) MostrarEstadisticas() {
-	fmt.Println("📡 Estadísticas del Canal Ruidoso:")
-	fmt.Printf("   BER objetivo: %.4f (%.2f%%)\n", stats.TargetBER, stats.TargetBER*100)
-	fmt.Printf("   BER promedio: %.4f (%.2f%%)\n", stats.AverageBER, stats.AverageBER*100)
-	fmt.Printf("   Desviación std BER: %.4f\n", stats.BERStdDev)
-	fmt.Printf("   Iteraciones: %d\n", stats.Iterations)
-	fmt.Printf("   Total de bits: %d\n", stats.TotalBits)
-	fmt.Printf("   Total de errores: %d\n", stats.TotalErrors)
-	fmt.Printf("   Errores promedio por transmisión: %.1f\n", stats.AverageErrorsPerTransmission)
-	fmt.Printf("   Rango de errores: %d - %d\n", stats.MinErrors, stats.MaxErrors)
+	stats.EscribirEstadisticas(os.Stdout)
+}
+
+// EscribirEstadisticas escribe las estadísticas del canal en w
+func (stats *ChannelStats) EscribirEstadisticas(w io.Writer) {
+	fmt.Fprintln(w, "📡 Estadísticas del Canal Ruidoso:")
+	fmt.Fprintf(w, "   BER objetivo: %.4f (%.2f%%)\n", stats.TargetBER, stats.TargetBER*100)
+	fmt.Fprintf(w, "   BER promedio: %.4f (%.2f%%)\n", stats.AverageBER, stats.AverageBER*100)
+	fmt.Fprintf(w, "   Desviación std BER: %.4f\n", stats.BERStdDev)
+	fmt.Fprintf(w, "   Iteraciones: %d\n", stats.Iterations)
+	fmt.Fprintf(w, "   Total de bits: %d\n", stats.TotalBits)
+	fmt.Fprintf(w, "   Total de errores: %d\n", stats.TotalErrors)
+	fmt.Fprintf(w, "   Errores promedio por transmisión: %.1f\n", stats.AverageErrorsPerTransmission)
+	fmt.Fprintf(w, "   Rango de errores: %d - %d\n", stats.MinErrors, stats.MaxErrors)
+	if stats.TotalErasures > 0 {
+		fmt.Fprintf(w, "   Bits borrados: %d (%.4f por bit; los errores son los borrados que cambiaron el bit)\n",
+			stats.TotalErasures, float64(stats.TotalErasures)/float64(stats.TotalBits))
+	}
 
 	// Mostrar distribución de errores (top 5)
-	fmt.Println("   Distribución de errores (top 5):")
+	fmt.Fprintln(w, "   Distribución de errores (top 5):")
 	type errorCount struct {
 		errors int
 		count  int
@@ -170,10 +277,12 @@ func (stats *ChannelStats) MostrarEstadisticas() {
 		distribution = append(distribution, errorCount{errors, count})
 	}
 
-	// Ordenar por frecuencia (simple bubble sort para pocos elementos)
+	// Ordenar por frecuencia (simple bubble sort para pocos elementos); los
+	// empates se ordenan por cantidad de errores para que la salida sea estable
 	for i := 0; i < len(distribution); i++ {
 		for j := i + 1; j < len(distribution); j++ {
-			if distribution[i].count < distribution[j].count {
+			if distribution[i].count < distribution[j].count ||
+				distribution[i].count == distribution[j].count && distribution[i].errors > distribution[j].errors {
 				distribution[i], distribution[j] = distribution[j], distribution[i]
 			}
 		}
@@ -186,10 +295,10 @@ func (stats *ChannelStats) MostrarEstadisticas() {
 
 	for i := 0; i < limit; i++ {
 		percentage := float64(distribution[i].count) / float64(stats.Iterations) * 100
-		fmt.Printf("     %d errores: %d veces (%.1f%%)\n",
+		fmt.Fprintf(w, "     %d errores: %d veces (%.1f%%)\n",
 			distribution[i].errors, distribution[i].count, percentage)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // ValidarConfiguracion valida los parámetros de ruido
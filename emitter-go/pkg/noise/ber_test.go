@@ -0,0 +1,253 @@
+package noise
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestNoiseLayer_AplicarRuido(t *testing.T) {
+	n := NewNoiseLayerWithSeed(12345) // Semilla fija para tests reproducibles
+
+	tests := []struct {
+		name    string
+		bits    []byte
+		ber     float64
+		wantErr bool
+	}{
+		{
+			name: "zero BER",
+			bits: []byte{0, 1, 0, 1, 1, 0, 1, 0},
+			ber:  0.0,
+		},
+		{
+			name: "low BER",
+			bits: []byte{0, 1, 0, 1, 1, 0, 1, 0},
+			ber:  0.01,
+		},
+		{
+			name: "high BER",
+			bits: []byte{0, 1, 0, 1},
+			ber:  0.5,
+		},
+		{
+			name:    "invalid BER - negative",
+			bits:    []byte{0, 1},
+			ber:     -0.1,
+			wantErr: true,
+		},
+		{
+			name:    "invalid BER - too high",
+			bits:    []byte{0, 1},
+			ber:     1.5,
+			wantErr: true,
+		},
+		{
+			name:    "invalid bits",
+			bits:    []byte{0, 1, 2, 1}, // Contains '2'
+			ber:     0.01,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := n.AplicarRuido(tt.bits, tt.ber)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AplicarRuido() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				// Verificar que el resultado tiene la estructura correcta
+				if len(result.OriginalBits) != len(tt.bits) {
+					t.Errorf("OriginalBits length = %d, want %d", len(result.OriginalBits), len(tt.bits))
+				}
+				if len(result.NoisyBits) != len(tt.bits) {
+					t.Errorf("NoisyBits length = %d, want %d", len(result.NoisyBits), len(tt.bits))
+				}
+				if result.TotalBits != len(tt.bits) {
+					t.Errorf("TotalBits = %d, want %d", result.TotalBits, len(tt.bits))
+				}
+				if result.ErrorsInjected != len(result.ErrorPositions) {
+					t.Errorf("ErrorsInjected = %d, but ErrorPositions length = %d",
+						result.ErrorsInjected, len(result.ErrorPositions))
+				}
+
+				// Para BER=0, no debe haber errores
+				if tt.ber == 0.0 && result.ErrorsInjected != 0 {
+					t.Errorf("With BER=0, expected 0 errors, got %d", result.ErrorsInjected)
+				}
+
+				// Verificar que los bits son válidos
+				for i, bit := range result.NoisyBits {
+					if bit != 0 && bit != 1 {
+						t.Errorf("Invalid bit at position %d: %d", i, bit)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNoiseLayer_ValidarConfiguracion(t *testing.T) {
+	n := NewNoiseLayer()
+
+	tests := []struct {
+		name    string
+		ber     float64
+		bits    []byte
+		wantErr bool
+	}{
+		{
+			name: "valid config",
+			ber:  0.01,
+			bits: []byte{0, 1, 0, 1},
+		},
+		{
+			name:    "invalid BER",
+			ber:     -0.1,
+			bits:    []byte{0, 1},
+			wantErr: true,
+		},
+		{
+			name:    "empty bits",
+			ber:     0.01,
+			bits:    []byte{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid bits",
+			ber:     0.01,
+			bits:    []byte{0, 1, 3},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := n.ValidarConfiguracion(tt.ber, tt.bits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidarConfiguracion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNoiseLayer_ConsistentSeed(t *testing.T) {
+	seed := int64(12345)
+	bits := []byte{0, 1, 0, 1, 1, 0, 1, 0, 1, 1, 0, 0, 1, 0, 1, 1}
+	ber := 0.2
+
+	// Crear dos instancias con la misma semilla
+	n1 := NewNoiseLayerWithSeed(seed)
+	n2 := NewNoiseLayerWithSeed(seed)
+
+	// Aplicar ruido con ambas instancias
+	result1, err1 := n1.AplicarRuido(bits, ber)
+	if err1 != nil {
+		t.Fatalf("First AplicarRuido failed: %v", err1)
+	}
+
+	result2, err2 := n2.AplicarRuido(bits, ber)
+	if err2 != nil {
+		t.Fatalf("Second AplicarRuido failed: %v", err2)
+	}
+
+	// Los resultados deben ser idénticos
+	if result1.ErrorsInjected != result2.ErrorsInjected {
+		t.Errorf("ErrorsInjected differ: %d vs %d", result1.ErrorsInjected, result2.ErrorsInjected)
+	}
+
+	if len(result1.ErrorPositions) != len(result2.ErrorPositions) {
+		t.Errorf("ErrorPositions length differ: %d vs %d",
+			len(result1.ErrorPositions), len(result2.ErrorPositions))
+	}
+
+	for i, pos := range result1.ErrorPositions {
+		if pos != result2.ErrorPositions[i] {
+			t.Errorf("ErrorPosition[%d] differ: %d vs %d", i, pos, result2.ErrorPositions[i])
+		}
+	}
+}
+
+// Benchmark para evaluar performance
+func BenchmarkNoiseLayer_AplicarRuido(b *testing.B) {
+	n := NewNoiseLayer()
+	bits := make([]byte, 1000) // 1KB de bits
+	for i := range bits {
+		bits[i] = byte(i % 2) // Patrón alternante
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := n.AplicarRuido(bits, 0.01)
+		if err != nil {
+			b.Fatalf("AplicarRuido failed: %v", err)
+		}
+	}
+}
+
+func TestNoiseLayer_AplicarRuidoBitsetIgualQueAplicarRuido(t *testing.T) {
+	data := bytes.Repeat([]byte("Hola mundo"), 40)
+	for _, maxPos := range []int{0, 10} {
+		a := NewNoiseLayerWithSeed(42)
+		b := NewNoiseLayerWithSeed(42)
+		a.FijarMaxPosiciones(maxPos)
+		b.FijarMaxPosiciones(maxPos)
+
+		want, err := a.AplicarRuido(frame.BytesToBits(data), 0.05)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := b.AplicarRuidoBitset(frame.BitsetFromBytes(data), 0.05)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.NoisyBitset.Bits(), want.NoisyBits) {
+			t.Errorf("max %d: los bits ruidosos difieren", maxPos)
+		}
+		if got.ErrorsInjected != want.ErrorsInjected || got.TotalBits != want.TotalBits || got.ActualBER != want.ActualBER ||
+			got.PosicionesTruncadas != want.PosicionesTruncadas {
+			t.Errorf("max %d: resultado %+v, se esperaba %+v", maxPos, got, want)
+		}
+		if len(got.ErrorPositions) != len(want.ErrorPositions) {
+			t.Fatalf("max %d: %d posiciones, se esperaban %d", maxPos, len(got.ErrorPositions), len(want.ErrorPositions))
+		}
+		for i := range want.ErrorPositions {
+			if got.ErrorPositions[i] != want.ErrorPositions[i] {
+				t.Fatalf("max %d: posiciones %v, se esperaban %v", maxPos, got.ErrorPositions, want.ErrorPositions)
+			}
+		}
+		if !bytes.Equal(got.OriginalBitset.Bytes(), data) {
+			t.Errorf("max %d: el ruido modificó los bits originales", maxPos)
+		}
+	}
+	if _, err := NewNoiseLayerWithSeed(1).AplicarRuidoBitset(frame.NewBitset(8), 1.5); err == nil {
+		t.Error("se esperaba error con BER fuera de rango")
+	}
+}
+
+// payloadGrande son 64 KB, el caso donde un byte por bit más pesa
+var payloadGrande = bytes.Repeat([]byte("ruido!! "), 8192)
+
+func BenchmarkNoiseLayer_AplicarRuidoSlice64KB(b *testing.B) {
+	n := NewNoiseLayerWithSeed(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := n.AplicarRuido(frame.BytesToBits(payloadGrande), 0.001); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNoiseLayer_AplicarRuidoBitset64KB(b *testing.B) {
+	n := NewNoiseLayerWithSeed(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := n.AplicarRuidoBitset(frame.BitsetFromBytes(payloadGrande), 0.001); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package noise
+
+import "fmt"
+
+// AplicarBorrado simula un canal de borrado: cada bit se marca como borrado
+// con probabilidad probBorrado y se registra en ErasurePositions. Los
+// decodificadores sin soporte de borrados (CRC, Hamming) ven el bit borrado
+// reemplazado por relleno, así que solo es un error cuando el original era
+// distinto del relleno. Con más borrados que el límite de
+// FijarMaxPosiciones, ErasurePositions es solo una muestra y no sirve como
+// máscara para un decodificador de borrados (ver BorradosTruncados).
+func (n *NoiseLayer) AplicarBorrado(bits []byte, probBorrado float64, relleno byte) (*ErrorResult, error) {
+	if probBorrado < 0.0 || probBorrado > 1.0 {
+		return nil, fmt.Errorf("probabilidad de borrado inválida: %.3f (debe estar entre 0.0 y 1.0)", probBorrado)
+	}
+	if relleno != 0 && relleno != 1 {
+		return nil, fmt.Errorf("valor de relleno inválido: %d (debe ser 0 o 1)", relleno)
+	}
+	for i, bit := range bits {
+		if bit != 0 && bit != 1 {
+			return nil, fmt.Errorf("bit inválido en posición %d: %d (debe ser 0 o 1)", i, bit)
+		}
+	}
+
+	noisyBits := make([]byte, len(bits))
+	copy(noisyBits, bits)
+
+	// Las dos listas respetan el límite de FijarMaxPosiciones como AplicarRuido
+	borrados := acumuladorPosiciones{max: n.maxPosiciones, rng: n.muestreo}
+	errores := acumuladorPosiciones{max: n.maxPosiciones, rng: n.muestreo}
+	for i := range noisyBits {
+		if n.rng.Float64() < probBorrado {
+			borrados.agregar(i)
+			if noisyBits[i] != relleno {
+				noisyBits[i] = relleno
+				errores.agregar(i)
+			}
+		}
+	}
+
+	erasurePositions, _ := borrados.resultado()
+	errorPositions, resumen := errores.resultado()
+	result := &ErrorResult{
+		OriginalBits:        bits,
+		NoisyBits:           noisyBits,
+		ErrorPositions:      errorPositions,
+		ErasurePositions:    erasurePositions,
+		ErasuresInjected:    borrados.total,
+		BorradosTruncados:   borrados.truncado(),
+		TotalBits:           len(bits),
+		ErrorsInjected:      errores.total,
+		PosicionesTruncadas: resumen != nil,
+		Resumen:             resumen,
+	}
+	if len(bits) > 0 {
+		result.ActualBER = float64(errores.total) / float64(len(bits))
+	}
+	return result, nil
+}
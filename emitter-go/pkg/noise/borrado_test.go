@@ -0,0 +1,123 @@
+package noise
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAplicarBorrado_RellenoYPosiciones(t *testing.T) {
+	n := NewNoiseLayerWithSeed(11)
+	bits := make([]byte, 2000)
+	for i := range bits {
+		bits[i] = byte(i % 2)
+	}
+
+	result, err := n.AplicarBorrado(bits, 0.1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ErasurePositions) == 0 {
+		t.Fatal("con 2000 bits y e=0.1 debería haber borrados")
+	}
+
+	borrado := make(map[int]bool)
+	for _, p := range result.ErasurePositions {
+		borrado[p] = true
+		if result.NoisyBits[p] != 0 {
+			t.Errorf("el bit borrado %d no tiene el valor de relleno", p)
+		}
+	}
+	for _, p := range result.ErrorPositions {
+		if !borrado[p] {
+			t.Errorf("el error %d no corresponde a un borrado", p)
+		}
+		if bits[p] != 1 {
+			t.Errorf("el error %d está en un bit que ya valía el relleno", p)
+		}
+	}
+	for i := range bits {
+		if !borrado[i] && result.NoisyBits[i] != bits[i] {
+			t.Fatalf("el bit %d cambió sin estar borrado", i)
+		}
+	}
+	if result.ErrorsInjected != len(result.ErrorPositions) {
+		t.Error("ErrorsInjected debe contar solo los borrados que cambiaron el bit")
+	}
+}
+
+func TestAplicarBorrado_LimiteDePosiciones(t *testing.T) {
+	bits := make([]byte, 2000)
+	for i := range bits {
+		bits[i] = 1
+	}
+	completo := NewNoiseLayerWithSeed(12)
+	completo.FijarMaxPosiciones(0)
+	truncado := NewNoiseLayerWithSeed(12)
+	truncado.FijarMaxPosiciones(50)
+
+	a, err := completo.AplicarBorrado(bits, 0.1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := truncado.AplicarBorrado(bits, 0.1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.BorradosTruncados || a.ErasuresInjected != len(a.ErasurePositions) {
+		t.Errorf("sin límite: %d borrados, %d posiciones, truncado %v", a.ErasuresInjected, len(a.ErasurePositions), a.BorradosTruncados)
+	}
+	if !b.BorradosTruncados || len(b.ErasurePositions) != 50 || b.ErasuresInjected != a.ErasuresInjected {
+		t.Errorf("con límite 50: %d borrados, %d posiciones, truncado %v", b.ErasuresInjected, len(b.ErasurePositions), b.BorradosTruncados)
+	}
+	if !b.PosicionesTruncadas || len(b.ErrorPositions) != 50 || b.ErrorsInjected != a.ErrorsInjected {
+		t.Errorf("con límite 50: %d errores, %d posiciones", b.ErrorsInjected, len(b.ErrorPositions))
+	}
+	// El límite no cambia qué bits se borran
+	for i := range a.NoisyBits {
+		if a.NoisyBits[i] != b.NoisyBits[i] {
+			t.Fatalf("el bit %d difiere con el límite de posiciones", i)
+		}
+	}
+}
+
+func TestAplicarBorrado_Validacion(t *testing.T) {
+	n := NewNoiseLayerWithSeed(1)
+	if _, err := n.AplicarBorrado([]byte{0, 1}, 1.5, 0); err == nil {
+		t.Error("se esperaba error con probabilidad fuera de rango")
+	}
+	if _, err := n.AplicarBorrado([]byte{0, 1}, 0.1, 2); err == nil {
+		t.Error("se esperaba error con relleno inválido")
+	}
+	if _, err := n.AplicarBorrado([]byte{0, 2}, 0.1, 0); err == nil {
+		t.Error("se esperaba error con bits inválidos")
+	}
+}
+
+func TestSimularCanalConBorrado(t *testing.T) {
+	n := NewNoiseLayerWithSeed(21)
+	bits := make([]byte, 500)
+	for i := range bits {
+		bits[i] = 1
+	}
+
+	// Con todos los bits en 1 y relleno 0, cada borrado es un error
+	stats, err := n.SimularCanalConBorrado(bits, 0.05, 0, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalErasures != stats.TotalErrors {
+		t.Errorf("borrados %d != errores %d", stats.TotalErasures, stats.TotalErrors)
+	}
+	if math.Abs(stats.AverageBER-0.05) > 0.005 {
+		t.Errorf("tasa de borrado observada %.4f, se esperaba ~0.05", stats.AverageBER)
+	}
+
+	// Con relleno igual al valor de los bits ningún borrado es error
+	stats, err = n.SimularCanalConBorrado(bits, 0.05, 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalErrors != 0 || stats.TotalErasures == 0 {
+		t.Errorf("se esperaban borrados sin errores: %d borrados, %d errores", stats.TotalErasures, stats.TotalErrors)
+	}
+}
@@ -0,0 +1,84 @@
+package noise
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/i18n"
+)
+
+// zConfianza95 es el cuantil normal usado para el intervalo de confianza del 95%
+const zConfianza95 = 1.959964
+
+// CalibracionBER compara el BER agregado de toda una corrida con el objetivo
+type CalibracionBER struct {
+	TargetBER          float64
+	ObservedBER        float64
+	TotalBits          int
+	TotalErrors        int
+	IntervaloBajo      float64 // Límite inferior del intervalo de confianza 95% (Wilson)
+	IntervaloAlto      float64 // Límite superior del intervalo de confianza 95% (Wilson)
+	DesviacionRelativa float64 // (observado - objetivo) / objetivo
+	Tolerancia         float64 // Desviación relativa máxima aceptada
+	DentroIntervalo    bool    // El objetivo cae dentro del intervalo de confianza
+	Aprobado           bool    // |DesviacionRelativa| <= Tolerancia
+	Aplica             bool    // false si ningún bit pasó por el canal ruidoso
+}
+
+// CalibrarBER calcula el reporte de calibración a partir de los totales de la
+// corrida. El intervalo de Wilson se comporta bien incluso con pocos errores.
+func CalibrarBER(totalBits, totalErrors int, targetBER, tolerancia float64) *CalibracionBER {
+	c := &CalibracionBER{
+		TargetBER:   targetBER,
+		TotalBits:   totalBits,
+		TotalErrors: totalErrors,
+		Tolerancia:  tolerancia,
+	}
+	if totalBits == 0 {
+		return c
+	}
+	c.Aplica = true
+
+	n := float64(totalBits)
+	p := float64(totalErrors) / n
+	c.ObservedBER = p
+
+	z2 := zConfianza95 * zConfianza95
+	centro := (p + z2/(2*n)) / (1 + z2/n)
+	margen := zConfianza95 * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+	c.IntervaloBajo = math.Max(0, centro-margen)
+	c.IntervaloAlto = math.Min(1, centro+margen)
+	c.DentroIntervalo = targetBER >= c.IntervaloBajo && targetBER <= c.IntervaloAlto
+
+	if targetBER > 0 {
+		c.DesviacionRelativa = (p - targetBER) / targetBER
+		c.Aprobado = math.Abs(c.DesviacionRelativa) <= tolerancia
+	} else {
+		// Con BER objetivo 0 cualquier error es una desviación
+		c.Aprobado = totalErrors == 0
+	}
+	return c
+}
+
+// EscribirReporte escribe la sección de calibración del resumen en w, en el
+// idioma de msgs (nil usa el idioma por defecto)
+func (c *CalibracionBER) EscribirReporte(w io.Writer, msgs *i18n.Catalogo) {
+	fmt.Fprintln(w, msgs.T("calibracion.titulo"))
+	if !c.Aplica {
+		fmt.Fprintln(w, msgs.T("calibracion.no_aplica"))
+		return
+	}
+
+	estado := msgs.T("calibracion.ok")
+	if !c.Aprobado {
+		estado = msgs.T("calibracion.fuera")
+	}
+	fmt.Fprint(w, msgs.T("calibracion.bits", c.TotalBits, c.TotalErrors))
+	fmt.Fprint(w, msgs.T("calibracion.observado", c.ObservedBER, c.IntervaloBajo, c.IntervaloAlto))
+	fmt.Fprint(w, msgs.T("calibracion.objetivo", c.TargetBER, c.DesviacionRelativa*100))
+	fmt.Fprint(w, msgs.T("calibracion.tolerancia", c.Tolerancia*100, estado))
+	if !c.DentroIntervalo {
+		fmt.Fprintln(w, msgs.T("calibracion.sesgo"))
+	}
+}
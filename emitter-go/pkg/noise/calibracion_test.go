@@ -0,0 +1,88 @@
+package noise
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCalibrarBER_Totales(t *testing.T) {
+	c := CalibrarBER(10000, 110, 0.01, 0.15)
+
+	if !c.Aplica {
+		t.Fatal("la calibración debería aplicar con bits transmitidos")
+	}
+	if c.ObservedBER != 0.011 {
+		t.Errorf("ObservedBER = %v, se esperaba 0.011", c.ObservedBER)
+	}
+	if math.Abs(c.DesviacionRelativa-0.1) > 1e-9 {
+		t.Errorf("DesviacionRelativa = %v, se esperaba 0.1", c.DesviacionRelativa)
+	}
+	if !c.Aprobado {
+		t.Error("una desviación del 10% debería aprobar con tolerancia 15%")
+	}
+	if !(c.IntervaloBajo < c.ObservedBER && c.ObservedBER < c.IntervaloAlto) {
+		t.Errorf("el BER observado debería estar dentro del intervalo [%v, %v]", c.IntervaloBajo, c.IntervaloAlto)
+	}
+	if !c.DentroIntervalo {
+		t.Error("el objetivo 0.01 debería caer dentro del intervalo")
+	}
+}
+
+func TestCalibrarBER_FueraDeTolerancia(t *testing.T) {
+	c := CalibrarBER(100000, 2000, 0.01, 0.1)
+	if c.Aprobado {
+		t.Errorf("una desviación del 100%% no debería aprobar: %+v", c)
+	}
+	if c.DentroIntervalo {
+		t.Error("con 100000 bits el objetivo no debería caer dentro del intervalo")
+	}
+}
+
+func TestCalibrarBER_NoAplica(t *testing.T) {
+	c := CalibrarBER(0, 0, 0.01, 0.1)
+	if c.Aplica {
+		t.Error("sin bits transmitidos la calibración no aplica")
+	}
+
+	var out bytes.Buffer
+	c.EscribirReporte(&out, nil)
+	if !strings.Contains(out.String(), "No aplica") {
+		t.Errorf("el reporte debería indicar que no aplica: %q", out.String())
+	}
+}
+
+func TestCalibrarBER_ObjetivoCero(t *testing.T) {
+	if c := CalibrarBER(1000, 0, 0, 0.1); !c.Aprobado {
+		t.Error("BER objetivo 0 sin errores debería aprobar")
+	}
+	if c := CalibrarBER(1000, 1, 0, 0.1); c.Aprobado {
+		t.Error("BER objetivo 0 con errores no debería aprobar")
+	}
+}
+
+func TestCalibrarBER_ConvergeConSemilla(t *testing.T) {
+	n := NewNoiseLayerWithSeed(2024)
+	bits := make([]byte, 1000)
+	const ber = 0.02
+
+	var totalBits, totalErrors int
+	for i := 0; i < 500; i++ {
+		result, err := n.AplicarRuido(bits, ber)
+		if err != nil {
+			t.Fatal(err)
+		}
+		totalBits += result.TotalBits
+		totalErrors += result.ErrorsInjected
+	}
+
+	c := CalibrarBER(totalBits, totalErrors, ber, 0.05)
+	if !c.DentroIntervalo {
+		t.Errorf("el objetivo %v debería caer en el IC [%v, %v] con %d bits",
+			ber, c.IntervaloBajo, c.IntervaloAlto, totalBits)
+	}
+	if !c.Aprobado {
+		t.Errorf("la desviación relativa %.4f excede la tolerancia", c.DesviacionRelativa)
+	}
+}
@@ -0,0 +1,19 @@
+package noise_test
+
+import (
+	"fmt"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/noise"
+)
+
+func ExampleNoiseLayer_AplicarRuido() {
+	// Con semilla fija las posiciones invertidas son siempre las mismas
+	layer := noise.NewNoiseLayerWithSeed(42)
+	result, err := layer.AplicarRuido(make([]byte, 64), 0.05)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(result.ErrorsInjected, result.ErrorPositions)
+	// Output:
+	// 3 [4 25 58]
+}
@@ -0,0 +1,125 @@
+package noise
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DirectivaInyeccion pide invertir exactamente Bits bits dentro del bloque
+// Hamming número Bloque (contando desde 1, como en emitter_hamming)
+type DirectivaInyeccion struct {
+	Bloque int
+	Bits   int
+}
+
+// String devuelve la directiva en el mismo formato que acepta ParseInyeccion
+func (d DirectivaInyeccion) String() string {
+	return fmt.Sprintf("block=%d:bits=%d", d.Bloque, d.Bits)
+}
+
+// ParseInyeccion interpreta directivas con el formato
+// "block=3:bits=2;block=5:bits=1"
+func ParseInyeccion(spec string) ([]DirectivaInyeccion, error) {
+	var directivas []DirectivaInyeccion
+	vistos := make(map[int]bool)
+
+	for _, parte := range strings.Split(spec, ";") {
+		parte = strings.TrimSpace(parte)
+		if parte == "" {
+			continue
+		}
+
+		var d DirectivaInyeccion
+		var tieneBloque, tieneBits bool
+		for _, campo := range strings.Split(parte, ":") {
+			clave, valor, ok := strings.Cut(campo, "=")
+			if !ok {
+				return nil, fmt.Errorf("directiva inválida %q: se esperaba clave=valor", parte)
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(valor))
+			if err != nil {
+				return nil, fmt.Errorf("directiva inválida %q: %q no es un número", parte, valor)
+			}
+			switch strings.TrimSpace(clave) {
+			case "block":
+				d.Bloque, tieneBloque = n, true
+			case "bits":
+				d.Bits, tieneBits = n, true
+			default:
+				return nil, fmt.Errorf("directiva inválida %q: clave desconocida %q", parte, clave)
+			}
+		}
+		if !tieneBloque || !tieneBits {
+			return nil, fmt.Errorf("directiva inválida %q: requiere block y bits", parte)
+		}
+		if d.Bloque < 1 {
+			return nil, fmt.Errorf("bloque inválido en %q: debe ser mayor o igual a 1", parte)
+		}
+		if d.Bits < 1 {
+			return nil, fmt.Errorf("bits inválido en %q: debe ser mayor o igual a 1", parte)
+		}
+		if vistos[d.Bloque] {
+			return nil, fmt.Errorf("el bloque %d aparece más de una vez", d.Bloque)
+		}
+		vistos[d.Bloque] = true
+		directivas = append(directivas, d)
+	}
+
+	if len(directivas) == 0 {
+		return nil, fmt.Errorf("no se indicó ninguna directiva de inyección")
+	}
+	return directivas, nil
+}
+
+// AplicarInyeccion invierte bits dentro de bloques específicos en lugar de
+// usar un BER. offset es la posición del primer bit del primer bloque dentro
+// de bits, tamBloque el largo de cada bloque y numBloques cuántos bloques hay.
+// Las posiciones dentro de cada bloque se eligen con el generador de la capa,
+// así que son reproducibles con semilla fija.
+func (n *NoiseLayer) AplicarInyeccion(bits []byte, directivas []DirectivaInyeccion, offset, tamBloque, numBloques int) (*ErrorResult, error) {
+	if offset < 0 || tamBloque <= 0 || offset+numBloques*tamBloque > len(bits) {
+		return nil, fmt.Errorf("layout inválido: %d bloques de %d bits desde %d exceden %d bits",
+			numBloques, tamBloque, offset, len(bits))
+	}
+	for i, bit := range bits {
+		if bit != 0 && bit != 1 {
+			return nil, fmt.Errorf("bit inválido en posición %d: %d (debe ser 0 o 1)", i, bit)
+		}
+	}
+	for _, d := range directivas {
+		if d.Bloque < 1 || d.Bloque > numBloques {
+			return nil, fmt.Errorf("bloque %d fuera de rango (1-%d)", d.Bloque, numBloques)
+		}
+		if d.Bits < 1 || d.Bits > tamBloque {
+			return nil, fmt.Errorf("no se pueden invertir %d bits en un bloque de %d", d.Bits, tamBloque)
+		}
+	}
+
+	noisyBits := make([]byte, len(bits))
+	copy(noisyBits, bits)
+
+	var errorPositions []int
+	for _, d := range directivas {
+		inicio := offset + (d.Bloque-1)*tamBloque
+		for _, k := range n.rng.Perm(tamBloque)[:d.Bits] {
+			pos := inicio + k
+			noisyBits[pos] = 1 - noisyBits[pos]
+			errorPositions = append(errorPositions, pos)
+		}
+	}
+	sort.Ints(errorPositions)
+
+	result := &ErrorResult{
+		OriginalBits:   bits,
+		NoisyBits:      noisyBits,
+		ErrorPositions: errorPositions,
+		TotalBits:      len(bits),
+		ErrorsInjected: len(errorPositions),
+	}
+	if len(bits) > 0 {
+		result.ActualBER = float64(len(errorPositions)) / float64(len(bits))
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package noise
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+)
+
+func TestParseInyeccion(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []DirectivaInyeccion
+		wantErr bool
+	}{
+		{
+			name: "dos bloques",
+			spec: "block=3:bits=2;block=5:bits=1",
+			want: []DirectivaInyeccion{{Bloque: 3, Bits: 2}, {Bloque: 5, Bits: 1}},
+		},
+		{
+			name: "orden de campos y espacios",
+			spec: " bits=1 : block=2 ; ",
+			want: []DirectivaInyeccion{{Bloque: 2, Bits: 1}},
+		},
+		{name: "vacío", spec: "", wantErr: true},
+		{name: "sin bits", spec: "block=1", wantErr: true},
+		{name: "clave desconocida", spec: "block=1:bitz=1", wantErr: true},
+		{name: "no numérico", spec: "block=a:bits=1", wantErr: true},
+		{name: "bloque cero", spec: "block=0:bits=1", wantErr: true},
+		{name: "bits cero", spec: "block=1:bits=0", wantErr: true},
+		{name: "bloque repetido", spec: "block=1:bits=1;block=1:bits=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInyeccion(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInyeccion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInyeccion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAplicarInyeccion_PosicionesDirigidas(t *testing.T) {
+	n := NewNoiseLayerWithSeed(99)
+	const offset, tam, bloques = 24, 7, 6
+	bits := make([]byte, offset+tam*bloques+32)
+
+	directivas := []DirectivaInyeccion{{Bloque: 3, Bits: 2}, {Bloque: 5, Bits: 1}}
+	result, err := n.AplicarInyeccion(bits, directivas, offset, tam, bloques)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if result.ErrorsInjected != 3 {
+		t.Fatalf("se esperaban 3 errores, se obtuvieron %d", result.ErrorsInjected)
+	}
+
+	porBloque := make(map[int]int)
+	for _, pos := range result.ErrorPositions {
+		if pos < offset || pos >= offset+tam*bloques {
+			t.Errorf("posición %d fuera de la región de bloques", pos)
+			continue
+		}
+		porBloque[(pos-offset)/tam+1]++
+		if result.NoisyBits[pos] != 1 {
+			t.Errorf("el bit %d no fue invertido", pos)
+		}
+	}
+	if !reflect.DeepEqual(porBloque, map[int]int{3: 2, 5: 1}) {
+		t.Errorf("errores por bloque = %v, se esperaba map[3:2 5:1]", porBloque)
+	}
+	for i := range bits {
+		if bits[i] != 0 {
+			t.Fatal("AplicarInyeccion no debe modificar la entrada")
+		}
+	}
+}
+
+func TestAplicarInyeccion_Validacion(t *testing.T) {
+	n := NewNoiseLayerWithSeed(1)
+	bits := make([]byte, 7*4)
+
+	if _, err := n.AplicarInyeccion(bits, []DirectivaInyeccion{{Bloque: 5, Bits: 1}}, 0, 7, 4); err == nil {
+		t.Error("se esperaba error con bloque fuera de rango")
+	}
+	if _, err := n.AplicarInyeccion(bits, []DirectivaInyeccion{{Bloque: 1, Bits: 8}}, 0, 7, 4); err == nil {
+		t.Error("se esperaba error con más bits que el tamaño del bloque")
+	}
+	if _, err := n.AplicarInyeccion(bits, []DirectivaInyeccion{{Bloque: 1, Bits: 1}}, 7, 7, 4); err == nil {
+		t.Error("se esperaba error con layout que excede la trama")
+	}
+}
+
+func TestHamming74Decode_RoundTripConRuido(t *testing.T) {
+	data := frame.BytesToBits([]byte("Hola, mundo"))
+	code, err := frame.Hamming74Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numBlocks := len(code) / 7
+
+	// Un bit invertido por bloque, en posiciones elegidas por la capa de ruido
+	var directivas []DirectivaInyeccion
+	for b := 1; b <= numBlocks; b++ {
+		directivas = append(directivas, DirectivaInyeccion{Bloque: b, Bits: 1})
+	}
+	ruido, err := NewNoiseLayerWithSeed(7).AplicarInyeccion(code, directivas, 0, 7, numBlocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, corrected, err := frame.Hamming74Decode(ruido.NoisyBits)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if !bytes.Equal(got[:len(data)], data) {
+		t.Error("Los datos decodificados no coinciden con los originales")
+	}
+	if len(corrected) != len(ruido.ErrorPositions) {
+		t.Fatalf("Corregidas %d posiciones, se inyectaron %d", len(corrected), len(ruido.ErrorPositions))
+	}
+	for i := range corrected {
+		if corrected[i] != ruido.ErrorPositions[i] {
+			t.Errorf("Posición corregida %d, se inyectó en %d", corrected[i], ruido.ErrorPositions[i])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package noise
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// Colores del mapa de errores
+var paletaMapa = color.Palette{
+	color.White, // bit sin error
+	color.Black, // bit invertido
+	color.RGBA{R: 200, G: 200, B: 200, A: 255}, // guía de región (header/payload/CRC)
+}
+
+const (
+	colorSinError = 0
+	colorError    = 1
+	colorGuia     = 2
+)
+
+// RenderizarMapaErrores dibuja un raster donde cada fila es una iteración y
+// cada columna una posición de bit: los bits invertidos en negro y el resto
+// en blanco. guias marca en gris las columnas donde empieza cada región de la
+// trama. Las posiciones fuera de anchoBits se descartan.
+func RenderizarMapaErrores(posiciones [][]int, anchoBits int, guias []int) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, anchoBits, len(posiciones)), paletaMapa)
+
+	for _, g := range guias {
+		if g < 0 || g >= anchoBits {
+			continue
+		}
+		for y := range posiciones {
+			img.SetColorIndex(g, y, colorGuia)
+		}
+	}
+	for y, fila := range posiciones {
+		for _, x := range fila {
+			if x >= 0 && x < anchoBits {
+				img.SetColorIndex(x, y, colorError)
+			}
+		}
+	}
+	return img
+}
+
+// EscribirMapaErroresPNG codifica el mapa de RenderizarMapaErrores como PNG en w
+func EscribirMapaErroresPNG(w io.Writer, posiciones [][]int, anchoBits int, guias []int) error {
+	return png.Encode(w, RenderizarMapaErrores(posiciones, anchoBits, guias))
+}
@@ -0,0 +1,61 @@
+package noise
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRenderizarMapaErrores(t *testing.T) {
+	posiciones := [][]int{
+		{0, 5},
+		{},
+		{2, 3, 4, 99}, // 99 queda fuera del ancho y se descarta
+	}
+	img := RenderizarMapaErrores(posiciones, 8, []int{3})
+
+	if b := img.Bounds(); b.Dx() != 8 || b.Dy() != 3 {
+		t.Fatalf("tamaño %v, se esperaba 8x3", b)
+	}
+
+	gris := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	tests := []struct {
+		x, y int
+		want color.Color
+	}{
+		{0, 0, color.Black},
+		{5, 0, color.Black},
+		{1, 0, color.White},
+		{3, 0, gris},        // guía sin error
+		{3, 1, gris},        // guía en fila sin errores
+		{0, 1, color.White}, // fila sin errores
+		{3, 2, color.Black}, // un error sobre la guía se ve como error
+		{7, 2, color.White},
+	}
+	for _, tt := range tests {
+		if got := img.At(tt.x, tt.y); !mismoColor(got, tt.want) {
+			t.Errorf("pixel (%d,%d) = %v, se esperaba %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestEscribirMapaErroresPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EscribirMapaErroresPNG(&buf, [][]int{{1}, {0}}, 4, nil); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("el PNG no se puede decodificar: %v", err)
+	}
+	if !mismoColor(img.At(1, 0), color.Black) || !mismoColor(img.At(0, 1), color.Black) || !mismoColor(img.At(0, 0), color.White) {
+		t.Error("los pixeles decodificados no coinciden con las posiciones de error")
+	}
+}
+
+func mismoColor(a, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
@@ -0,0 +1,109 @@
+package noise
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SegmentoBER es un tramo del plan durante el cual el canal mantiene un BER fijo
+type SegmentoBER struct {
+	Duracion time.Duration
+	BER      float64
+}
+
+// PlanBER describe un canal no estacionario como una secuencia de segmentos
+// consecutivos. Al terminar el último segmento el plan vuelve a empezar si
+// Repetir es true, o se considera agotado.
+type PlanBER struct {
+	Segmentos []SegmentoBER
+	Repetir   bool
+}
+
+// ParsePlanBER lee un plan con un segmento por línea con el formato
+// "<duración> <ber>", por ejemplo "30s 0.01". Las líneas vacías y las que
+// empiezan con '#' se ignoran. Los segmentos son consecutivos, así que no
+// pueden solaparse.
+func ParsePlanBER(r io.Reader) (*PlanBER, error) {
+	plan := &PlanBER{}
+	scanner := bufio.NewScanner(r)
+	linea := 0
+	for scanner.Scan() {
+		linea++
+		texto := strings.TrimSpace(scanner.Text())
+		if texto == "" || strings.HasPrefix(texto, "#") {
+			continue
+		}
+
+		campos := strings.Fields(texto)
+		if len(campos) != 2 {
+			return nil, fmt.Errorf("línea %d: se esperaba '<duración> <ber>', se obtuvo %q", linea, texto)
+		}
+		duracion, err := time.ParseDuration(campos[0])
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: duración inválida %q", linea, campos[0])
+		}
+		ber, err := strconv.ParseFloat(campos[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: BER inválido %q", linea, campos[1])
+		}
+		plan.Segmentos = append(plan.Segmentos, SegmentoBER{Duracion: duracion, BER: ber})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := plan.Validar(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Validar verifica que el plan tenga segmentos con duración positiva y BER en [0, 1]
+func (p *PlanBER) Validar() error {
+	if len(p.Segmentos) == 0 {
+		return fmt.Errorf("el plan de BER no tiene segmentos")
+	}
+	for i, s := range p.Segmentos {
+		if s.Duracion <= 0 {
+			return fmt.Errorf("segmento %d: la duración debe ser positiva: %v", i+1, s.Duracion)
+		}
+		if s.BER < 0 || s.BER > 1 {
+			return fmt.Errorf("segmento %d: BER inválido: %.3f (debe estar entre 0.0 y 1.0)", i+1, s.BER)
+		}
+	}
+	return nil
+}
+
+// DuracionTotal devuelve la duración de una pasada completa por el plan
+func (p *PlanBER) DuracionTotal() time.Duration {
+	var total time.Duration
+	for _, s := range p.Segmentos {
+		total += s.Duracion
+	}
+	return total
+}
+
+// SegmentoEn devuelve el índice (desde 0) del segmento activo tras
+// transcurrido desde el inicio. ok es false si el plan se agotó y no se repite.
+func (p *PlanBER) SegmentoEn(transcurrido time.Duration) (indice int, ok bool) {
+	total := p.DuracionTotal()
+	if transcurrido < 0 {
+		transcurrido = 0
+	}
+	if transcurrido >= total {
+		if !p.Repetir {
+			return 0, false
+		}
+		transcurrido %= total
+	}
+	for i, s := range p.Segmentos {
+		if transcurrido < s.Duracion {
+			return i, true
+		}
+		transcurrido -= s.Duracion
+	}
+	return len(p.Segmentos) - 1, true
+}
@@ -0,0 +1,81 @@
+package noise
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePlanBER(t *testing.T) {
+	plan, err := ParsePlanBER(strings.NewReader(`
+# canal limpio, ventana de interferencia y recuperación
+10s 0.001
+5s  0.05
+
+10s 0.001
+`))
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(plan.Segmentos) != 3 {
+		t.Fatalf("se esperaban 3 segmentos, se obtuvieron %d", len(plan.Segmentos))
+	}
+	if plan.Segmentos[1] != (SegmentoBER{Duracion: 5 * time.Second, BER: 0.05}) {
+		t.Errorf("segmento 2 inesperado: %+v", plan.Segmentos[1])
+	}
+	if plan.DuracionTotal() != 25*time.Second {
+		t.Errorf("DuracionTotal = %v, se esperaba 25s", plan.DuracionTotal())
+	}
+}
+
+func TestParsePlanBER_Invalido(t *testing.T) {
+	tests := []struct {
+		name  string
+		texto string
+	}{
+		{"vacío", "# solo comentarios\n"},
+		{"duración negativa", "-5s 0.01"},
+		{"duración cero", "0s 0.01"},
+		{"duración sin unidad", "10 0.01"},
+		{"BER fuera de rango", "10s 1.5"},
+		{"BER no numérico", "10s alto"},
+		{"campos de más", "10s 0.01 bsc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePlanBER(strings.NewReader(tt.texto)); err == nil {
+				t.Error("se esperaba error")
+			}
+		})
+	}
+}
+
+func TestPlanBER_SegmentoEn(t *testing.T) {
+	plan := &PlanBER{Segmentos: []SegmentoBER{
+		{Duracion: 10 * time.Second, BER: 0.001},
+		{Duracion: 5 * time.Second, BER: 0.05},
+	}}
+
+	tests := []struct {
+		transcurrido time.Duration
+		indice       int
+		ok           bool
+	}{
+		{0, 0, true},
+		{9 * time.Second, 0, true},
+		{10 * time.Second, 1, true},
+		{14 * time.Second, 1, true},
+		{15 * time.Second, 0, false},
+	}
+	for _, tt := range tests {
+		i, ok := plan.SegmentoEn(tt.transcurrido)
+		if ok != tt.ok || (ok && i != tt.indice) {
+			t.Errorf("SegmentoEn(%v) = (%d, %v), se esperaba (%d, %v)", tt.transcurrido, i, ok, tt.indice, tt.ok)
+		}
+	}
+
+	plan.Repetir = true
+	if i, ok := plan.SegmentoEn(26 * time.Second); !ok || i != 1 {
+		t.Errorf("con repetición SegmentoEn(26s) = (%d, %v), se esperaba (1, true)", i, ok)
+	}
+}
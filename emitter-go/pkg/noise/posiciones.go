@@ -0,0 +1,81 @@
+package noise
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// DefaultMaxPosiciones es la cantidad de posiciones de error que AplicarRuido
+// guarda exactas por defecto; por encima solo guarda una muestra
+const DefaultMaxPosiciones = 10000
+
+// ResumenPosiciones describe todas las posiciones de error de una transmisión
+// cuyo detalle no se guardó completo
+type ResumenPosiciones struct {
+	Primera       int     // Primera posición con error
+	Ultima        int     // Última posición con error
+	HuecoMin      int     // Menor distancia entre errores consecutivos
+	HuecoMax      int     // Mayor distancia entre errores consecutivos
+	HuecoPromedio float64 // Distancia promedio entre errores consecutivos
+}
+
+func (r ResumenPosiciones) String() string {
+	return fmt.Sprintf("región %d-%d, huecos entre errores %d-%d (promedio %.1f)",
+		r.Primera, r.Ultima, r.HuecoMin, r.HuecoMax, r.HuecoPromedio)
+}
+
+// acumuladorPosiciones guarda las posiciones exactas hasta max y, a partir de
+// ahí, una muestra uniforme de max posiciones (reservoir sampling) más el
+// resumen de región y huecos. max <= 0 guarda todas.
+type acumuladorPosiciones struct {
+	max        int
+	rng        *rand.Rand
+	total      int
+	posiciones []int
+	resumen    ResumenPosiciones
+	sumaHuecos int
+}
+
+func (a *acumuladorPosiciones) agregar(pos int) {
+	if a.total == 0 {
+		a.resumen.Primera = pos
+	} else {
+		hueco := pos - a.resumen.Ultima
+		if a.total == 1 || hueco < a.resumen.HuecoMin {
+			a.resumen.HuecoMin = hueco
+		}
+		if hueco > a.resumen.HuecoMax {
+			a.resumen.HuecoMax = hueco
+		}
+		a.sumaHuecos += hueco
+	}
+	a.resumen.Ultima = pos
+	a.total++
+
+	if a.max <= 0 || len(a.posiciones) < a.max {
+		a.posiciones = append(a.posiciones, pos)
+		return
+	}
+	// Algoritmo R: la posición número total reemplaza a una de la muestra con
+	// probabilidad max/total
+	if j := a.rng.Intn(a.total); j < a.max {
+		a.posiciones[j] = pos
+	}
+}
+
+// truncado indica si se descartaron posiciones
+func (a *acumuladorPosiciones) truncado() bool {
+	return a.max > 0 && a.total > a.max
+}
+
+// resultado devuelve las posiciones (ordenadas) y, si se truncaron, el resumen
+func (a *acumuladorPosiciones) resultado() ([]int, *ResumenPosiciones) {
+	if !a.truncado() {
+		return a.posiciones, nil
+	}
+	sort.Ints(a.posiciones)
+	resumen := a.resumen
+	resumen.HuecoPromedio = float64(a.sumaHuecos) / float64(a.total-1)
+	return a.posiciones, &resumen
+}
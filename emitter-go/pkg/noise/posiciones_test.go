@@ -0,0 +1,116 @@
+package noise
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAplicarRuido_PosicionesExactasBajoElLimite(t *testing.T) {
+	n := NewNoiseLayerWithSeed(3)
+	n.FijarMaxPosiciones(100)
+
+	result, err := n.AplicarRuido(make([]byte, 1000), 0.02)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PosicionesTruncadas || result.Resumen != nil {
+		t.Fatalf("con %d errores no debería truncarse", result.ErrorsInjected)
+	}
+	if len(result.ErrorPositions) != result.ErrorsInjected {
+		t.Errorf("posiciones = %d, errores = %d", len(result.ErrorPositions), result.ErrorsInjected)
+	}
+}
+
+func TestAplicarRuido_PosicionesTruncadas(t *testing.T) {
+	bits := make([]byte, 20000)
+	const limite = 500
+
+	completo := NewNoiseLayerWithSeed(11)
+	completo.FijarMaxPosiciones(0)
+	todas, err := completo.AplicarRuido(bits, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	truncado := NewNoiseLayerWithSeed(11)
+	truncado.FijarMaxPosiciones(limite)
+	result, err := truncado.AplicarRuido(bits, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// El límite no cambia qué bits se invierten ni el conteo
+	if !reflect.DeepEqual(result.NoisyBits, todas.NoisyBits) {
+		t.Fatal("el límite de posiciones alteró el ruido aplicado")
+	}
+	if result.ErrorsInjected != todas.ErrorsInjected || result.ActualBER != todas.ActualBER {
+		t.Errorf("conteo %d/%f, se esperaba %d/%f", result.ErrorsInjected, result.ActualBER, todas.ErrorsInjected, todas.ActualBER)
+	}
+
+	if !result.PosicionesTruncadas || result.Resumen == nil {
+		t.Fatal("se esperaba PosicionesTruncadas con resumen")
+	}
+	if len(result.ErrorPositions) != limite || !sort.IntsAreSorted(result.ErrorPositions) {
+		t.Fatalf("se esperaba una muestra ordenada de %d posiciones", limite)
+	}
+	vistas := map[int]bool{}
+	for _, p := range result.ErrorPositions {
+		if result.NoisyBits[p] == bits[p] || vistas[p] {
+			t.Fatalf("la posición %d de la muestra no es un error distinto", p)
+		}
+		vistas[p] = true
+	}
+
+	// El resumen describe todas las posiciones, no la muestra
+	want := ResumenPosiciones{Primera: todas.ErrorPositions[0], Ultima: todas.ErrorPositions[len(todas.ErrorPositions)-1]}
+	suma := 0
+	for i := 1; i < len(todas.ErrorPositions); i++ {
+		h := todas.ErrorPositions[i] - todas.ErrorPositions[i-1]
+		if i == 1 || h < want.HuecoMin {
+			want.HuecoMin = h
+		}
+		if h > want.HuecoMax {
+			want.HuecoMax = h
+		}
+		suma += h
+	}
+	want.HuecoPromedio = float64(suma) / float64(len(todas.ErrorPositions)-1)
+	if *result.Resumen != want {
+		t.Errorf("Resumen = %+v, want %+v", *result.Resumen, want)
+	}
+}
+
+func TestAplicarRuido_MuestraUniforme(t *testing.T) {
+	// Con errores uniformes en [0, N) la muestra debe tener media ≈ N/2 y
+	// repartirse parejo entre las mitades de la trama; con una muestra
+	// sesgada (por ejemplo, las primeras posiciones) la media sería mucho menor
+	const nbits = 50000
+	const limite = 1000
+	n := NewNoiseLayerWithSeed(21)
+	n.FijarMaxPosiciones(limite)
+
+	result, err := n.AplicarRuido(make([]byte, nbits), 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var suma float64
+	primeraMitad := 0
+	for _, p := range result.ErrorPositions {
+		suma += float64(p)
+		if p < nbits/2 {
+			primeraMitad++
+		}
+	}
+	media := suma / limite
+	// Desvío estándar de la media de una uniforme: N/sqrt(12·limite) ≈ 456
+	if math.Abs(media-nbits/2) > 4*nbits/math.Sqrt(12*limite) {
+		t.Errorf("media de la muestra %.0f, se esperaba cerca de %d", media, nbits/2)
+	}
+	// Binomial(limite, 0.5): desvío ≈ 16
+	if math.Abs(float64(primeraMitad)-limite/2) > 4*math.Sqrt(limite*0.25) {
+		t.Errorf("%d de %d posiciones en la primera mitad", primeraMitad, limite)
+	}
+}
@@ -0,0 +1,64 @@
+package noise
+
+import "fmt"
+
+// AplicarRafagas simula un canal con errores en ráfaga: fuera de una ráfaga
+// cada bit inicia una con probabilidad fija y la ráfaga invierte largo bits
+// contiguos (menos si la trama termina antes). La probabilidad de inicio se
+// elige para que la fracción media de bits invertidos sea ber, así que el
+// resultado es comparable con AplicarRuido al mismo BER; con largo 1 es el
+// canal binario simétrico.
+func (n *NoiseLayer) AplicarRafagas(bits []byte, ber float64, largo int) (*ErrorResult, error) {
+	if ber < 0.0 || ber > 1.0 {
+		return nil, fmt.Errorf("BER inválido: %.3f (debe estar entre 0.0 y 1.0)", ber)
+	}
+	if largo < 1 {
+		return nil, fmt.Errorf("largo de ráfaga inválido: %d (debe ser al menos 1)", largo)
+	}
+	for i, bit := range bits {
+		if bit != 0 && bit != 1 {
+			return nil, fmt.Errorf("bit inválido en posición %d: %d (debe ser 0 o 1)", i, bit)
+		}
+	}
+
+	noisyBits := make([]byte, len(bits))
+	copy(noisyBits, bits)
+	posiciones := acumuladorPosiciones{max: n.maxPosiciones, rng: n.muestreo}
+
+	// Entre ráfagas hay en promedio (1-p)/p bits sanos: la fracción invertida
+	// es p·largo / (p·largo + 1 - p) = ber
+	inicio := ber / (float64(largo) - ber*float64(largo-1))
+	for i := 0; i < len(noisyBits); {
+		if n.rng.Float64() >= inicio {
+			i++
+			continue
+		}
+		for fin := i + largo; i < fin && i < len(noisyBits); i++ {
+			noisyBits[i] = 1 - noisyBits[i]
+			posiciones.agregar(i)
+		}
+	}
+
+	errorPositions, resumen := posiciones.resultado()
+	result := &ErrorResult{
+		OriginalBits:        bits,
+		NoisyBits:           noisyBits,
+		ErrorPositions:      errorPositions,
+		TotalBits:           len(bits),
+		ErrorsInjected:      posiciones.total,
+		PosicionesTruncadas: resumen != nil,
+		Resumen:             resumen,
+	}
+	if len(bits) > 0 {
+		result.ActualBER = float64(posiciones.total) / float64(len(bits))
+	}
+	return result, nil
+}
+
+// SimularCanalConRafagas es SimularCanalRuidoso sobre el canal de ráfagas de
+// AplicarRafagas
+func (n *NoiseLayer) SimularCanalConRafagas(bits []byte, ber float64, largo, iteraciones int) (*ChannelStats, error) {
+	return n.simular(bits, ber, iteraciones, func() (*ErrorResult, error) {
+		return n.AplicarRafagas(bits, ber, largo)
+	})
+}
@@ -0,0 +1,63 @@
+package noise
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAplicarRafagas_ErroresContiguos(t *testing.T) {
+	n := NewNoiseLayerWithSeed(5)
+	n.FijarMaxPosiciones(0)
+	bits := make([]byte, 20000)
+
+	result, err := n.AplicarRafagas(bits, 0.02, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.ActualBER-0.02) > 0.005 {
+		t.Errorf("BER real %.4f, se esperaba cerca de 0.02", result.ActualBER)
+	}
+
+	// Las posiciones se agrupan en corridas de largo múltiplo de 4, salvo la
+	// que toque el final de la trama
+	corrida := 0
+	for i, p := range result.ErrorPositions {
+		corrida++
+		ultima := i == len(result.ErrorPositions)-1
+		if ultima || result.ErrorPositions[i+1] != p+1 {
+			if corrida%4 != 0 && !(ultima && p == len(bits)-1) {
+				t.Fatalf("corrida de %d errores terminada en %d", corrida, p)
+			}
+			corrida = 0
+		}
+	}
+	for _, p := range result.ErrorPositions {
+		if result.NoisyBits[p] != 1 {
+			t.Fatalf("el bit %d debería estar invertido", p)
+		}
+	}
+}
+
+func TestAplicarRafagas_LargoUnoEsBSC(t *testing.T) {
+	n := NewNoiseLayerWithSeed(3)
+	stats, err := n.SimularCanalConRafagas(make([]byte, 500), 0.05, 1, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(stats.AverageBER-0.05) > 0.005 {
+		t.Errorf("BER promedio %.4f, se esperaba cerca de 0.05", stats.AverageBER)
+	}
+}
+
+func TestAplicarRafagas_Validacion(t *testing.T) {
+	n := NewNoiseLayerWithSeed(1)
+	if _, err := n.AplicarRafagas([]byte{0, 1}, 1.5, 3); err == nil {
+		t.Error("se esperaba error con BER fuera de rango")
+	}
+	if _, err := n.AplicarRafagas([]byte{0, 1}, 0.1, 0); err == nil {
+		t.Error("se esperaba error con largo 0")
+	}
+	if _, err := n.AplicarRafagas([]byte{0, 2}, 0.1, 3); err == nil {
+		t.Error("se esperaba error con bits inválidos")
+	}
+}
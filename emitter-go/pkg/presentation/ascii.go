@@ -15,6 +15,10 @@ func NewPresentationLayer() *PresentationLayer {
 
 // CodificarMensaje convierte texto ASCII a bits
 func (p *PresentationLayer) CodificarMensaje(texto string) ([]byte, error) {
+	if texto == "" {
+		return nil, fmt.Errorf("el texto no puede estar vacío")
+	}
+
 	if !utf8.ValidString(texto) {
 		return nil, fmt.Errorf("el texto contiene caracteres no válidos UTF-8")
 	}
@@ -156,31 +160,22 @@ func (p *PresentationLayer) ValidarTexto(texto string) error {
 	return nil
 }
 
-// ConvertirBitsABytes convierte un slice de bits a bytes (para compatibilidad)
+// ConvertirBitsABytes convierte un slice de bits a bytes (para compatibilidad),
+// completando el último byte con ceros sin modificar bits
 func (p *PresentationLayer) ConvertirBitsABytes(bits []byte) []byte {
-	if len(bits) == 0 {
-		return []byte{}
-	}
-
-	// Hacer padding a múltiplo de 8 si es necesario
-	paddedBits := make([]byte, len(bits))
-	copy(paddedBits, bits)
-
-	for len(paddedBits)%8 != 0 {
-		paddedBits = append(paddedBits, 0)
-	}
+	resultado, _ := p.ConvertirBitsABytesConRelleno(bits)
+	return resultado
+}
 
-	// Convertir grupos de 8 bits a bytes
-	var resultado []byte
-	for i := 0; i < len(paddedBits); i += 8 {
-		var byteVal byte
-		for j := 0; j < 8; j++ {
-			byteVal |= paddedBits[i+j] << (7 - j)
-		}
-		resultado = append(resultado, byteVal)
+// ConvertirBitsABytesConRelleno es ConvertirBitsABytes que además devuelve
+// cuántos bits de relleno (0 a 7) se agregaron al final, para descartarlos al
+// volver a bits con ConvertirBytesABits
+func (p *PresentationLayer) ConvertirBitsABytesConRelleno(bits []byte) (resultado []byte, relleno int) {
+	resultado = make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		resultado[i/8] |= bit << (7 - i%8)
 	}
-
-	return resultado
+	return resultado, len(resultado)*8 - len(bits)
 }
 
 // ConvertirBytesABits convierte bytes a bits (para compatibilidad)
@@ -0,0 +1,166 @@
+package presentation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPresentationLayer_CodificarMensaje(t *testing.T) {
+	p := NewPresentationLayer()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:  "single character A",
+			input: "A",
+			want:  []byte{0, 1, 0, 0, 0, 0, 0, 1}, // ASCII 65 = 01000001
+		},
+		{
+			name:  "simple text Hi",
+			input: "Hi",
+			want: []byte{
+				0, 1, 0, 0, 1, 0, 0, 0, // H = 72 = 01001000
+				0, 1, 1, 0, 1, 0, 0, 1, // i = 105 = 01101001
+			},
+		},
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "non-ASCII character",
+			input:   "Hölá",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.CodificarMensaje(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CodificarMensaje() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CodificarMensaje() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresentationLayer_DecodificarMensaje(t *testing.T) {
+	p := NewPresentationLayer()
+
+	tests := []struct {
+		name    string
+		input   []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "single character A",
+			input: []byte{0, 1, 0, 0, 0, 0, 0, 1}, // ASCII 65
+			want:  "A",
+		},
+		{
+			name: "simple text Hi",
+			input: []byte{
+				0, 1, 0, 0, 1, 0, 0, 0, // H = 72
+				0, 1, 1, 0, 1, 0, 0, 1, // i = 105
+			},
+			want: "Hi",
+		},
+		{
+			name:    "invalid length",
+			input:   []byte{0, 1, 0}, // Not multiple of 8
+			wantErr: true,
+		},
+		{
+			name:    "invalid bit value",
+			input:   []byte{0, 1, 0, 2, 0, 0, 0, 1}, // Contains '2'
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.DecodificarMensaje(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodificarMensaje() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("DecodificarMensaje() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresentationLayer_RoundTrip(t *testing.T) {
+	p := NewPresentationLayer()
+
+	testMessages := []string{
+		"Hello World!",
+		"Test123",
+		"ASCII only text",
+		"Special chars: !@#$%^&*()",
+	}
+
+	for _, original := range testMessages {
+		t.Run(original, func(t *testing.T) {
+			// Encode
+			bits, err := p.CodificarMensaje(original)
+			if err != nil {
+				t.Fatalf("CodificarMensaje() failed: %v", err)
+			}
+
+			// Decode
+			decoded, err := p.DecodificarMensaje(bits)
+			if err != nil {
+				t.Fatalf("DecodificarMensaje() failed: %v", err)
+			}
+
+			if decoded != original {
+				t.Errorf("Round trip failed: got %q, want %q", decoded, original)
+			}
+		})
+	}
+}
+
+func TestPresentationLayer_ConvertirBitsABytesConRelleno(t *testing.T) {
+	p := NewPresentationLayer()
+	backing := []byte{1, 1, 0, 1, 0, 0, 1, 0, 1, 1, 7, 7, 7, 7, 7, 7}
+	for n := 0; n <= 10; n++ {
+		bits := backing[:n]
+		got, relleno := p.ConvertirBitsABytesConRelleno(bits)
+		if len(got) != (n+7)/8 || relleno != len(got)*8-n {
+			t.Fatalf("%d bits: %d bytes con %d de relleno", n, len(got), relleno)
+		}
+		vuelta := p.ConvertirBytesABits(got)
+		if string(vuelta[:len(vuelta)-relleno]) != string(bits) {
+			t.Errorf("%d bits: % x no recupera los bits", n, got)
+		}
+	}
+	if string(backing[10:]) != string([]byte{7, 7, 7, 7, 7, 7}) {
+		t.Errorf("la conversión modificó el arreglo del llamador: %v", backing)
+	}
+}
+
+func BenchmarkPresentationLayer_CodificarMensaje(b *testing.B) {
+	p := NewPresentationLayer()
+	mensaje := strings.Repeat("Hello World! ", 100) // ~1.3KB texto
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := p.CodificarMensaje(mensaje)
+		if err != nil {
+			b.Fatalf("CodificarMensaje failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package presentation_test
+
+import (
+	"fmt"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/presentation"
+)
+
+func ExamplePresentationLayer_CodificarMensaje() {
+	p := presentation.NewPresentationLayer()
+	bits, err := p.CodificarMensaje("Hi")
+	if err != nil {
+		panic(err)
+	}
+	texto, err := p.DecodificarMensaje(bits)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(bits)
+	fmt.Println(texto)
+	// Output:
+	// [0 1 0 0 1 0 0 0 0 1 1 0 1 0 0 1]
+	// Hi
+}
@@ -0,0 +1,103 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpcionesNormalizacion controla qué transforma NormalizarTexto
+type OpcionesNormalizacion struct {
+	QuitarDiacriticos bool // Reemplazar letras acentuadas por su base (á → a)
+}
+
+// Sustitucion registra un cambio hecho por la normalización
+type Sustitucion struct {
+	Posicion  int    // Posición (en bytes) en el texto original
+	Original  string // Texto reemplazado
+	Reemplazo string // Texto ASCII enviado en su lugar (vacío si se eliminó)
+}
+
+func (s Sustitucion) String() string {
+	return fmt.Sprintf("posición %d: %q → %q", s.Posicion, s.Original, s.Reemplazo)
+}
+
+// puntuacionASCII mapea puntuación Unicode común a su equivalente ASCII
+var puntuacionASCII = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '′': "'", '‹': "'", '›': "'",
+	'“': "\"", '”': "\"", '„': "\"", '‟': "\"", '″': "\"", '«': "\"", '»': "\"",
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '−': "-",
+	'…': "...", '•': "*",
+	'\u200b': "", '\u200c': "", '\u200d': "", '\ufeff': "", // caracteres de ancho cero
+}
+
+// diacriticosASCII mapea letras latinas acentuadas a su letra base
+var diacriticosASCII = map[rune]string{
+	'á': "a", 'à': "a", 'ä': "a", 'â': "a", 'ã': "a", 'å': "a",
+	'é': "e", 'è': "e", 'ë': "e", 'ê': "e",
+	'í': "i", 'ì': "i", 'ï': "i", 'î': "i",
+	'ó': "o", 'ò': "o", 'ö': "o", 'ô': "o", 'õ': "o",
+	'ú': "u", 'ù': "u", 'ü': "u", 'û': "u",
+	'ñ': "n", 'ç': "c", 'ý': "y", 'ÿ': "y",
+	'Á': "A", 'À': "A", 'Ä': "A", 'Â': "A", 'Ã': "A", 'Å': "A",
+	'É': "E", 'È': "E", 'Ë': "E", 'Ê': "E",
+	'Í': "I", 'Ì': "I", 'Ï': "I", 'Î': "I",
+	'Ó': "O", 'Ò': "O", 'Ö': "O", 'Ô': "O", 'Õ': "O",
+	'Ú': "U", 'Ù': "U", 'Ü': "U", 'Û': "U",
+	'Ñ': "N", 'Ç': "C", 'Ý': "Y",
+}
+
+// esEspacioExotico indica si r es un espacio Unicode distinto del espacio ASCII
+func esEspacioExotico(r rune) bool {
+	switch {
+	case r == '\u00a0', r == '\u202f', r == '\u205f', r == '\u3000':
+		return true
+	case r >= '\u2000' && r <= '\u200a':
+		return true
+	}
+	return false
+}
+
+// NormalizarTexto reemplaza puntuación Unicode común por ASCII, colapsa cada
+// secuencia de espacios exóticos en un espacio y, si se pide, quita los
+// diacríticos. Devuelve el texto resultante y cada sustitución realizada para
+// que el usuario sepa que el texto enviado difiere del ingresado. Los
+// caracteres sin equivalente se dejan intactos y los rechaza CodificarMensaje.
+func (p *PresentationLayer) NormalizarTexto(texto string, opts OpcionesNormalizacion) (string, []Sustitucion) {
+	var b strings.Builder
+	var sustituciones []Sustitucion
+	espacio := -1 // inicio de la secuencia de espacios exóticos en curso
+
+	cerrarEspacio := func(fin int) {
+		if espacio >= 0 {
+			b.WriteByte(' ')
+			sustituciones = append(sustituciones, Sustitucion{
+				Posicion: espacio, Original: texto[espacio:fin], Reemplazo: " ",
+			})
+			espacio = -1
+		}
+	}
+
+	for i, r := range texto {
+		if esEspacioExotico(r) {
+			if espacio < 0 {
+				espacio = i
+			}
+			continue
+		}
+		cerrarEspacio(i)
+
+		reemplazo, ok := puntuacionASCII[r]
+		if !ok && opts.QuitarDiacriticos {
+			reemplazo, ok = diacriticosASCII[r]
+		}
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(reemplazo)
+		sustituciones = append(sustituciones, Sustitucion{Posicion: i, Original: string(r), Reemplazo: reemplazo})
+	}
+	cerrarEspacio(len(texto))
+
+	return b.String(), sustituciones
+}
@@ -0,0 +1,100 @@
+package presentation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizarTexto(t *testing.T) {
+	p := NewPresentationLayer()
+
+	tests := []struct {
+		name          string
+		texto         string
+		opts          OpcionesNormalizacion
+		want          string
+		sustituciones []Sustitucion
+	}{
+		{
+			name:  "ASCII sin cambios",
+			texto: "Hola mundo",
+			want:  "Hola mundo",
+		},
+		{
+			name:  "comillas tipográficas",
+			texto: "“Hola” y ‘chau’",
+			want:  "\"Hola\" y 'chau'",
+			sustituciones: []Sustitucion{
+				{0, "“", "\""}, {7, "”", "\""}, {13, "‘", "'"}, {20, "’", "'"},
+			},
+		},
+		{
+			name:          "guiones y puntos suspensivos",
+			texto:         "a—b…",
+			want:          "a-b...",
+			sustituciones: []Sustitucion{{1, "—", "-"}, {5, "…", "..."}},
+		},
+		{
+			name:          "espacios exóticos se colapsan",
+			texto:         "a\u00a0\u2003b",
+			want:          "a b",
+			sustituciones: []Sustitucion{{1, "\u00a0\u2003", " "}},
+		},
+		{
+			name:          "espacio exótico al final",
+			texto:         "fin\u3000",
+			want:          "fin ",
+			sustituciones: []Sustitucion{{3, "\u3000", " "}},
+		},
+		{
+			name:          "ancho cero se elimina",
+			texto:         "a\u200bb",
+			want:          "ab",
+			sustituciones: []Sustitucion{{1, "\u200b", ""}},
+		},
+		{
+			name:  "diacríticos se conservan por defecto",
+			texto: "canción",
+			want:  "canción",
+		},
+		{
+			name:          "diacríticos opcionales",
+			texto:         "Ñandú",
+			opts:          OpcionesNormalizacion{QuitarDiacriticos: true},
+			want:          "Nandu",
+			sustituciones: []Sustitucion{{0, "Ñ", "N"}, {5, "ú", "u"}},
+		},
+		{
+			name:  "sin equivalente queda intacto",
+			texto: "a→b",
+			want:  "a→b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, sust := p.NormalizarTexto(tt.texto, tt.opts)
+			if got != tt.want {
+				t.Errorf("NormalizarTexto() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(sust, tt.sustituciones) {
+				t.Errorf("sustituciones = %v, want %v", sust, tt.sustituciones)
+			}
+		})
+	}
+}
+
+func TestNormalizarTexto_ResultadoCodificable(t *testing.T) {
+	p := NewPresentationLayer()
+	texto, _ := p.NormalizarTexto("“Canción” — versión…", OpcionesNormalizacion{QuitarDiacriticos: true})
+	if _, err := p.CodificarMensaje(texto); err != nil {
+		t.Errorf("el texto normalizado %q debería ser ASCII: %v", texto, err)
+	}
+}
+
+func TestSustitucion_String(t *testing.T) {
+	s := Sustitucion{Posicion: 4, Original: "—", Reemplazo: "-"}
+	if got := s.String(); got != `posición 4: "—" → "-"` {
+		t.Errorf("String() = %s", got)
+	}
+}
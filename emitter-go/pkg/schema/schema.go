@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Campo describe un flag o un campo de configuración para herramientas externas
+type Campo struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Default string   `json:"default"`
+	Help    string   `json:"help"`
+	Allowed []string `json:"allowed,omitempty"`
+	Min     *float64 `json:"min,omitempty"`
+	Max     *float64 `json:"max,omitempty"`
+}
+
+// Restriccion agrega valores permitidos o rangos a un campo
+type Restriccion struct {
+	Allowed []string
+	Min     *float64
+	Max     *float64
+}
+
+// Rango restringe un campo numérico a [min, max]
+func Rango(min, max float64) Restriccion {
+	return Restriccion{Min: &min, Max: &max}
+}
+
+// Minimo restringe un campo numérico a valores >= min
+func Minimo(min float64) Restriccion {
+	return Restriccion{Min: &min}
+}
+
+// Valores restringe un campo a una lista de valores permitidos
+func Valores(allowed []string) Restriccion {
+	return Restriccion{Allowed: allowed}
+}
+
+// Aplicar copia la restricción en el campo
+func (r Restriccion) Aplicar(c *Campo) {
+	c.Allowed = r.Allowed
+	c.Min = r.Min
+	c.Max = r.Max
+}
+
+// Schema es el documento que describe la configuración de un comando
+type Schema struct {
+	Command string              `json:"command"`
+	Flags   []Campo             `json:"flags"`
+	Config  []Campo             `json:"config,omitempty"`
+	Enums   map[string][]string `json:"enums,omitempty"`
+}
+
+// DesdeFlags genera el schema a partir de los flags registrados en fs, de modo
+// que la descripción no pueda desviarse de lo que el comando acepta realmente
+func DesdeFlags(command string, fs *flag.FlagSet, restricciones map[string]Restriccion) *Schema {
+	s := &Schema{Command: command}
+	fs.VisitAll(func(f *flag.Flag) {
+		c := Campo{
+			Name:    f.Name,
+			Type:    tipoFlag(f),
+			Default: f.DefValue,
+			Help:    f.Usage,
+		}
+		if r, ok := restricciones[f.Name]; ok {
+			r.Aplicar(&c)
+		}
+		s.Flags = append(s.Flags, c)
+	})
+	return s
+}
+
+// Escribir serializa el schema como JSON indentado
+func (s *Schema) Escribir(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// Buscar devuelve el flag con el nombre dado, o nil si no existe
+func (s *Schema) Buscar(name string) *Campo {
+	for i := range s.Flags {
+		if s.Flags[i].Name == name {
+			return &s.Flags[i]
+		}
+	}
+	return nil
+}
+
+// tipoFlag deduce el tipo del flag a partir del valor que almacena
+func tipoFlag(f *flag.Flag) string {
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return "string"
+	}
+	t := fmt.Sprintf("%T", getter.Get())
+	// time.Duration se documenta como "duration" (ej: 10s, 5m)
+	if t == "time.Duration" {
+		return "duration"
+	}
+	return strings.TrimPrefix(t, "*")
+}
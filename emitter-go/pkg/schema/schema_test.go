@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+	"time"
+)
+
+func TestDesdeFlags(t *testing.T) {
+	fs := flag.NewFlagSet("prueba", flag.ContinueOnError)
+	fs.String("mode", "manual", "Modo de operación")
+	fs.Float64("ber", 0.01, "Bit Error Rate")
+	fs.Int("count", 10, "Iteraciones")
+	fs.Bool("verbose", false, "Más salida")
+	fs.Duration("duration", 5*time.Second, "Duración")
+
+	s := DesdeFlags("prueba", fs, map[string]Restriccion{
+		"mode": Valores([]string{"manual", "benchmark"}),
+		"ber":  Rango(0, 1),
+	})
+
+	want := map[string]string{
+		"mode":     "string",
+		"ber":      "float64",
+		"count":    "int",
+		"verbose":  "bool",
+		"duration": "duration",
+	}
+	if len(s.Flags) != len(want) {
+		t.Fatalf("se esperaban %d flags, se obtuvieron %d", len(want), len(s.Flags))
+	}
+	for name, tipo := range want {
+		c := s.Buscar(name)
+		if c == nil {
+			t.Errorf("falta el flag %s", name)
+			continue
+		}
+		if c.Type != tipo {
+			t.Errorf("%s: tipo %q, se esperaba %q", name, c.Type, tipo)
+		}
+	}
+
+	if c := s.Buscar("duration"); c.Default != "5s" {
+		t.Errorf("default de duration = %q, se esperaba 5s", c.Default)
+	}
+	if c := s.Buscar("mode"); len(c.Allowed) != 2 {
+		t.Errorf("mode debería listar 2 valores permitidos: %v", c.Allowed)
+	}
+	if c := s.Buscar("ber"); c.Min == nil || c.Max == nil || *c.Min != 0 || *c.Max != 1 {
+		t.Errorf("ber debería tener rango [0, 1]: %+v", c)
+	}
+}
+
+func TestSchema_EscribirJSON(t *testing.T) {
+	fs := flag.NewFlagSet("prueba", flag.ContinueOnError)
+	fs.Int("count", 10, "Iteraciones")
+	s := DesdeFlags("prueba", fs, map[string]Restriccion{"count": Minimo(1)})
+	s.Enums = map[string][]string{"algorithm": {"crc", "hamming"}}
+
+	var out bytes.Buffer
+	if err := s.Escribir(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Schema
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON inválido: %v", err)
+	}
+	if decoded.Command != "prueba" || len(decoded.Flags) != 1 || *decoded.Flags[0].Min != 1 {
+		t.Errorf("round trip inesperado: %+v", decoded)
+	}
+	if decoded.Flags[0].Max != nil {
+		t.Error("max no debería serializarse si no está definido")
+	}
+}
@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// ProbNoDeteccionCRC32 es la probabilidad de que un patrón de errores
+// aleatorio no altere el CRC-32 (2^-32)
+var ProbNoDeteccionCRC32 = ProbNoDeteccionCRC(32)
+
+// ProbNoDeteccionCRC es la probabilidad de que un patrón de errores aleatorio
+// no altere un CRC de bits bits (2^-bits)
+func ProbNoDeteccionCRC(bits int) float64 {
+	return math.Pow(2, -float64(bits))
+}
+
+// ProbBloqueHamming74 es la probabilidad de que un bloque Hamming(7,4) se
+// recupere con BER p: a lo sumo un error en sus 7 bits,
+// (1-p)^7 + 7p(1-p)^6
+func ProbBloqueHamming74(p float64) float64 {
+	return ProbBloqueHamming(7, p)
+}
+
+// ProbBloqueHamming generaliza ProbBloqueHamming74 a bloques de n bits:
+// (1-p)^n + np(1-p)^(n-1)
+func ProbBloqueHamming(n int, p float64) float64 {
+	return math.Pow(1-p, float64(n)) + float64(n)*p*math.Pow(1-p, float64(n-1))
+}
+
+// CodigoHamming describe un código Hamming(N,K) candidato de ElegirHamming
+type CodigoHamming struct {
+	N, K int
+}
+
+// RendimientoHamming es la fracción de los bits codificados que llega como
+// dato útil: bitsDatos / (bloques·N), por la probabilidad de recuperar todos
+// los bloques del payload con BER ber
+func RendimientoHamming(c CodigoHamming, bitsDatos int, ber float64) float64 {
+	if bitsDatos <= 0 {
+		return 0
+	}
+	bloques := (bitsDatos + c.K - 1) / c.K
+	return float64(bitsDatos) / float64(bloques*c.N) * math.Pow(ProbBloqueHamming(c.N, ber), float64(bloques))
+}
+
+// ElegirHamming devuelve el índice del código de codigos con mayor
+// RendimientoHamming para un payload de bitsDatos bits. Ante un empate gana el
+// primero, así que conviene listar los códigos del más robusto al más
+// eficiente.
+func ElegirHamming(codigos []CodigoHamming, bitsDatos int, ber float64) int {
+	mejor, mejorRendimiento := 0, -1.0
+	for i, c := range codigos {
+		if r := RendimientoHamming(c, bitsDatos, ber); r > mejorRendimiento {
+			mejor, mejorRendimiento = i, r
+		}
+	}
+	return mejor
+}
+
+// ProbSinErrores es la probabilidad de que n bits lleguen intactos con BER p
+func ProbSinErrores(n int, p float64) float64 {
+	return math.Pow(1-p, float64(n))
+}
+
+// Prediccion son las probabilidades teóricas para una trama con BER dado
+type Prediccion struct {
+	Algoritmo string
+	BitsTrama int     // Bits de la trama completa (header + payload + CRC)
+	Bloques   int     // Bloques Hamming del payload (0 en crc)
+	BER       float64 // Probabilidad de error por bit
+	// TramaIntacta es la probabilidad de que ningún bit de la trama cambie,
+	// que es la de aceptación por CRC salvo errores no detectados
+	TramaIntacta float64
+	// DeteccionCRC es la probabilidad de que el CRC de BitsCRC bits detecte
+	// una trama alterada por errores aleatorios (1-2^-BitsCRC)
+	DeteccionCRC float64
+	BitsCRC      int
+	// BloqueRecuperable y PayloadRecuperable (solo hamming) son las
+	// probabilidades de corregir un bloque y todos los del payload
+	BloqueRecuperable  float64
+	PayloadRecuperable float64
+}
+
+// Predecir calcula la predicción de algoritmo para una trama de bitsTrama
+// bits con bloques bloques Hamming (ignorado en crc) protegida por CRC-32
+// (ver FijarBitsCRC)
+func Predecir(algoritmo string, bitsTrama, bloques int, ber float64) (*Prediccion, error) {
+	if ber < 0 || ber > 1 {
+		return nil, fmt.Errorf("BER inválido: %.3f (debe estar entre 0.0 y 1.0)", ber)
+	}
+	if bitsTrama <= 0 {
+		return nil, fmt.Errorf("la trama debe tener bits: %d", bitsTrama)
+	}
+
+	p := &Prediccion{
+		Algoritmo:    algoritmo,
+		BitsTrama:    bitsTrama,
+		BER:          ber,
+		TramaIntacta: ProbSinErrores(bitsTrama, ber),
+		DeteccionCRC: 1 - ProbNoDeteccionCRC32,
+		BitsCRC:      32,
+	}
+	switch algoritmo {
+	case "crc":
+	case "hamming":
+		if bloques <= 0 || bloques*7 > bitsTrama {
+			return nil, fmt.Errorf("cantidad de bloques Hamming inválida para %d bits: %d", bitsTrama, bloques)
+		}
+		p.Bloques = bloques
+		p.BloqueRecuperable = ProbBloqueHamming74(ber)
+		p.PayloadRecuperable = math.Pow(p.BloqueRecuperable, float64(bloques))
+	default:
+		return nil, fmt.Errorf("algoritmo sin modelo teórico: %s", algoritmo)
+	}
+	return p, nil
+}
+
+// FijarBitsCRC cambia el ancho del CRC de la predicción. No afecta las demás
+// probabilidades, que ya cuentan todos los bits de la trama.
+func (p *Prediccion) FijarBitsCRC(bits int) {
+	p.BitsCRC = bits
+	p.DeteccionCRC = 1 - ProbNoDeteccionCRC(bits)
+}
+
+// DesviacionRelativa es (medido - predicho) / predicho; 0 si ambos son 0
+func DesviacionRelativa(medido, predicho float64) float64 {
+	if predicho == 0 {
+		if medido == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return (medido - predicho) / predicho
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func casiIgual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestProbBloqueHamming74(t *testing.T) {
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{1, 0},
+		{0.5, 8.0 / 128},                // (1/2)^7 · (1 + 7)
+		{0.1, 0.4782969 + 0.7*0.531441}, // 0.9^7 + 7·0.1·0.9^6
+		{0.01, 0.9320653479069899 + 0.07*0.941480149401}, // 0.99^7 + 7·0.01·0.99^6
+	}
+	for _, tt := range tests {
+		if got := ProbBloqueHamming74(tt.p); !casiIgual(got, tt.want) {
+			t.Errorf("ProbBloqueHamming74(%v) = %v, se esperaba %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestPredecir(t *testing.T) {
+	crc, err := Predecir("crc", 2, 0, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !casiIgual(crc.TramaIntacta, 0.81) || crc.PayloadRecuperable != 0 {
+		t.Errorf("predicción crc inesperada: %+v", crc)
+	}
+	if !casiIgual(crc.DeteccionCRC, 1-1/4294967296.0) {
+		t.Errorf("DeteccionCRC = %v", crc.DeteccionCRC)
+	}
+
+	// Trama Hamming de "Hola": 3 + 7 + 4 bytes, 8 bloques
+	h, err := Predecir("hamming", 112, 8, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(h.TramaIntacta-0.3244455) > 1e-6 || math.Abs(h.PayloadRecuperable-0.9838667) > 1e-6 {
+		t.Errorf("predicción hamming inesperada: %+v", h)
+	}
+
+	for _, c := range []struct {
+		algoritmo     string
+		bits, bloques int
+		ber           float64
+	}{
+		{"hamming", 112, 0, 0.01},
+		{"hamming", 14, 8, 0.01},
+		{"crc", 0, 0, 0.01},
+		{"crc", 8, 0, 1.5},
+		{"rs", 8, 0, 0.01},
+	} {
+		if _, err := Predecir(c.algoritmo, c.bits, c.bloques, c.ber); err == nil {
+			t.Errorf("se esperaba error para %+v", c)
+		}
+	}
+}
+
+func TestDesviacionRelativa(t *testing.T) {
+	if d := DesviacionRelativa(0.9, 1); !casiIgual(d, -0.1) {
+		t.Errorf("DesviacionRelativa(0.9, 1) = %v", d)
+	}
+	if d := DesviacionRelativa(0, 0); d != 0 {
+		t.Errorf("DesviacionRelativa(0, 0) = %v", d)
+	}
+}
+
+func TestPrediccion_FijarBitsCRC(t *testing.T) {
+	p, err := Predecir("crc", 56, 0, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intacta := p.TramaIntacta
+	p.FijarBitsCRC(8)
+	if p.BitsCRC != 8 || !casiIgual(p.DeteccionCRC, 1-1/256.0) || p.TramaIntacta != intacta {
+		t.Errorf("predicción con CRC-8 inesperada: %+v", p)
+	}
+}
+
+func TestElegirHamming(t *testing.T) {
+	codigos := []CodigoHamming{{7, 4}, {15, 11}}
+	bers := []float64{0, 0.001, 0.01, 0.05, 0.1}
+	// Índice elegido por largo del payload (filas) y BER (columnas): (7,4)
+	// desperdicia menos en mensajes de un byte y resiste mejor BER altos;
+	// (15,11) rinde más en el resto
+	grilla := []struct {
+		bits  int
+		elige []int
+	}{
+		{8, []int{0, 0, 0, 0, 0}},
+		{32, []int{1, 1, 1, 1, 0}},
+		{88, []int{1, 1, 1, 0, 0}},
+		{400, []int{1, 1, 1, 0, 0}},
+		{4000, []int{1, 1, 0, 0, 0}},
+	}
+	for _, fila := range grilla {
+		for i, ber := range bers {
+			if got := ElegirHamming(codigos, fila.bits, ber); got != fila.elige[i] {
+				t.Errorf("ElegirHamming(%d bits, BER %v) = %d, se esperaba %d", fila.bits, ber, got, fila.elige[i])
+			}
+		}
+	}
+
+	// Determinista y con empate a favor del primero
+	if ElegirHamming(codigos, 0, 0.01) != 0 || ElegirHamming([]CodigoHamming{{15, 11}, {15, 11}}, 88, 0) != 0 {
+		t.Error("el empate debería favorecer al primer código")
+	}
+}
+
+func TestProbBloqueHamming(t *testing.T) {
+	if got, want := ProbBloqueHamming(7, 0.1), ProbBloqueHamming74(0.1); got != want {
+		t.Errorf("ProbBloqueHamming(7, 0.1) = %v, ProbBloqueHamming74 = %v", got, want)
+	}
+	if got := ProbBloqueHamming(15, 0.5); !casiIgual(got, 16/32768.0) {
+		t.Errorf("ProbBloqueHamming(15, 0.5) = %v", got)
+	}
+}
@@ -1,25 +1,291 @@
 package wsclient
 
 import (
-    "github.com/gorilla/websocket"
-    "time"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/gorilla/websocket"
 )
 
+// tlsSessionCacheSize es la cantidad de sesiones TLS que se conservan para reanudar
+const tlsSessionCacheSize = 64
+
+// dialer es compartido por todas las conexiones para que la caché de sesiones
+// TLS permita reanudar el handshake al reconectar contra wss://
+var dialer = newDialer(&tls.Config{})
+
+func newDialer(cfg *tls.Config) *websocket.Dialer {
+	cfg = cfg.Clone()
+	if cfg.ClientSessionCache == nil {
+		cfg.ClientSessionCache = tls.NewLRUClientSessionCache(tlsSessionCacheSize)
+	}
+	return &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+		TLSClientConfig:  cfg,
+	}
+}
+
+// SetTLSConfig reemplaza la configuración TLS usada para wss:// (por ejemplo
+// para confiar en una CA propia). Si cfg no trae caché de sesiones se crea una.
+func SetTLSConfig(cfg *tls.Config) {
+	dialer = newDialer(cfg)
+}
+
+// ConnStats contiene los tiempos de establecimiento de una conexión
+type ConnStats struct {
+	Dial         time.Duration // Conexión TCP
+	TLSHandshake time.Duration // Handshake TLS (0 si la URL es ws://)
+	TLSResumed   bool          // La sesión TLS se reanudó desde la caché
+}
+
 // SendFrame se conecta al servidor WebSocket en url y envía la trama bytes.
 func SendFrame(url string, frame []byte) error {
-    // 1) Conexión
-    conn, _, err := websocket.DefaultDialer.Dial(url, nil)
-    if err != nil {
-        return err
-    }
-    defer conn.Close()
-
-    // 2) Establecer un deadline para la escritura
-    conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
-
-    // 3) Enviar trama como mensaje binario
-    if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
-        return err
-    }
-    return nil
+	_, err := SendFrameWithStats(url, frame)
+	return err
+}
+
+// SendFrameWithStats es como SendFrame pero además devuelve los tiempos de
+// conexión y handshake TLS medidos con httptrace.
+func SendFrameWithStats(url string, frame []byte) (*ConnStats, error) {
+	return SendFrameContext(context.Background(), url, frame)
+}
+
+// writeTimeout es el plazo de escritura cuando ctx no trae deadline propio
+const writeTimeout = 5 * time.Second
+
+// SendFrameContext es como SendFrameWithStats pero respeta ctx: la conexión
+// se cancela con él y el deadline de escritura es el de ctx si vence antes
+// que writeTimeout.
+func SendFrameContext(ctx context.Context, url string, frame []byte) (*ConnStats, error) {
+	conn, stats, err := enviar(ctx, url, frame)
+	if err != nil {
+		return stats, err
+	}
+	conn.Close()
+	return stats, nil
+}
+
+// Errores de SendFrameAwaitReply una vez enviada la trama: ErrNoReply si el
+// receptor no respondió dentro del plazo (o cerró la conexión sin hacerlo) y
+// ErrInvalidReply si lo que respondió no es un ACK/NACK válido
+var (
+	ErrNoReply      = errors.New("el receptor no respondió")
+	ErrInvalidReply = errors.New("respuesta inválida")
+)
+
+// SendFrameAwaitReply envía la trama como SendFrame y espera hasta timeout un
+// mensaje binario de respuesta, que decodifica con frame.ParseReply. Sin
+// respuesta a tiempo devuelve un error que envuelve ErrNoReply.
+func SendFrameAwaitReply(url string, trama []byte, timeout time.Duration) (*frame.Reply, error) {
+	_, reply, err := SendFrameAwaitReplyContext(context.Background(), url, trama, timeout)
+	return reply, err
+}
+
+// SendFrameAwaitReplyContext es SendFrameAwaitReply respetando ctx como
+// SendFrameContext, y devuelve además los tiempos de conexión. La espera
+// termina con timeout o con el deadline de ctx, lo que llegue antes.
+func SendFrameAwaitReplyContext(ctx context.Context, url string, trama []byte, timeout time.Duration) (*ConnStats, *frame.Reply, error) {
+	conn, stats, err := enviar(ctx, url, trama)
+	if err != nil {
+		return stats, nil, err
+	}
+	defer conn.Close()
+	// Cancelar ctx corta la lectura cerrando la conexión
+	defer context.AfterFunc(ctx, func() { conn.Close() })()
+
+	limite := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(limite) {
+		limite = d
+	}
+	conn.SetReadDeadline(limite)
+
+	tipo, msg, err := conn.ReadMessage()
+	if err != nil {
+		if ctx.Err() != nil {
+			return stats, nil, ctx.Err()
+		}
+		var nerr net.Error
+		if errors.As(err, &nerr) && nerr.Timeout() {
+			return stats, nil, fmt.Errorf("%w en %v", ErrNoReply, timeout)
+		}
+		return stats, nil, fmt.Errorf("%w: %v", ErrNoReply, err)
+	}
+	if tipo != websocket.BinaryMessage {
+		return stats, nil, fmt.Errorf("%w: mensaje de texto, se esperaba una trama binaria", ErrInvalidReply)
+	}
+	reply, err := frame.ParseReply(msg)
+	if err != nil {
+		return stats, nil, fmt.Errorf("%w: %w", ErrInvalidReply, err)
+	}
+	return stats, reply, nil
+}
+
+// enviar se conecta a url y envía la trama; quien llama cierra la conexión
+func enviar(ctx context.Context, url string, frame []byte) (*websocket.Conn, *ConnStats, error) {
+	stats := &ConnStats{}
+	var dialStart, tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		GetConn:           func(string) { dialStart = time.Now() },
+		GotConn:           func(httptrace.GotConnInfo) { stats.Dial = time.Since(dialStart) },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			stats.TLSHandshake = time.Since(tlsStart)
+			stats.TLSResumed = err == nil && state.DidResume
+		},
+	}
+	ctx = httptrace.WithClientTrace(ctx, trace)
+
+	// 1) Conexión
+	conn, _, err := dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, stats, err
+	}
+
+	// 2) Establecer un deadline para la escritura
+	limite := time.Now().Add(writeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(limite) {
+		limite = d
+	}
+	conn.SetWriteDeadline(limite)
+
+	// 3) Enviar trama como mensaje binario
+	if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+		conn.Close()
+		return nil, stats, err
+	}
+	return conn, stats, nil
+}
+
+// ErrKeepalive envuelve el error de escritura que detuvo el keepalive; Send
+// lo devuelve desde entonces porque la conexión ya no sirve
+var ErrKeepalive = errors.New("el keepalive falló")
+
+// Conn es una conexión persistente para enviar muchas tramas sin reconectar
+type Conn struct {
+	ws *websocket.Conn
+	// reloj mide la inactividad del keepalive; los deadlines del socket
+	// siguen en tiempo real
+	reloj clock.Clock
+
+	// mu serializa las escrituras de Send y del keepalive: la conexión admite
+	// un solo escritor a la vez
+	mu           sync.Mutex
+	ultimo       time.Time     // Última trama enviada, según reloj
+	errKeepalive error         // Primer error de escritura del keepalive
+	stop         chan struct{} // Se cierra para detener el keepalive
+	done         chan struct{} // Se cierra cuando el keepalive terminó
+}
+
+// Dial abre una conexión persistente con el receptor en url
+func Dial(url string) (*Conn, error) {
+	return dial(url, clock.Real())
+}
+
+func dial(url string, reloj clock.Clock) (*Conn, error) {
+	ws, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{ws: ws, reloj: reloj, ultimo: reloj.Now()}, nil
+}
+
+// Send envía la trama como mensaje binario sobre la conexión abierta
+func (c *Conn) Send(frame []byte) error {
+	return c.SendContext(context.Background(), frame)
+}
+
+// SendContext es Send con el deadline de escritura de ctx si vence antes
+// que writeTimeout. Si el keepalive falló devuelve su error, que envuelve
+// ErrKeepalive, sin intentar escribir.
+func (c *Conn) SendContext(ctx context.Context, frame []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.errKeepalive != nil {
+		return c.errKeepalive
+	}
+	limite := time.Now().Add(writeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(limite) {
+		limite = d
+	}
+	c.ws.SetWriteDeadline(limite)
+	c.ultimo = c.reloj.Now()
+	return c.ws.WriteMessage(websocket.BinaryMessage, frame)
+}
+
+// StartKeepalive envía una trama frame.MsgTypeKeepalive (payload vacío) cada
+// vez que la conexión pasa interval sin enviar nada, para que el receptor o
+// un proxy intermedio no la cierren por inactividad. Es opcional porque un
+// receptor que no conoce el tipo la rechaza. El keepalive termina con Close o
+// con el primer error de escritura, que Send devuelve desde entonces.
+func (c *Conn) StartKeepalive(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("intervalo de keepalive inválido: %v", interval)
+	}
+	keepalive, err := frame.BuildKeepaliveFrame()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		return errors.New("el keepalive ya está activo")
+	}
+	c.stop, c.done = make(chan struct{}), make(chan struct{})
+	go c.keepalive(c.reloj.NewTicker(interval), interval, keepalive, c.stop, c.done)
+	return nil
+}
+
+func (c *Conn) keepalive(ticker clock.Ticker, interval time.Duration, trama []byte, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C():
+		}
+		c.mu.Lock()
+		var err error
+		if c.reloj.Since(c.ultimo) >= interval {
+			c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+			c.ultimo = c.reloj.Now()
+			if err = c.ws.WriteMessage(websocket.BinaryMessage, trama); err != nil {
+				c.errKeepalive = fmt.Errorf("%w: %w", ErrKeepalive, err)
+			}
+		}
+		c.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Close detiene el keepalive y cierra la conexión avisando al receptor con un
+// mensaje de cierre
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop = nil
+	c.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second))
+	return c.ws.Close()
 }
@@ -0,0 +1,333 @@
+package wsclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/clock"
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/frame"
+	"github.com/gorilla/websocket"
+)
+
+// newTLSReceiver levanta un servidor wss:// de prueba que reenvía cada trama a received
+func newTLSReceiver(t *testing.T, received chan<- []byte) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, msg, err := conn.ReadMessage()
+		if err == nil {
+			received <- msg
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	SetTLSConfig(&tls.Config{RootCAs: pool})
+	t.Cleanup(func() { SetTLSConfig(&tls.Config{}) })
+	return srv
+}
+
+func TestSendFrameWithStats_ReanudaSesionTLS(t *testing.T) {
+	received := make(chan []byte, 2)
+	srv := newTLSReceiver(t, received)
+	url := "wss" + strings.TrimPrefix(srv.URL, "https")
+	frame := []byte{0x01, 0x00, 0x01, 0x41, 0xde, 0xad, 0xbe, 0xef}
+
+	first, err := SendFrameWithStats(url, frame)
+	if err != nil {
+		t.Fatalf("primer envío falló: %v", err)
+	}
+	if got := <-received; !bytes.Equal(got, frame) {
+		t.Errorf("trama recibida %x, se esperaba %x", got, frame)
+	}
+	if first.TLSResumed {
+		t.Error("la primera conexión no debería reanudar sesión")
+	}
+	if first.Dial <= 0 || first.TLSHandshake <= 0 {
+		t.Errorf("tiempos no registrados: dial=%v tls=%v", first.Dial, first.TLSHandshake)
+	}
+
+	second, err := SendFrameWithStats(url, frame)
+	if err != nil {
+		t.Fatalf("segundo envío falló: %v", err)
+	}
+	<-received
+	if !second.TLSResumed {
+		t.Error("la segunda conexión debería reanudar la sesión desde la caché")
+	}
+}
+
+func TestSendFrameWithStats_SinTLS(t *testing.T) {
+	received := make(chan []byte, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, msg, err := conn.ReadMessage(); err == nil {
+			received <- msg
+		}
+	}))
+	defer srv.Close()
+
+	stats, err := SendFrameWithStats("ws"+strings.TrimPrefix(srv.URL, "http"), []byte{0x01})
+	if err != nil {
+		t.Fatalf("envío falló: %v", err)
+	}
+	<-received
+	if stats.Dial <= 0 {
+		t.Errorf("tiempo de dial no registrado: %v", stats.Dial)
+	}
+	if stats.TLSHandshake != 0 || stats.TLSResumed {
+		t.Errorf("ws:// no debería registrar TLS: %+v", stats)
+	}
+}
+
+func TestSendFrameContext_PlazoVencido(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("con el plazo vencido no debería llegar ninguna conexión")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if _, err := SendFrameContext(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), []byte{0x01}); err == nil {
+		t.Error("se esperaba error con el contexto vencido")
+	}
+}
+
+// newReplyReceiver levanta un receptor ws:// que contesta cada trama con
+// responder (sin contestar si devuelve nil)
+func newReplyReceiver(t *testing.T, responder func(msg []byte) []byte) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if resp := responder(msg); resp != nil {
+			conn.WriteMessage(websocket.BinaryMessage, resp)
+		}
+		// Esperar a que el emisor cierre
+		conn.ReadMessage()
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestSendFrameAwaitReply(t *testing.T) {
+	url := newReplyReceiver(t, func(msg []byte) []byte {
+		f, err := frame.ParseFrame(msg)
+		if err != nil {
+			nack, _ := frame.BuildNackFrame(0, frame.ReplyCodeFor(err))
+			return nack
+		}
+		ack, _ := frame.BuildAckFrame(f.Seq)
+		return ack
+	})
+
+	trama, _ := frame.BuildFrameWithTypeAndSeq([]byte("Hola"), frame.MsgTypeData, 12)
+	reply, err := SendFrameAwaitReply(url, trama, time.Second)
+	if err != nil || *reply != (frame.Reply{Ack: true, Seq: 12}) {
+		t.Errorf("respuesta %+v, %v", reply, err)
+	}
+
+	trama[len(trama)-1] ^= 1
+	reply, err = SendFrameAwaitReply(url, trama, time.Second)
+	if err != nil || reply.Ack || reply.Code != frame.ReplyCRCMismatch {
+		t.Errorf("respuesta a una trama corrupta %+v, %v", reply, err)
+	}
+}
+
+func TestSendFrameAwaitReply_SinRespuesta(t *testing.T) {
+	url := newReplyReceiver(t, func([]byte) []byte { return nil })
+	inicio := time.Now()
+	if _, err := SendFrameAwaitReply(url, []byte{0x01}, 50*time.Millisecond); !errors.Is(err, ErrNoReply) {
+		t.Errorf("error %v, se esperaba ErrNoReply", err)
+	}
+	if d := time.Since(inicio); d > 2*time.Second {
+		t.Errorf("la espera duró %v con un plazo de 50ms", d)
+	}
+}
+
+func TestSendFrameAwaitReply_RespuestaInvalida(t *testing.T) {
+	url := newReplyReceiver(t, func([]byte) []byte { return []byte{0x09, 0x00} })
+	if _, err := SendFrameAwaitReply(url, []byte{0x01}, time.Second); !errors.Is(err, ErrInvalidReply) {
+		t.Errorf("error %v, se esperaba una respuesta inválida", err)
+	}
+}
+
+func TestSendFrameAwaitReplyContext_Cancelar(t *testing.T) {
+	url := newReplyReceiver(t, func([]byte) []byte { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	if _, _, err := SendFrameAwaitReplyContext(ctx, url, []byte{0x01}, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("error %v, se esperaba context.Canceled", err)
+	}
+}
+
+// newFrameReceiver levanta un receptor ws:// que reenvía cada trama a received
+func newFrameReceiver(t *testing.T, received chan<- []byte) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- msg
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// recibirTipo espera la próxima trama y devuelve su tipo
+func recibirTipo(t *testing.T, received <-chan []byte) byte {
+	t.Helper()
+	select {
+	case msg := <-received:
+		f, err := frame.ParseFrame(msg)
+		if err != nil {
+			t.Fatalf("trama inválida %x: %v", msg, err)
+		}
+		return f.MsgType
+	case <-time.After(2 * time.Second):
+		t.Fatal("el receptor no recibió ninguna trama")
+		return 0
+	}
+}
+
+// dialManual abre una conexión con el receptor cuyo keepalive mide la
+// inactividad con un reloj manual
+func dialManual(t *testing.T, url string) (*Conn, *clock.Manual) {
+	t.Helper()
+	reloj := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	conn, err := dial(url, reloj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn, reloj
+}
+
+func TestConn_Keepalive(t *testing.T) {
+	received := make(chan []byte, 16)
+	conn, reloj := dialManual(t, newFrameReceiver(t, received))
+	if err := conn.StartKeepalive(0); err == nil {
+		t.Error("se esperaba error con un intervalo de 0")
+	}
+	if err := conn.StartKeepalive(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.StartKeepalive(time.Minute); err == nil {
+		t.Error("se esperaba error al activar el keepalive dos veces")
+	}
+
+	for i := 0; i < 2; i++ {
+		reloj.Advance(time.Minute)
+		if tipo := recibirTipo(t, received); tipo != frame.MsgTypeKeepalive {
+			t.Fatalf("trama %d de tipo %#02x, se esperaba keepalive", i, tipo)
+		}
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close() // Cerrar dos veces no debe detener el keepalive de nuevo
+}
+
+func TestConn_KeepaliveSoloSinTrafico(t *testing.T) {
+	received := make(chan []byte, 16)
+	conn, reloj := dialManual(t, newFrameReceiver(t, received))
+	defer conn.Close()
+	if err := conn.StartKeepalive(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// Una trama a mitad del intervalo hace que el tick no encuentre la
+	// conexión inactiva
+	trama, _ := frame.BuildFrame([]byte("Hola"))
+	reloj.Advance(30 * time.Second)
+	if err := conn.Send(trama); err != nil {
+		t.Fatal(err)
+	}
+	reloj.Advance(30 * time.Second)
+	if tipo := recibirTipo(t, received); tipo != frame.MsgTypeData {
+		t.Fatalf("llegó una trama de tipo %#02x, se esperaba data", tipo)
+	}
+
+	// Un intervalo entero sin tráfico sí dispara el keepalive
+	reloj.Advance(time.Minute)
+	if tipo := recibirTipo(t, received); tipo != frame.MsgTypeKeepalive {
+		t.Errorf("tras el tráfico llegó una trama de tipo %#02x, se esperaba keepalive", tipo)
+	}
+}
+
+func TestConn_KeepaliveErrorEnSend(t *testing.T) {
+	conn, reloj := dialManual(t, newFrameReceiver(t, make(chan []byte, 16)))
+	defer conn.Close()
+	if err := conn.StartKeepalive(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// Con el socket cerrado la escritura del keepalive falla y lo detiene
+	conn.ws.UnderlyingConn().Close()
+	reloj.Advance(time.Minute)
+	conn.mu.Lock()
+	done := conn.done
+	conn.mu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("el keepalive no terminó tras el error de escritura")
+	}
+
+	trama, _ := frame.BuildFrame([]byte("Hola"))
+	if err := conn.Send(trama); !errors.Is(err, ErrKeepalive) {
+		t.Errorf("Send devolvió %v, se esperaba el error del keepalive", err)
+	}
+	if err := conn.SendContext(context.Background(), trama); !errors.Is(err, ErrKeepalive) {
+		t.Errorf("SendContext devolvió %v, se esperaba el error del keepalive", err)
+	}
+}
+
+func TestConn_SendContextCancelado(t *testing.T) {
+	received := make(chan []byte, 16)
+	conn, err := Dial(newFrameReceiver(t, received))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := conn.SendContext(ctx, []byte{0x01}); !errors.Is(err, context.Canceled) {
+		t.Errorf("error %v, se esperaba context.Canceled", err)
+	}
+}
@@ -0,0 +1,48 @@
+package wsclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/Diegoval-Dev/R-Lab2/emitter-go/pkg/wsclient"
+	"github.com/gorilla/websocket"
+)
+
+func ExampleDial() {
+	// Receptor mínimo que cuenta los bytes de cada trama
+	recibidas := make(chan int, 2)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			recibidas <- len(msg)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := wsclient.Dial("ws" + strings.TrimPrefix(srv.URL, "http"))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+
+	for _, trama := range [][]byte{{0x01, 0x00, 0x00}, {0x01, 0x00, 0x01, 0x41}} {
+		if err := conn.Send(trama); err != nil {
+			panic(err)
+		}
+		fmt.Println("recibidos:", <-recibidas, "bytes")
+	}
+	// Output:
+	// recibidos: 3 bytes
+	// recibidos: 4 bytes
+}
@@ -136,4 +136,79 @@ def parse_frame_header(frame_bytes: bytes) -> Tuple[int, int]:
     msg_type = frame_bytes[0]
     payload_length = int.from_bytes(frame_bytes[1:3], 'big')
     
-    return msg_type, payload_length
\ No newline at end of file
+    return msg_type, payload_length
+
+# Subheader del payload Hamming (ver HammingPadSize en emitter-go): un byte con
+# los bits de relleno a descartar tras decodificar; su bit alto indica que le
+# sigue un byte con la profundidad de entrelazado
+HAMMING_PAD_SIZE = 1
+HAMMING_FLAG_INTERLEAVE = 0x80
+
+
+def _interleaved_index(i: int, length: int, depth: int) -> int:
+    """Posicion de salida del entrelazado para el bit i (ver Interleave en emitter-go)"""
+    rows = (length + depth - 1) // depth
+    full = length % depth or depth  # Columnas con rows bits; las demas tienen rows-1
+    col, row = i % depth, i // depth
+    if col < full:
+        return col * rows + row
+    return full * rows + (col - full) * (rows - 1) + row
+
+
+def deinterleave(bits: List[int], depth: int) -> List[int]:
+    """Deshace el entrelazado por bloques de profundidad depth del emisor"""
+    if depth <= 1:
+        return list(bits)
+    return [bits[_interleaved_index(i, len(bits), depth)] for i in range(len(bits))]
+
+
+def parse_hamming_payload(payload: bytes) -> Tuple[int, int, bytes]:
+    """
+    Separa el subheader de un payload Hamming.
+    
+    Args:
+        payload: Payload de la trama [relleno(1)][profundidad(1) si entrelazado] + bloques
+        
+    Returns:
+        (pad_bits, depth, blocks): bits de relleno, profundidad de entrelazado
+        (0 sin entrelazar) y bloques ya desentrelazados
+    """
+    if len(payload) < HAMMING_PAD_SIZE:
+        raise ValueError("Payload Hamming sin subheader")
+    
+    pad_bits = payload[0] & ~HAMMING_FLAG_INTERLEAVE & 0xFF
+    if payload[0] & HAMMING_FLAG_INTERLEAVE == 0:
+        return pad_bits, 0, payload[HAMMING_PAD_SIZE:]
+    
+    if len(payload) < HAMMING_PAD_SIZE + 1:
+        raise ValueError("Payload Hamming entrelazado sin byte de profundidad")
+    depth = payload[HAMMING_PAD_SIZE]
+    blocks = payload[HAMMING_PAD_SIZE + 1:]
+    return pad_bits, depth, bits_to_bytes(deinterleave(bytes_to_bits(blocks), depth))
+
+
+# Tramas de bits (MsgTypeBits en emitter-go): BuildFrameFromBits las usa cuando
+# los bits no completan el último byte; el payload es un byte con los bits de
+# relleno (1 a 7) seguido de los bits agrupados en bytes
+MSG_TYPE_BITS = 0x0D
+BITS_PAD_SIZE = 1
+
+
+def parse_bits_payload(payload: bytes) -> List[int]:
+    """
+    Separa el subheader de un payload de bits y descarta el relleno.
+    
+    Args:
+        payload: Payload de la trama [relleno(1)] + bits agrupados en bytes
+        
+    Returns:
+        Bits de datos, sin el relleno del último byte
+    """
+    if len(payload) < BITS_PAD_SIZE + 1:
+        raise ValueError("Payload de bits sin subheader o sin datos")
+    
+    pad_bits = payload[0]
+    if pad_bits < 1 or pad_bits > 7:
+        raise ValueError(f"Relleno inválido: {pad_bits} bits, se esperaba de 1 a 7")
+    bits = bytes_to_bits(payload[BITS_PAD_SIZE:])
+    return bits[:len(bits) - pad_bits]
@@ -9,7 +9,7 @@ from presentation import ascii_to_bits, bits_to_ascii
 from link import LinkLayer
 from noise import inject_noise, calculate_error_stats
 from transport import MockTransport
-from algorithms import bytes_to_bits, bits_to_bytes
+from algorithms import bytes_to_bits, bits_to_bytes, MSG_TYPE_BITS
 
 
 class BenchmarkRunner:
@@ -128,12 +128,14 @@ class BenchmarkRunner:
                 original_bits_len = 0
                 encoded_bits_len = 0
             
-            if msg_type == 0x01:  # CRC - must be valid
+            if msg_type in (0x01, MSG_TYPE_BITS):  # CRC - must be valid
                 if not is_valid:
                     result['error'] = 'CRC validation failed'
                     return result
                 
                 payload_bits = bytes_to_bits(payload)
+                if msg_type == MSG_TYPE_BITS:
+                    payload_bits = payload_bits[:original_bits_len]
                 recovered_message = bits_to_ascii(payload_bits)
                 result['recovered_message'] = recovered_message
                 result['valid'] = True
@@ -13,7 +13,7 @@ from dataclasses import dataclass, asdict
 import logging
 
 # Import capas existentes
-from algorithms import verify_crc, hamming74_decode, bytes_to_bits, bits_to_bytes, parse_frame_header
+from algorithms import verify_crc, hamming74_decode, bytes_to_bits, bits_to_bytes, parse_frame_header, parse_hamming_payload, MSG_TYPE_BITS
 from presentation import bits_to_ascii, ascii_to_bits
 from link import LinkLayer
 import noise
@@ -107,8 +107,8 @@ class LayeredReceiver:
                 logger.info(f"🔍 Tipo de mensaje detectado: 0x{tentative_msg_type:02x}")
                 
                 # Manejo simple y robusto del tipo de mensaje con ruido
-                if tentative_msg_type == 0x01:
-                    # CRC claro
+                if tentative_msg_type in [0x01, MSG_TYPE_BITS]:
+                    # CRC claro (0x0D: bits que no completan el último byte)
                     algorithm_type = "crc"
                 elif tentative_msg_type == 0x02:
                     # Hamming claro
@@ -142,6 +142,9 @@ class LayeredReceiver:
                     result.crc_valid = True
                     self.stats['crc_valid'] += 1
                     decoded_bits = bytes_to_bits(payload)
+                    if msg_type == MSG_TYPE_BITS:
+                        # Descartar el relleno del último byte
+                        decoded_bits = decoded_bits[:original_bits_len]
                     logger.debug("✅ Frame CRC válido")
                     
                 elif algorithm_type == "hamming":
@@ -178,13 +181,14 @@ class LayeredReceiver:
                         logger.info(f"🔧 Hamming corrigió {len(corrections)} errores")
                         self.stats['hamming_corrected'] += 1
                     
-                    # Decodificar payload corregido
+                    # Decodificar payload corregido (parse_frame ya quitó el
+                    # subheader) y descartar los bits de relleno
                     payload_bits = bytes_to_bits(payload)
-                    valid_length = (len(payload_bits) // 7) * 7
-                    trimmed_bits = payload_bits[:valid_length]
+                    trimmed_bits = payload_bits[:encoded_bits_len]
                     
                     try:
                         decoded_bits, _ = hamming74_decode(trimmed_bits)
+                        decoded_bits = decoded_bits[:original_bits_len]
                     except Exception as e:
                         result.error_message = f"Final Hamming decode failed: {str(e)}"
                         self.stats['hamming_failed'] += 1
@@ -305,8 +309,13 @@ class LayeredReceiver:
                 logger.warning(f"⚠️ Longitud de payload no coincide: esperado {payload_length}, recibido {len(payload)}")
                 # Continuar anyway, puede ser por ruido en header
             
-            # Aplicar corrección Hamming al payload
-            payload_bits = bytes_to_bits(payload)
+            # Separar el subheader [relleno][profundidad] de los bloques
+            pad_bits, depth, blocks = parse_hamming_payload(payload)
+            if depth:
+                logger.debug(f"🔀 Bloques Hamming entrelazados con profundidad {depth}")
+            
+            # Aplicar corrección Hamming a los bloques
+            payload_bits = bytes_to_bits(blocks)
             valid_length = (len(payload_bits) // 7) * 7
             trimmed_bits = payload_bits[:valid_length]
             
@@ -320,8 +329,9 @@ class LayeredReceiver:
             from link import LinkLayer
             corrected_hamming_bits = LinkLayer.apply_hamming(decoded_bits)
             
-            # Convertir a bytes con padding si necesario
-            corrected_payload = bits_to_bytes(corrected_hamming_bits)
+            # Convertir a bytes con padding si necesario; los bloques ya van
+            # desentrelazados, así que el subheader es solo el relleno
+            corrected_payload = bytes([pad_bits]) + bits_to_bytes(corrected_hamming_bits)
             
             # Reconstruir frame con payload corregido
             # Usar la longitud del payload corregido
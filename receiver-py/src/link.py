@@ -5,7 +5,11 @@ Handles CRC-32 and Hamming(7,4) processing
 
 import binascii
 from typing import List, Tuple
-from algorithms import hamming74_decode, bytes_to_bits, bits_to_bytes
+from algorithms import (
+    hamming74_decode, bytes_to_bits, bits_to_bytes,
+    parse_hamming_payload, HAMMING_FLAG_INTERLEAVE,
+    parse_bits_payload, MSG_TYPE_BITS
+)
 
 
 class LinkLayer:
@@ -106,25 +110,36 @@ class LinkLayer:
         
         Args:
             payload: Payload data
-            msg_type: Message type (0x01 = RAW+CRC, 0x02 = HAMMING+CRC)
+            msg_type: Message type (0x01 = RAW+CRC, 0x02 = HAMMING+CRC, 0x0D = BITS+CRC)
             original_bits_len: Original bit length before Hamming encoding (for msg_type=0x02)
-            encoded_bits_len: Hamming encoded bit length before byte conversion (for msg_type=0x02)
+                or data bit length, not a multiple of 8 (for msg_type=0x0D)
+            encoded_bits_len: Unused, kept for compatibility: the block count follows from the payload
             
         Returns:
             Complete frame bytes
         """
+        # For Hamming messages, prepend the pad subheader: the number of extra
+        # data bits that decoding every whole 7-bit block yields
+        if msg_type == 0x02:
+            if original_bits_len is None:
+                raise ValueError("For Hamming frames, original_bits_len is required")
+            pad_bits = (len(payload) * 8) // 7 * 4 - original_bits_len
+            if pad_bits < 0 or pad_bits >= HAMMING_FLAG_INTERLEAVE:
+                raise ValueError(f"Hamming padding out of range: {pad_bits} bits")
+            payload = bytes([pad_bits]) + payload
+        
+        # For bit messages, prepend the padding that completes the last byte
+        if msg_type == MSG_TYPE_BITS:
+            if original_bits_len is None:
+                raise ValueError("For bit frames, original_bits_len is required")
+            pad_bits = len(payload) * 8 - original_bits_len
+            if pad_bits < 1 or pad_bits > 7:
+                raise ValueError(f"Bit frame padding out of range: {pad_bits} bits")
+            payload = bytes([pad_bits]) + payload
+        
         # Build header: type (1 byte) + length (2 bytes, big-endian)
         header = bytes([msg_type]) + len(payload).to_bytes(2, 'big')
         
-        # For Hamming messages, add subheader with bit lengths
-        if msg_type == 0x02:
-            if original_bits_len is not None and encoded_bits_len is not None:
-                # Add 2 bytes for original bit length + 2 bytes for encoded bit length
-                subheader = original_bits_len.to_bytes(2, 'big') + encoded_bits_len.to_bytes(2, 'big')
-                header += subheader
-            else:
-                raise ValueError("For Hamming frames, both original_bits_len and encoded_bits_len are required")
-        
         # Combine header + payload
         data = header + payload
         
@@ -144,7 +159,10 @@ class LinkLayer:
         Returns:
             Tuple of (is_valid, msg_type, payload, original_bits_len, encoded_bits_len)
             For msg_type=0x01: original_bits_len=0, encoded_bits_len=0
-            For msg_type=0x02: actual lengths
+            For msg_type=0x02: lengths derived from the pad subheader, with
+            payload holding only the (deinterleaved) Hamming blocks
+            For msg_type=0x0D: original_bits_len is the data bit count, with
+            payload holding the packed bits without the pad subheader
         """
         if len(frame) < 7:  # Minimum: 3 header + 0 payload + 4 CRC
             return False, 0, b'', 0, 0
@@ -158,22 +176,32 @@ class LinkLayer:
         msg_type = data_part[0]
         payload_length = int.from_bytes(data_part[1:3], 'big')
         
-        original_bits_len = 0
-        encoded_bits_len = 0
-        header_size = 3
-        
-        # For Hamming messages, parse extended subheader
-        if msg_type == 0x02:
-            if len(data_part) < 7:  # Need at least 7 bytes for extended header (3 + 2 + 2)
-                return False, msg_type, b'', 0, 0
-            original_bits_len = int.from_bytes(data_part[3:5], 'big')
-            encoded_bits_len = int.from_bytes(data_part[5:7], 'big')
-            header_size = 7
-            
-        payload = data_part[header_size:]
+        payload = data_part[3:]
         
         # Verify payload length matches header
         if len(payload) != payload_length:
-            return False, msg_type, payload, original_bits_len, encoded_bits_len
+            return False, msg_type, payload, 0, 0
+        
+        # For Hamming messages, strip the pad subheader (deinterleaving the
+        # blocks if flagged) and derive the bit lengths from it
+        if msg_type == 0x02:
+            try:
+                pad_bits, _, payload = parse_hamming_payload(payload)
+            except ValueError:
+                return False, msg_type, b'', 0, 0
+            encoded_bits_len = (len(payload) * 8) // 7 * 7
+            original_bits_len = encoded_bits_len // 7 * 4 - pad_bits
+            if original_bits_len < 0:
+                return False, msg_type, payload, 0, encoded_bits_len
+            return True, msg_type, payload, original_bits_len, encoded_bits_len
+        
+        # For bit messages, strip the pad subheader; the last byte of payload
+        # still holds the padding bits
+        if msg_type == MSG_TYPE_BITS:
+            try:
+                original_bits_len = len(parse_bits_payload(payload))
+            except ValueError:
+                return False, msg_type, b'', 0, 0
+            return True, msg_type, payload[1:], original_bits_len, 0
         
-        return True, msg_type, payload, original_bits_len, encoded_bits_len
\ No newline at end of file
+        return True, msg_type, payload, 0, 0
@@ -17,7 +17,7 @@ from presentation import ascii_to_bits, bits_to_ascii
 from link import LinkLayer
 from noise import inject_noise, calculate_error_stats
 from transport import MockTransport
-from algorithms import bytes_to_bits, bits_to_bytes
+from algorithms import bytes_to_bits, bits_to_bytes, MSG_TYPE_BITS
 
 
 class LabDemo:
@@ -126,9 +126,11 @@ class LabDemo:
                 result['error'] = 'CRC validation failed'
                 return result
             
-            if msg_type == 0x01:  # RAW + CRC
-                # Direct payload to ASCII
+            if msg_type in (0x01, MSG_TYPE_BITS):  # RAW + CRC
+                # Direct payload to ASCII, dropping the padding of bit frames
                 payload_bits = bytes_to_bits(payload)
+                if msg_type == MSG_TYPE_BITS:
+                    payload_bits = payload_bits[:original_bits_len]
                 recovered_message = bits_to_ascii(payload_bits)
                 result['recovered_message'] = recovered_message
                 result['valid'] = True
@@ -253,7 +253,8 @@ def test_frame_sender():
                     # Para Hamming, primero codificar y luego crear frame
                     hamming_bits = link_layer.apply_hamming(message_bits)
                     hamming_payload = bits_to_bytes(hamming_bits)
-                    frame_bytes = link_layer.build_frame(hamming_payload, 0x02)  # HAMMING+CRC
+                    frame_bytes = link_layer.build_frame(hamming_payload, 0x02,  # HAMMING+CRC
+                                                         original_bits_len=len(message_bits))
                 
                 frame_hex = frame_bytes.hex()
                 # Mostrar frame completo si es corto, truncado si es largo
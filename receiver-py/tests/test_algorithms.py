@@ -2,7 +2,8 @@ import pytest
 import binascii
 from src.algorithms import (
     verify_crc, hamming74_decode, bytes_to_bits, bits_to_bytes,
-    parse_frame_header
+    parse_frame_header, parse_hamming_payload, deinterleave,
+    parse_bits_payload
 )
 
 
@@ -158,6 +159,74 @@ class TestFrameHeader:
             parse_frame_header(frame)
 
 
+class TestHammingPayload:
+    """Pruebas para el subheader Hamming del emisor Go: [relleno|0x80][profundidad]"""
+    
+    # Tramas de BuildFrameOpts([]byte("Hola"), WithHamming74()) en emitter-go,
+    # sin entrelazar y con WithInterleave(7)
+    HOLA = bytes.fromhex('0200080098e177f5dd1769c7fa7b0a')
+    HOLA_ENTRELAZADA = bytes.fromhex('0200098007957f54fbbe3a115cc249df')
+    
+    def _decode(self, frame):
+        ok, payload = verify_crc(frame)
+        assert ok
+        pad_bits, depth, blocks = parse_hamming_payload(payload)
+        code_bits = bytes_to_bits(blocks)
+        data_bits, _ = hamming74_decode(code_bits[:len(code_bits) // 7 * 7])
+        return bits_to_bytes(data_bits[:len(data_bits) - pad_bits]), depth
+    
+    def test_parse_hamming_payload_sin_entrelazar(self):
+        data, depth = self._decode(self.HOLA)
+        assert data == b'Hola'
+        assert depth == 0
+    
+    def test_parse_hamming_payload_entrelazado(self):
+        data, depth = self._decode(self.HOLA_ENTRELAZADA)
+        assert data == b'Hola'
+        assert depth == 7
+    
+    def test_parse_hamming_payload_descarta_relleno(self):
+        # 5 bits de relleno: 2 bloques dan 8 bits de datos, quedan 3
+        pad_bits, depth, blocks = parse_hamming_payload(b'\x05\xAB\xCD')
+        assert (pad_bits, depth, blocks) == (5, 0, b'\xAB\xCD')
+    
+    def test_parse_hamming_payload_sin_profundidad(self):
+        with pytest.raises(ValueError, match="profundidad"):
+            parse_hamming_payload(b'\x80')
+    
+    def test_parse_hamming_payload_vacio(self):
+        with pytest.raises(ValueError, match="subheader"):
+            parse_hamming_payload(b'')
+    
+    def test_deinterleave_ultima_fila_incompleta(self):
+        # 7 bits en profundidad 3: filas [0,1,2] [3,4,5] [6], leídas por columnas
+        assert deinterleave([0, 3, 6, 1, 4, 2, 5], 3) == list(range(7))
+        assert deinterleave([1, 0, 1], 1) == [1, 0, 1]
+
+
+class TestBitsPayload:
+    """Pruebas para las tramas de bits (0x0D) del emisor Go: [relleno] + bits"""
+    
+    # Trama de BuildFrameFromBits con los 17 bits 01001000011010010 (ver
+    # cmd/emitter_crc/testdata/bits17.golden en emitter-go)
+    BITS17 = bytes.fromhex('0d0004074869005e5a2c51')
+    
+    def test_parse_bits_payload_trama_go(self):
+        ok, payload = verify_crc(self.BITS17)
+        assert ok
+        bits = parse_bits_payload(payload)
+        assert ''.join(map(str, bits)) == '01001000011010010'
+    
+    def test_parse_bits_payload_relleno_invalido(self):
+        for pad in (0, 8):
+            with pytest.raises(ValueError, match="Relleno"):
+                parse_bits_payload(bytes([pad, 0xFF]))
+    
+    def test_parse_bits_payload_sin_datos(self):
+        with pytest.raises(ValueError, match="subheader"):
+            parse_bits_payload(b'\x07')
+
+
 class TestIntegrationScenarios:
     """Pruebas de escenarios integrados como se especifica en la consigna"""
     